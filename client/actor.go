@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"os"
+	"os/user"
+
+	"github.com/tinkerbell/tink/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Actor returns who the requests are made on behalf of. It is the TINK_ACTOR
+// environment variable when set, user@hostname otherwise.
+func Actor() string {
+	if actor := os.Getenv("TINK_ACTOR"); actor != "" {
+		return actor
+	}
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return name
+	}
+	return name + "@" + host
+}
+
+// actorInterceptor sends the actor along with every unary request, so the
+// server can record who changed the data
+func actorInterceptor(actor string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, pkg.ActorMetadataKey, actor)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
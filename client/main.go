@@ -9,8 +9,11 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	"github.com/tinkerbell/tink/protos/admin"
+	"github.com/tinkerbell/tink/protos/events"
 	"github.com/tinkerbell/tink/protos/hardware"
 	"github.com/tinkerbell/tink/protos/template"
+	"github.com/tinkerbell/tink/protos/webhook"
 	"github.com/tinkerbell/tink/protos/workflow"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -21,6 +24,9 @@ var (
 	TemplateClient template.TemplateServiceClient
 	WorkflowClient workflow.WorkflowServiceClient
 	HardwareClient hardware.HardwareServiceClient
+	EventsClient   events.EventsServiceClient
+	WebhookClient  webhook.WebhookServiceClient
+	AdminClient    admin.AdminServiceClient
 )
 
 // FullClient aggregates all the gRPC clients available from Tinkerbell Server
@@ -28,6 +34,9 @@ type FullClient struct {
 	TemplateClient template.TemplateServiceClient
 	WorkflowClient workflow.WorkflowServiceClient
 	HardwareClient hardware.HardwareServiceClient
+	EventsClient   events.EventsServiceClient
+	WebhookClient  webhook.WebhookServiceClient
+	AdminClient    admin.AdminServiceClient
 }
 
 // NewFullClientFromGlobal is a dirty hack that returns a FullClient using the
@@ -47,6 +56,9 @@ func NewFullClientFromGlobal() (*FullClient, error) {
 		TemplateClient: TemplateClient,
 		WorkflowClient: WorkflowClient,
 		HardwareClient: HardwareClient,
+		EventsClient:   EventsClient,
+		WebhookClient:  WebhookClient,
+		AdminClient:    AdminClient,
 	}, nil
 }
 
@@ -58,6 +70,9 @@ func NewFullClient(conn grpc.ClientConnInterface) *FullClient {
 		TemplateClient: template.NewTemplateServiceClient(conn),
 		WorkflowClient: workflow.NewWorkflowServiceClient(conn),
 		HardwareClient: hardware.NewHardwareServiceClient(conn),
+		EventsClient:   events.NewEventsServiceClient(conn),
+		WebhookClient:  webhook.NewWebhookServiceClient(conn),
+		AdminClient:    admin.NewAdminServiceClient(conn),
 	}
 }
 
@@ -90,7 +105,9 @@ func NewClientConn(opt *ConnOptions) (*grpc.ClientConn, error) {
 	}
 
 	creds := credentials.NewClientTLSFromCert(cp, "")
-	conn, err := grpc.Dial(opt.GRPCAuthority, grpc.WithTransportCredentials(creds))
+	conn, err := grpc.Dial(opt.GRPCAuthority, grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(actorInterceptor(Actor()), namespaceUnaryInterceptor(Namespace())),
+		grpc.WithStreamInterceptor(namespaceStreamInterceptor(Namespace())))
 	if err != nil {
 		return nil, errors.Wrap(err, "connect to tinkerbell server")
 	}
@@ -125,7 +142,9 @@ func GetConnection() (*grpc.ClientConn, error) {
 		return nil, errors.New("undefined TINKERBELL_GRPC_AUTHORITY")
 	}
 	creds := credentials.NewClientTLSFromCert(cp, "")
-	conn, err := grpc.Dial(grpcAuthority, grpc.WithTransportCredentials(creds))
+	conn, err := grpc.Dial(grpcAuthority, grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(actorInterceptor(Actor()), namespaceUnaryInterceptor(Namespace())),
+		grpc.WithStreamInterceptor(namespaceStreamInterceptor(Namespace())))
 	if err != nil {
 		return nil, errors.Wrap(err, "connect to tinkerbell server")
 	}
@@ -141,6 +160,9 @@ func Setup() error {
 	TemplateClient = template.NewTemplateServiceClient(conn)
 	WorkflowClient = workflow.NewWorkflowServiceClient(conn)
 	HardwareClient = hardware.NewHardwareServiceClient(conn)
+	EventsClient = events.NewEventsServiceClient(conn)
+	WebhookClient = webhook.NewWebhookServiceClient(conn)
+	AdminClient = admin.NewAdminServiceClient(conn)
 	return nil
 }
 
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"os"
+
+	"github.com/tinkerbell/tink/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Namespace returns the namespace the requests are restricted to, the
+// TINK_NAMESPACE environment variable. When it is empty the requests are
+// restricted to the default namespace, with pkg.AllNamespaces they see all
+// of them if the server allows it.
+func Namespace() string {
+	return os.Getenv("TINK_NAMESPACE")
+}
+
+// namespaceUnaryInterceptor sends the namespace along with every unary
+// request, unless it is empty
+func namespaceUnaryInterceptor(ns string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if ns != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, pkg.NamespaceMetadataKey, ns)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// namespaceStreamInterceptor is namespaceUnaryInterceptor for the streams
+func namespaceStreamInterceptor(ns string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if ns != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, pkg.NamespaceMetadataKey, ns)
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/admin"
+)
+
+func NewAdminCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "admin",
+		Short:   "tink admin client",
+		Example: "tink admin [command]",
+		Args: func(c *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("%v requires arguments", c.UseLine())
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(admin.NewGCCmd())
+	cmd.AddCommand(admin.NewExportCmd())
+	cmd.AddCommand(admin.NewImportCmd())
+
+	return cmd
+}
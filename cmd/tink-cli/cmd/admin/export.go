@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/admin"
+)
+
+// NewExportCmd represents the export command
+func NewExportCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "export all the state of the server in an archive",
+		Long: `The export command writes the hardware, the templates, the workflows with
+their state, events and data, and the events of the server in a versioned
+archive, the deleted ones included. The import command restores it in an
+empty server.`,
+		Example: "tink admin export -o tink.tar.gz",
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return errors.New("takes no argument")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if output == "" || output == "-" {
+				if err := exportArchive(context.Background(), os.Stdout); err != nil {
+					log.Fatal(err)
+				}
+				return
+			}
+			f, err := os.Create(output)
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = exportArchive(context.Background(), f)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				_ = os.Remove(output)
+				log.Fatal(err)
+			}
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&output, "output", "o", "", "the file to write the archive to, the standard output when it is not set")
+	return cmd
+}
+
+func exportArchive(ctx context.Context, w io.Writer) error {
+	stream, err := client.AdminClient.Export(ctx, &admin.ExportRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			return errors.Wrap(err, "write archive")
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/admin"
+	"google.golang.org/grpc"
+)
+
+func TestExportArchive(t *testing.T) {
+	defer func(c admin.AdminServiceClient) { client.AdminClient = c }(client.AdminClient)
+	chunks := [][]byte{[]byte("first "), []byte("second")}
+	client.AdminClient = &admin.AdminServiceClientMock{
+		ExportFunc: func(ctx context.Context, in *admin.ExportRequest, opts ...grpc.CallOption) (admin.AdminService_ExportClient, error) {
+			return &admin.AdminService_ExportClientMock{
+				RecvFunc: func() (*admin.ArchiveChunk, error) {
+					if len(chunks) == 0 {
+						return nil, io.EOF
+					}
+					chunk := &admin.ArchiveChunk{Data: chunks[0]}
+					chunks = chunks[1:]
+					return chunk, nil
+				},
+			}, nil
+		},
+	}
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, exportArchive(context.Background(), out))
+	assert.Equal(t, "first second", out.String())
+}
+
+func TestImportArchive(t *testing.T) {
+	defer func(c admin.AdminServiceClient) { client.AdminClient = c }(client.AdminClient)
+	archive := bytes.Repeat([]byte{42}, importChunkSize+10)
+	received := &bytes.Buffer{}
+	sent := 0
+	client.AdminClient = &admin.AdminServiceClientMock{
+		ImportFunc: func(ctx context.Context, opts ...grpc.CallOption) (admin.AdminService_ImportClient, error) {
+			return &admin.AdminService_ImportClientMock{
+				SendFunc: func(chunk *admin.ArchiveChunk) error {
+					sent++
+					received.Write(chunk.Data)
+					return nil
+				},
+				CloseAndRecvFunc: func() (*admin.ImportResponse, error) {
+					return &admin.ImportResponse{Hardware: 3, Events: 12}, nil
+				},
+			}, nil
+		},
+	}
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, importArchive(context.Background(), out, bytes.NewReader(archive)))
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, archive, received.Bytes())
+	assert.Regexp(t, `hardware\s+\|\s+3`, out.String())
+	assert.Regexp(t, `events\s+\|\s+12`, out.String())
+}
@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/admin"
+)
+
+// NewGCCmd represents the gc command
+func NewGCCmd() *cobra.Command {
+	var (
+		dryRun bool
+		days   int32
+	)
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "remove what the retention policy does not keep",
+		Long: `The gc command removes the hardware, templates and workflows deleted longer
+ago than the retention period, and the events and data of the workflows
+finished longer ago than it. The deleted templates still used by a workflow
+are kept.
+
+The retention period of the server, set with its --retention-days flag, is
+used when --retention-days is not set.`,
+		Example: "tink admin gc --dry-run --retention-days 30",
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return errors.New("takes no argument")
+			}
+			if days < 0 {
+				return errors.New("--retention-days can not be negative")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			req := &admin.GarbageCollectRequest{DryRun: dryRun, RetentionDays: days}
+			if err := collectGarbage(context.Background(), os.Stdout, req); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.BoolVar(&dryRun, "dry-run", false, "report what would be removed, without removing it")
+	flags.Int32Var(&days, "retention-days", 0, "the retention period in days, the one of the server when it is not set")
+	return cmd
+}
+
+func collectGarbage(ctx context.Context, w io.Writer, req *admin.GarbageCollectRequest) error {
+	res, err := client.AdminClient.GarbageCollect(ctx, req)
+	if err != nil {
+		return err
+	}
+	verb := "Removed"
+	if req.DryRun {
+		verb = "Would remove"
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Rows", verb})
+	t.AppendRows([]table.Row{
+		{"hardware", res.Hardware},
+		{"hardware revisions", res.HardwareRevisions},
+		{"templates", res.Templates},
+		{"workflows", res.Workflows},
+		{"workflow events", res.WorkflowEvents},
+		{"workflow data", res.WorkflowData},
+	})
+	t.Render()
+	return nil
+}
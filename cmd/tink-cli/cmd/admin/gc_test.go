@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/admin"
+	"google.golang.org/grpc"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	defer func(c admin.AdminServiceClient) { client.AdminClient = c }(client.AdminClient)
+	client.AdminClient = &admin.AdminServiceClientMock{
+		GarbageCollectFunc: func(ctx context.Context, in *admin.GarbageCollectRequest, opts ...grpc.CallOption) (*admin.GarbageCollectResponse, error) {
+			assert.True(t, in.DryRun)
+			assert.Equal(t, int32(30), in.RetentionDays)
+			return &admin.GarbageCollectResponse{Workflows: 2, WorkflowEvents: 42}, nil
+		},
+	}
+
+	out := &bytes.Buffer{}
+	err := collectGarbage(context.Background(), out, &admin.GarbageCollectRequest{DryRun: true, RetentionDays: 30})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "WOULD REMOVE")
+	assert.Regexp(t, `workflow events\s+\|\s+42`, out.String())
+	assert.Regexp(t, `workflows\s+\|\s+2`, out.String())
+}
@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/admin"
+)
+
+// importChunkSize is the size of the chunks an archive is sent in
+const importChunkSize = 1 << 20
+
+// NewImportCmd represents the import command
+func NewImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <archive>",
+		Short: "restore an archive made by export in an empty server",
+		Long: `The import command restores all the state of an archive made by the export
+command. The server must be empty: it refuses the archive when it already has
+hardware, templates, workflows or events, and restores nothing of it when any
+row fails.`,
+		Example: "tink admin import tink.tar.gz",
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("takes the archive as argument")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer f.Close()
+			if err := importArchive(context.Background(), os.Stdout, f); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	return cmd
+}
+
+func importArchive(ctx context.Context, w io.Writer, r io.Reader) error {
+	stream, err := client.AdminClient.Import(ctx)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			// the server stopped the stream, CloseAndRecv tells why
+			if err := stream.Send(&admin.ArchiveChunk{Data: buf[:n]}); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "read archive")
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Rows", "Imported"})
+	t.AppendRows([]table.Row{
+		{"hardware", res.Hardware},
+		{"hardware revisions", res.HardwareRevisions},
+		{"templates", res.Templates},
+		{"workflows", res.Workflows},
+		{"workflow events", res.WorkflowEvents},
+		{"workflow data", res.WorkflowData},
+		{"events", res.Events},
+	})
+	t.Render()
+	return nil
+}
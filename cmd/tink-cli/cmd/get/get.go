@@ -13,6 +13,10 @@ import (
 	"google.golang.org/grpc"
 )
 
+// PageSize is the number of resources RetrieveData asks for with every call
+// while it pages through them
+const PageSize = 100
+
 type Options struct {
 	// Headers is the list of headers you want to print as part of the list
 	Headers []string
@@ -22,13 +22,19 @@ func NewHardwareCommand() *cobra.Command {
 		},
 	}
 
+	cmd.AddCommand(hardware.NewApplyCmd())
 	cmd.AddCommand(get.NewGetCommand(hardware.NewGetOptions()))
 	cmd.AddCommand(delete.NewDeleteCommand(hardware.NewDeleteOptions()))
+	cmd.AddCommand(hardware.NewHistoryCmd())
 	cmd.AddCommand(hardware.NewGetByIDCmd())
+	cmd.AddCommand(hardware.NewImportCmd())
 	cmd.AddCommand(hardware.NewGetByIPCmd())
 	cmd.AddCommand(hardware.NewListCmd())
 	cmd.AddCommand(hardware.NewGetByMACCmd())
+	cmd.AddCommand(hardware.NewPatchCmd())
 	cmd.AddCommand(hardware.NewPushCmd())
+	cmd.AddCommand(hardware.NewStateCmd())
+	cmd.AddCommand(hardware.NewWatchCmd())
 
 	return cmd
 }
@@ -0,0 +1,285 @@
+package hardware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/pkg"
+	"github.com/tinkerbell/tink/protos/hardware"
+)
+
+// The actions of an apply plan
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+)
+
+// planStep is a change apply makes to the hardware stored by the server
+type planStep struct {
+	action string
+	id     string
+	// hw is the hardware to push, nil for a deletion
+	hw *hardware.Hardware
+	// changes are the fields modified by an update
+	changes []pkg.Change
+}
+
+// NewApplyCmd represents the apply command
+func NewApplyCmd() *cobra.Command {
+	var (
+		dir    string
+		prune  bool
+		dryRun bool
+	)
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "make the hardware stored by tink match a directory of definitions",
+		Long: `The apply command compares the hardware definitions found in a directory with
+the hardware stored by tink. It prints a plan of what has to be created or
+updated, and with --prune deleted, to make them match, then it carries it out.
+
+The definitions are JSON files, like the ones taken by tink hardware push, and
+YAML files holding one or more hardware documents.
+
+The hardware is the one of the namespace in TINK_NAMESPACE, the default one
+when it is not set. --prune can not be used with all the namespaces, it would
+delete the hardware of the other teams.`,
+		Example: `tink hardware apply -f ./hardware/ --dry-run
+tink hardware apply -f ./hardware/ --prune`,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			if dir == "" {
+				return fmt.Errorf("%v requires the '--file' flag", c.UseLine())
+			}
+			if prune && client.Namespace() == pkg.AllNamespaces {
+				return fmt.Errorf("--prune can not be used with TINK_NAMESPACE=%s, set the namespace of the hardware", pkg.AllNamespaces)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			desired, err := readDefinitions(dir)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			cl, err := client.NewFullClientFromGlobal()
+			if err != nil {
+				log.Fatal(err)
+			}
+			data, err := (&getHardware{}).RetrieveData(ctx, cl)
+			if err != nil {
+				log.Fatal(err)
+			}
+			current := make([]*hardware.Hardware, 0, len(data))
+			for _, v := range data {
+				current = append(current, v.(*hardware.Hardware))
+			}
+
+			plan, err := buildPlan(desired, current, prune)
+			if err != nil {
+				log.Fatal(err)
+			}
+			printPlan(os.Stdout, plan)
+			if dryRun || len(plan) == 0 {
+				return
+			}
+
+			for _, step := range plan {
+				if step.action == actionDelete {
+					_, err = cl.HardwareClient.Delete(ctx, &hardware.DeleteRequest{Id: step.id})
+				} else {
+					_, err = cl.HardwareClient.Push(ctx, &hardware.PushRequest{Data: step.hw})
+				}
+				if err != nil {
+					log.Fatalf("%s %s: %v", step.action, step.id, err)
+				}
+				fmt.Printf("%s: %sd\n", step.id, step.action)
+			}
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.StringVarP(&dir, "file", "f", "", "directory holding the hardware definitions")
+	flags.BoolVar(&prune, "prune", false, "delete the hardware that is not defined in the directory")
+	flags.BoolVar(&dryRun, "dry-run", false, "only print the plan")
+	return cmd
+}
+
+// readDefinitions reads the hardware defined by the JSON and YAML files
+// found in dir and its subdirectories
+func readDefinitions(dir string) ([]*hardware.Hardware, error) {
+	hws := []*hardware.Hardware{}
+	files := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		var docs []map[string]interface{}
+		switch filepath.Ext(path) {
+		case ".json":
+			docs, err = readJSONDefinition(path)
+		case ".yaml", ".yml":
+			var f *os.File
+			f, err = os.Open(filepath.Clean(path))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			docs, err = readYAMLDocuments(f)
+		default:
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, path)
+		}
+
+		for _, doc := range docs {
+			hw, err := hardwareFromDocument(doc)
+			if err != nil {
+				return errors.Wrap(err, path)
+			}
+			if hw.Id == "" {
+				return fmt.Errorf("%s: the hardware id is required", path)
+			}
+			if other, ok := files[hw.Id]; ok {
+				return fmt.Errorf("%s: hardware %s is already defined in %s", path, hw.Id, other)
+			}
+			files[hw.Id] = path
+			hws = append(hws, hw)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hws, nil
+}
+
+func readJSONDefinition(path string) ([]map[string]interface{}, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	doc := map[string]interface{}{}
+	if err := json.NewDecoder(f).Decode(&doc); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	return []map[string]interface{}{doc}, nil
+}
+
+// buildPlan returns the steps making current match desired. The hardware
+// only in current is deleted when prune is set. The steps are sorted by
+// action and id.
+func buildPlan(desired, current []*hardware.Hardware, prune bool) ([]planStep, error) {
+	stored := map[string]*hardware.Hardware{}
+	for _, hw := range current {
+		stored[hw.Id] = hw
+	}
+
+	plan := []planStep{}
+	for _, hw := range desired {
+		cur, ok := stored[hw.Id]
+		delete(stored, hw.Id)
+		if !ok {
+			plan = append(plan, planStep{action: actionCreate, id: hw.Id, hw: hw})
+			continue
+		}
+		want, err := comparableHardware(hw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "hardware %s", hw.Id)
+		}
+		have, err := comparableHardware(cur)
+		if err != nil {
+			return nil, errors.Wrapf(err, "hardware %s", hw.Id)
+		}
+		changes := pkg.DiffJSON(have, want)
+		if len(changes) == 0 {
+			continue
+		}
+		// the push fails if someone else changes the hardware in the
+		// meantime
+		hw.Version = cur.Version
+		plan = append(plan, planStep{action: actionUpdate, id: hw.Id, hw: hw, changes: changes})
+	}
+	if prune {
+		for id := range stored {
+			plan = append(plan, planStep{action: actionDelete, id: id})
+		}
+	}
+
+	order := map[string]int{actionCreate: 0, actionUpdate: 1, actionDelete: 2}
+	sort.Slice(plan, func(i, j int) bool {
+		if plan[i].action != plan[j].action {
+			return order[plan[i].action] < order[plan[j].action]
+		}
+		return plan[i].id < plan[j].id
+	})
+	return plan, nil
+}
+
+// comparableHardware returns the JSON document of the hardware without
+// what the server manages, so a definition can be compared with what is
+// stored
+func comparableHardware(hw *hardware.Hardware) (map[string]interface{}, error) {
+	b, err := json.Marshal(pkg.HardwareWrapper{Hardware: hw})
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	delete(doc, "version")
+	delete(doc, "state")
+	// the server stores the MAC addresses in lowercase
+	if network, ok := doc["network"].(map[string]interface{}); ok {
+		interfaces, _ := network["interfaces"].([]interface{})
+		for _, iface := range interfaces {
+			i, _ := iface.(map[string]interface{})
+			dhcp, _ := i["dhcp"].(map[string]interface{})
+			if mac, ok := dhcp["mac"].(string); ok {
+				dhcp["mac"] = strings.ToLower(mac)
+			}
+		}
+	}
+	return doc, nil
+}
+
+func printPlan(w io.Writer, plan []planStep) {
+	if len(plan) == 0 {
+		fmt.Fprintln(w, "No changes, the hardware matches the definitions.")
+		return
+	}
+	symbols := map[string]string{actionCreate: "+", actionUpdate: "~", actionDelete: "-"}
+	counts := map[string]int{}
+	for _, step := range plan {
+		counts[step.action]++
+		fmt.Fprintf(w, "%s %s %s\n", symbols[step.action], step.action, step.id)
+		if step.action != actionUpdate {
+			continue
+		}
+		for _, c := range step.changes {
+			before, after := string(c.Old), string(c.New)
+			if before == "" {
+				before = "<none>"
+			}
+			if after == "" {
+				after = "<none>"
+			}
+			fmt.Fprintf(w, "    %s: %s -> %s\n", c.Path, before, after)
+		}
+	}
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", counts[actionCreate], counts[actionUpdate], counts[actionDelete])
+}
@@ -0,0 +1,106 @@
+package hardware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hardware_proto "github.com/tinkerbell/tink/protos/hardware"
+)
+
+func TestReadDefinitions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tink-apply")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.json":          `{"id":"0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95","metadata":{"state":"provisioning"}}`,
+		"rack1/more.yaml": "id: 224ee6ab-ad62-4070-a900-ed816444cec0\n---\nid: cb76ae54-93e9-401c-a5b2-d455bb3800b1\n",
+		"README.md":       "not a definition",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+
+	hws, err := readDefinitions(dir)
+	assert.NoError(t, err)
+	ids := []string{}
+	for _, hw := range hws {
+		ids = append(ids, hw.Id)
+	}
+	assert.Equal(t, []string{"0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95", "224ee6ab-ad62-4070-a900-ed816444cec0", "cb76ae54-93e9-401c-a5b2-d455bb3800b1"}, ids)
+	assert.JSONEq(t, `{"state":"provisioning"}`, hws[0].Metadata)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.json"), []byte(files["a.json"]), 0600))
+	_, err = readDefinitions(dir)
+	assert.Error(t, err)
+}
+
+func TestBuildPlan(t *testing.T) {
+	hw := func(id, mac, metadata string, version int64) *hardware_proto.Hardware {
+		return &hardware_proto.Hardware{
+			Id:       id,
+			Version:  version,
+			Metadata: metadata,
+			Network: &hardware_proto.Hardware_Network{
+				Interfaces: []*hardware_proto.Hardware_Network_Interface{
+					{Dhcp: &hardware_proto.Hardware_DHCP{Mac: mac}},
+				},
+			},
+		}
+	}
+
+	desired := []*hardware_proto.Hardware{
+		hw("a", "08:00:27:00:00:01", `{"state":"provisioning"}`, 0),
+		// the server stores the MAC addresses in lowercase
+		hw("b", "08:00:27:AA:00:02", `{"facility": {"facility_code": "onprem"}}`, 0),
+		hw("c", "08:00:27:00:00:03", `{"state":"in_use"}`, 0),
+	}
+	current := []*hardware_proto.Hardware{
+		hw("b", "08:00:27:aa:00:02", `{"facility":{"facility_code":"onprem"}}`, 4),
+		hw("c", "08:00:27:00:00:03", `{"state":"provisioning"}`, 7),
+		hw("d", "08:00:27:00:00:04", "", 1),
+	}
+
+	plan, err := buildPlan(desired, current, false)
+	assert.NoError(t, err)
+	out := new(bytes.Buffer)
+	printPlan(out, plan)
+	assert.Equal(t, `+ create a
+~ update c
+    metadata.state: "provisioning" -> "in_use"
+
+Plan: 1 to create, 1 to update, 0 to delete.
+`, out.String())
+	assert.Equal(t, int64(7), plan[1].hw.Version)
+
+	plan, err = buildPlan(desired, current, true)
+	assert.NoError(t, err)
+	assert.Len(t, plan, 3)
+	assert.Equal(t, planStep{action: actionDelete, id: "d"}, plan[2])
+
+	plan, err = buildPlan(current, current, false)
+	assert.NoError(t, err)
+	out.Reset()
+	printPlan(out, plan)
+	assert.Equal(t, "No changes, the hardware matches the definitions.\n", out.String())
+}
+
+func TestApplyPruneAllNamespaces(t *testing.T) {
+	defer os.Setenv("TINK_NAMESPACE", os.Getenv("TINK_NAMESPACE"))
+	cmd := NewApplyCmd()
+	cmd.SetArgs([]string{"-f", ".", "--prune"})
+	cmd.SetOut(ioutil.Discard)
+	cmd.SetErr(ioutil.Discard)
+
+	os.Setenv("TINK_NAMESPACE", "*")
+	err := cmd.Execute()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "--prune")
+	}
+}
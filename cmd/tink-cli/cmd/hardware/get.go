@@ -7,6 +7,7 @@ import (
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/tinkerbell/tink/client"
 	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/get"
+	"github.com/tinkerbell/tink/pkg"
 	"github.com/tinkerbell/tink/protos/hardware"
 )
 
@@ -30,19 +31,25 @@ func (h *getHardware) RetrieveByID(ctx context.Context, cl *client.FullClient, r
 }
 
 func (h *getHardware) RetrieveData(ctx context.Context, cl *client.FullClient) ([]interface{}, error) {
-	list, err := cl.HardwareClient.All(ctx, &hardware.Empty{})
-	if err != nil {
-		return nil, err
-	}
 	data := []interface{}{}
-	var hw *hardware.Hardware
-	for hw, err = list.Recv(); err == nil && hw != nil; hw, err = list.Recv() {
-		data = append(data, hw)
-	}
-	if err != nil && err != io.EOF {
-		return nil, err
+	req := &hardware.ListRequest{PageSize: get.PageSize}
+	for {
+		list, err := cl.HardwareClient.All(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var hw *hardware.Hardware
+		for hw, err = list.Recv(); err == nil; hw, err = list.Recv() {
+			data = append(data, hw)
+		}
+		if err != io.EOF {
+			return nil, err
+		}
+		req.PageToken = pkg.NextPageToken(list)
+		if req.PageToken == "" {
+			return data, nil
+		}
 	}
-	return data, nil
 }
 
 func (h *getHardware) PopulateTable(data []interface{}, t table.Writer) error {
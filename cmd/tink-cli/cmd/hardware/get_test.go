@@ -12,6 +12,7 @@ import (
 	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/get"
 	hardware_proto "github.com/tinkerbell/tink/protos/hardware"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestGetHardware(t *testing.T) {
@@ -78,7 +79,7 @@ func TestGetHardware(t *testing.T) {
 		t.Run(s.Name, func(t *testing.T) {
 			cl := &client.FullClient{
 				HardwareClient: &hardware_proto.HardwareServiceClientMock{
-					AllFunc: func(ctx context.Context, in *hardware_proto.Empty, opts ...grpc.CallOption) (hardware_proto.HardwareService_AllClient, error) {
+					AllFunc: func(ctx context.Context, in *hardware_proto.ListRequest, opts ...grpc.CallOption) (hardware_proto.HardwareService_AllClient, error) {
 						return &hardware_proto.HardwareService_AllClientMock{
 							RecvFunc: func() (*hardware_proto.Hardware, error) {
 								s.counter = s.counter + 1
@@ -87,6 +88,9 @@ func TestGetHardware(t *testing.T) {
 								}
 								return s.ReturnedHardwares[s.counter-1], nil
 							},
+							TrailerFunc: func() metadata.MD {
+								return nil
+							},
 						}, nil
 					},
 				},
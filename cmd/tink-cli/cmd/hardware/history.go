@@ -0,0 +1,68 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/hardware"
+)
+
+// NewHistoryCmd represents the history command
+func NewHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "history",
+		Short:   "display the changes made to a hardware",
+		Example: "tink hardware history 224ee6ab-ad62-4070-a900-ed816444cec0",
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires exactly one id")
+			}
+			return verifyUUIDs(args)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			history, err := client.HardwareClient.History(context.Background(), &hardware.GetRequest{Id: args[0]})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+			t.AppendHeader(table.Row{"Revision", "Version", "Event", "Actor", "Time", "Changes"})
+			var rev *hardware.HardwareRevision
+			for rev, err = history.Recv(); err == nil && rev != nil; rev, err = history.Recv() {
+				createdAt, _ := ptypes.Timestamp(rev.CreatedAt)
+				t.AppendRow(table.Row{rev.Revision, rev.Version, rev.EventType, rev.Actor, createdAt.Format(time.RFC3339), formatChanges(rev.Changes)})
+			}
+			if err != nil && err != io.EOF {
+				log.Fatal(err)
+			}
+			t.Render()
+		},
+	}
+}
+
+// formatChanges returns one line per change, in the form path: old -> new
+func formatChanges(changes []*hardware.HardwareRevision_Change) string {
+	lines := make([]string, 0, len(changes))
+	for _, c := range changes {
+		before, after := c.OldValue, c.NewValue
+		if before == "" {
+			before = "<none>"
+		}
+		if after == "" {
+			after = "<none>"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s -> %s", c.Path, before, after))
+	}
+	return strings.Join(lines, "\n")
+}
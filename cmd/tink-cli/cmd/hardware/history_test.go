@@ -0,0 +1,21 @@
+package hardware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hardware_proto "github.com/tinkerbell/tink/protos/hardware"
+)
+
+func TestFormatChanges(t *testing.T) {
+	changes := []*hardware_proto.HardwareRevision_Change{
+		{Path: "network.interfaces[0].netboot.allow_workflow", OldValue: "true", NewValue: "false"},
+		{Path: "labels.rack", NewValue: `"r1"`},
+		{Path: "metadata.state", OldValue: `"provisioning"`},
+	}
+	expected := `network.interfaces[0].netboot.allow_workflow: true -> false
+labels.rack: <none> -> "r1"
+metadata.state: "provisioning" -> <none>`
+	assert.Equal(t, expected, formatChanges(changes))
+	assert.Equal(t, "", formatChanges(nil))
+}
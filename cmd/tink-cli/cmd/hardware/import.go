@@ -0,0 +1,429 @@
+package hardware
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/pkg"
+	"github.com/tinkerbell/tink/protos/hardware"
+	"gopkg.in/yaml.v2"
+)
+
+// fieldMapping tells which column holds the value of a hardware field and
+// how to convert it. In the mapping file it can be written as just the
+// column name, the value is a string then.
+type fieldMapping struct {
+	Column string `yaml:"column"`
+	// Type is one of string, number, bool or json
+	Type string `yaml:"type"`
+}
+
+// UnmarshalYAML accepts both a column name and a column with a type
+func (f *fieldMapping) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var column string
+	if err := unmarshal(&column); err == nil {
+		*f = fieldMapping{Column: column}
+		return nil
+	}
+	type plain fieldMapping
+	return unmarshal((*plain)(f))
+}
+
+// importMapping describes how to build a hardware from a row of the
+// inventory, for example:
+//
+//	defaults:
+//	  network.interfaces[0].netboot.allow_pxe: true
+//	fields:
+//	  id: uuid
+//	  network.interfaces[0].dhcp.mac: mac
+//	  network.interfaces[0].dhcp.lease_time: {column: lease, type: number}
+//	  metadata.facility.facility_code: facility
+//	  labels.rack: rack
+type importMapping struct {
+	// Defaults are the values set on every hardware, by path
+	Defaults map[string]interface{} `yaml:"defaults"`
+	// Fields maps the path of a hardware field to the column holding its
+	// value. An empty cell leaves the field unset.
+	Fields map[string]fieldMapping `yaml:"fields"`
+}
+
+// importRow is a hardware read from the inventory, or the reason why it
+// could not be read
+type importRow struct {
+	hw  *hardware.Hardware
+	err error
+}
+
+// NewImportCmd represents the import command
+func NewImportCmd() *cobra.Command {
+	var (
+		format      string
+		mappingFile string
+		dryRun      bool
+		force       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "push many hardware at once from a CSV or YAML inventory",
+		Long: `The import command reads an inventory, builds a hardware for every row and
+pushes all of them in a single batch. Nothing is pushed when one of the rows is
+not valid.
+
+A CSV inventory has a header row. Without a mapping file the column names are
+the paths of the hardware fields, like network.interfaces[0].dhcp.mac.
+
+A YAML inventory has one document per hardware. Without a mapping file every
+document is a hardware, like the ones taken by tink hardware push.
+
+The mapping file tells which column holds every hardware field.`,
+		Example: `tink hardware import inventory.csv --mapping mapping.yaml
+tink hardware import inventory.yaml --dry-run`,
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires exactly one inventory file")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			var mapping *importMapping
+			if mappingFile != "" {
+				var err error
+				mapping, err = readImportMapping(mappingFile)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+			f, err := os.Open(filepath.Clean(args[0]))
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer f.Close()
+
+			if format == "" {
+				format = strings.TrimPrefix(filepath.Ext(args[0]), ".")
+			}
+			rows, err := readInventory(f, format, mapping)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(rows) == 0 {
+				log.Fatal("the inventory is empty")
+			}
+			if !validateImport(rows) {
+				printImportReport(rows, nil, dryRun)
+				os.Exit(1)
+			}
+
+			req := &hardware.PushBatchRequest{DryRun: dryRun, Force: force}
+			for _, r := range rows {
+				req.Data = append(req.Data, r.hw)
+			}
+			res, err := client.HardwareClient.PushBatch(context.Background(), req)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for i, result := range res.GetResults() {
+				if result.Error != "" && i < len(rows) {
+					rows[i].err = errors.New(result.Error)
+				}
+			}
+			printImportReport(rows, res, dryRun)
+			if !res.Pushed && !dryRun {
+				os.Exit(1)
+			}
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&format, "format", "", "format of the inventory, csv or yaml, guessed from the file extension by default")
+	flags.StringVar(&mappingFile, "mapping", "", "YAML file mapping the columns of the inventory to the hardware fields")
+	flags.BoolVar(&dryRun, "dry-run", false, "only validate the inventory, without pushing it")
+	flags.BoolVar(&force, "force", false, "overwrite the stored hardware whatever their version")
+	return cmd
+}
+
+func readImportMapping(path string) (*importMapping, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m := &importMapping{}
+	if err := yaml.NewDecoder(f).Decode(m); err != nil {
+		return nil, errors.Wrap(err, "invalid mapping file")
+	}
+	for path, field := range m.Fields {
+		switch field.Type {
+		case "", "string", "number", "bool", "json":
+		default:
+			return nil, fmt.Errorf("invalid mapping for %s, unknown type %q", path, field.Type)
+		}
+		if field.Column == "" {
+			return nil, fmt.Errorf("invalid mapping for %s, the column is empty", path)
+		}
+	}
+	return m, nil
+}
+
+// readInventory builds a hardware for every row of the inventory
+func readInventory(r io.Reader, format string, mapping *importMapping) ([]importRow, error) {
+	var records []map[string]interface{}
+	switch format {
+	case "csv":
+		header, lines, err := readCSV(r)
+		if err != nil {
+			return nil, err
+		}
+		if mapping == nil {
+			mapping = &importMapping{Fields: map[string]fieldMapping{}}
+			for _, column := range header {
+				mapping.Fields[column] = fieldMapping{Column: column}
+			}
+		}
+		records = lines
+	case "yaml", "yml":
+		docs, err := readYAMLDocuments(r)
+		if err != nil {
+			return nil, err
+		}
+		records = docs
+	default:
+		return nil, fmt.Errorf("unknown inventory format %q, expected csv or yaml", format)
+	}
+
+	rows := make([]importRow, 0, len(records))
+	for _, record := range records {
+		doc := record
+		if mapping != nil {
+			var err error
+			doc, err = mapRecord(record, mapping)
+			if err != nil {
+				rows = append(rows, importRow{err: err})
+				continue
+			}
+		}
+		hw, err := hardwareFromDocument(doc)
+		rows = append(rows, importRow{hw: hw, err: err})
+	}
+	return rows, nil
+}
+
+func readCSV(r io.Reader) ([]string, []map[string]interface{}, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	lines, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid CSV inventory")
+	}
+	if len(lines) == 0 {
+		return nil, nil, nil
+	}
+	header := lines[0]
+	records := make([]map[string]interface{}, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		record := map[string]interface{}{}
+		for i, column := range header {
+			record[column] = line[i]
+		}
+		records = append(records, record)
+	}
+	return header, records, nil
+}
+
+func readYAMLDocuments(r io.Reader) ([]map[string]interface{}, error) {
+	decoder := yaml.NewDecoder(r)
+	docs := []map[string]interface{}{}
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid YAML inventory")
+		}
+		if doc == nil {
+			continue
+		}
+		m, ok := convertYAML(doc).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid YAML inventory, document %d is not an object", len(docs)+1)
+		}
+		docs = append(docs, m)
+	}
+}
+
+// convertYAML turns the maps decoded by yaml.v2 into maps that can be
+// encoded in JSON
+func convertYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			m[fmt.Sprint(k)] = convertYAML(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertYAML(item)
+		}
+	}
+	return v
+}
+
+// mapRecord builds the JSON document of a hardware from a record of the
+// inventory
+func mapRecord(record map[string]interface{}, mapping *importMapping) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+	for _, path := range sortedKeys(mapping.Defaults) {
+		if err := setPath(doc, strings.Split(path, "."), convertYAML(mapping.Defaults[path]), true); err != nil {
+			return nil, errors.Wrapf(err, "invalid default for %s", path)
+		}
+	}
+
+	paths := make([]string, 0, len(mapping.Fields))
+	for path := range mapping.Fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		field := mapping.Fields[path]
+		raw, ok := record[field.Column]
+		if !ok || raw == nil || raw == "" {
+			continue
+		}
+		value, err := convertValue(raw, field.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %s", field.Column)
+		}
+		if err := setPath(doc, strings.Split(path, "."), value, true); err != nil {
+			return nil, errors.Wrapf(err, "column %s", field.Column)
+		}
+	}
+	return doc, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// convertValue converts a cell of the inventory to the type of the field
+func convertValue(raw interface{}, typ string) (interface{}, error) {
+	s, isString := raw.(string)
+	switch typ {
+	case "", "string":
+		if isString {
+			return s, nil
+		}
+		return fmt.Sprint(raw), nil
+	case "number":
+		if !isString {
+			if _, err := strconv.ParseFloat(fmt.Sprint(raw), 64); err != nil {
+				return nil, fmt.Errorf("%v is not a number", raw)
+			}
+			return raw, nil
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		return n, nil
+	case "bool":
+		if b, ok := raw.(bool); ok {
+			return b, nil
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(fmt.Sprint(raw)))
+		if err != nil {
+			return nil, fmt.Errorf("%v is not a boolean", raw)
+		}
+		return b, nil
+	case "json":
+		if !isString {
+			return convertYAML(raw), nil
+		}
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, errors.Wrap(err, "invalid JSON")
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("unknown type %q", typ)
+}
+
+func hardwareFromDocument(doc map[string]interface{}) (*hardware.Hardware, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	hw := pkg.HardwareWrapper{Hardware: &hardware.Hardware{}}
+	if err := json.Unmarshal(b, &hw); err != nil {
+		return nil, errors.Wrap(err, "invalid hardware")
+	}
+	return hw.Hardware, nil
+}
+
+// validateImport checks the rows before anything gets pushed, it tells if
+// all of them are valid
+func validateImport(rows []importRow) bool {
+	valid := true
+	ids := map[string]int{}
+	for i := range rows {
+		r := &rows[i]
+		if r.err == nil {
+			if _, err := uuid.Parse(r.hw.GetId()); err != nil {
+				r.err = fmt.Errorf("id must be set to a UUID, got id: %s", r.hw.GetId())
+			} else if j, ok := ids[r.hw.Id]; ok {
+				r.err = fmt.Errorf("id %s is already used by row %d", r.hw.Id, j+1)
+			} else {
+				ids[r.hw.Id] = i
+			}
+		}
+		if r.err != nil {
+			valid = false
+		}
+	}
+	return valid
+}
+
+// printImportReport prints the result for every row of the inventory. res
+// is nil when the inventory did not get sent.
+func printImportReport(rows []importRow, res *hardware.PushBatchResponse, dryRun bool) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Row", "ID", "Result"})
+	for i, r := range rows {
+		result := "valid"
+		switch {
+		case r.err != nil:
+			result = r.err.Error()
+		case res == nil:
+			result = "not pushed"
+		case res.Pushed:
+			result = "pushed"
+		case !dryRun:
+			result = "not pushed"
+		}
+		t.AppendRow(table.Row{i + 1, r.hw.GetId(), result})
+	}
+	t.Render()
+}
@@ -0,0 +1,137 @@
+package hardware
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const testMapping = `
+defaults:
+  network.interfaces[0].netboot.allow_pxe: true
+fields:
+  id: uuid
+  network.interfaces[0].dhcp.mac: mac
+  network.interfaces[0].dhcp.lease_time: {column: lease, type: number}
+  network.interfaces[0].netboot.allow_workflow: {column: workflow, type: bool}
+  metadata.facility.facility_code: facility
+  labels.rack: rack
+`
+
+func TestReadInventory(t *testing.T) {
+	mapping := &importMapping{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testMapping), mapping))
+
+	table := []struct {
+		Name          string
+		Format        string
+		Inventory     string
+		Mapping       *importMapping
+		ExpectedIDs   []string
+		ExpectedError bool
+	}{
+		{
+			Name:   "csv-with-mapping",
+			Format: "csv",
+			Inventory: `uuid,mac,lease,workflow,facility,rack
+0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95,08:00:27:00:00:01,86400,true,onprem,r1
+224ee6ab-ad62-4070-a900-ed816444cec0,08:00:27:00:00:02,,false,,r2
+`,
+			Mapping:     mapping,
+			ExpectedIDs: []string{"0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95", "224ee6ab-ad62-4070-a900-ed816444cec0"},
+		},
+		{
+			Name:   "csv-without-mapping",
+			Format: "csv",
+			Inventory: `id,network.interfaces[0].dhcp.mac,labels.rack
+0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95,08:00:27:00:00:01,r1
+`,
+			ExpectedIDs: []string{"0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"},
+		},
+		{
+			Name:   "yaml-documents",
+			Format: "yaml",
+			Inventory: `id: 0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95
+network:
+  interfaces:
+  - dhcp:
+      mac: 08:00:27:00:00:01
+metadata:
+  facility:
+    facility_code: onprem
+---
+id: 224ee6ab-ad62-4070-a900-ed816444cec0
+`,
+			ExpectedIDs: []string{"0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95", "224ee6ab-ad62-4070-a900-ed816444cec0"},
+		},
+		{
+			Name:          "unknown-format",
+			Format:        "xlsx",
+			ExpectedError: true,
+		},
+		{
+			Name:          "yaml-not-an-object",
+			Format:        "yaml",
+			Inventory:     "- a\n- b\n",
+			ExpectedError: true,
+		},
+	}
+
+	for _, s := range table {
+		t.Run(s.Name, func(t *testing.T) {
+			rows, err := readInventory(strings.NewReader(s.Inventory), s.Format, s.Mapping)
+			if s.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			ids := []string{}
+			for _, r := range rows {
+				assert.NoError(t, r.err)
+				ids = append(ids, r.hw.GetId())
+			}
+			assert.Equal(t, s.ExpectedIDs, ids)
+		})
+	}
+}
+
+func TestReadInventoryFields(t *testing.T) {
+	mapping := &importMapping{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testMapping), mapping))
+
+	rows, err := readInventory(strings.NewReader(`uuid,mac,lease,workflow,facility,rack
+0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95,08:00:27:00:00:01,86400,true,onprem,r1
+224ee6ab-ad62-4070-a900-ed816444cec0,08:00:27:00:00:02,soon,false,,r2
+`), "csv", mapping)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	hw := rows[0].hw
+	iface := hw.GetNetwork().GetInterfaces()[0]
+	assert.Equal(t, "08:00:27:00:00:01", iface.GetDhcp().GetMac())
+	assert.Equal(t, int64(86400), iface.GetDhcp().GetLeaseTime())
+	assert.True(t, iface.GetNetboot().GetAllowPxe())
+	assert.True(t, iface.GetNetboot().GetAllowWorkflow())
+	assert.JSONEq(t, `{"facility":{"facility_code":"onprem"}}`, hw.GetMetadata())
+	assert.Equal(t, map[string]string{"rack": "r1"}, hw.GetLabels())
+
+	assert.EqualError(t, rows[1].err, `column lease: "soon" is not a number`)
+}
+
+func TestValidateImport(t *testing.T) {
+	rows, err := readInventory(strings.NewReader(`id,network.interfaces[0].dhcp.mac
+0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95,08:00:27:00:00:01
+0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95,08:00:27:00:00:02
+server001,08:00:27:00:00:03
+224ee6ab-ad62-4070-a900-ed816444cec0,08:00:27:00:00:04
+`), "csv", nil)
+	assert.NoError(t, err)
+
+	assert.False(t, validateImport(rows))
+	assert.NoError(t, rows[0].err)
+	assert.EqualError(t, rows[1].err, "id 0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95 is already used by row 1")
+	assert.EqualError(t, rows[2].err, "id must be set to a UUID, got id: server001")
+	assert.NoError(t, rows[3].err)
+}
@@ -10,18 +10,24 @@ import (
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/spf13/cobra"
 	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/get"
+	"github.com/tinkerbell/tink/pkg"
 	"github.com/tinkerbell/tink/protos/hardware"
 )
 
 var (
-	quiet bool
-	t     table.Writer
+	quiet         bool
+	labelSelector string
+	filters       []string
+	t             table.Writer
 )
 
 func NewListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "list all known hardware",
+		Example: `tink hardware list
+tink hardware list --selector arch=arm64,rack!=r1 --filter metadata.facility.facility_code=onprem`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if quiet {
 				listHardware()
@@ -36,26 +42,39 @@ func NewListCmd() *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.BoolVarP(&quiet, "quiet", "q", false, "only display hardware IDs")
+	flags.StringVarP(&labelSelector, "selector", "l", "", "label selector, like arch=arm64,rack!=r1")
+	flags.StringArrayVar(&filters, "filter", nil, "only display hardware with a value equal to the given one at path, in the form path=value")
 	return cmd
 }
 
 func listHardware() {
-	list, err := client.HardwareClient.All(context.Background(), &hardware.Empty{})
-	if err != nil {
-		log.Fatal(err)
+	req := &hardware.ListRequest{
+		LabelSelector: labelSelector,
+		Filters:       filters,
+		PageSize:      get.PageSize,
 	}
+	for {
+		list, err := client.HardwareClient.All(context.Background(), req)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	var hw *hardware.Hardware
-	for hw, err = list.Recv(); err == nil && hw != nil; hw, err = list.Recv() {
-		for _, iface := range hw.GetNetwork().GetInterfaces() {
-			if quiet {
-				fmt.Println(hw.Id)
-			} else {
-				t.AppendRow(table.Row{hw.Id, iface.Dhcp.Mac, iface.Dhcp.Ip.Address, iface.Dhcp.Hostname})
+		var hw *hardware.Hardware
+		for hw, err = list.Recv(); err == nil; hw, err = list.Recv() {
+			for _, iface := range hw.GetNetwork().GetInterfaces() {
+				if quiet {
+					fmt.Println(hw.Id)
+				} else {
+					t.AppendRow(table.Row{hw.Id, iface.Dhcp.Mac, iface.Dhcp.Ip.Address, iface.Dhcp.Hostname})
+				}
 			}
 		}
-	}
-	if err != nil && err != io.EOF {
-		log.Fatal(err)
+		if err != io.EOF {
+			log.Fatal(err)
+		}
+		req.PageToken = pkg.NextPageToken(list)
+		if req.PageToken == "" {
+			return
+		}
 	}
 }
@@ -0,0 +1,315 @@
+package hardware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/pkg"
+	"github.com/tinkerbell/tink/protos/hardware"
+)
+
+var indexRegexp = regexp.MustCompile(`^([^\[\]]+)\[(\d+)\]$`)
+
+// assignment is a path=value given to --set or --set-json
+type assignment struct {
+	flag  string
+	value string
+}
+
+// assignmentsFlag appends the values of its flag to a list shared by
+// --set and --set-json, keeping the order of the command line
+type assignmentsFlag struct {
+	flag string
+	list *[]assignment
+}
+
+func (f assignmentsFlag) Set(value string) error {
+	*f.list = append(*f.list, assignment{flag: "--" + f.flag, value: value})
+	return nil
+}
+
+func (f assignmentsFlag) String() string {
+	var values []string
+	for _, a := range *f.list {
+		if a.flag == "--"+f.flag {
+			values = append(values, a.value)
+		}
+	}
+	return "[" + strings.Join(values, ",") + "]"
+}
+
+func (f assignmentsFlag) Type() string {
+	return "stringArray"
+}
+
+// NewPatchCmd represents the patch command
+func NewPatchCmd() *cobra.Command {
+	var sets []assignment
+	cmd := &cobra.Command{
+		Use:   "patch",
+		Short: "patch the fields of an existing hardware",
+		Example: `tink hardware patch 224ee6ab-ad62-4070-a900-ed816444cec0 --set metadata.facility.plan_slug=c2.medium.x86
+tink hardware patch 224ee6ab-ad62-4070-a900-ed816444cec0 --set network.interfaces[0].netboot.allow_workflow=false
+tink hardware patch 224ee6ab-ad62-4070-a900-ed816444cec0 --set-json metadata.facility='{"facility_code":"dc1"}' --set metadata.facility.plan_slug=c2.medium.x86`,
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires exactly one id")
+			}
+			return verifyUUIDs(args)
+		},
+		PreRunE: func(c *cobra.Command, args []string) error {
+			if len(sets) == 0 {
+				return fmt.Errorf("at least one '--set' or '--set-json' flag is required")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			hw, err := client.HardwareClient.ByID(ctx, &hardware.GetRequest{Id: args[0]})
+			if err != nil {
+				log.Fatal(err)
+			}
+			if hw.GetId() == "" {
+				log.Fatalf("hardware with id %s not found", args[0])
+			}
+			patch, err := buildMergePatch(hw, sets)
+			if err != nil {
+				log.Fatal(err)
+			}
+			hw, err = client.HardwareClient.Patch(ctx, &hardware.PatchRequest{
+				Id:         hw.Id,
+				MergePatch: patch,
+				Version:    hw.Version,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			b, err := json.Marshal(pkg.HardwareWrapper{Hardware: hw})
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(string(b))
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.Var(assignmentsFlag{flag: "set", list: &sets}, "set", "set a field as path=value, the value being converted to the type of the field")
+	flags.Var(assignmentsFlag{flag: "set-json", list: &sets}, "set-json", "set a field as path=value, the value being parsed as JSON")
+	return cmd
+}
+
+// buildMergePatch applies the path=value assignments, in order, to the JSON
+// representation of hw and returns a JSON merge patch holding the top level
+// fields that got modified. The values of --set are converted to the type of
+// the field, see convertValue, the ones of --set-json are parsed as JSON.
+// Arrays can not be partially patched, so the whole top level field is sent.
+func buildMergePatch(hw *hardware.Hardware, sets []assignment) (string, error) {
+	b, err := json.Marshal(pkg.HardwareWrapper{Hardware: hw})
+	if err != nil {
+		return "", err
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return "", err
+	}
+
+	patch := map[string]interface{}{}
+	for _, set := range sets {
+		parts := strings.SplitN(set.value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return "", fmt.Errorf("invalid %s %q, expected path=value", set.flag, set.value)
+		}
+		path := strings.Split(parts[0], ".")
+		var value interface{}
+		if set.flag == "--set-json" {
+			err = json.Unmarshal([]byte(parts[1]), &value)
+		} else {
+			value, err = convertSetValue(doc, path, parts[1])
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid %s %q", set.flag, set.value)
+		}
+		if err := setPath(doc, path, value, false); err != nil {
+			return "", errors.Wrapf(err, "invalid %s %q", set.flag, set.value)
+		}
+		key := path[0]
+		if m := indexRegexp.FindStringSubmatch(key); m != nil {
+			key = m[1]
+		}
+		patch[key] = doc[key]
+	}
+
+	b, err = json.Marshal(patch)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// convertSetValue converts the value of a --set to the type of the field at
+// path: the type of its current value in doc, or, when it is not set, the
+// type of the field of the hardware message, false booleans and zero numbers
+// being left out of the JSON. Anything else, like the fields of the metadata
+// not set yet, is a string.
+func convertSetValue(doc map[string]interface{}, path []string, value string) (interface{}, error) {
+	var kind reflect.Kind
+	switch lookupPath(doc, path).(type) {
+	case bool:
+		kind = reflect.Bool
+	case float64:
+		kind = reflect.Float64
+	case nil:
+		kind = fieldKind(reflect.TypeOf(hardware.Hardware{}), path)
+	}
+
+	switch kind {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is a boolean", strings.Join(path, "."))
+		}
+		return b, nil
+	case reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is an integer", strings.Join(path, "."))
+		}
+		return i, nil
+	case reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is an unsigned integer", strings.Join(path, "."))
+		}
+		return u, nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is a number", strings.Join(path, "."))
+		}
+		return f, nil
+	}
+	return value, nil
+}
+
+// lookupPath returns the value at path in doc, nil when it is not set
+func lookupPath(doc map[string]interface{}, path []string) interface{} {
+	var value interface{} = doc
+	for _, key := range path {
+		index := -1
+		if m := indexRegexp.FindStringSubmatch(key); m != nil {
+			key = m[1]
+			index, _ = strconv.Atoi(m[2])
+		}
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[key]
+		if index < 0 {
+			continue
+		}
+		list, ok := value.([]interface{})
+		if !ok || index >= len(list) {
+			return nil
+		}
+		value = list[index]
+	}
+	return value
+}
+
+// fieldKind returns the kind of the field of t at path, following the JSON
+// names of the fields, reflect.Invalid when there is none
+func fieldKind(t reflect.Type, path []string) reflect.Kind {
+	for _, key := range path {
+		if m := indexRegexp.FindStringSubmatch(key); m != nil {
+			key = m[1]
+		}
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return reflect.Invalid
+		}
+		field, ok := jsonField(t, key)
+		if !ok {
+			return reflect.Invalid
+		}
+		t = field.Type
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind()
+}
+
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.Split(f.Tag.Get("json"), ",")[0] == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// setPath sets value at path in doc, creating the intermediate objects when
+// they are missing. A path element can index an array with the name[i]
+// syntax. The array has to be long enough unless grow is set, in which case
+// it gets created or extended as needed.
+func setPath(doc map[string]interface{}, path []string, value interface{}, grow bool) error {
+	key := path[0]
+	index := -1
+	if m := indexRegexp.FindStringSubmatch(key); m != nil {
+		key = m[1]
+		index, _ = strconv.Atoi(m[2])
+	}
+
+	if index < 0 {
+		if len(path) == 1 {
+			doc[key] = value
+			return nil
+		}
+		next, ok := doc[key].(map[string]interface{})
+		if !ok {
+			if doc[key] != nil {
+				return fmt.Errorf("%s is not an object", key)
+			}
+			next = map[string]interface{}{}
+			doc[key] = next
+		}
+		return setPath(next, path[1:], value, grow)
+	}
+
+	list, ok := doc[key].([]interface{})
+	if !ok && (!grow || doc[key] != nil) {
+		return fmt.Errorf("%s is not an array", key)
+	}
+	if index >= len(list) {
+		if !grow {
+			return fmt.Errorf("index %d out of range for %s with length %d", index, key, len(list))
+		}
+		list = append(list, make([]interface{}, index+1-len(list))...)
+		doc[key] = list
+	}
+	if len(path) == 1 {
+		list[index] = value
+		return nil
+	}
+	next, ok := list[index].(map[string]interface{})
+	if !ok {
+		if list[index] != nil {
+			return fmt.Errorf("%s[%d] is not an object", key, index)
+		}
+		next = map[string]interface{}{}
+		list[index] = next
+	}
+	return setPath(next, path[1:], value, grow)
+}
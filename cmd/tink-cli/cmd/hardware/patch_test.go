@@ -0,0 +1,127 @@
+package hardware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hardware_proto "github.com/tinkerbell/tink/protos/hardware"
+)
+
+func TestBuildMergePatch(t *testing.T) {
+	hw := &hardware_proto.Hardware{
+		Id:       "224ee6ab-ad62-4070-a900-ed816444cec0",
+		Metadata: `{"facility":{"facility_code":"onprem"}}`,
+		Network: &hardware_proto.Hardware_Network{
+			Interfaces: []*hardware_proto.Hardware_Network_Interface{
+				{
+					Dhcp: &hardware_proto.Hardware_DHCP{
+						Mac: "08:00:27:00:00:01",
+					},
+					Netboot: &hardware_proto.Hardware_Netboot{
+						AllowPxe:      true,
+						AllowWorkflow: true,
+					},
+				},
+			},
+		},
+	}
+
+	table := []struct {
+		Name          string
+		Sets          []assignment
+		ExpectedPatch string
+		ExpectedError bool
+	}{
+		{
+			Name:          "array-index",
+			Sets:          []assignment{{"--set-json", "network.interfaces[0].netboot.allow_workflow=false"}},
+			ExpectedPatch: `{"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:01"},"netboot":{"allow_pxe":true,"allow_workflow":false}}]}}`,
+		},
+		{
+			Name:          "new-nested-field-as-string",
+			Sets:          []assignment{{"--set", "metadata.facility.plan_slug=c2.medium.x86"}},
+			ExpectedPatch: `{"metadata":{"facility":{"facility_code":"onprem","plan_slug":"c2.medium.x86"}}}`,
+		},
+		{
+			Name:          "string-looking-like-json",
+			Sets:          []assignment{{"--set", "metadata.facility.plan_slug=1"}, {"--set", "metadata.facility.spare=true"}},
+			ExpectedPatch: `{"metadata":{"facility":{"facility_code":"onprem","plan_slug":"1","spare":"true"}}}`,
+		},
+		{
+			Name:          "json-object",
+			Sets:          []assignment{{"--set-json", `metadata.facility={"facility_code":"dc1","rack":2}`}},
+			ExpectedPatch: `{"metadata":{"facility":{"facility_code":"dc1","rack":2}}}`,
+		},
+		{
+			Name:          "invalid-json",
+			Sets:          []assignment{{"--set-json", "metadata.facility.plan_slug=c2.medium.x86"}},
+			ExpectedError: true,
+		},
+		{
+			Name:          "multiple-sets",
+			Sets:          []assignment{{"--set", "metadata.state=provisioning"}, {"--set", "network.interfaces[0].dhcp.hostname=server001"}},
+			ExpectedPatch: `{"metadata":{"facility":{"facility_code":"onprem"},"state":"provisioning"},"network":{"interfaces":[{"dhcp":{"hostname":"server001","mac":"08:00:27:00:00:01"},"netboot":{"allow_pxe":true,"allow_workflow":true}}]}}`,
+		},
+		{
+			Name:          "typed-bool",
+			Sets:          []assignment{{"--set", "network.interfaces[0].netboot.allow_workflow=false"}},
+			ExpectedPatch: `{"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:01"},"netboot":{"allow_pxe":true,"allow_workflow":false}}]}}`,
+		},
+		{
+			Name:          "typed-unset-fields",
+			Sets:          []assignment{{"--set", "network.interfaces[0].dhcp.uefi=true"}, {"--set", "network.interfaces[0].dhcp.lease_time=86400"}},
+			ExpectedPatch: `{"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:01","uefi":true,"lease_time":86400},"netboot":{"allow_pxe":true,"allow_workflow":true}}]}}`,
+		},
+		{
+			Name:          "typed-existing-metadata",
+			Sets:          []assignment{{"--set-json", `metadata.facility.rack=2`}, {"--set", "metadata.facility.rack=3"}},
+			ExpectedPatch: `{"metadata":{"facility":{"facility_code":"onprem","rack":3}}}`,
+		},
+		{
+			Name:          "invalid-bool",
+			Sets:          []assignment{{"--set", "network.interfaces[0].netboot.allow_workflow=maybe"}},
+			ExpectedError: true,
+		},
+		{
+			Name:          "command-line-order",
+			Sets:          []assignment{{"--set", "metadata.facility.plan_slug=c2.medium.x86"}, {"--set-json", `metadata.facility={"facility_code":"dc1"}`}, {"--set", "metadata.facility.rack=r1"}},
+			ExpectedPatch: `{"metadata":{"facility":{"facility_code":"dc1","rack":"r1"}}}`,
+		},
+		{
+			Name:          "index-out-of-range",
+			Sets:          []assignment{{"--set-json", "network.interfaces[1].netboot.allow_workflow=false"}},
+			ExpectedError: true,
+		},
+		{
+			Name:          "not-an-object",
+			Sets:          []assignment{{"--set", "id.value=1"}},
+			ExpectedError: true,
+		},
+		{
+			Name:          "missing-value",
+			Sets:          []assignment{{"--set", "metadata"}},
+			ExpectedError: true,
+		},
+	}
+
+	for _, s := range table {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			patch, err := buildMergePatch(hw, s.Sets)
+			if s.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, s.ExpectedPatch, patch)
+		})
+	}
+}
+
+func TestPatchFlagsOrder(t *testing.T) {
+	cmd := NewPatchCmd()
+	err := cmd.ParseFlags([]string{"--set-json", "a={}", "--set", "a.b=1", "--set-json", "c=2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[a.b=1]", cmd.Flag("set").Value.String())
+	assert.Equal(t, "[a={},c=2]", cmd.Flag("set-json").Value.String())
+}
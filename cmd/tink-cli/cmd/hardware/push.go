@@ -21,6 +21,7 @@ import (
 var (
 	file  string
 	sFile = "file"
+	force bool
 )
 
 // pushCmd represents the push command
@@ -60,7 +61,7 @@ tink hardware push --file /tmp/data.json`,
 			if err != nil {
 				log.Fatal(err)
 			}
-			if _, err := client.HardwareClient.Push(context.Background(), &hardware.PushRequest{Data: hw.Hardware}); err != nil {
+			if _, err := client.HardwareClient.Push(context.Background(), &hardware.PushRequest{Data: hw.Hardware, Force: force}); err != nil {
 				log.Fatal(err)
 			}
 			log.Println("Hardware data pushed successfully")
@@ -68,6 +69,7 @@ tink hardware push --file /tmp/data.json`,
 	}
 	flags := cmd.PersistentFlags()
 	flags.StringVarP(&file, "file", "", "", "hardware data file")
+	flags.BoolVar(&force, "force", false, "overwrite the stored hardware whatever its version")
 	return cmd
 }
 
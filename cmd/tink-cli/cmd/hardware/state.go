@@ -0,0 +1,69 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/hardware"
+)
+
+// NewStateCmd represents the state command
+func NewStateCmd() *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "state <id> <state>",
+		Short: "move a hardware to a new lifecycle state",
+		Long: `The state command moves a hardware to a new lifecycle state. The states are
+discovered, available, provisioning, provisioned, deprovisioning and broken.
+The server refuses a transition that is not allowed from the current state,
+unless --force is set.`,
+		Example: "tink hardware state 224ee6ab-ad62-4070-a900-ed816444cec0 available",
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("requires an id and a state")
+			}
+			if _, err := parseState(args[1]); err != nil {
+				return err
+			}
+			return verifyUUIDs(args[:1])
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			state, _ := parseState(args[1])
+			hw, err := client.HardwareClient.SetState(context.Background(), &hardware.SetStateRequest{
+				Id:    args[0],
+				State: state,
+				Force: force,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s: %s\n", hw.Id, stateName(hw.State))
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.BoolVar(&force, "force", false, "skip the check of the transition")
+	return cmd
+}
+
+// parseState parses a state name like "available", or "STATE_AVAILABLE"
+func parseState(s string) (hardware.State, error) {
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, "STATE_") {
+		name = "STATE_" + name
+	}
+	v, ok := hardware.State_value[name]
+	if !ok || v == int32(hardware.State_STATE_UNSPECIFIED) {
+		return hardware.State_STATE_UNSPECIFIED, fmt.Errorf("unknown state %q", s)
+	}
+	return hardware.State(v), nil
+}
+
+// stateName returns the lowercase name of the state, as parseState takes it
+func stateName(s hardware.State) string {
+	return strings.ToLower(strings.TrimPrefix(s.String(), "STATE_"))
+}
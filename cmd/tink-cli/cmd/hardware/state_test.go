@@ -0,0 +1,21 @@
+package hardware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/protos/hardware"
+)
+
+func TestParseState(t *testing.T) {
+	for _, s := range []string{"provisioned", "PROVISIONED", "state_provisioned", "STATE_PROVISIONED"} {
+		state, err := parseState(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, hardware.State_STATE_PROVISIONED, state, s)
+	}
+	for _, s := range []string{"unspecified", "ready", ""} {
+		_, err := parseState(s)
+		assert.Error(t, err, s)
+	}
+	assert.Equal(t, "deprovisioning", stateName(hardware.State_STATE_DEPROVISIONING))
+}
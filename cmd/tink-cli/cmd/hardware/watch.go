@@ -0,0 +1,91 @@
+package hardware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/pkg"
+	"github.com/tinkerbell/tink/protos/hardware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchEvent is how a watch event gets printed, one JSON document per line
+type watchEvent struct {
+	Revision int64               `json:"revision"`
+	Type     string              `json:"type"`
+	Hardware pkg.HardwareWrapper `json:"hardware"`
+}
+
+// NewWatchCmd represents the watch command
+func NewWatchCmd() *cobra.Command {
+	req := &hardware.WatchRequest{}
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "print the changes of the hardware as they happen",
+		Long: `The watch command prints a JSON document for every creation, update and
+deletion of the hardware matching the flags. When the connection with the
+server breaks it resumes from the last change it printed, so no change is
+missed.`,
+		Example: `tink hardware watch --selector rack=r1
+tink hardware watch --id 224ee6ab-ad62-4070-a900-ed816444cec0 --since 42`,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			return verifyUUIDs(req.Ids)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := watchHardware(context.Background(), req, os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.StringArrayVar(&req.Ids, "id", nil, "watch only the hardware with this id, it can be repeated")
+	flags.StringVarP(&req.LabelSelector, "selector", "l", "", "label selector, like arch=arm64,rack!=r1")
+	flags.StringArrayVar(&req.Filters, "filter", nil, "watch only hardware with a value equal to the given one at path, in the form path=value")
+	flags.Int64Var(&req.SinceRevision, "since", 0, "print the changes made after this revision")
+	flags.BoolVar(&req.SendExisting, "existing", false, "start by printing the hardware as it is")
+	return cmd
+}
+
+// watchHardware prints the watch events until the server ends the stream.
+// A stream broken because the server is unavailable is resumed from the
+// last revision received.
+func watchHardware(ctx context.Context, req *hardware.WatchRequest, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for {
+		stream, err := client.HardwareClient.Watch(ctx, req)
+		if err == nil {
+			var ev *hardware.WatchEvent
+			for ev, err = stream.Recv(); err == nil; ev, err = stream.Recv() {
+				err = enc.Encode(watchEvent{
+					Revision: ev.Revision,
+					Type:     strings.ToLower(strings.TrimPrefix(ev.Type.String(), "TYPE_")),
+					Hardware: pkg.HardwareWrapper{Hardware: ev.Hardware},
+				})
+				if err != nil {
+					return err
+				}
+				// the existing hardware is sent only once, the
+				// changes follow it
+				req.SendExisting = false
+				req.SinceRevision = ev.Revision
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "connection lost, resuming from revision %d: %v\n", req.SinceRevision, err)
+		time.Sleep(time.Second)
+	}
+}
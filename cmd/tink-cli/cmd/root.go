@@ -34,6 +34,8 @@ func Execute(version string) error {
 	rootCmd.AddCommand(NewHardwareCommand())
 	rootCmd.AddCommand(NewTemplateCommand())
 	rootCmd.AddCommand(NewWorkflowCommand())
+	rootCmd.AddCommand(NewWebhookCommand())
+	rootCmd.AddCommand(NewAdminCommand())
 	return rootCmd.Execute()
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tinkerbell/tink/client"
 	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/get"
+	"github.com/tinkerbell/tink/pkg"
 	"github.com/tinkerbell/tink/protos/template"
 )
 
@@ -61,24 +62,31 @@ func (h *getTemplate) RetrieveByID(ctx context.Context, cl *client.FullClient, r
 }
 
 func (h *getTemplate) RetrieveData(ctx context.Context, cl *client.FullClient) ([]interface{}, error) {
-	list, err := cl.TemplateClient.ListTemplates(ctx, &template.ListRequest{
+	data := []interface{}{}
+	req := &template.ListRequest{
 		FilterBy: &template.ListRequest_Name{
 			Name: "*",
 		},
-	})
-	if err != nil {
-		return nil, err
+		PageSize: get.PageSize,
 	}
+	for {
+		list, err := cl.TemplateClient.ListTemplates(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 
-	data := []interface{}{}
-	var tmp *template.WorkflowTemplate
-	for tmp, err = list.Recv(); err == nil && tmp.Name != ""; tmp, err = list.Recv() {
-		data = append(data, tmp)
-	}
-	if err != nil && err != io.EOF {
-		return nil, err
+		var tmp *template.WorkflowTemplate
+		for tmp, err = list.Recv(); err == nil; tmp, err = list.Recv() {
+			data = append(data, tmp)
+		}
+		if err != io.EOF {
+			return nil, err
+		}
+		req.PageToken = pkg.NextPageToken(list)
+		if req.PageToken == "" {
+			return data, nil
+		}
 	}
-	return data, nil
 }
 
 func (h *getTemplate) PopulateTable(data []interface{}, t table.Writer) error {
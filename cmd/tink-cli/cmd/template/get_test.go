@@ -13,6 +13,7 @@ import (
 	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/get"
 	"github.com/tinkerbell/tink/protos/template"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -62,6 +63,9 @@ func TestGetTemplate(t *testing.T) {
 								}
 								return s.ReturnedTemplate[s.counter-1], nil
 							},
+							TrailerFunc: func() metadata.MD {
+								return nil
+							},
 						}, nil
 					},
 				},
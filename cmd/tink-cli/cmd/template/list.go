@@ -11,6 +11,8 @@ import (
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/spf13/cobra"
 	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/get"
+	"github.com/tinkerbell/tink/pkg"
 	"github.com/tinkerbell/tink/protos/template"
 )
 
@@ -61,22 +63,29 @@ please familiarise and migrate your automation accordingly.`,
 }
 
 func listTemplates() {
-	list, err := client.TemplateClient.ListTemplates(context.Background(), &template.ListRequest{
+	req := &template.ListRequest{
 		FilterBy: &template.ListRequest_Name{
 			Name: "*",
 		},
-	})
-	if err != nil {
-		log.Fatal(err)
+		PageSize: get.PageSize,
 	}
+	for {
+		list, err := client.TemplateClient.ListTemplates(context.Background(), req)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	var tmp *template.WorkflowTemplate
-	for tmp, err = list.Recv(); err == nil && tmp.Name != ""; tmp, err = list.Recv() {
-		printOutput(tmp)
-	}
-
-	if err != nil && err != io.EOF {
-		log.Fatal(err)
+		var tmp *template.WorkflowTemplate
+		for tmp, err = list.Recv(); err == nil; tmp, err = list.Recv() {
+			printOutput(tmp)
+		}
+		if err != io.EOF {
+			log.Fatal(err)
+		}
+		req.PageToken = pkg.NextPageToken(list)
+		if req.PageToken == "" {
+			return
+		}
 	}
 }
 
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/webhook"
+)
+
+func NewWebhookCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "webhook",
+		Short:   "tink webhook client",
+		Example: "tink webhook [command]",
+		Args: func(c *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("%v requires arguments", c.UseLine())
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(webhook.NewCreateCmd())
+	cmd.AddCommand(webhook.NewDeleteCmd())
+	cmd.AddCommand(webhook.NewFailuresCmd())
+	cmd.AddCommand(webhook.NewListCmd())
+
+	return cmd
+}
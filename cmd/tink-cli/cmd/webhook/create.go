@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/webhook"
+)
+
+// NewCreateCmd represents the create command
+func NewCreateCmd() *cobra.Command {
+	var (
+		secret string
+		events []string
+	)
+	cmd := &cobra.Command{
+		Use:   "create <url>",
+		Short: "subscribe an endpoint to the workflow events",
+		Long: `The create command subscribes an HTTP endpoint to the workflow events. The
+server POSTs a JSON document to the endpoint for every event, with its
+HMAC-SHA256 signature keyed with the secret in the X-Tink-Signature header.
+The signature covers the X-Tink-Timestamp header, the Unix time in seconds
+of the request, a dot and the document: the endpoint should refuse the
+requests more than 5 minutes away from its clock.
+The events are workflow_state_changed and action_failed, all of them are
+sent when --event is not set.
+
+The secret is read from the TINK_WEBHOOK_SECRET environment variable when
+--secret is not set.`,
+		Example: "tink webhook create https://tickets.example.com/tink --secret s3cr3t --event action_failed",
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires the url of the endpoint")
+			}
+			if secret == "" {
+				secret = os.Getenv("TINK_WEBHOOK_SECRET")
+			}
+			if secret == "" {
+				return errors.New("requires a secret, set --secret or TINK_WEBHOOK_SECRET")
+			}
+			for _, e := range events {
+				if _, err := parseEventType(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			req := &webhook.CreateRequest{Url: args[0], Secret: secret}
+			for _, e := range events {
+				t, _ := parseEventType(e)
+				req.EventTypes = append(req.EventTypes, t)
+			}
+			res, err := client.WebhookClient.CreateWebhook(context.Background(), req)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println("Created Webhook:", res.Id)
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&secret, "secret", "", "key of the signature of the payloads")
+	flags.StringArrayVar(&events, "event", nil, "send only this event, it can be repeated")
+	return cmd
+}
+
+// parseEventType parses an event name like "action_failed", or
+// "EVENT_TYPE_ACTION_FAILED"
+func parseEventType(s string) (webhook.EventType, error) {
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, "EVENT_TYPE_") {
+		name = "EVENT_TYPE_" + name
+	}
+	v, ok := webhook.EventType_value[name]
+	if !ok || v == int32(webhook.EventType_EVENT_TYPE_UNSPECIFIED) {
+		return webhook.EventType_EVENT_TYPE_UNSPECIFIED, fmt.Errorf("unknown event %q", s)
+	}
+	return webhook.EventType(v), nil
+}
+
+// eventTypeName returns the lowercase name of the event, as parseEventType
+// takes it
+func eventTypeName(t webhook.EventType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "EVENT_TYPE_"))
+}
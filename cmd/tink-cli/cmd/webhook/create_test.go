@@ -0,0 +1,21 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/protos/webhook"
+)
+
+func TestParseEventType(t *testing.T) {
+	for _, s := range []string{"action_failed", "ACTION_FAILED", "event_type_action_failed", "EVENT_TYPE_ACTION_FAILED"} {
+		e, err := parseEventType(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, webhook.EventType_EVENT_TYPE_ACTION_FAILED, e, s)
+	}
+	for _, s := range []string{"unspecified", "workflow_done", ""} {
+		_, err := parseEventType(s)
+		assert.Error(t, err, s)
+	}
+	assert.Equal(t, "workflow_state_changed", eventTypeName(webhook.EventType_EVENT_TYPE_WORKFLOW_STATE_CHANGED))
+}
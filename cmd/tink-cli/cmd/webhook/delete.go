@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/webhook"
+)
+
+// NewDeleteCmd represents the delete command
+func NewDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <id>",
+		Short:   "remove a webhook subscription",
+		Example: "tink webhook delete 224ee6ab-ad62-4070-a900-ed816444cec0",
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("requires the id of the webhook")
+			}
+			if _, err := uuid.Parse(args[0]); err != nil {
+				return fmt.Errorf("invalid uuid: %s", args[0])
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			_, err := client.WebhookClient.DeleteWebhook(context.Background(), &webhook.GetRequest{Id: args[0]})
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println("Deleted Webhook:", args[0])
+		},
+	}
+}
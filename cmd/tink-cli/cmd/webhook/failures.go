@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/webhook"
+)
+
+// NewFailuresCmd represents the failures command
+func NewFailuresCmd() *cobra.Command {
+	var showPayload bool
+	cmd := &cobra.Command{
+		Use:   "failures [webhook id]",
+		Short: "list the events that could not be delivered",
+		Long: `The failures command lists the events the server gave up delivering after
+all the attempts, for one webhook or for all of them.`,
+		Example: `tink webhook failures
+tink webhook failures 224ee6ab-ad62-4070-a900-ed816444cec0 --payload`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req := &webhook.ListFailedDeliveriesRequest{}
+			if len(args) == 1 {
+				req.WebhookId = args[0]
+			}
+			if err := listFailures(context.Background(), req, showPayload, os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	cmd.PersistentFlags().BoolVar(&showPayload, "payload", false, "print the payloads that were sent")
+	return cmd
+}
+
+func listFailures(ctx context.Context, req *webhook.ListFailedDeliveriesRequest, showPayload bool, w io.Writer) error {
+	stream, err := client.WebhookClient.ListFailedDeliveries(ctx, req)
+	if err != nil {
+		return err
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	header := table.Row{"Delivery ID", "Webhook ID", "Event", "Attempts", "Error", "Created At"}
+	if showPayload {
+		header = append(header, "Payload")
+	}
+	t.AppendHeader(header)
+	var f *webhook.FailedDelivery
+	for f, err = stream.Recv(); err == nil; f, err = stream.Recv() {
+		row := table.Row{f.Id, f.WebhookId, eventTypeName(f.EventType), f.Attempts, f.Error, time.Unix(f.CreatedAt.GetSeconds(), 0)}
+		if showPayload {
+			row = append(row, f.Payload)
+		}
+		t.AppendRow(row)
+	}
+	if err != io.EOF {
+		return err
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/webhook"
+)
+
+// NewListCmd represents the list command
+func NewListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "list the webhook subscriptions",
+		Example: "tink webhook list",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := listWebhooks(context.Background(), os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+}
+
+func listWebhooks(ctx context.Context, w io.Writer) error {
+	stream, err := client.WebhookClient.ListWebhooks(ctx, &webhook.Empty{})
+	if err != nil {
+		return err
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Webhook ID", "URL", "Events", "Created At"})
+	var wh *webhook.Webhook
+	for wh, err = stream.Recv(); err == nil; wh, err = stream.Recv() {
+		events := []string{}
+		for _, e := range wh.EventTypes {
+			events = append(events, eventTypeName(e))
+		}
+		if len(events) == 0 {
+			events = append(events, "all")
+		}
+		t.AppendRow(table.Row{wh.Id, wh.Url, strings.Join(events, ","), time.Unix(wh.CreatedAt.GetSeconds(), 0)})
+	}
+	if err != io.EOF {
+		return err
+	}
+	t.Render()
+	return nil
+}
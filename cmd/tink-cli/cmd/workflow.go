@@ -29,6 +29,7 @@ func NewWorkflowCommand() *cobra.Command {
 	cmd.AddCommand(workflow.NewShowCommand())
 	cmd.AddCommand(workflow.NewListCommand())
 	cmd.AddCommand(workflow.NewStateCommand())
+	cmd.AddCommand(workflow.NewWatchCommand())
 
 	// If the variable TINK_CLI_VERSION is not set to 0.0.0 use the old get
 	// command
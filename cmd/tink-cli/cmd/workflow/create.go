@@ -4,38 +4,66 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 
+	"github.com/jedib0t/go-pretty/table"
 	"github.com/spf13/cobra"
 	"github.com/tinkerbell/tink/client"
 	"github.com/tinkerbell/tink/protos/workflow"
 )
 
 var (
-	fTemplate = "template"
-	fHardware = "hardware"
-	template  string
-	hardware  string
+	fTemplate       = "template"
+	fHardware       = "hardware"
+	template        string
+	hardware        string
+	labelSelector   string
+	filters         []string
+	hardwareMapping string
+	dryRun          bool
 )
 
 func NewCreateCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "create a workflow",
-		Example: "tink workflow create [flags]",
+		Use:   "create",
+		Short: "create a workflow",
+		Example: `tink workflow create [flags]
+tink workflow create -t <template-id> -r '{"device_1": "08:00:27:00:00:01"}'
+tink workflow create -t <template-id> --selector rack=r1 --dry-run`,
 		PreRunE: func(c *cobra.Command, args []string) error {
 			tmp, _ := c.Flags().GetString(fTemplate)
 			err := validateID(tmp)
-			return err
+			if err != nil {
+				return err
+			}
+			bulk := labelSelector != "" || len(filters) > 0
+			if hardware == "" && !bulk {
+				return fmt.Errorf("either '--%s' or '--selector'/'--filter' is required", fHardware)
+			}
+			if hardware != "" && bulk {
+				return fmt.Errorf("'--%s' can not be used together with '--selector'/'--filter'", fHardware)
+			}
+			if !bulk && (hardwareMapping != "" || dryRun) {
+				return fmt.Errorf("'--mapping' and '--dry-run' require '--selector' or '--filter'")
+			}
+			return nil
 		},
 		Run: func(c *cobra.Command, args []string) {
-			createWorkflow(args)
+			if hardware != "" {
+				createWorkflow(args)
+				return
+			}
+			createWorkflows()
 		},
 	}
 	flags := cmd.PersistentFlags()
 	flags.StringVarP(&template, "template", "t", "", "workflow template")
 	flags.StringVarP(&hardware, "hardware", "r", "", "workflow targeted hardwares")
+	flags.StringVarP(&labelSelector, "selector", "l", "", "create a workflow for every hardware matching the label selector, like rack=r1")
+	flags.StringArrayVar(&filters, "filter", nil, "create a workflow for every hardware matching the filter, in the form path=value")
+	flags.StringVar(&hardwareMapping, "mapping", "", `Go template rendering the workflow targeted hardwares from each hardware (default '{"device_1": "{{ (index .network.interfaces 0).dhcp.mac }}"}')`)
+	flags.BoolVar(&dryRun, "dry-run", false, "only list the hardware the workflows would be created for")
 
-	_ = cmd.MarkPersistentFlagRequired(fHardware)
 	_ = cmd.MarkPersistentFlagRequired(fTemplate)
 	return cmd
 }
@@ -48,3 +76,36 @@ func createWorkflow(args []string) {
 	}
 	fmt.Println("Created Workflow: ", res.Id)
 }
+
+func createWorkflows() {
+	req := workflow.CreateWorkflowsRequest{
+		Template:        template,
+		LabelSelector:   labelSelector,
+		Filters:         filters,
+		HardwareMapping: hardwareMapping,
+		DryRun:          dryRun,
+	}
+	res, err := client.WorkflowClient.CreateWorkflows(context.Background(), &req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(res.Targets) == 0 {
+		fmt.Println("No hardware matches the selector")
+		return
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	if dryRun {
+		t.AppendHeader(table.Row{"Hardware ID", "Hardware"})
+	} else {
+		t.AppendHeader(table.Row{"Workflow ID", "Hardware ID", "Hardware"})
+	}
+	for _, target := range res.Targets {
+		if dryRun {
+			t.AppendRow(table.Row{target.HardwareId, target.Hardware})
+		} else {
+			t.AppendRow(table.Row{target.WorkflowId, target.HardwareId, target.Hardware})
+		}
+	}
+	t.Render()
+}
@@ -23,18 +23,29 @@ var (
 )
 
 func NewShowCommand() *cobra.Command {
+	var follow bool
 	cmd := &cobra.Command{
-		Use:                   "events [id]",
-		Short:                 "show all events for a workflow",
-		DisableFlagsInUseLine: true,
-		Example:               "tink workflow events [id]",
+		Use:     "events [id]",
+		Short:   "show all events for a workflow",
+		Example: "tink workflow events [id]",
+		Long: `The events command shows the events recorded for workflows. With --follow
+it prints the events of a single workflow as they are recorded, until the
+workflow is done, and it exits like tink workflow watch: with 0 when the
+workflow succeeded, 2 when it failed, 3 when it timed out and 4 when the
+events ended before the workflow was done.`,
 		Args: func(c *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				return fmt.Errorf("%v takes an arguments", c.UseLine())
 			}
+			if follow && len(args) != 1 {
+				return fmt.Errorf("%v follows a single workflow", c.UseLine())
+			}
 			return nil
 		},
 		Run: func(c *cobra.Command, args []string) {
+			if follow {
+				os.Exit(watchWorkflow(args[0]))
+			}
 			t := table.NewWriter()
 			t.SetOutputMirror(os.Stdout)
 			t.AppendHeader(table.Row{hWorkerID, hTaskName, hActionName, hExecutionTime, hMessage, hStatus})
@@ -43,6 +54,7 @@ func NewShowCommand() *cobra.Command {
 
 		},
 	}
+	cmd.PersistentFlags().BoolVar(&follow, "follow", false, "print the new events until the workflow is done")
 	return cmd
 }
 
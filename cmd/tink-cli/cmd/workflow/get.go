@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tinkerbell/tink/client"
 	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/get"
+	"github.com/tinkerbell/tink/pkg"
 	"github.com/tinkerbell/tink/protos/workflow"
 )
 
@@ -65,21 +66,26 @@ func (h *getWorkflow) RetrieveByID(ctx context.Context, cl *client.FullClient, r
 }
 
 func (h *getWorkflow) RetrieveData(ctx context.Context, cl *client.FullClient) ([]interface{}, error) {
-	list, err := cl.WorkflowClient.ListWorkflows(ctx, &workflow.Empty{})
-	if err != nil {
-		return nil, err
-	}
-
 	data := []interface{}{}
+	req := &workflow.ListRequest{PageSize: get.PageSize}
+	for {
+		list, err := cl.WorkflowClient.ListWorkflows(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 
-	var w *workflow.Workflow
-	for w, err = list.Recv(); err == nil && w.Id != ""; w, err = list.Recv() {
-		data = append(data, w)
-	}
-	if err != nil && err != io.EOF {
-		return nil, err
+		var w *workflow.Workflow
+		for w, err = list.Recv(); err == nil; w, err = list.Recv() {
+			data = append(data, w)
+		}
+		if err != io.EOF {
+			return nil, err
+		}
+		req.PageToken = pkg.NextPageToken(list)
+		if req.PageToken == "" {
+			return data, nil
+		}
 	}
-	return data, nil
 }
 
 func (h *getWorkflow) PopulateTable(data []interface{}, t table.Writer) error {
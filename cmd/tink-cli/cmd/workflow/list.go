@@ -11,6 +11,8 @@ import (
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/spf13/cobra"
 	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/cmd/tink-cli/cmd/get"
+	"github.com/tinkerbell/tink/pkg"
 	"github.com/tinkerbell/tink/protos/workflow"
 )
 
@@ -63,18 +65,24 @@ func NewListCommand() *cobra.Command {
 }
 
 func listWorkflows() {
-	list, err := client.WorkflowClient.ListWorkflows(context.Background(), &workflow.Empty{})
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	var wf *workflow.Workflow
-	for wf, err = list.Recv(); err == nil && wf.Id != ""; wf, err = list.Recv() {
-		printOutput(wf)
-	}
+	req := &workflow.ListRequest{PageSize: get.PageSize}
+	for {
+		list, err := client.WorkflowClient.ListWorkflows(context.Background(), req)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	if err != nil && err != io.EOF {
-		log.Fatal(err)
+		var wf *workflow.Workflow
+		for wf, err = list.Recv(); err == nil; wf, err = list.Recv() {
+			printOutput(wf)
+		}
+		if err != io.EOF {
+			log.Fatal(err)
+		}
+		req.PageToken = pkg.NextPageToken(list)
+		if req.PageToken == "" {
+			return
+		}
 	}
 }
 
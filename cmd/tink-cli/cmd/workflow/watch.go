@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The exit codes of the commands following a workflow, a failure of the
+// command itself exits with 1
+const (
+	exitSuccess = 0
+	exitFailed  = 2
+	exitTimeout = 3
+	// exitUnknown is for a stream ending before the workflow is done
+	exitUnknown = 4
+)
+
+// reconnectDelay is the wait before resuming a broken stream
+var reconnectDelay = time.Second
+
+// NewWatchCommand represents the watch command
+func NewWatchCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch <id>",
+		Short: "print the events of a workflow until it is done",
+		Long: `The watch command prints the events of a workflow as they are recorded,
+until the workflow succeeds, fails or times out. It exits with 0 when the
+workflow succeeded, 2 when it failed, 3 when it timed out and 4 when the
+events ended before the workflow was done.`,
+		Example: "tink workflow watch 224ee6ab-ad62-4070-a900-ed816444cec0 && echo provisioned",
+		Args:    cobra.ExactArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			os.Exit(watchWorkflow(args[0]))
+		},
+	}
+}
+
+// watchWorkflow follows the events of the workflow and returns the exit
+// code matching its final state
+func watchWorkflow(id string) int {
+	state, err := followWorkflowEvents(context.Background(), id, os.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return exitCode(state)
+}
+
+// followWorkflowEvents prints the events of the workflow until it is done
+// and returns the state of the last one. A stream broken because the server
+// is unavailable is resumed after the last event received.
+func followWorkflowEvents(ctx context.Context, id string, w io.Writer) (workflow.State, error) {
+	req := &workflow.WatchWorkflowEventsRequest{Id: id}
+	last := workflow.State_STATE_PENDING
+	for {
+		stream, err := client.WorkflowClient.WatchWorkflowEvents(ctx, req)
+		if err == nil {
+			var ev *workflow.WorkflowActionStatus
+			for ev, err = stream.Recv(); err == nil; ev, err = stream.Recv() {
+				fmt.Fprintln(w, formatEvent(ev))
+				last = ev.ActionStatus
+				req.AfterEventId = ev.EventId
+			}
+		}
+		if err == io.EOF {
+			return last, nil
+		}
+		if status.Code(err) != codes.Unavailable {
+			return last, err
+		}
+		fmt.Fprintf(os.Stderr, "connection lost, resuming after event %d: %v\n", req.AfterEventId, err)
+		time.Sleep(reconnectDelay)
+	}
+}
+
+func formatEvent(ev *workflow.WorkflowActionStatus) string {
+	return fmt.Sprintf("%s %s %s/%s %s %ds %s",
+		ev.CreatedAt.AsTime().Local().Format(time.RFC3339), ev.WorkerId, ev.TaskName, ev.ActionName,
+		ev.ActionStatus, ev.Seconds, ev.Message)
+}
+
+func exitCode(state workflow.State) int {
+	switch state {
+	case workflow.State_STATE_SUCCESS:
+		return exitSuccess
+	case workflow.State_STATE_FAILED:
+		return exitFailed
+	case workflow.State_STATE_TIMEOUT:
+		return exitTimeout
+	}
+	return exitUnknown
+}
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/client"
+	"github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFollowWorkflowEvents(t *testing.T) {
+	const id = "224ee6ab-ad62-4070-a900-ed816444cec0"
+	// the first stream breaks after one event, the second one resumes
+	// after it
+	streams := [][]*workflow.WorkflowActionStatus{
+		{{TaskName: "provision", ActionName: "disk-wipe", ActionStatus: workflow.State_STATE_RUNNING, EventId: 7}},
+		{{TaskName: "provision", ActionName: "disk-wipe", ActionStatus: workflow.State_STATE_FAILED, Message: "exit status 1", EventId: 8}},
+	}
+	var resumes []int64
+	defer func(c workflow.WorkflowServiceClient) { client.WorkflowClient = c }(client.WorkflowClient)
+	client.WorkflowClient = &workflow.WorkflowServiceClientMock{
+		WatchWorkflowEventsFunc: func(ctx context.Context, in *workflow.WatchWorkflowEventsRequest, opts ...grpc.CallOption) (workflow.WorkflowService_WatchWorkflowEventsClient, error) {
+			assert.Equal(t, id, in.Id)
+			resumes = append(resumes, in.AfterEventId)
+			events := streams[len(resumes)-1]
+			end := io.EOF
+			if len(resumes) == 1 {
+				end = status.Error(codes.Unavailable, "server is shutting down")
+			}
+			return &workflow.WorkflowService_WatchWorkflowEventsClientMock{
+				RecvFunc: func() (*workflow.WorkflowActionStatus, error) {
+					if len(events) == 0 {
+						return nil, end
+					}
+					ev := events[0]
+					events = events[1:]
+					return ev, nil
+				},
+			}, nil
+		},
+	}
+	reconnectDelay = 0
+
+	out := &bytes.Buffer{}
+	state, err := followWorkflowEvents(context.Background(), id, out)
+	assert.NoError(t, err)
+	assert.Equal(t, workflow.State_STATE_FAILED, state)
+	assert.Equal(t, []int64{0, 7}, resumes)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Contains(t, lines[1], "provision/disk-wipe STATE_FAILED 0s exit status 1")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, exitCode(workflow.State_STATE_SUCCESS))
+	assert.Equal(t, 2, exitCode(workflow.State_STATE_FAILED))
+	assert.Equal(t, 3, exitCode(workflow.State_STATE_TIMEOUT))
+	// a stream ending before the workflow is done is neither a success
+	// nor a failure
+	assert.Equal(t, 4, exitCode(workflow.State_STATE_RUNNING))
+	assert.Equal(t, 4, exitCode(workflow.State_STATE_PENDING))
+}
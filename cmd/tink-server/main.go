@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/packethost/pkg/log"
 	"github.com/spf13/cobra"
@@ -28,10 +29,18 @@ var (
 // You can change the configuration via environment variable, or file, or command flags.
 type DaemonConfig struct {
 	Facility              string
+	Backend               string
+	BoltPath              string
 	PGDatabase            string
 	PGUSer                string
 	PGPassword            string
 	PGSSLMode             string
+	PGMaxOpenConns        int
+	PGMaxIdleConns        int
+	PGConnMaxLifetime     time.Duration
+	PGQueryTimeout        time.Duration
+	PGReplicaDSN          string
+	PGReplicaMaxLag       time.Duration
 	OnlyMigration         bool
 	GRPCAuthority         string
 	TLSCert               string
@@ -39,19 +48,41 @@ type DaemonConfig struct {
 	HTTPAuthority         string
 	HTTPBasicAuthUsername string
 	HTTPBasicAuthPassword string
+	WatchInterval         time.Duration
+	WebhookAttempts       int
+	WebhookTimeout        time.Duration
+	RetentionDays         int
+	GCInterval            time.Duration
+	MaxDataVersions       int
+	AllowAllNamespaces    bool
 }
 
 func (c *DaemonConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.Facility, "facility", "deprecated", "This is temporary. It will be removed")
+	fs.StringVar(&c.Backend, "backend", "postgres", "The database storing the data: postgres, or bolt to use an embedded one")
+	fs.StringVar(&c.BoltPath, "bolt-path", "/var/lib/tinkerbell/tink.db", "The file of the embedded database, with --backend bolt")
 	fs.StringVar(&c.PGDatabase, "postgres-database", "tinkerbell", "The Postgres database name")
 	fs.StringVar(&c.PGUSer, "postgres-user", "tinkerbell", "The Postgres database username")
 	fs.StringVar(&c.PGPassword, "postgres-password", "tinkerbell", "The Postgres database password")
 	fs.StringVar(&c.PGSSLMode, "postgres-sslmode", "disable", "Enable or disable SSL mode in postgres")
+	fs.IntVar(&c.PGMaxOpenConns, "postgres-max-open-conns", 0, "The maximum number of open connections to the Postgres database, zero means no limit")
+	fs.IntVar(&c.PGMaxIdleConns, "postgres-max-idle-conns", 2, "The maximum number of idle connections to the Postgres database kept in the pool")
+	fs.DurationVar(&c.PGConnMaxLifetime, "postgres-conn-max-lifetime", 0, "How long a connection to the Postgres database can be reused, zero means forever")
+	fs.DurationVar(&c.PGQueryTimeout, "postgres-query-timeout", 30*time.Second, "How long a call to the Postgres database can take before it is cancelled, zero means no timeout")
+	fs.StringVar(&c.PGReplicaDSN, "postgres-replica-dsn", "", "The connection string of a read replica of the Postgres database serving the list and get calls, none when empty")
+	fs.DurationVar(&c.PGReplicaMaxLag, "postgres-replica-max-lag", 5*time.Second, "How far behind the primary the read replica can be before the reads go back to the primary, zero means no bound")
 	fs.BoolVar(&c.OnlyMigration, "only-migration", false, "When enabled the server applies the migration to postgres database and it exits")
 	fs.StringVar(&c.GRPCAuthority, "grpc-authority", ":42113", "The address used to expose the gRPC server")
 	fs.StringVar(&c.TLSCert, "tls-cert", "", "")
 	fs.StringVar(&c.CertDir, "cert-dir", "", "")
 	fs.StringVar(&c.HTTPAuthority, "http-authority", ":42114", "The address used to expose the HTTP server")
+	fs.DurationVar(&c.WatchInterval, "watch-interval", time.Second, "How often the hardware watchers look for changes made by other servers")
+	fs.IntVar(&c.WebhookAttempts, "webhook-attempts", 5, "How many times a webhook delivery is tried before it is recorded as failed")
+	fs.DurationVar(&c.WebhookTimeout, "webhook-timeout", 10*time.Second, "The timeout of every webhook delivery attempt")
+	fs.IntVar(&c.RetentionDays, "retention-days", 0, "How many days the deleted hardware, templates and workflows, and the events and data of the finished workflows, are kept. Zero keeps them forever")
+	fs.DurationVar(&c.GCInterval, "gc-interval", time.Hour, "How often what is past the retention period gets removed")
+	fs.IntVar(&c.MaxDataVersions, "max-workflow-data-versions", db.DefaultMaxDataVersions, "How many versions of the workflow data keep their data, when the template of the workflow does not set max_data_versions")
+	fs.BoolVar(&c.AllowAllNamespaces, "allow-all-namespaces", false, "Serve the requests for all the namespaces, made with the namespace *, like the ones of boots and hegel when the hardware is in several namespaces. With the ones of the default namespace, they are the only ones using the events, the webhooks and the administration")
 }
 
 func (c *DaemonConfig) PopulateFromLegacyEnvVar() {
@@ -93,6 +124,11 @@ func (c *DaemonConfig) PopulateFromLegacyEnvVar() {
 	if basicAuthPass := os.Getenv("TINK_AUTH_PASSWORD"); basicAuthPass != "" {
 		c.HTTPBasicAuthPassword = basicAuthPass
 	}
+	if maxVersions := os.Getenv("MAX_WORKFLOW_DATA_VERSIONS"); maxVersions != "" {
+		if v, err := strconv.Atoi(maxVersions); err == nil {
+			c.MaxDataVersions = v
+		}
+	}
 }
 
 func main() {
@@ -114,7 +150,7 @@ func main() {
 func NewRootCommand(config *DaemonConfig, logger log.Logger) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "tink-server",
-		PreRunE: func(cmd *cobra.Command, args []string) error {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			viper, err := createViper(logger)
 			if err != nil {
 				return err
@@ -140,46 +176,46 @@ func NewRootCommand(config *DaemonConfig, logger log.Logger) *cobra.Command {
 			// figure this out in another PR
 			errCh := make(chan error, 2)
 
-			// TODO(gianarb): I moved this up because we need to be sure that both
-			// connection, the one used for the resources and the one used for
-			// listening to events and notification are coming in the same way.
-			// BUT we should be using the right flags
-			connInfo := fmt.Sprintf("dbname=%s user=%s password=%s sslmode=%s",
-				config.PGDatabase,
-				config.PGUSer,
-				config.PGPassword,
-				config.PGSSLMode,
-			)
-
-			dbCon, err := sql.Open("postgres", connInfo)
-			if err != nil {
-				return err
-			}
-			tinkDB := db.Connect(dbCon, logger)
-
-			if config.OnlyMigration {
-				logger.Info("Applying migrations. This process will end when migrations will take place.")
-				numAppliedMigrations, err := tinkDB.Migrate()
+			var database db.Database
+			switch config.Backend {
+			case "postgres":
+				tinkDB, err := connectPostgres(config, logger)
+				if err != nil || tinkDB == nil {
+					return err
+				}
+				defer tinkDB.Close()
+				tinkDB.SetMaxDataVersions(config.MaxDataVersions)
+				database = tinkDB
+			case "bolt":
+				if config.OnlyMigration {
+					logger.Info("The embedded database has no migrations to apply.")
+					return nil
+				}
+				boltDB, err := db.OpenBolt(config.BoltPath, logger)
 				if err != nil {
 					return err
 				}
-				logger.With("num_applied_migrations", numAppliedMigrations).Info("Migrations applied successfully")
-				return nil
-			}
-
-			numAvailableMigrations, err := tinkDB.CheckRequiredMigrations()
-			if err != nil {
-				return err
-			}
-			if numAvailableMigrations != 0 {
-				logger.Info("Your database schema is not up to date. Please apply migrations running tink-server with env var ONLY_MIGRATION set.")
+				defer boltDB.Close()
+				boltDB.SetMaxDataVersions(config.MaxDataVersions)
+				database = boltDB
+			default:
+				return fmt.Errorf("unknown backend %q, expected postgres or bolt", config.Backend)
 			}
 
 			cert, modT := rpcServer.SetupGRPC(ctx, logger, &rpcServer.ConfigGRPCServer{
 				Facility:      config.Facility,
 				TLSCert:       config.TLSCert,
 				GRPCAuthority: config.GRPCAuthority,
-				DB:            tinkDB,
+				DB:            database,
+				WatchInterval: config.WatchInterval,
+
+				WebhookAttempts: config.WebhookAttempts,
+				WebhookTimeout:  config.WebhookTimeout,
+
+				RetentionDays: config.RetentionDays,
+				GCInterval:    config.GCInterval,
+
+				AllowAllNamespaces: config.AllowAllNamespaces,
 			}, errCh)
 
 			httpServer.SetupHTTP(ctx, logger, &httpServer.HTTPServerConfig{
@@ -193,14 +229,14 @@ func NewRootCommand(config *DaemonConfig, logger log.Logger) *cobra.Command {
 
 			<-ctx.Done()
 			select {
-			case err = <-errCh:
+			case err := <-errCh:
 				logger.Error(err)
 			case sig := <-sigs:
 				logger.With("signal", sig.String()).Info("signal received, stopping servers")
 			}
 
 			// wait for grpc server to shutdown
-			err = <-errCh
+			err := <-errCh
 			if err != nil {
 				return err
 			}
@@ -211,10 +247,79 @@ func NewRootCommand(config *DaemonConfig, logger log.Logger) *cobra.Command {
 			return nil
 		},
 	}
-	config.AddFlags(cmd.Flags())
+	config.AddFlags(cmd.PersistentFlags())
+	cmd.AddCommand(NewMigrateCommand(config, logger))
 	return cmd
 }
 
+// openPostgres opens the PostgreSQL database of the server
+func openPostgres(config *DaemonConfig, logger log.Logger) (*db.TinkDB, error) {
+	// TODO(gianarb): I moved this up because we need to be sure that both
+	// connection, the one used for the resources and the one used for
+	// listening to events and notification are coming in the same way.
+	// BUT we should be using the right flags
+	connInfo := fmt.Sprintf("dbname=%s user=%s password=%s sslmode=%s",
+		config.PGDatabase,
+		config.PGUSer,
+		config.PGPassword,
+		config.PGSSLMode,
+	)
+
+	dbCon, err := sql.Open("postgres", connInfo)
+	if err != nil {
+		return nil, err
+	}
+	dbCon.SetMaxOpenConns(config.PGMaxOpenConns)
+	dbCon.SetMaxIdleConns(config.PGMaxIdleConns)
+	dbCon.SetConnMaxLifetime(config.PGConnMaxLifetime)
+
+	tinkDB := db.Connect(dbCon, logger)
+	tinkDB.SetQueryTimeout(config.PGQueryTimeout)
+
+	if config.PGReplicaDSN != "" {
+		replicaCon, err := sql.Open("postgres", config.PGReplicaDSN)
+		if err != nil {
+			_ = dbCon.Close()
+			return nil, err
+		}
+		replicaCon.SetMaxOpenConns(config.PGMaxOpenConns)
+		replicaCon.SetMaxIdleConns(config.PGMaxIdleConns)
+		replicaCon.SetConnMaxLifetime(config.PGConnMaxLifetime)
+		tinkDB.SetReplica(replicaCon, config.PGReplicaMaxLag)
+	}
+	return tinkDB, nil
+}
+
+// connectPostgres returns the PostgreSQL database of the server, or nil when
+// the server only had to apply the migrations
+func connectPostgres(config *DaemonConfig, logger log.Logger) (*db.TinkDB, error) {
+	tinkDB, err := openPostgres(config, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.OnlyMigration {
+		defer tinkDB.Close()
+		logger.Info("Applying migrations. This process will end when migrations will take place.")
+		numAppliedMigrations, err := tinkDB.Migrate()
+		if err != nil {
+			return nil, err
+		}
+		logger.With("num_applied_migrations", numAppliedMigrations).Info("Migrations applied successfully")
+		return nil, nil
+	}
+
+	numAvailableMigrations, err := tinkDB.CheckRequiredMigrations()
+	if err != nil {
+		_ = tinkDB.Close()
+		return nil, err
+	}
+	if numAvailableMigrations != 0 {
+		logger.Info("Your database schema is not up to date. Please apply migrations running tink-server migrate up, or with env var ONLY_MIGRATION set.")
+	}
+	return tinkDB, nil
+}
+
 func createViper(logger log.Logger) (*viper.Viper, error) {
 	v := viper.New()
 	v.AutomaticEnv()
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/packethost/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/tinkerbell/tink/db"
+)
+
+// NewMigrateCommand returns the command managing the schema of the
+// PostgreSQL database
+func NewMigrateCommand(config *DaemonConfig, logger log.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Inspect, apply and roll back the migrations of the PostgreSQL database",
+		Long: `The migrate command manages the schema of the PostgreSQL database:
+
+  migrate status      lists the migrations, applied or pending
+  migrate up          applies the pending migrations
+  migrate down [n]    rolls back the last n migrations applied, 1 by default
+  migrate to <id>     applies or rolls back the migrations to get to <id>
+
+Some migrations drop data for good and can not be rolled back, migrate down
+then fails without rolling back any of them.`,
+	}
+	// open returns the database once the flags are set
+	open := func() (*db.TinkDB, error) {
+		config.PopulateFromLegacyEnvVar()
+		if config.Backend != "postgres" {
+			return nil, fmt.Errorf("the %s backend has no migrations", config.Backend)
+		}
+		return openPostgres(config, logger)
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "List the migrations, applied or pending",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tinkDB, err := open()
+			if err != nil {
+				return err
+			}
+			defer tinkDB.Close()
+			status, err := tinkDB.MigrationStatus()
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "MIGRATION\tAPPLIED AT\tREVERSIBLE")
+			for _, s := range status {
+				applied := "pending"
+				if !s.AppliedAt.IsZero() {
+					applied = s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%t\n", s.ID, applied, s.Reversible)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			pending, err := tinkDB.CheckRequiredMigrations()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d pending migrations\n", pending)
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "up",
+		Short: "Apply the pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tinkDB, err := open()
+			if err != nil {
+				return err
+			}
+			defer tinkDB.Close()
+			n, err := tinkDB.Migrate()
+			logger.With("num_applied_migrations", n).Info("Migrations applied")
+			return err
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "down [n]",
+		Short: "Roll back the last n migrations applied, 1 by default",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n := 1
+			if len(args) == 1 {
+				var err error
+				if n, err = strconv.Atoi(args[0]); err != nil || n <= 0 {
+					return errors.Errorf("invalid number of migrations: %s", args[0])
+				}
+			}
+			tinkDB, err := open()
+			if err != nil {
+				return err
+			}
+			defer tinkDB.Close()
+			n, err = tinkDB.MigrateDown(n)
+			logger.With("num_rolled_back_migrations", n).Info("Migrations rolled back")
+			return err
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "to <id>",
+		Short: "Apply or roll back the migrations to get to the given one",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tinkDB, err := open()
+			if err != nil {
+				return err
+			}
+			defer tinkDB.Close()
+			n, err := tinkDB.MigrateTo(args[0])
+			logger.With("migration", args[0], "num_migrations", n).Info("Migrated")
+			return err
+		},
+	})
+
+	return cmd
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ArchiveVersion is the version of the format of the archives WriteArchive
+// writes. ReadArchive refuses the archives of another version.
+const ArchiveVersion = 1
+
+// archiveManifest is the first file of an archive
+const archiveManifest = "manifest.json"
+
+// ArchiveManifest describes an archive
+type ArchiveManifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// archiveFile is a file of an archive, it holds the rows of a table, one
+// JSON document per line
+type archiveFile struct {
+	name string
+	// rows points to the slice of the Backup with the rows
+	rows interface{}
+}
+
+// archiveFiles returns the files of an archive of b, in the order they are
+// written
+func (b *Backup) archiveFiles() []archiveFile {
+	return []archiveFile{
+		{"hardware.jsonl", &b.Hardware},
+		{"hardware_revision.jsonl", &b.HardwareRevisions},
+		{"hardware_revision_compaction.jsonl", &b.HardwareCompactions},
+		{"template.jsonl", &b.Templates},
+		{"workflow.jsonl", &b.Workflows},
+		{"workflow_state.jsonl", &b.WorkflowStates},
+		{"workflow_worker_map.jsonl", &b.WorkflowWorkers},
+		{"workflow_event.jsonl", &b.WorkflowEvents},
+		{"workflow_data.jsonl", &b.WorkflowData},
+		{"events.jsonl", &b.Events},
+	}
+}
+
+// WriteArchive writes b as a gzipped tarball: a manifest telling the version
+// of the format, then a file per table.
+func WriteArchive(w io.Writer, b Backup) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	add := func(name string, data []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: now,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+
+	manifest, err := json.Marshal(ArchiveManifest{Version: ArchiveVersion, CreatedAt: now})
+	if err != nil {
+		return err
+	}
+	if err := add(archiveManifest, manifest); err != nil {
+		return errors.Wrap(err, "write archive")
+	}
+	for _, file := range b.archiveFiles() {
+		buf := &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		rows := reflect.ValueOf(file.rows).Elem()
+		for i := 0; i < rows.Len(); i++ {
+			if err := enc.Encode(rows.Index(i).Interface()); err != nil {
+				return errors.Wrapf(err, "encode %s", file.name)
+			}
+		}
+		if err := add(file.name, buf.Bytes()); err != nil {
+			return errors.Wrap(err, "write archive")
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "write archive")
+	}
+	return errors.Wrap(gz.Close(), "write archive")
+}
+
+// ReadArchive reads an archive written by WriteArchive
+func ReadArchive(r io.Reader) (Backup, error) {
+	var b Backup
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return b, errors.Wrap(err, "read archive")
+	}
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != archiveManifest {
+		return b, errors.New("read archive: the manifest is missing")
+	}
+	var manifest ArchiveManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return b, errors.Wrap(err, "read archive: invalid manifest")
+	}
+	if manifest.Version != ArchiveVersion {
+		return b, fmt.Errorf("read archive: version %d is not supported, expected %d", manifest.Version, ArchiveVersion)
+	}
+
+	files := map[string]interface{}{}
+	for _, file := range b.archiveFiles() {
+		files[file.name] = file.rows
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Backup{}, errors.Wrap(err, "read archive")
+		}
+		file, ok := files[hdr.Name]
+		if !ok {
+			return Backup{}, fmt.Errorf("read archive: unexpected file %s", hdr.Name)
+		}
+		rows := reflect.ValueOf(file).Elem()
+		dec := json.NewDecoder(tr)
+		for {
+			row := reflect.New(rows.Type().Elem())
+			err := dec.Decode(row.Interface())
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return Backup{}, errors.Wrapf(err, "read archive: invalid %s", hdr.Name)
+			}
+			rows.Set(reflect.Append(rows, row.Elem()))
+		}
+	}
+	return b, nil
+}
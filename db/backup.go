@@ -0,0 +1,442 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinkerbell/tink/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Backup is the state of a server, as Export reads it and Import restores
+// it: every row of the tables, the deleted ones included. The webhooks are
+// not part of it, they hold secrets and point at the services of an
+// environment.
+type Backup struct {
+	Hardware          []BackupHardware
+	HardwareRevisions []BackupHardwareRevision
+	// HardwareCompactions are the last revisions of the hardware whose
+	// history got purged
+	HardwareCompactions []BackupHardwareCompaction
+	Templates           []BackupTemplate
+	Workflows           []BackupWorkflow
+	WorkflowStates      []BackupWorkflowState
+	WorkflowWorkers     []BackupWorkflowWorker
+	WorkflowEvents      []BackupWorkflowEvent
+	WorkflowData        []BackupWorkflowData
+	Events              []BackupEvent
+}
+
+// BackupHardware is a row of the hardware table
+type BackupHardware struct {
+	ID         string          `json:"id"`
+	Data       json.RawMessage `json:"data"`
+	InsertedAt time.Time       `json:"inserted_at"`
+	DeletedAt  *time.Time      `json:"deleted_at,omitempty"`
+}
+
+// BackupHardwareRevision is a row of the hardware_revision table
+type BackupHardwareRevision struct {
+	Revision   int64           `json:"revision"`
+	HardwareID string          `json:"hardware_id"`
+	Version    int64           `json:"version"`
+	EventType  string          `json:"event_type"`
+	Actor      string          `json:"actor"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Data       json.RawMessage `json:"data"`
+	Diff       []pkg.Change    `json:"diff,omitempty"`
+}
+
+// BackupHardwareCompaction is a row of the hardware_revision_compaction
+// table
+type BackupHardwareCompaction struct {
+	HardwareID string          `json:"hardware_id"`
+	Revision   int64           `json:"revision"`
+	Version    int64           `json:"version"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// BackupTemplate is a row of the template table
+type BackupTemplate struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Data      string     `json:"data"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Namespace is empty in the archives made before the namespaces, the
+	// template gets imported in the default one
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BackupWorkflow is a row of the workflow table
+type BackupWorkflow struct {
+	ID              string     `json:"id"`
+	Template        string     `json:"template"`
+	Devices         string     `json:"devices"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	MaxDataVersions int        `json:"max_data_versions,omitempty"`
+	Namespace       string     `json:"namespace,omitempty"`
+}
+
+// BackupWorkflowState is a row of the workflow_state table
+type BackupWorkflowState struct {
+	WorkflowID           string          `json:"workflow_id"`
+	CurrentWorker        string          `json:"current_worker"`
+	CurrentTaskName      string          `json:"current_task_name"`
+	CurrentActionName    string          `json:"current_action_name"`
+	CurrentActionState   int32           `json:"current_action_state"`
+	ActionList           json.RawMessage `json:"action_list"`
+	CurrentActionIndex   int64           `json:"current_action_index"`
+	TotalNumberOfActions int64           `json:"total_number_of_actions"`
+}
+
+// BackupWorkflowWorker is a row of the workflow_worker_map table
+type BackupWorkflowWorker struct {
+	WorkflowID string `json:"workflow_id"`
+	WorkerID   string `json:"worker_id"`
+}
+
+// BackupWorkflowEvent is a row of the workflow_event table
+type BackupWorkflowEvent struct {
+	WorkflowID    string    `json:"workflow_id"`
+	WorkerID      string    `json:"worker_id"`
+	TaskName      string    `json:"task_name"`
+	ActionName    string    `json:"action_name"`
+	ExecutionTime int64     `json:"execution_time"`
+	Message       string    `json:"message"`
+	Status        int32     `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BackupWorkflowData is a row of the workflow_data table, Data is nil once
+// the version got pruned
+type BackupWorkflowData struct {
+	WorkflowID string           `json:"workflow_id"`
+	Version    int32            `json:"version"`
+	Metadata   json.RawMessage  `json:"metadata"`
+	Data       *json.RawMessage `json:"data"`
+}
+
+// BackupEvent is a row of the events table
+type BackupEvent struct {
+	ID           int64     `json:"id"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	EventType    string    `json:"event_type"`
+	Actor        string    `json:"actor"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// errNotEmpty is returned when a backup is imported in a database which is
+// not empty
+var errNotEmpty = status.Error(codes.FailedPrecondition, "a backup can only be imported in an empty database")
+
+// Export reads all the rows of the database from a single snapshot. It does
+// not hold up the writers, so it is not bounded by the query timeout.
+func (d TinkDB) Export(ctx context.Context) (Backup, error) {
+	var b Backup
+	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return b, errors.Wrap(err, "BEGIN transaction")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	tables := []struct {
+		query string
+		scan  func(*sql.Rows) error
+	}{
+		{`SELECT id, data, COALESCE(inserted_at, 'epoch'), deleted_at FROM hardware ORDER BY id`, func(rows *sql.Rows) error {
+			var hw BackupHardware
+			var data []byte
+			if err := rows.Scan(&hw.ID, &data, &hw.InsertedAt, &hw.DeletedAt); err != nil {
+				return err
+			}
+			hw.Data = data
+			b.Hardware = append(b.Hardware, hw)
+			return nil
+		}},
+		{`SELECT revision, hardware_id, version, event_type, actor, created_at, data, diff FROM hardware_revision ORDER BY revision`, func(rows *sql.Rows) error {
+			var (
+				rev  BackupHardwareRevision
+				data []byte
+				diff []byte
+			)
+			if err := rows.Scan(&rev.Revision, &rev.HardwareID, &rev.Version, &rev.EventType, &rev.Actor, &rev.CreatedAt, &data, &diff); err != nil {
+				return err
+			}
+			rev.Data = data
+			if len(diff) > 0 {
+				if err := json.Unmarshal(diff, &rev.Diff); err != nil {
+					return errors.Wrap(err, "invalid hardware revision diff")
+				}
+			}
+			b.HardwareRevisions = append(b.HardwareRevisions, rev)
+			return nil
+		}},
+		{`SELECT hardware_id, revision, version, created_at, data FROM hardware_revision_compaction ORDER BY revision`, func(rows *sql.Rows) error {
+			var c BackupHardwareCompaction
+			var data []byte
+			if err := rows.Scan(&c.HardwareID, &c.Revision, &c.Version, &c.CreatedAt, &data); err != nil {
+				return err
+			}
+			c.Data = data
+			b.HardwareCompactions = append(b.HardwareCompactions, c)
+			return nil
+		}},
+		{`SELECT id, name, data, created_at, updated_at, deleted_at, namespace FROM template ORDER BY created_at, id`, func(rows *sql.Rows) error {
+			var t BackupTemplate
+			if err := rows.Scan(&t.ID, &t.Name, &t.Data, &t.CreatedAt, &t.UpdatedAt, &t.DeletedAt, &t.Namespace); err != nil {
+				return err
+			}
+			b.Templates = append(b.Templates, t)
+			return nil
+		}},
+		{`SELECT id, template, devices, created_at, updated_at, deleted_at, max_data_versions, namespace FROM workflow ORDER BY created_at, id`, func(rows *sql.Rows) error {
+			var wf BackupWorkflow
+			if err := rows.Scan(&wf.ID, &wf.Template, &wf.Devices, &wf.CreatedAt, &wf.UpdatedAt, &wf.DeletedAt, &wf.MaxDataVersions, &wf.Namespace); err != nil {
+				return err
+			}
+			b.Workflows = append(b.Workflows, wf)
+			return nil
+		}},
+		{`
+		SELECT workflow_id, COALESCE(current_worker, ''), COALESCE(current_task_name, ''), COALESCE(current_action_name, ''),
+			COALESCE(current_action_state, 0), action_list, COALESCE(current_action_index, 0), COALESCE(total_number_of_actions, 0)
+		FROM workflow_state
+		ORDER BY workflow_id`, func(rows *sql.Rows) error {
+			var ws BackupWorkflowState
+			var actions []byte
+			err := rows.Scan(&ws.WorkflowID, &ws.CurrentWorker, &ws.CurrentTaskName, &ws.CurrentActionName,
+				&ws.CurrentActionState, &actions, &ws.CurrentActionIndex, &ws.TotalNumberOfActions)
+			if err != nil {
+				return err
+			}
+			ws.ActionList = actions
+			b.WorkflowStates = append(b.WorkflowStates, ws)
+			return nil
+		}},
+		{`SELECT workflow_id, worker_id FROM workflow_worker_map ORDER BY workflow_id, worker_id`, func(rows *sql.Rows) error {
+			var ww BackupWorkflowWorker
+			if err := rows.Scan(&ww.WorkflowID, &ww.WorkerID); err != nil {
+				return err
+			}
+			b.WorkflowWorkers = append(b.WorkflowWorkers, ww)
+			return nil
+		}},
+		{`
+		SELECT workflow_id, worker_id, COALESCE(task_name, ''), COALESCE(action_name, ''), COALESCE(execution_time, 0),
+			COALESCE(message, ''), COALESCE(status, 0), created_at
+		FROM workflow_event
+		ORDER BY created_at`, func(rows *sql.Rows) error {
+			var ev BackupWorkflowEvent
+			if err := rows.Scan(&ev.WorkflowID, &ev.WorkerID, &ev.TaskName, &ev.ActionName, &ev.ExecutionTime, &ev.Message, &ev.Status, &ev.CreatedAt); err != nil {
+				return err
+			}
+			b.WorkflowEvents = append(b.WorkflowEvents, ev)
+			return nil
+		}},
+		{`SELECT workflow_id, version, metadata, data FROM workflow_data ORDER BY workflow_id, version`, func(rows *sql.Rows) error {
+			var (
+				wd             BackupWorkflowData
+				metadata, data []byte
+			)
+			if err := rows.Scan(&wd.WorkflowID, &wd.Version, &metadata, &data); err != nil {
+				return err
+			}
+			wd.Metadata = metadata
+			if data != nil {
+				raw := json.RawMessage(data)
+				wd.Data = &raw
+			}
+			b.WorkflowData = append(b.WorkflowData, wd)
+			return nil
+		}},
+		{`SELECT id, resource_type, resource_id, event_type, actor, created_at FROM events ORDER BY id`, func(rows *sql.Rows) error {
+			var ev BackupEvent
+			if err := rows.Scan(&ev.ID, &ev.ResourceType, &ev.ResourceID, &ev.EventType, &ev.Actor, &ev.CreatedAt); err != nil {
+				return err
+			}
+			b.Events = append(b.Events, ev)
+			return nil
+		}},
+	}
+	for _, table := range tables {
+		if err := scanAll(ctx, tx, table.query, table.scan); err != nil {
+			err = errors.Wrap(err, "SELECT")
+			d.logger.Error(err)
+			return Backup{}, err
+		}
+	}
+	return b, nil
+}
+
+func scanAll(ctx context.Context, tx *sql.Tx, query string, scan func(*sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Import restores a backup made by Export, in a single transaction. The
+// database has to be empty, the revisions of the hardware and the ids of the
+// events are kept, so the clients following them can carry on. The writers
+// wait for it, it is bounded by the query timeout: a large backup may need a
+// longer one.
+func (d TinkDB) Import(ctx context.Context, b Backup) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable, workflowEventsTable)
+	if err != nil {
+		return err
+	}
+
+	var used bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT
+		EXISTS (SELECT 1 FROM hardware)
+		OR EXISTS (SELECT 1 FROM hardware_revision)
+		OR EXISTS (SELECT 1 FROM hardware_revision_compaction)
+		OR EXISTS (SELECT 1 FROM template)
+		OR EXISTS (SELECT 1 FROM workflow)
+		OR EXISTS (SELECT 1 FROM workflow_state)
+		OR EXISTS (SELECT 1 FROM workflow_worker_map)
+		OR EXISTS (SELECT 1 FROM workflow_event)
+		OR EXISTS (SELECT 1 FROM workflow_data)
+		OR EXISTS (SELECT 1 FROM events)
+	`).Scan(&used)
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "SELECT")
+	}
+	if used {
+		_ = tx.Rollback()
+		return errNotEmpty
+	}
+
+	// insert stops at the first error, err tells it once all the rows went
+	insert := func(query string, args ...interface{}) {
+		if err == nil {
+			_, err = tx.ExecContext(ctx, query, args...)
+		}
+	}
+	// the hardware of the archives made before the namespaces goes in the
+	// default one
+	for _, hw := range b.Hardware {
+		insert(`
+		INSERT INTO
+			hardware (id, data, inserted_at, deleted_at)
+		VALUES
+			($1, $2::jsonb || jsonb_build_object('namespace', COALESCE($2::jsonb ->> 'namespace', $5)), $3, $4)`,
+			hw.ID, string(hw.Data), hw.InsertedAt, hw.DeletedAt, DefaultNamespace)
+	}
+	for _, rev := range b.HardwareRevisions {
+		var diff interface{}
+		if len(rev.Diff) > 0 {
+			buf, _ := json.Marshal(rev.Diff)
+			diff = string(buf)
+		}
+		insert(`
+		INSERT INTO
+			hardware_revision (revision, hardware_id, version, event_type, actor, created_at, data, diff)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7::jsonb || jsonb_build_object('namespace', COALESCE($7::jsonb ->> 'namespace', $9)), $8)`,
+			rev.Revision, rev.HardwareID, rev.Version, rev.EventType, rev.Actor, rev.CreatedAt, string(rev.Data), diff, DefaultNamespace)
+	}
+	for _, c := range b.HardwareCompactions {
+		insert(`
+		INSERT INTO
+			hardware_revision_compaction (hardware_id, revision, version, created_at, data)
+		VALUES
+			($1, $2, $3, $4, $5::jsonb || jsonb_build_object('namespace', COALESCE($5::jsonb ->> 'namespace', $6)))`,
+			c.HardwareID, c.Revision, c.Version, c.CreatedAt, string(c.Data), DefaultNamespace)
+	}
+	for _, t := range b.Templates {
+		insert(`INSERT INTO template (id, name, data, created_at, updated_at, deleted_at, namespace) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			t.ID, t.Name, t.Data, t.CreatedAt, t.UpdatedAt, t.DeletedAt, normalizeNamespace(t.Namespace))
+	}
+	for _, wf := range b.Workflows {
+		insert(`
+		INSERT INTO
+			workflow (id, template, devices, created_at, updated_at, deleted_at, max_data_versions, namespace)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)`,
+			wf.ID, wf.Template, wf.Devices, wf.CreatedAt, wf.UpdatedAt, wf.DeletedAt, wf.MaxDataVersions, normalizeNamespace(wf.Namespace))
+	}
+	for _, ws := range b.WorkflowStates {
+		insert(`
+		INSERT INTO
+			workflow_state (workflow_id, current_worker, current_task_name, current_action_name, current_action_state, action_list, current_action_index, total_number_of_actions)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)`,
+			ws.WorkflowID, ws.CurrentWorker, ws.CurrentTaskName, ws.CurrentActionName, ws.CurrentActionState,
+			string(ws.ActionList), ws.CurrentActionIndex, ws.TotalNumberOfActions)
+	}
+	for _, ww := range b.WorkflowWorkers {
+		insert(`INSERT INTO workflow_worker_map (workflow_id, worker_id) VALUES ($1, $2)`, ww.WorkflowID, ww.WorkerID)
+	}
+	for _, ev := range b.WorkflowEvents {
+		insert(`
+		INSERT INTO
+			workflow_event (workflow_id, worker_id, task_name, action_name, execution_time, message, status, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)`,
+			ev.WorkflowID, ev.WorkerID, ev.TaskName, ev.ActionName, ev.ExecutionTime, ev.Message, ev.Status, ev.CreatedAt)
+	}
+	for _, wd := range b.WorkflowData {
+		var data interface{}
+		if wd.Data != nil {
+			data = string(*wd.Data)
+		}
+		insert(`INSERT INTO workflow_data (workflow_id, version, metadata, data) VALUES ($1, $2, $3, $4)`,
+			wd.WorkflowID, wd.Version, string(wd.Metadata), data)
+	}
+	for _, ev := range b.Events {
+		insert(`
+		INSERT INTO
+			events (id, resource_id, resource_type, event_type, actor, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)`,
+			ev.ID, ev.ResourceID, ev.ResourceType, ev.EventType, ev.Actor, ev.CreatedAt)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "INSERT")
+	}
+
+	// the next revisions and events follow the imported ones, the purged
+	// revisions included
+	for _, seq := range []string{
+		`SELECT setval(pg_get_serial_sequence('hardware_revision', 'revision'), GREATEST(
+			(SELECT COALESCE(MAX(revision), 0) FROM hardware_revision),
+			(SELECT COALESCE(MAX(revision), 0) FROM hardware_revision_compaction)
+		) + 1, false)`,
+		`SELECT setval(pg_get_serial_sequence('events', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM events`,
+	} {
+		if _, err := tx.ExecContext(ctx, seq); err != nil {
+			_ = tx.Rollback()
+			return errors.Wrap(err, "UPDATE sequence")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "COMMIT")
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
@@ -15,6 +16,8 @@ import (
 	"github.com/tinkerbell/tink/db/migration"
 	tb "github.com/tinkerbell/tink/protos/template"
 	pb "github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Database interface for tinkerbell database operations
@@ -22,47 +25,222 @@ type Database interface {
 	hardware
 	template
 	workflow
+	events
+	webhooks
+	retention
+	backup
 }
 
 type hardware interface {
 	DeleteFromDB(ctx context.Context, id string) error
 	InsertIntoDB(ctx context.Context, data string) error
+	InsertHardwareBatch(ctx context.Context, data []string) error
 	GetByMAC(ctx context.Context, mac string) (string, error)
 	GetByIP(ctx context.Context, ip string) (string, error)
 	GetByID(ctx context.Context, id string) (string, error)
-	GetAll(fn func([]byte) error) error
+	GetAll(ctx context.Context, fn func([]byte) error) error
+	GetHardwareHistory(ctx context.Context, id string, fn func(HardwareRevision) error) error
+	GetHardwareChanges(ctx context.Context, since int64, limit int, fn func(HardwareRevision) error) error
+	LatestHardwareRevision(ctx context.Context) (int64, error)
+	ListHardware(ctx context.Context, labels map[string]string, opts ListOptions, fn func([]byte) error) error
+	SetHardwareState(ctx context.Context, id string, from []int32, to int32) (string, error)
+}
+
+type events interface {
+	GetEvents(ctx context.Context, filter EventFilter, since int64, limit int, fn func(Event) error) error
+	LatestEventID(ctx context.Context) (int64, error)
+}
+
+type webhooks interface {
+	CreateWebhook(ctx context.Context, wh Webhook) error
+	DeleteWebhook(ctx context.Context, id string) error
+	ListWebhooks(ctx context.Context, fn func(Webhook) error) error
+	InsertWebhookFailure(ctx context.Context, f WebhookFailure) error
+	ListWebhookFailures(ctx context.Context, webhookID string, fn func(WebhookFailure) error) error
+}
+
+type retention interface {
+	Purge(ctx context.Context, before time.Time, dryRun bool) (PurgeReport, error)
+}
+
+type backup interface {
+	Export(ctx context.Context) (Backup, error)
+	Import(ctx context.Context, b Backup) error
 }
 
 type template interface {
 	CreateTemplate(ctx context.Context, name string, data string, id uuid.UUID) error
 	GetTemplate(ctx context.Context, fields map[string]string, deleted bool) (*tb.WorkflowTemplate, error)
 	DeleteTemplate(ctx context.Context, name string) error
-	ListTemplates(in string, fn func(id, n string, in, del *timestamp.Timestamp) error) error
+	ListTemplates(ctx context.Context, in string, opts ListOptions, fn func(id, n string, in, del *timestamp.Timestamp) error) error
 	UpdateTemplate(ctx context.Context, name string, data string, id uuid.UUID) error
 }
 
 type workflow interface {
 	CreateWorkflow(ctx context.Context, wf Workflow, data string, id uuid.UUID) error
+	CreateWorkflows(ctx context.Context, wfs []Workflow, data []string) error
 	InsertIntoWfDataTable(ctx context.Context, req *pb.UpdateWorkflowDataRequest) error
 	GetfromWfDataTable(ctx context.Context, req *pb.GetWorkflowDataRequest) ([]byte, error)
 	GetWorkflowMetadata(ctx context.Context, req *pb.GetWorkflowDataRequest) ([]byte, error)
 	GetWorkflowDataVersion(ctx context.Context, workflowID string) (int32, error)
-	GetWorkflowsForWorker(id string) ([]string, error)
+	GetWorkflowDataRevision(ctx context.Context, workflowID string, version int32) (WorkflowDataRevision, error)
+	GetWorkflowsForWorker(ctx context.Context, id string) ([]string, error)
 	GetWorkflow(ctx context.Context, id string) (Workflow, error)
 	DeleteWorkflow(ctx context.Context, id string, state int32) error
-	ListWorkflows(fn func(wf Workflow) error) error
+	ListWorkflows(ctx context.Context, filter WorkflowFilter, opts ListOptions, fn func(wf Workflow) error) error
 	UpdateWorkflow(ctx context.Context, wf Workflow, state int32) error
 	UpdateWorkflowState(ctx context.Context, wfContext *pb.WorkflowContext) error
 	GetWorkflowContexts(ctx context.Context, wfID string) (*pb.WorkflowContext, error)
 	GetWorkflowActions(ctx context.Context, wfID string) (*pb.WorkflowActionList, error)
 	InsertIntoWorkflowEventTable(ctx context.Context, wfEvent *pb.WorkflowActionStatus, time time.Time) error
-	ShowWorkflowEvents(wfID string, fn func(wfs *pb.WorkflowActionStatus) error) error
+	ShowWorkflowEvents(ctx context.Context, wfID string, fn func(wfs *pb.WorkflowActionStatus) error) error
+	GetWorkflowEventsFrom(ctx context.Context, wfID string, from int64, fn func(id int64, wfs *pb.WorkflowActionStatus) error) error
+}
+
+// ListOptions sorts and pages the rows returned by the list methods
+type ListOptions struct {
+	// Limit is the maximum number of rows returned, zero means no limit
+	Limit int
+	// Offset is the number of rows skipped
+	Offset int
+	// OrderBy is the field the rows are sorted by, optionally followed by
+	// "desc". Every list method documents the fields it accepts.
+	OrderBy string
+	// CreatedAfter excludes the rows created before it, when it is set
+	CreatedAfter time.Time
+	// After, when it is set, excludes the rows up to the one at this
+	// position in the order, included. It is the cursor of the last row of
+	// the previous page, with the same OrderBy.
+	After *Cursor
+	// Cursor, when it is set, is called with the cursor of every row just
+	// before the row is returned
+	Cursor func(Cursor)
+}
+
+// Cursor is the position of a row in the order of a list: the value of the
+// field the rows are sorted by and the id of the row, which breaks the ties.
+// Unlike an offset it still points at the right place when rows before it
+// are added or removed.
+type Cursor struct {
+	Value string `json:"value"`
+	ID    string `json:"id"`
+}
+
+// cursor reports the cursor of a row returned to opts.Cursor
+func (o ListOptions) cursor(value interface{}, id string) {
+	if o.Cursor == nil {
+		return
+	}
+	c := Cursor{ID: id}
+	switch v := value.(type) {
+	case time.Time:
+		c.Value = v.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		c.Value = string(v)
+	case string:
+		c.Value = v
+	default:
+		c.Value = fmt.Sprint(v)
+	}
+	o.Cursor(c)
+}
+
+// listClauses are the SQL clauses of the options of a list method
+type listClauses struct {
+	// column is the expression the rows are sorted by, the list methods
+	// select it with the tie breaker for the cursors
+	column string
+	// after is the condition excluding the rows up to opts.After, TRUE when
+	// it is not set, and args are its arguments
+	after string
+	args  []interface{}
+	// order is made of the ORDER BY, LIMIT and OFFSET clauses
+	order string
+}
+
+// listClauses returns the clauses for the options. columns maps the
+// accepted OrderBy fields to their SQL expression, def is the field used
+// when OrderBy is empty and tieBreaker makes the order stable across pages.
+// The arguments of the after condition are numbered from arg.
+func (o ListOptions) listClauses(columns map[string]string, def, tieBreaker string, arg int) (listClauses, error) {
+	var c listClauses
+	field, desc, err := parseOrderBy(o.OrderBy)
+	if err != nil {
+		return c, err
+	}
+	if field == "" {
+		field = def
+	}
+	column, ok := columns[field]
+	if !ok {
+		return c, status.Errorf(codes.InvalidArgument, "can not order by %q", field)
+	}
+	c.column = column
+	direction, after := "ASC", ">"
+	if desc {
+		direction, after = "DESC", "<"
+	}
+
+	c.after = "TRUE"
+	if o.After != nil {
+		// the arguments take the types of the columns they are compared to
+		c.after = fmt.Sprintf("(%s, %s) %s ($%d, $%d)", column, tieBreaker, after, arg, arg+1)
+		c.args = []interface{}{o.After.Value, o.After.ID}
+	}
+
+	c.order = fmt.Sprintf("ORDER BY %s %s", column, direction)
+	if column != tieBreaker {
+		c.order += fmt.Sprintf(", %s %s", tieBreaker, direction)
+	}
+	if o.Limit > 0 {
+		c.order += fmt.Sprintf(" LIMIT %d", o.Limit)
+	}
+	if o.Offset > 0 {
+		c.order += fmt.Sprintf(" OFFSET %d", o.Offset)
+	}
+	return c, nil
+}
+
+func parseOrderBy(orderBy string) (string, bool, error) {
+	parts := strings.Fields(strings.ToLower(orderBy))
+	switch {
+	case len(parts) == 0:
+		return "", false, nil
+	case len(parts) == 1:
+		return parts[0], false, nil
+	case len(parts) == 2 && (parts[1] == "asc" || parts[1] == "desc"):
+		return parts[0], parts[1] == "desc", nil
+	}
+	return "", false, status.Errorf(codes.InvalidArgument, "invalid order %q, expected a field optionally followed by asc or desc", orderBy)
 }
 
 // TinkDB implements the Database interface
 type TinkDB struct {
 	instance *sql.DB
 	logger   log.Logger
+	// maxDataVersions is how many versions of the workflow data keep
+	// their data when the template of the workflow does not say,
+	// DefaultMaxDataVersions when it is zero
+	maxDataVersions int
+	// queryTimeout bounds every call to the database, zero means no
+	// timeout
+	queryTimeout time.Duration
+	// replica is the read replica set with SetReplica, nil when there is
+	// none
+	replica *replica
+}
+
+// Close closes the connections to the database and to its replica, and
+// stops measuring the lag of the replica
+func (t *TinkDB) Close() error {
+	err := t.instance.Close()
+	if t.replica != nil {
+		t.replica.stop()
+		if rerr := t.replica.instance.Close(); err == nil {
+			err = rerr
+		}
+	}
+	return err
 }
 
 // Connect returns a connection to postgres database
@@ -70,6 +248,43 @@ func Connect(db *sql.DB, lg log.Logger) *TinkDB {
 	return &TinkDB{instance: db, logger: lg}
 }
 
+// SetMaxDataVersions sets how many versions of the workflow data keep their
+// data when the template of the workflow does not say
+func (t *TinkDB) SetMaxDataVersions(n int) {
+	t.maxDataVersions = n
+}
+
+// SetQueryTimeout sets how long a call to the database can take before it
+// gets cancelled, so a slow database does not hang its callers. The list
+// methods stream the rows to their callback, only the query is bounded: a
+// slow client or a long watch does not break the stream.
+func (t *TinkDB) SetQueryTimeout(timeout time.Duration) {
+	t.queryTimeout = timeout
+}
+
+// withTimeout returns ctx bounded by the query timeout, if there is one
+func (t TinkDB) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, t.queryTimeout)
+}
+
+// withQueryTimeout returns ctx cancelled when the query timeout passes
+// before queried is called, for the methods streaming the rows to a
+// callback: the rows are read with ctx, they can not outlive its deadline.
+func (t TinkDB) withQueryTimeout(ctx context.Context) (_ context.Context, queried func(), _ context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if t.queryTimeout <= 0 {
+		return ctx, func() {}, cancel
+	}
+	timer := time.AfterFunc(t.queryTimeout, cancel)
+	return ctx, func() { timer.Stop() }, func() {
+		timer.Stop()
+		cancel()
+	}
+}
+
 func (t *TinkDB) Migrate() (int, error) {
 	return migrate.Exec(t.instance, "postgres", migration.GetMigrations(), migrate.Up)
 }
@@ -83,6 +298,96 @@ func (t *TinkDB) CheckRequiredMigrations() (int, error) {
 	return len(migrations) - len(records), nil
 }
 
+// MigrationStatus tells if a migration is applied to the database
+type MigrationStatus struct {
+	ID string
+	// AppliedAt is zero when the migration is pending
+	AppliedAt time.Time
+	// Reversible tells if the migration can be rolled back
+	Reversible bool
+}
+
+// MigrationStatus returns the status of all the migrations, in the order
+// they apply
+func (t *TinkDB) MigrationStatus() ([]MigrationStatus, error) {
+	records, err := migrate.GetMigrationRecords(t.instance, "postgres")
+	if err != nil {
+		return nil, err
+	}
+	applied := map[string]time.Time{}
+	for _, r := range records {
+		applied[r.Id] = r.AppliedAt
+	}
+	var status []MigrationStatus
+	for _, m := range migration.GetMigrations().Migrations {
+		status = append(status, MigrationStatus{
+			ID:         m.Id,
+			AppliedAt:  applied[m.Id],
+			Reversible: len(m.Down) > 0,
+		})
+	}
+	return status, nil
+}
+
+// MigrateDown rolls back the last n migrations applied. It rolls back none
+// when one of them can not be.
+func (t *TinkDB) MigrateDown(n int) (int, error) {
+	if n <= 0 {
+		return 0, errors.Errorf("invalid number of migrations to roll back: %d", n)
+	}
+	source := migration.GetMigrations()
+	planned, _, err := migrate.PlanMigration(t.instance, "postgres", source, migrate.Down, n)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range planned {
+		if len(m.Down) == 0 {
+			return 0, errors.Errorf("migration %s can not be rolled back", m.Id)
+		}
+	}
+	return migrate.ExecMax(t.instance, "postgres", source, migrate.Down, n)
+}
+
+// MigrateTo applies the migrations up to the one with the given id, or rolls
+// back the ones applied after it. It returns how many it applied or rolled
+// back. It fails when some migrations before the given one are pending while
+// some after it are applied, there is no order to do both in.
+func (t *TinkDB) MigrateTo(id string) (int, error) {
+	status, err := t.MigrationStatus()
+	if err != nil {
+		return 0, err
+	}
+	target := -1
+	for i, s := range status {
+		if s.ID == id {
+			target = i
+		}
+	}
+	if target == -1 {
+		return 0, errors.Errorf("unknown migration %s", id)
+	}
+
+	var pending, after int
+	for i, s := range status {
+		switch {
+		case i <= target && s.AppliedAt.IsZero():
+			pending++
+		case i > target && !s.AppliedAt.IsZero():
+			after++
+		}
+	}
+	if pending > 0 && after > 0 {
+		return 0, errors.Errorf("%d migrations up to %s are pending and %d after it are applied", pending, id, after)
+	}
+	if pending > 0 {
+		return migrate.ExecMax(t.instance, "postgres", migration.GetMigrations(), migrate.Up, pending)
+	}
+	if after > 0 {
+		return t.MigrateDown(after)
+	}
+	return 0, nil
+}
+
 // Error returns the underlying cause for error
 func Error(err error) *pq.Error {
 	if pqErr, ok := errors.Cause(err).(*pq.Error); ok {
@@ -102,13 +407,42 @@ func get(ctx context.Context, db *sql.DB, query string, args ...interface{}) (st
 	return string(buf), nil
 }
 
-// buildGetCondition builds a where condition string in the format "column_name = 'field_value' AND"
-// takes in a map[string]string with keys being the column name and the values being the field values
-func buildGetCondition(fields map[string]string) (string, error) {
-	for column, field := range fields {
-		if field != "" {
-			return fmt.Sprintf("%s = '%s'", column, field), nil
+// getColumns are the columns the GetBy fields can select a row by
+var getColumns = []string{"id", "name"}
+
+// buildGetCondition builds a where condition in the format "column_name = $1"
+// and returns the value to pass as its parameter. It takes in a
+// map[string]string with keys being the column name and the values being the
+// field values.
+func buildGetCondition(fields map[string]string) (string, string, error) {
+	column, value, err := getField(fields)
+	if err != nil {
+		return "", "", err
+	}
+	return column + " = $1", value, nil
+}
+
+// getField returns the column and the value of the first field set, the
+// columns are checked against getColumns
+func getField(fields map[string]string) (string, string, error) {
+	for column := range fields {
+		if !isGetColumn(column) {
+			return "", "", fmt.Errorf("can not get by %q", column)
+		}
+	}
+	for _, column := range getColumns {
+		if field := fields[column]; field != "" {
+			return column, field, nil
+		}
+	}
+	return "", "", errors.New("one GetBy field must be set to build a get condition")
+}
+
+func isGetColumn(column string) bool {
+	for _, c := range getColumns {
+		if c == column {
+			return true
 		}
 	}
-	return "", errors.New("one GetBy field must be set to build a get condition")
+	return false
 }
@@ -3,7 +3,11 @@ package db_test
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,15 +18,56 @@ import (
 	"github.com/tinkerbell/tink/db"
 )
 
-type NewPostgresDatabaseRequest struct {
+// backend is the implementation of db.Database the tests run against. The
+// same tests run against every backend.
+var backend = flag.String("db-backend", "postgres", "The database backend the tests run against: postgres, bolt or memory")
+
+type NewDatabaseRequest struct {
 	ApplyMigration bool
 }
 
+// NewDatabaseClient returns a database of the backend selected with the
+// -db-backend flag, ready to be used, and the function releasing it when the
+// test is over. Tests using this function are safe to run in parallel
+func NewDatabaseClient(t testing.TB, ctx context.Context, req NewDatabaseRequest) (db.Database, func() error) {
+	switch *backend {
+	case "postgres":
+		_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, req)
+		return tinkDB, cl
+	case "bolt":
+		return NewBoltDatabaseClient(t)
+	case "memory":
+		memoryDB := db.NewMemoryDB(log.Test(t, "db-test"))
+		return memoryDB, memoryDB.Close
+	}
+	t.Fatalf("unknown database backend %q", *backend)
+	return nil, nil
+}
+
+// NewBoltDatabaseClient returns a database stored in a bbolt file, removed
+// when the test is over. There are no migrations to apply to it.
+func NewBoltDatabaseClient(t testing.TB) (*db.EmbeddedDB, func() error) {
+	dir, err := ioutil.TempDir("", "tink-db-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	boltDB, err := db.OpenBolt(filepath.Join(dir, "tink.db"), log.Test(t, "db-test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return boltDB, func() error {
+		if err := boltDB.Close(); err != nil {
+			return err
+		}
+		return os.RemoveAll(dir)
+	}
+}
+
 // NewPostgresDatabaseClient returns a SQL client ready to be used. Behind the
 // scene it is starting a Docker container that will get cleaned up when the
 // test is over. Tests using this function are safe to run in parallel
-func NewPostgresDatabaseClient(t *testing.T, ctx context.Context, req NewPostgresDatabaseRequest) (*sql.DB, *db.TinkDB, func() error) {
-	testcontainers.SkipIfProviderIsNotHealthy(t)
+func NewPostgresDatabaseClient(t testing.TB, ctx context.Context, req NewDatabaseRequest) (*sql.DB, *db.TinkDB, func() error) {
+	skipIfDockerIsNotHealthy(t)
 	postgresC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: testcontainers.ContainerRequest{
 			Image:        "postgres:13.1",
@@ -80,3 +125,15 @@ func NewPostgresDatabaseClient(t *testing.T, ctx context.Context, req NewPostgre
 		return postgresC.Terminate(ctx)
 	}
 }
+
+// skipIfDockerIsNotHealthy is testcontainers.SkipIfProviderIsNotHealthy for
+// the fuzz tests as well
+func skipIfDockerIsNotHealthy(t testing.TB) {
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	if err == nil {
+		err = provider.Health(context.Background())
+	}
+	if err != nil {
+		t.Skipf("Docker is not running. TestContainers can't perform is work without it: %s", err)
+	}
+}
@@ -0,0 +1,179 @@
+package dbtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tinkerbell/tink/db"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+)
+
+func testBackup(t *testing.T, d, empty db.Database) {
+	ctx := context.Background()
+	// the history of a hardware removed by a purge is kept as a compaction
+	purged := newHardware("08:00:27:00:00:04", "192.168.1.8")
+	insertHardware(t, d, purged)
+	if err := d.DeleteFromDB(ctx, purged.id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Purge(ctx, time.Now(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	gone := newHardware("08:00:27:00:00:02", "192.168.1.6")
+	insertHardware(t, d, h)
+	insertHardware(t, d, gone)
+	if err := d.DeleteFromDB(ctx, gone.id); err != nil {
+		t.Fatal(err)
+	}
+	templateID := createTemplate(t, d, "conformance")
+	deleted := createTemplate(t, d, "deleted")
+	if err := d.DeleteTemplate(ctx, deleted); err != nil {
+		t.Fatal(err)
+	}
+	workflowID := createWorkflow(t, d, templateID, h.mac)
+	err := d.InsertIntoWorkflowEventTable(ctx, &pb.WorkflowActionStatus{
+		WorkflowId:   workflowID,
+		WorkerId:     h.id,
+		TaskName:     "provision",
+		ActionName:   "partition",
+		ActionStatus: pb.State_STATE_SUCCESS,
+		Seconds:      12,
+	}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	insertWorkflowData(t, d, workflowID, db.DefaultMaxDataVersions+2)
+
+	backup, err := d.Export(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backup.Hardware) != 2 || len(backup.Templates) != 2 || len(backup.Workflows) != 1 {
+		t.Errorf("expected the deleted rows to be exported, got %d hardware, %d templates and %d workflows",
+			len(backup.Hardware), len(backup.Templates), len(backup.Workflows))
+	}
+	if len(backup.HardwareCompactions) != 1 || backup.HardwareCompactions[0].HardwareID != purged.id {
+		t.Errorf("expected the compaction of the purged hardware to be exported, got %+v", backup.HardwareCompactions)
+	}
+
+	// through an archive
+	buf := &bytes.Buffer{}
+	if err := db.WriteArchive(buf, backup); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := db.ReadArchive(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := empty.Import(ctx, restored); err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := empty.Export(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(backup)
+	got, _ := json.Marshal(again)
+	if !bytes.Equal(want, got) {
+		t.Errorf("expected the imported database to export the same backup\nwant %s\ngot  %s", want, got)
+	}
+
+	if _, err := empty.GetByMAC(ctx, h.mac); err != nil {
+		t.Errorf("expected the hardware to be restored: %v", err)
+	}
+	_, err = empty.GetByID(ctx, gone.id)
+	expectNoRows(t, err)
+	ids, err := empty.GetWorkflowsForWorker(ctx, h.id)
+	if err != nil || !equalStrings(ids, []string{workflowID}) {
+		t.Errorf("expected the workflow of the worker to be restored, got %v: %v", ids, err)
+	}
+	if got, want := retainedVersions(t, empty, workflowID), retainedVersions(t, d, workflowID); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected the versions %v of the workflow data to be restored, got %v", want, got)
+	}
+	events := 0
+	err = empty.ShowWorkflowEvents(ctx, workflowID, func(*pb.WorkflowActionStatus) error {
+		events++
+		return nil
+	})
+	if err != nil || events != 1 {
+		t.Errorf("expected the workflow event to be restored, got %d: %v", events, err)
+	}
+	var compacted []string
+	err = empty.GetHardwareChanges(ctx, 0, 100, func(rev db.HardwareRevision) error {
+		if rev.EventType == db.HardwareCompacted {
+			compacted = append(compacted, rev.HardwareID)
+		}
+		return nil
+	})
+	if err != nil || !equalStrings(compacted, []string{purged.id}) {
+		t.Errorf("expected the compaction of the purged hardware to be restored, got %v: %v", compacted, err)
+	}
+
+	// the events and the revisions carry on from the imported ones
+	lastEvent, err := d.LatestEventID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastRevision, err := d.LatestHardwareRevision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	insertHardware(t, empty, newHardware("08:00:27:00:00:03", "192.168.1.7"))
+	if id, err := empty.LatestEventID(ctx); err != nil || id != lastEvent+1 {
+		t.Errorf("expected the next event to be %d, got %d: %v", lastEvent+1, id, err)
+	}
+	if rev, err := empty.LatestHardwareRevision(ctx); err != nil || rev != lastRevision+1 {
+		t.Errorf("expected the next hardware revision to be %d, got %d: %v", lastRevision+1, rev, err)
+	}
+
+	// only an empty database takes a backup
+	expectCode(t, empty.Import(ctx, restored), codes.FailedPrecondition)
+}
+
+// testImportInUsed imports a backup with the rows of a single table, then
+// another one that gets refused: the rows of any table make a database used
+func testImportInUsed(t *testing.T, newDB NewDatabase) {
+	ctx := context.Background()
+	workflowID := uuid.New().String()
+	backups := map[string]db.Backup{
+		"hardware_revision_compaction": {HardwareCompactions: []db.BackupHardwareCompaction{{
+			HardwareID: uuid.New().String(), Revision: 3, Version: 2, CreatedAt: time.Now().UTC(), Data: json.RawMessage(`{}`),
+		}}},
+		"workflow_state":      {WorkflowStates: []db.BackupWorkflowState{{WorkflowID: workflowID, ActionList: json.RawMessage(`[]`)}}},
+		"workflow_worker_map": {WorkflowWorkers: []db.BackupWorkflowWorker{{WorkflowID: workflowID, WorkerID: uuid.New().String()}}},
+		"workflow_event":      {WorkflowEvents: []db.BackupWorkflowEvent{{WorkflowID: workflowID, WorkerID: uuid.New().String(), CreatedAt: time.Now().UTC()}}},
+		"workflow_data":       {WorkflowData: []db.BackupWorkflowData{{WorkflowID: workflowID, Version: 1, Metadata: json.RawMessage(`{}`)}}},
+	}
+	for table, backup := range backups {
+		backup := backup
+		t.Run(table, func(t *testing.T) {
+			d, release := newDB(t)
+			defer release()
+			if err := d.Import(ctx, backup); err != nil {
+				t.Fatal(err)
+			}
+			expectCode(t, d.Import(ctx, db.Backup{}), codes.FailedPrecondition)
+		})
+	}
+
+	// the revisions carry on from the compactions, when the purge left no
+	// other revision
+	d, release := newDB(t)
+	defer release()
+	if err := d.Import(ctx, backups["hardware_revision_compaction"]); err != nil {
+		t.Fatal(err)
+	}
+	insertHardware(t, d, newHardware("08:00:27:00:00:01", "192.168.1.5"))
+	if rev, err := d.LatestHardwareRevision(ctx); err != nil || rev != 4 {
+		t.Errorf("expected the next hardware revision to be 4, got %d: %v", rev, err)
+	}
+}
@@ -0,0 +1,72 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/tinkerbell/tink/db"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+)
+
+func testCancelledContext(t *testing.T, d db.Database) {
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+	templateID := createTemplate(t, d, "conformance")
+	workflowID := createWorkflow(t, d, templateID, h.mac)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	reads := map[string]func() error{
+		"GetByID": func() error {
+			_, err := d.GetByID(ctx, h.id)
+			return err
+		},
+		"GetAll": func() error {
+			return d.GetAll(ctx, func([]byte) error {
+				called = true
+				return nil
+			})
+		},
+		"ListTemplates": func() error {
+			return d.ListTemplates(ctx, "%", db.ListOptions{}, func(_, _ string, _, _ *timestamp.Timestamp) error {
+				called = true
+				return nil
+			})
+		},
+		"GetWorkflowsForWorker": func() error {
+			_, err := d.GetWorkflowsForWorker(ctx, h.id)
+			return err
+		},
+		"ListWorkflows": func() error {
+			return d.ListWorkflows(ctx, db.WorkflowFilter{}, db.ListOptions{}, func(db.Workflow) error {
+				called = true
+				return nil
+			})
+		},
+		"ShowWorkflowEvents": func() error {
+			return d.ShowWorkflowEvents(ctx, workflowID, func(*pb.WorkflowActionStatus) error {
+				called = true
+				return nil
+			})
+		},
+	}
+	for name, read := range reads {
+		if err := read(); err == nil {
+			t.Errorf("%s: expected an error with a cancelled context", name)
+		}
+	}
+	if called {
+		t.Error("expected no row to be read with a cancelled context")
+	}
+
+	// nothing gets written either
+	gone := newHardware("08:00:27:00:00:02", "192.168.1.6")
+	if err := d.InsertIntoDB(ctx, gone.data(t, 0)); err == nil {
+		t.Error("expected an error inserting with a cancelled context")
+	}
+	_, err := d.GetByID(context.Background(), gone.id)
+	expectNoRows(t, err)
+}
@@ -0,0 +1,278 @@
+// Package dbtest is a conformance suite for the implementations of
+// db.Database. It checks the semantics the servers rely on, whatever the
+// storage: how the resources are created, read, listed and deleted, the
+// soft deletes, the pruning of the workflow data versions, the mapping of the
+// workflows to their workers, the namespaces, the backups and that a
+// cancelled context stops a call.
+//
+// An implementation runs it from one of its tests:
+//
+//	func TestConformance(t *testing.T) {
+//		dbtest.Run(t, func(t *testing.T) (db.Database, func()) {
+//			d := open(t)
+//			return d, func() { d.Close() }
+//		})
+//	}
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewDatabase returns an empty database for a test, and the function
+// releasing it when the test is over
+type NewDatabase func(t *testing.T) (db.Database, func())
+
+type conformanceTest struct {
+	name string
+	run  func(t *testing.T, d db.Database)
+}
+
+var tests = []conformanceTest{
+	{"Hardware/CreateAndGet", testHardwareCreateAndGet},
+	{"Hardware/Versions", testHardwareVersions},
+	{"Hardware/Conflicts", testHardwareConflicts},
+	{"Hardware/SoftDelete", testHardwareSoftDelete},
+	{"Hardware/List", testHardwareList},
+	{"Hardware/Batch", testHardwareBatch},
+	{"Hardware/State", testHardwareState},
+	{"Hardware/History", testHardwareHistory},
+	{"Template/CreateAndGet", testTemplateCreateAndGet},
+	{"Template/SoftDelete", testTemplateSoftDelete},
+	{"Template/List", testTemplateList},
+	{"Template/Update", testTemplateUpdate},
+	{"Workflow/CreateAndGet", testWorkflowCreateAndGet},
+	{"Workflow/Workers", testWorkflowWorkers},
+	{"Workflow/SoftDelete", testWorkflowSoftDelete},
+	{"Workflow/List", testWorkflowList},
+	{"Workflow/DataVersions", testWorkflowDataVersions},
+	{"Workflow/DataRetention", testWorkflowDataRetention},
+	{"Workflow/DataRevision", testWorkflowDataRevision},
+	{"Workflow/Events", testWorkflowEvents},
+	{"Events", testEvents},
+	{"Webhooks", testWebhooks},
+	{"Namespaces", testNamespaces},
+	{"Retention", testRetention},
+	{"CancelledContext", testCancelledContext},
+}
+
+// Run runs the conformance suite, every test gets its own database from
+// newDB
+func Run(t *testing.T, newDB NewDatabase) {
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d, release := newDB(t)
+			defer release()
+			tt.run(t, d)
+		})
+	}
+
+	// the backup of a database gets imported in a second one
+	t.Run("Backup", func(t *testing.T) {
+		d, release := newDB(t)
+		defer release()
+		empty, releaseEmpty := newDB(t)
+		defer releaseEmpty()
+		testBackup(t, d, empty)
+	})
+	t.Run("Backup/ImportInUsed", func(t *testing.T) {
+		testImportInUsed(t, newDB)
+	})
+}
+
+// The template of the workflows of the suite, it has two actions run by the
+// worker of device_1
+const templateData = `version: '0.1'
+name: conformance
+global_timeout: 600
+tasks:
+- name: "provision"
+  worker: "{{.device_1}}"
+  actions:
+  - name: "partition"
+    image: partition
+    timeout: 60
+  - name: "install"
+    image: install
+    timeout: 60
+`
+
+type hardwareSpec struct {
+	id     string
+	mac    string
+	ip     string
+	labels map[string]string
+	// hostname is "host-" and the MAC address when it is empty
+	hostname string
+}
+
+func (h hardwareSpec) data(t *testing.T, version int64) string {
+	t.Helper()
+	hostname := h.hostname
+	if hostname == "" {
+		hostname = "host-" + h.mac
+	}
+	doc := map[string]interface{}{
+		"id": h.id,
+		"network": map[string]interface{}{
+			"interfaces": []interface{}{
+				map[string]interface{}{
+					"dhcp": map[string]interface{}{
+						"mac":      h.mac,
+						"hostname": hostname,
+						"ip":       map[string]string{"address": h.ip},
+					},
+				},
+			},
+		},
+	}
+	if h.labels != nil {
+		doc["labels"] = h.labels
+	}
+	if version != 0 {
+		doc["version"] = version
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func newHardware(mac, ip string) hardwareSpec {
+	return hardwareSpec{id: uuid.New().String(), mac: mac, ip: ip}
+}
+
+func insertHardware(t *testing.T, d db.Database, h hardwareSpec) {
+	t.Helper()
+	if err := d.InsertIntoDB(context.Background(), h.data(t, 0)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// updateHardware writes the hardware again, with the version it has in d
+func updateHardware(t *testing.T, d db.Database, h hardwareSpec) {
+	t.Helper()
+	ctx := context.Background()
+	data, err := d.GetByID(ctx, h.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.InsertIntoDB(ctx, h.data(t, decodeHardware(t, data).Version)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// storedHardware is what the suite reads back of the hardware data
+type storedHardware struct {
+	ID      string `json:"id"`
+	Version int64  `json:"version"`
+	State   int32  `json:"state"`
+}
+
+func decodeHardware(t *testing.T, data string) storedHardware {
+	t.Helper()
+	var hw storedHardware
+	if err := json.Unmarshal([]byte(data), &hw); err != nil {
+		t.Fatalf("invalid hardware data %q: %v", data, err)
+	}
+	return hw
+}
+
+func createTemplate(t *testing.T, d db.Database, name string) string {
+	t.Helper()
+	id := uuid.New()
+	if err := d.CreateTemplate(context.Background(), name, templateData, id); err != nil {
+		t.Fatal(err)
+	}
+	return id.String()
+}
+
+// newWorkflow returns a workflow of the template run by the hardware with
+// the given MAC address, and its rendered template
+func newWorkflow(t *testing.T, templateID, mac string) (db.Workflow, string) {
+	t.Helper()
+	devices := `{"device_1":"` + mac + `"}`
+	data, err := workflow.RenderTemplate(templateID, templateData, []byte(devices))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.Workflow{ID: uuid.New().String(), Template: templateID, Hardware: devices}, data
+}
+
+func createWorkflow(t *testing.T, d db.Database, templateID, mac string) string {
+	t.Helper()
+	wf, data := newWorkflow(t, templateID, mac)
+	if err := d.CreateWorkflow(context.Background(), wf, data, uuid.MustParse(wf.ID)); err != nil {
+		t.Fatal(err)
+	}
+	return wf.ID
+}
+
+func expectCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Errorf("expected error code %s, got %v", code, err)
+	}
+}
+
+// expectNoRows checks the error returned when a row does not exist, the
+// servers look for sql.ErrNoRows whatever the storage is
+func expectNoRows(t *testing.T, err error) {
+	t.Helper()
+	if errors.Cause(err) != sql.ErrNoRows {
+		t.Errorf("expected %v, got %v", sql.ErrNoRows, err)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedStrings(s ...string) []string {
+	sorted := append([]string{}, s...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// listPages lists the rows ordered by orderBy one by one, each page starting
+// after the cursor of the last row of the previous one, and returns them all
+func listPages(t *testing.T, list func(db.ListOptions) []string, orderBy string) []string {
+	t.Helper()
+	var (
+		all   []string
+		after *db.Cursor
+	)
+	for {
+		var last db.Cursor
+		page := list(db.ListOptions{OrderBy: orderBy, Limit: 1, After: after, Cursor: func(c db.Cursor) { last = c }})
+		if len(page) == 0 {
+			return all
+		}
+		all = append(all, page...)
+		if len(all) > 100 {
+			t.Fatalf("the pages ordered by %s do not end", orderBy)
+		}
+		after = &last
+	}
+}
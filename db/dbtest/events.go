@@ -0,0 +1,183 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tinkerbell/tink/db"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+)
+
+func testEvents(t *testing.T, d db.Database) {
+	ctx := db.WithActor(context.Background(), "conformance")
+	latest, err := d.LatestEventID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 0 {
+		t.Errorf("expected no event, got %d", latest)
+	}
+
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	if err := d.InsertIntoDB(ctx, h.data(t, 0)); err != nil {
+		t.Fatal(err)
+	}
+	templateID := createTemplate(t, d, "conformance")
+	if err := d.DeleteTemplate(ctx, templateID); err != nil {
+		t.Fatal(err)
+	}
+	templateID = createTemplate(t, d, "conformance")
+	id := createWorkflow(t, d, templateID, h.mac)
+	if err := d.DeleteWorkflow(ctx, id, int32(pb.State_STATE_PENDING)); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func(filter db.EventFilter, since int64, limit int) []db.Event {
+		t.Helper()
+		var events []db.Event
+		err := d.GetEvents(ctx, filter, since, limit, func(ev db.Event) error {
+			events = append(events, ev)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return events
+	}
+	describe := func(events []db.Event) []string {
+		var described []string
+		for _, ev := range events {
+			described = append(described, ev.ResourceType+" "+ev.EventType)
+		}
+		return described
+	}
+
+	all := list(db.EventFilter{}, 0, 100)
+	want := []string{
+		db.ResourceHardware + " " + db.EventCreated,
+		db.ResourceTemplate + " " + db.EventCreated,
+		db.ResourceTemplate + " " + db.EventDeleted,
+		db.ResourceTemplate + " " + db.EventCreated,
+		db.ResourceWorkflow + " " + db.EventCreated,
+		db.ResourceWorkflow + " " + db.EventDeleted,
+	}
+	if got := describe(all); !equalStrings(got, want) {
+		t.Fatalf("expected the events %v, got %v", want, got)
+	}
+	for i, ev := range all {
+		if i > 0 && ev.ID <= all[i-1].ID {
+			t.Errorf("expected the events to be numbered in order, got %d after %d", ev.ID, all[i-1].ID)
+		}
+		if ev.CreatedAt.IsZero() {
+			t.Errorf("expected the time of the event %d to be set", ev.ID)
+		}
+	}
+	if all[0].ResourceID != h.id || all[0].Actor != "conformance" {
+		t.Errorf("expected the hardware %s to be created by conformance, got %s by %q", h.id, all[0].ResourceID, all[0].Actor)
+	}
+
+	latest, err = d.LatestEventID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != all[len(all)-1].ID {
+		t.Errorf("expected the latest event to be %d, got %d", all[len(all)-1].ID, latest)
+	}
+
+	got := describe(list(db.EventFilter{
+		ResourceTypes: []string{db.ResourceTemplate, db.ResourceWorkflow},
+		EventTypes:    []string{db.EventDeleted},
+	}, 0, 100))
+	if want := []string{db.ResourceTemplate + " " + db.EventDeleted, db.ResourceWorkflow + " " + db.EventDeleted}; !equalStrings(got, want) {
+		t.Errorf("expected the deletions of templates and workflows, got %v", got)
+	}
+	if got := list(db.EventFilter{ResourceIDs: []string{id}}, 0, 100); len(got) != 2 || got[0].ResourceID != id {
+		t.Errorf("expected the 2 events of the workflow %s, got %v", id, got)
+	}
+	if got := list(db.EventFilter{}, all[1].ID, 2); len(got) != 2 || got[0].ID != all[2].ID || got[1].ID != all[3].ID {
+		t.Errorf("expected the 2 events following %d, got %v", all[1].ID, got)
+	}
+}
+
+func testWebhooks(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	created := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	hooks := []db.Webhook{
+		{ID: uuid.New().String(), URL: "https://example.com/first", Secret: "first", EventTypes: []string{db.ResourceWorkflow + "." + db.EventCreated}, CreatedAt: created},
+		{ID: uuid.New().String(), URL: "https://example.com/second", Secret: "second", EventTypes: []string{}, CreatedAt: created.Add(time.Minute)},
+	}
+	// created out of order, listed by time
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := d.CreateWebhook(ctx, hooks[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list := func() []db.Webhook {
+		t.Helper()
+		var listed []db.Webhook
+		err := d.ListWebhooks(ctx, func(wh db.Webhook) error {
+			listed = append(listed, wh)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return listed
+	}
+	listed := list()
+	if len(listed) != len(hooks) {
+		t.Fatalf("expected %d webhooks, got %d", len(hooks), len(listed))
+	}
+	for i, wh := range listed {
+		if wh.ID != hooks[i].ID || wh.URL != hooks[i].URL || wh.Secret != hooks[i].Secret {
+			t.Errorf("expected the webhook %s at %d, got %s", hooks[i].ID, i, wh.ID)
+		}
+		if !equalStrings(wh.EventTypes, hooks[i].EventTypes) {
+			t.Errorf("expected the webhook %s to get %v, got %v", wh.ID, hooks[i].EventTypes, wh.EventTypes)
+		}
+		if !wh.CreatedAt.Equal(hooks[i].CreatedAt) {
+			t.Errorf("expected the webhook %s to be created at %s, got %s", wh.ID, hooks[i].CreatedAt, wh.CreatedAt)
+		}
+	}
+
+	if err := d.DeleteWebhook(ctx, hooks[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if listed := list(); len(listed) != 1 || listed[0].ID != hooks[1].ID {
+		t.Errorf("expected only the webhook %s, got %v", hooks[1].ID, listed)
+	}
+	expectCode(t, d.DeleteWebhook(ctx, hooks[0].ID), codes.NotFound)
+	expectCode(t, d.DeleteWebhook(ctx, uuid.New().String()), codes.NotFound)
+
+	failures := []db.WebhookFailure{
+		{ID: uuid.New().String(), WebhookID: hooks[1].ID, EventType: "workflow.created", Payload: "{}", Attempts: 5, Error: "timeout", CreatedAt: created.Add(2 * time.Minute)},
+		{ID: uuid.New().String(), WebhookID: hooks[0].ID, EventType: "workflow.created", Payload: "{}", Attempts: 5, Error: "timeout", CreatedAt: created},
+	}
+	for _, f := range failures {
+		if err := d.InsertWebhookFailure(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	listFailures := func(webhookID string) []string {
+		t.Helper()
+		var ids []string
+		err := d.ListWebhookFailures(ctx, webhookID, func(f db.WebhookFailure) error {
+			ids = append(ids, f.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ids
+	}
+	if got := listFailures(""); !equalStrings(got, []string{failures[1].ID, failures[0].ID}) {
+		t.Errorf("expected all the failures by time, got %v", got)
+	}
+	if got := listFailures(hooks[1].ID); !equalStrings(got, []string{failures[0].ID}) {
+		t.Errorf("expected the failures of the webhook %s, got %v", hooks[1].ID, got)
+	}
+}
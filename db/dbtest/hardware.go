@@ -0,0 +1,348 @@
+package dbtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tinkerbell/tink/db"
+	hpb "github.com/tinkerbell/tink/protos/hardware"
+	"google.golang.org/grpc/codes"
+)
+
+func testHardwareCreateAndGet(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+
+	get := map[string]func() (string, error){
+		"GetByID":  func() (string, error) { return d.GetByID(ctx, h.id) },
+		"GetByMAC": func() (string, error) { return d.GetByMAC(ctx, h.mac) },
+		"GetByIP":  func() (string, error) { return d.GetByIP(ctx, h.ip) },
+	}
+	for name, fn := range get {
+		data, err := fn()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		hw := decodeHardware(t, data)
+		if hw.ID != h.id || hw.Version != 1 {
+			t.Errorf("%s: expected hardware %s at version 1, got %s at version %d", name, h.id, hw.ID, hw.Version)
+		}
+		if hw.State != int32(hpb.State_STATE_AVAILABLE) {
+			t.Errorf("%s: expected new hardware to be %s, got %s", name, hpb.State_STATE_AVAILABLE, hpb.State(hw.State))
+		}
+	}
+
+	_, err := d.GetByMAC(ctx, "08:00:27:00:00:99")
+	expectNoRows(t, err)
+	_, err = d.GetByIP(ctx, "192.168.1.99")
+	expectNoRows(t, err)
+
+	count := 0
+	err = d.GetAll(ctx, func([]byte) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 hardware, got %d", count)
+	}
+}
+
+func testHardwareVersions(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+
+	// a hardware already stored can not be written without its version,
+	// unless the write is forced
+	expectCode(t, d.InsertIntoDB(ctx, h.data(t, 0)), codes.FailedPrecondition)
+	if err := d.InsertIntoDB(db.WithForce(ctx), h.data(t, 0)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := d.GetByID(ctx, h.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := decodeHardware(t, data).Version; v != 2 {
+		t.Fatalf("expected version 2, got %d", v)
+	}
+
+	err = d.InsertIntoDB(ctx, h.data(t, 1))
+	expectCode(t, err, codes.Aborted)
+
+	if err := d.InsertIntoDB(ctx, h.data(t, 2)); err != nil {
+		t.Fatal(err)
+	}
+	data, err = d.GetByID(ctx, h.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := decodeHardware(t, data).Version; v != 3 {
+		t.Errorf("expected version 3, got %d", v)
+	}
+}
+
+func testHardwareConflicts(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	first := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, first)
+
+	sameHostname := newHardware("08:00:27:00:00:03", "192.168.1.7")
+	sameHostname.hostname = "host-" + first.mac
+	for name, h := range map[string]hardwareSpec{
+		"MAC address": newHardware("08:00:27:00:00:01", "192.168.1.6"),
+		"IP address":  newHardware("08:00:27:00:00:02", "192.168.1.5"),
+		"hostname":    sameHostname,
+	} {
+		err := d.InsertIntoDB(ctx, h.data(t, 0))
+		if err == nil {
+			t.Errorf("expected a hardware using the same %s to be rejected", name)
+			continue
+		}
+		expectCode(t, err, codes.AlreadyExists)
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("expected the conflict to be on the %s, got %v", name, err)
+		}
+		_, err = d.GetByID(ctx, h.id)
+		expectNoRows(t, err)
+	}
+
+	// nothing in common with the first one
+	insertHardware(t, d, newHardware("08:00:27:00:00:04", "192.168.1.8"))
+}
+
+func testHardwareSoftDelete(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+	updateHardware(t, d, h)
+
+	if err := d.DeleteFromDB(ctx, h.id); err != nil {
+		t.Fatal(err)
+	}
+	_, err := d.GetByID(ctx, h.id)
+	expectNoRows(t, err)
+	_, err = d.GetByMAC(ctx, h.mac)
+	expectNoRows(t, err)
+	err = d.GetAll(ctx, func([]byte) error {
+		t.Error("expected the deleted hardware not to be listed")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// deleting twice is not an error, deleting what never existed is
+	if err := d.DeleteFromDB(ctx, h.id); err != nil {
+		t.Errorf("expected a second delete to succeed, got %v", err)
+	}
+	expectCode(t, d.DeleteFromDB(ctx, newHardware("", "").id), codes.NotFound)
+
+	// the addresses of a deleted hardware are free
+	insertHardware(t, d, newHardware(h.mac, h.ip))
+
+	var events []string
+	err = d.GetHardwareHistory(ctx, h.id, func(rev db.HardwareRevision) error {
+		events = append(events, rev.EventType)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{db.HardwareCreated, db.HardwareUpdated, db.HardwareDeleted}
+	if !equalStrings(events, want) {
+		t.Errorf("expected the history %v, got %v", want, events)
+	}
+}
+
+func testHardwareList(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	specs := []hardwareSpec{
+		newHardware("08:00:27:00:00:01", "192.168.1.5"),
+		newHardware("08:00:27:00:00:02", "192.168.1.6"),
+		newHardware("08:00:27:00:00:03", "192.168.1.7"),
+	}
+	specs[0].labels = map[string]string{"rack": "a", "role": "worker"}
+	specs[1].labels = map[string]string{"rack": "a"}
+	specs[2].labels = map[string]string{"rack": "b", "role": "worker"}
+	for _, h := range specs {
+		insertHardware(t, d, h)
+	}
+	if err := d.DeleteFromDB(ctx, specs[1].id); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func(labels map[string]string, opts db.ListOptions) []string {
+		t.Helper()
+		var ids []string
+		err := d.ListHardware(ctx, labels, opts, func(data []byte) error {
+			ids = append(ids, decodeHardware(t, string(data)).ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ids
+	}
+
+	if ids := list(map[string]string{"rack": "a"}, db.ListOptions{}); !equalStrings(ids, []string{specs[0].id}) {
+		t.Errorf("expected only the hardware of rack a which is not deleted, got %v", ids)
+	}
+	if ids := list(map[string]string{"role": "worker", "rack": "b"}, db.ListOptions{}); !equalStrings(ids, []string{specs[2].id}) {
+		t.Errorf("expected the hardware having all the labels, got %v", ids)
+	}
+
+	all := sortedStrings(specs[0].id, specs[2].id)
+	if ids := list(nil, db.ListOptions{}); !equalStrings(ids, all) {
+		t.Errorf("expected all the hardware ordered by id, got %v", ids)
+	}
+	if ids := list(nil, db.ListOptions{OrderBy: "id desc", Limit: 1}); !equalStrings(ids, all[1:]) {
+		t.Errorf("expected the last hardware by id, got %v", ids)
+	}
+	if ids := list(nil, db.ListOptions{Offset: 1}); !equalStrings(ids, all[1:]) {
+		t.Errorf("expected the hardware after the first one, got %v", ids)
+	}
+	var cursors []db.Cursor
+	list(nil, db.ListOptions{Cursor: func(c db.Cursor) { cursors = append(cursors, c) }})
+	if len(cursors) != 2 || cursors[0].ID != all[0] || cursors[1].ID != all[1] {
+		t.Fatalf("expected the cursors of the hardware, got %v", cursors)
+	}
+	if ids := list(nil, db.ListOptions{After: &cursors[0]}); !equalStrings(ids, all[1:]) {
+		t.Errorf("expected the hardware after the cursor of the first one, got %v", ids)
+	}
+	if ids := list(nil, db.ListOptions{OrderBy: "id desc", After: &cursors[1]}); !equalStrings(ids, all[:1]) {
+		t.Errorf("expected the hardware before the cursor of the last one, got %v", ids)
+	}
+
+	err := d.ListHardware(ctx, nil, db.ListOptions{OrderBy: "mac"}, func([]byte) error { return nil })
+	expectCode(t, err, codes.InvalidArgument)
+	err = d.ListHardware(ctx, nil, db.ListOptions{CreatedAfter: time.Now()}, func([]byte) error { return nil })
+	expectCode(t, err, codes.InvalidArgument)
+}
+
+func testHardwareBatch(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	first := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	conflict := newHardware("08:00:27:00:00:01", "192.168.1.6")
+
+	err := d.InsertHardwareBatch(ctx, []string{first.data(t, 0), conflict.data(t, 0)})
+	batchErr, ok := err.(*db.BatchError)
+	if !ok {
+		t.Fatalf("expected a *db.BatchError, got %v", err)
+	}
+	if batchErr.Index != 1 {
+		t.Errorf("expected the second item to fail, got %d", batchErr.Index)
+	}
+	_, err = d.GetByID(ctx, first.id)
+	expectNoRows(t, err)
+
+	second := newHardware("08:00:27:00:00:02", "192.168.1.6")
+	if err := d.InsertHardwareBatch(ctx, []string{first.data(t, 0), second.data(t, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []hardwareSpec{first, second} {
+		if _, err := d.GetByID(ctx, h.id); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func testHardwareState(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+
+	available := []int32{int32(hpb.State_STATE_AVAILABLE)}
+	provisioning := int32(hpb.State_STATE_PROVISIONING)
+	data, err := d.SetHardwareState(ctx, h.id, available, provisioning)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hw := decodeHardware(t, data)
+	if hw.State != provisioning || hw.Version != 2 {
+		t.Errorf("expected the hardware %s at version 2, got %s at version %d", hpb.State(provisioning), hpb.State(hw.State), hw.Version)
+	}
+
+	_, err = d.SetHardwareState(ctx, h.id, available, provisioning)
+	expectCode(t, err, codes.FailedPrecondition)
+	_, err = d.SetHardwareState(ctx, newHardware("", "").id, nil, provisioning)
+	expectCode(t, err, codes.NotFound)
+
+	// a push does not change the state
+	updateHardware(t, d, h)
+	data, err = d.GetByID(ctx, h.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := decodeHardware(t, data).State; s != provisioning {
+		t.Errorf("expected the state to be kept, got %s", hpb.State(s))
+	}
+}
+
+func testHardwareHistory(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	latest, err := d.LatestHardwareRevision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 0 {
+		t.Errorf("expected no revision, got %d", latest)
+	}
+
+	first := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	second := newHardware("08:00:27:00:00:02", "192.168.1.6")
+	insertHardware(t, d, first)
+	insertHardware(t, d, second)
+	updateHardware(t, d, first)
+
+	var revisions []db.HardwareRevision
+	err = d.GetHardwareChanges(ctx, 0, 10, func(rev db.HardwareRevision) error {
+		revisions = append(revisions, rev)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revisions))
+	}
+	for i := 1; i < len(revisions); i++ {
+		if revisions[i].Revision <= revisions[i-1].Revision {
+			t.Errorf("expected the revisions to be numbered in order, got %d after %d", revisions[i].Revision, revisions[i-1].Revision)
+		}
+	}
+	if r := revisions[2]; r.HardwareID != first.id || r.EventType != db.HardwareUpdated || r.Version != 2 {
+		t.Errorf("expected the update of %s to version 2, got the %s of %s to version %d", first.id, r.EventType, r.HardwareID, r.Version)
+	}
+	if r := revisions[0]; r.PreviousData != "" {
+		t.Errorf("expected no previous data for a creation, got %s", r.PreviousData)
+	}
+	if r := revisions[2]; r.PreviousData != revisions[0].Data {
+		t.Errorf("expected the previous data of the update to be %s, got %s", revisions[0].Data, r.PreviousData)
+	}
+
+	latest, err = d.LatestHardwareRevision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != revisions[2].Revision {
+		t.Errorf("expected the latest revision to be %d, got %d", revisions[2].Revision, latest)
+	}
+
+	var since []int64
+	err = d.GetHardwareChanges(ctx, revisions[0].Revision, 1, func(rev db.HardwareRevision) error {
+		since = append(since, rev.Revision)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(since) != 1 || since[0] != revisions[1].Revision {
+		t.Errorf("expected the revision following %d, got %v", revisions[0].Revision, since)
+	}
+}
@@ -0,0 +1,138 @@
+package dbtest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/uuid"
+	"github.com/tinkerbell/tink/db"
+	"google.golang.org/grpc/codes"
+)
+
+func testNamespaces(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	teamA := db.WithNamespace(ctx, "team-a")
+	teamB := db.WithNamespace(ctx, "team-b")
+
+	// the hardware
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	if err := d.InsertIntoDB(teamA, h.data(t, 0)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := d.GetByMAC(teamA, h.mac)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored struct {
+		Namespace string `json:"namespace"`
+	}
+	if err := json.Unmarshal([]byte(data), &stored); err != nil || stored.Namespace != "team-a" {
+		t.Errorf("expected the hardware to be in team-a, got %q: %v", stored.Namespace, err)
+	}
+	if _, err := d.GetByMAC(ctx, h.mac); err != nil {
+		t.Errorf("expected a request without namespace to see the hardware: %v", err)
+	}
+	_, err = d.GetByMAC(teamB, h.mac)
+	expectNoRows(t, err)
+	_, err = d.GetByID(teamB, h.id)
+	expectNoRows(t, err)
+	count := 0
+	err = d.GetAll(teamB, func([]byte) error {
+		count++
+		return nil
+	})
+	if err != nil || count != 0 {
+		t.Errorf("expected no hardware in team-b, got %d: %v", count, err)
+	}
+	// the conflicts do not tell the id of the hardware of another namespace
+	for _, conflict := range []hardwareSpec{h, newHardware(h.mac, "192.168.1.6")} {
+		err := d.InsertIntoDB(teamB, conflict.data(t, 0))
+		expectCode(t, err, codes.AlreadyExists)
+		if err != nil && strings.Contains(err.Error(), h.id) {
+			t.Errorf("expected the conflict not to tell the id of the hardware of team-a, got %v", err)
+		}
+	}
+	expectCode(t, d.DeleteFromDB(teamB, h.id), codes.NotFound)
+
+	// the templates, their names are unique within a namespace
+	templateA := uuid.New()
+	if err := d.CreateTemplate(teamA, "conformance", templateData, templateA); err != nil {
+		t.Fatal(err)
+	}
+	templateB := uuid.New()
+	if err := d.CreateTemplate(teamB, "conformance", templateData, templateB); err != nil {
+		t.Fatalf("expected the name of a template of another namespace to be free: %v", err)
+	}
+	if err := d.CreateTemplate(teamA, "conformance", templateData, uuid.New()); err == nil {
+		t.Error("expected a template using the same name in the same namespace to be rejected")
+	}
+	tmpl, err := d.GetTemplate(teamB, map[string]string{"name": "conformance"}, false)
+	if err != nil || tmpl.Id != templateB.String() || tmpl.Namespace != "team-b" {
+		t.Errorf("expected the template %s of team-b, got %s in %q: %v", templateB, tmpl.Id, tmpl.Namespace, err)
+	}
+	_, err = d.GetTemplate(teamB, map[string]string{"id": templateA.String()}, false)
+	expectNoRows(t, err)
+	// without namespace the names are the ones of the default namespace
+	_, err = d.GetTemplate(ctx, map[string]string{"name": "conformance"}, false)
+	expectNoRows(t, err)
+	expectCode(t, d.DeleteTemplate(teamB, templateA.String()), codes.NotFound)
+	templates := func(ctx context.Context) int {
+		t.Helper()
+		n := 0
+		err := d.ListTemplates(ctx, "%", db.ListOptions{}, func(_, _ string, _, _ *timestamp.Timestamp) error {
+			n++
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	if got := templates(teamA); got != 1 {
+		t.Errorf("expected 1 template in team-a, got %d", got)
+	}
+	if got := templates(ctx); got != 2 {
+		t.Errorf("expected a request without namespace to list the 2 templates, got %d", got)
+	}
+
+	// the workflows and their workers
+	wf, wfData := newWorkflow(t, templateA.String(), h.mac)
+	if err := d.CreateWorkflow(teamA, wf, wfData, uuid.MustParse(wf.ID)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.GetWorkflow(ctx, wf.ID)
+	if err != nil || got.Namespace != "team-a" {
+		t.Errorf("expected the workflow to be in team-a, got %q: %v", got.Namespace, err)
+	}
+	if _, err := d.GetWorkflow(teamB, wf.ID); err == nil {
+		t.Error("expected the workflow not to be visible in team-b")
+	}
+	if ids, err := d.GetWorkflowsForWorker(teamB, h.id); err != nil || len(ids) != 0 {
+		t.Errorf("expected no workflow of the worker in team-b, got %v: %v", ids, err)
+	}
+	if ids, err := d.GetWorkflowsForWorker(teamA, h.id); err != nil || !equalStrings(ids, []string{wf.ID}) {
+		t.Errorf("expected the workflow of the worker in team-a, got %v: %v", ids, err)
+	}
+	_, err = d.GetWorkflowContexts(teamB, wf.ID)
+	expectCode(t, err, codes.NotFound)
+	expectCode(t, d.DeleteWorkflow(teamB, wf.ID, 0), codes.NotFound)
+	count = 0
+	err = d.ListWorkflows(teamB, db.WorkflowFilter{}, db.ListOptions{}, func(db.Workflow) error {
+		count++
+		return nil
+	})
+	if err != nil || count != 0 {
+		t.Errorf("expected no workflow in team-b, got %d: %v", count, err)
+	}
+
+	// the worker of a workflow has to be a hardware of its namespace
+	other, otherData := newWorkflow(t, templateB.String(), h.mac)
+	if err := d.CreateWorkflow(teamB, other, otherData, uuid.MustParse(other.ID)); err == nil {
+		t.Error("expected a workflow of a worker of another namespace to be rejected")
+	}
+	other.Namespace = "team-a"
+	expectCode(t, d.CreateWorkflow(teamB, other, otherData, uuid.MustParse(other.ID)), codes.InvalidArgument)
+}
@@ -0,0 +1,221 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tinkerbell/tink/db"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+)
+
+func testRetention(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	gone := newHardware("08:00:27:00:00:02", "192.168.1.6")
+	insertHardware(t, d, h)
+	insertHardware(t, d, gone)
+	if err := d.DeleteFromDB(ctx, gone.id); err != nil {
+		t.Fatal(err)
+	}
+
+	templateID := createTemplate(t, d, "conformance")
+	unused := createTemplate(t, d, "unused")
+	used := createTemplate(t, d, "used")
+	for _, id := range []string{unused, used} {
+		if err := d.DeleteTemplate(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	finished := createWorkflow(t, d, templateID, h.mac)
+	running := createWorkflow(t, d, templateID, h.mac)
+	deleted := createWorkflow(t, d, templateID, h.mac)
+	pending := createWorkflow(t, d, used, h.mac)
+
+	start := time.Now().Add(-2 * time.Hour)
+	event := func(wfID, action string, state pb.State, at time.Time) {
+		t.Helper()
+		err := d.InsertIntoWorkflowEventTable(ctx, &pb.WorkflowActionStatus{
+			WorkflowId:   wfID,
+			WorkerId:     h.id,
+			TaskName:     "provision",
+			ActionName:   action,
+			ActionStatus: state,
+		}, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	setState := func(id string, index int64, state pb.State) {
+		t.Helper()
+		err := d.UpdateWorkflowState(ctx, &pb.WorkflowContext{
+			WorkflowId:         id,
+			CurrentWorker:      h.id,
+			CurrentTask:        "provision",
+			CurrentAction:      "install",
+			CurrentActionIndex: index,
+			CurrentActionState: state,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []string{finished, running, deleted} {
+		event(id, "partition", pb.State_STATE_SUCCESS, start)
+		err := d.InsertIntoWfDataTable(ctx, &pb.UpdateWorkflowDataRequest{
+			WorkflowId: id,
+			Metadata:   []byte(`{}`),
+			Data:       []byte(`{}`),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	event(finished, "install", pb.State_STATE_SUCCESS, start.Add(time.Minute))
+	setState(finished, 1, pb.State_STATE_SUCCESS)
+	setState(running, 0, pb.State_STATE_SUCCESS)
+	if err := d.DeleteWorkflow(ctx, deleted, int32(pb.State_STATE_PENDING)); err != nil {
+		t.Fatal(err)
+	}
+
+	events := func(id string) int {
+		t.Helper()
+		var n int
+		err := d.ShowWorkflowEvents(ctx, id, func(*pb.WorkflowActionStatus) error {
+			n++
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// what was removed an hour ago and more is kept
+	report, err := d.Purge(ctx, start.Add(-time.Hour), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report != (db.PurgeReport{}) {
+		t.Errorf("expected nothing to be removed before the deletions, got %+v", report)
+	}
+
+	// the deletions happened just now, the events of the finished workflow
+	// two hours ago
+	before := time.Now().Add(time.Hour)
+	want := db.PurgeReport{
+		Hardware:          1,
+		HardwareRevisions: 2,
+		Templates:         1,
+		Workflows:         1,
+		WorkflowEvents:    3,
+		WorkflowData:      2,
+	}
+	latest, err := d.LatestHardwareRevision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := func(since int64) []db.HardwareRevision {
+		t.Helper()
+		var revs []db.HardwareRevision
+		err := d.GetHardwareChanges(ctx, since, 10, func(rev db.HardwareRevision) error {
+			revs = append(revs, rev)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return revs
+	}
+	compactions := func(revs []db.HardwareRevision) []string {
+		var ids []string
+		for _, rev := range revs {
+			if rev.EventType == db.HardwareCompacted {
+				ids = append(ids, rev.HardwareID)
+			}
+		}
+		return ids
+	}
+	dryRun, err := d.Purge(ctx, before, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dryRun != want {
+		t.Errorf("expected the dry run to report %+v, got %+v", want, dryRun)
+	}
+	if n := events(finished); n != 2 {
+		t.Errorf("expected the dry run to keep the events of the finished workflow, got %d", n)
+	}
+	if _, err := d.GetTemplate(ctx, map[string]string{"id": unused}, true); err != nil {
+		t.Errorf("expected the dry run to keep the deleted template: %v", err)
+	}
+	if revs := changes(0); len(revs) != 3 || len(compactions(revs)) != 0 {
+		t.Errorf("expected the dry run to keep the 3 hardware changes, got %d", len(revs))
+	}
+
+	report, err = d.Purge(ctx, before, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report != want {
+		t.Errorf("expected the purge to report %+v, got %+v", want, report)
+	}
+
+	if n := events(finished); n != 0 {
+		t.Errorf("expected the events of the finished workflow to be removed, got %d", n)
+	}
+	if _, err := d.GetWorkflow(ctx, finished); err != nil {
+		t.Errorf("expected the finished workflow to be kept: %v", err)
+	}
+	if n := events(running); n != 1 {
+		t.Errorf("expected the events of the running workflow to be kept, got %d", n)
+	}
+	if data, err := d.GetfromWfDataTable(ctx, &pb.GetWorkflowDataRequest{WorkflowId: running}); err != nil || string(data) != `{}` {
+		t.Errorf("expected the data of the running workflow to be kept, got %s: %v", data, err)
+	}
+	if _, err := d.GetTemplate(ctx, map[string]string{"id": unused}, true); err == nil {
+		t.Error("expected the unused deleted template to be removed")
+	}
+	if _, err := d.GetTemplate(ctx, map[string]string{"id": used}, true); err != nil {
+		t.Errorf("expected the deleted template of the pending workflow %s to be kept: %v", pending, err)
+	}
+	if _, err := d.GetByID(ctx, h.id); err != nil {
+		t.Errorf("expected the hardware to be kept: %v", err)
+	}
+	var history int
+	err = d.GetHardwareHistory(ctx, gone.id, func(db.HardwareRevision) error {
+		history++
+		return nil
+	})
+	if err == nil && history != 0 {
+		t.Errorf("expected the history of the deleted hardware to be removed, got %d revisions", history)
+	}
+	// the history of the removed hardware is replaced by its last revision,
+	// the changes of the other hardware are kept
+	revs := changes(0)
+	if len(revs) != 2 || revs[0].HardwareID != h.id || revs[1].EventType != db.HardwareCompacted {
+		t.Fatalf("expected the creation of the hardware and the compaction of the removed one, got %+v", revs)
+	}
+	if c := revs[1]; c.HardwareID != gone.id || c.Revision != latest || decodeHardware(t, c.Data).ID != gone.id {
+		t.Errorf("expected the compaction of %s at the revision %d, got %+v", gone.id, latest, c)
+	}
+	if ids := compactions(changes(latest - 1)); !equalStrings(ids, []string{gone.id}) {
+		t.Errorf("expected the compaction after an older revision, got %v", ids)
+	}
+	if revs := changes(latest); len(revs) != 0 {
+		t.Errorf("expected nothing after the last revision purged, got %+v", revs)
+	}
+	if revision, err := d.LatestHardwareRevision(ctx); err != nil || revision != latest {
+		t.Errorf("expected the last revision to be kept by the compaction, got %d: %v", revision, err)
+	}
+
+	// nothing is left to remove
+	report, err = d.Purge(ctx, before, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report != (db.PurgeReport{}) {
+		t.Errorf("expected nothing left to remove, got %+v", report)
+	}
+}
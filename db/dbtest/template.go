@@ -0,0 +1,144 @@
+package dbtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/uuid"
+	"github.com/tinkerbell/tink/db"
+	"google.golang.org/grpc/codes"
+)
+
+func testTemplateCreateAndGet(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	id := createTemplate(t, d, "conformance")
+
+	for name, fields := range map[string]map[string]string{
+		"id":   {"id": id},
+		"name": {"name": "conformance"},
+	} {
+		tmpl, err := d.GetTemplate(ctx, fields, false)
+		if err != nil {
+			t.Fatalf("by %s: %v", name, err)
+		}
+		if tmpl.Id != id || tmpl.Name != "conformance" || tmpl.Data != templateData {
+			t.Errorf("by %s: expected the template %s, got %s named %q", name, id, tmpl.Id, tmpl.Name)
+		}
+		if tmpl.CreatedAt == nil || tmpl.UpdatedAt == nil {
+			t.Errorf("by %s: expected the template timestamps to be set", name)
+		}
+	}
+
+	_, err := d.GetTemplate(ctx, map[string]string{"id": uuid.New().String()}, false)
+	expectNoRows(t, err)
+	_, err = d.GetTemplate(ctx, map[string]string{"name": "unknown"}, false)
+	expectNoRows(t, err)
+
+	if err := d.CreateTemplate(ctx, "invalid", "not: [a template", uuid.New()); err == nil {
+		t.Error("expected an invalid template to be rejected")
+	}
+	if err := d.CreateTemplate(ctx, "conformance", templateData, uuid.New()); err == nil {
+		t.Error("expected a template using the same name to be rejected")
+	}
+}
+
+func testTemplateSoftDelete(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	id := createTemplate(t, d, "conformance")
+
+	if err := d.DeleteTemplate(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	_, err := d.GetTemplate(ctx, map[string]string{"id": id}, false)
+	expectNoRows(t, err)
+
+	tmpl, err := d.GetTemplate(ctx, map[string]string{"id": id}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Id != id {
+		t.Errorf("expected the deleted template %s, got %s", id, tmpl.Id)
+	}
+
+	expectCode(t, d.DeleteTemplate(ctx, uuid.New().String()), codes.NotFound)
+
+	// the name of a deleted template is free
+	other := createTemplate(t, d, "conformance")
+	tmpl, err = d.GetTemplate(ctx, map[string]string{"name": "conformance"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Id != other {
+		t.Errorf("expected the template %s, got %s", other, tmpl.Id)
+	}
+}
+
+func testTemplateList(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	names := []string{"ubuntu-focal", "debian", "ubuntu-bionic"}
+	ids := map[string]string{}
+	for _, name := range names {
+		ids[name] = createTemplate(t, d, name)
+	}
+	if err := d.DeleteTemplate(ctx, ids["debian"]); err != nil {
+		t.Fatal(err)
+	}
+
+	list := func(filter string, opts db.ListOptions) []string {
+		t.Helper()
+		var listed []string
+		err := d.ListTemplates(ctx, filter, opts, func(id, n string, _, _ *timestamp.Timestamp) error {
+			if id != ids[n] {
+				t.Errorf("expected the template %q to be %s, got %s", n, ids[n], id)
+			}
+			listed = append(listed, n)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return listed
+	}
+
+	if got := list("%", db.ListOptions{OrderBy: "name"}); !equalStrings(got, []string{"ubuntu-bionic", "ubuntu-focal"}) {
+		t.Errorf("expected the templates which are not deleted ordered by name, got %v", got)
+	}
+	if got := list("%", db.ListOptions{OrderBy: "name desc", Limit: 1}); !equalStrings(got, []string{"ubuntu-focal"}) {
+		t.Errorf("expected the last template by name, got %v", got)
+	}
+	if got := list("UBUNTU-F%", db.ListOptions{}); !equalStrings(got, []string{"ubuntu-focal"}) {
+		t.Errorf("expected the filter to ignore the case, got %v", got)
+	}
+	if got := list("%bionic", db.ListOptions{}); !equalStrings(got, []string{"ubuntu-bionic"}) {
+		t.Errorf("expected the templates ending with bionic, got %v", got)
+	}
+	for _, order := range []string{"name", "created_at desc", "updated_at"} {
+		if got := listPages(t, func(opts db.ListOptions) []string { return list("%", opts) }, order); !equalStrings(sortedStrings(got...), []string{"ubuntu-bionic", "ubuntu-focal"}) {
+			t.Errorf("expected the pages ordered by %s to hold every template once, got %v", order, got)
+		}
+	}
+
+	err := d.ListTemplates(ctx, "%", db.ListOptions{OrderBy: "data"}, func(_, _ string, _, _ *timestamp.Timestamp) error { return nil })
+	expectCode(t, err, codes.InvalidArgument)
+}
+
+func testTemplateUpdate(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	id := createTemplate(t, d, "conformance")
+
+	data := strings.Replace(templateData, "timeout: 60", "timeout: 90", -1)
+	if err := d.UpdateTemplate(ctx, "renamed", data, uuid.MustParse(id)); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := d.GetTemplate(ctx, map[string]string{"id": id}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Name != "renamed" || tmpl.Data != data {
+		t.Errorf("expected the template to be updated, got %q with %q", tmpl.Name, tmpl.Data)
+	}
+	_, err = d.GetTemplate(ctx, map[string]string{"name": "conformance"}, false)
+	expectNoRows(t, err)
+}
@@ -0,0 +1,525 @@
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tinkerbell/tink/db"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	"github.com/tinkerbell/tink/workflow"
+	"google.golang.org/grpc/codes"
+)
+
+func testWorkflowCreateAndGet(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+	templateID := createTemplate(t, d, "conformance")
+	id := createWorkflow(t, d, templateID, h.mac)
+
+	wf, err := d.GetWorkflow(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wf.ID != id || wf.Template != templateID {
+		t.Errorf("expected the workflow %s of the template %s, got %s of %s", id, templateID, wf.ID, wf.Template)
+	}
+	if wf.CreatedAt == nil || wf.UpdatedAt == nil {
+		t.Error("expected the workflow timestamps to be set")
+	}
+
+	wfCtx, err := d.GetWorkflowContexts(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wfCtx.TotalNumberOfActions != 2 || wfCtx.CurrentActionState != pb.State_STATE_PENDING {
+		t.Errorf("expected a pending workflow of 2 actions, got %s with %d actions", wfCtx.CurrentActionState, wfCtx.TotalNumberOfActions)
+	}
+
+	actions, err := d.GetWorkflowActions(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, action := range actions.GetActionList() {
+		names = append(names, action.Name)
+		if action.WorkerId != h.id {
+			t.Errorf("expected the action %q to run on the worker %s, got %s", action.Name, h.id, action.WorkerId)
+		}
+		if action.TaskName != "provision" {
+			t.Errorf("expected the action %q to be part of the provision task, got %q", action.Name, action.TaskName)
+		}
+	}
+	if !equalStrings(names, []string{"partition", "install"}) {
+		t.Errorf("expected the actions of the template in order, got %v", names)
+	}
+
+	if _, err := d.GetWorkflow(ctx, uuid.New().String()); err == nil {
+		t.Error("expected an error getting an unknown workflow")
+	}
+	if _, err := d.GetWorkflowContexts(ctx, uuid.New().String()); err == nil {
+		t.Error("expected an error getting the context of an unknown workflow")
+	}
+}
+
+func testWorkflowWorkers(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	first := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	second := newHardware("08:00:27:00:00:02", "192.168.1.6")
+	insertHardware(t, d, first)
+	insertHardware(t, d, second)
+	templateID := createTemplate(t, d, "conformance")
+
+	ids := []string{
+		createWorkflow(t, d, templateID, first.mac),
+		createWorkflow(t, d, templateID, first.mac),
+		createWorkflow(t, d, templateID, second.mac),
+	}
+
+	workflows := func(workerID string) []string {
+		t.Helper()
+		ids, err := d.GetWorkflowsForWorker(ctx, workerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sortedStrings(ids...)
+	}
+	if got := workflows(first.id); !equalStrings(got, sortedStrings(ids[0], ids[1])) {
+		t.Errorf("expected the workflows of the first worker, got %v", got)
+	}
+	if got := workflows(second.id); !equalStrings(got, ids[2:]) {
+		t.Errorf("expected the workflow of the second worker, got %v", got)
+	}
+	if got := workflows(uuid.New().String()); len(got) != 0 {
+		t.Errorf("expected no workflow for an unknown worker, got %v", got)
+	}
+
+	// the worker of a workflow has to be a known hardware
+	wf, data := newWorkflow(t, templateID, "08:00:27:00:00:99")
+	if err := d.CreateWorkflow(ctx, wf, data, uuid.MustParse(wf.ID)); err == nil {
+		t.Error("expected a workflow of an unknown worker to be rejected")
+	}
+	if _, err := d.GetWorkflow(ctx, wf.ID); err == nil {
+		t.Error("expected the rejected workflow not to be stored")
+	}
+
+	// so does every workflow of a batch
+	valid, validData := newWorkflow(t, templateID, second.mac)
+	err := d.CreateWorkflows(ctx, []db.Workflow{valid, wf}, []string{validData, data})
+	if err == nil {
+		t.Error("expected a batch with a workflow of an unknown worker to be rejected")
+	}
+	if got := workflows(second.id); !equalStrings(got, ids[2:]) {
+		t.Errorf("expected the workflows of a failed batch not to be mapped to their worker, got %v", got)
+	}
+}
+
+func testWorkflowSoftDelete(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+	templateID := createTemplate(t, d, "conformance")
+	id := createWorkflow(t, d, templateID, h.mac)
+	other := createWorkflow(t, d, templateID, h.mac)
+
+	if err := d.DeleteWorkflow(ctx, id, int32(pb.State_STATE_PENDING)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetWorkflow(ctx, id); err == nil {
+		t.Error("expected the deleted workflow not to be found")
+	}
+	if _, err := d.GetWorkflowContexts(ctx, id); err == nil {
+		t.Error("expected the state of the deleted workflow to be removed")
+	}
+	ids, err := d.GetWorkflowsForWorker(ctx, h.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalStrings(ids, []string{other}) {
+		t.Errorf("expected the deleted workflow not to be mapped to its worker, got %v", ids)
+	}
+	err = d.ListWorkflows(ctx, db.WorkflowFilter{}, db.ListOptions{}, func(wf db.Workflow) error {
+		if wf.ID == id {
+			t.Error("expected the deleted workflow not to be listed")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectCode(t, d.DeleteWorkflow(ctx, uuid.New().String(), int32(pb.State_STATE_PENDING)), codes.NotFound)
+}
+
+func testWorkflowList(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	first := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	second := newHardware("08:00:27:00:00:0a", "192.168.1.6")
+	insertHardware(t, d, first)
+	insertHardware(t, d, second)
+	templateID := createTemplate(t, d, "conformance")
+	otherTemplateID := createTemplate(t, d, "other")
+
+	pending := createWorkflow(t, d, templateID, first.mac)
+	running := createWorkflow(t, d, templateID, second.mac)
+	done := createWorkflow(t, d, otherTemplateID, first.mac)
+
+	setState := func(id string, index int64, state pb.State) {
+		t.Helper()
+		err := d.UpdateWorkflowState(ctx, &pb.WorkflowContext{
+			WorkflowId:         id,
+			CurrentWorker:      first.id,
+			CurrentTask:        "provision",
+			CurrentAction:      "partition",
+			CurrentActionIndex: index,
+			CurrentActionState: state,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a successful action is not the end of the workflow until it is the
+	// last one
+	setState(running, 0, pb.State_STATE_SUCCESS)
+	setState(done, 1, pb.State_STATE_SUCCESS)
+
+	list := func(filter db.WorkflowFilter, opts db.ListOptions) []string {
+		t.Helper()
+		var ids []string
+		err := d.ListWorkflows(ctx, filter, opts, func(wf db.Workflow) error {
+			ids = append(ids, wf.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ids
+	}
+
+	for _, tt := range []struct {
+		name   string
+		filter db.WorkflowFilter
+		want   []string
+	}{
+		{"pending", db.WorkflowFilter{States: []int32{int32(pb.State_STATE_PENDING)}}, []string{pending}},
+		{"running", db.WorkflowFilter{States: []int32{int32(pb.State_STATE_RUNNING)}}, []string{running}},
+		{"successful", db.WorkflowFilter{States: []int32{int32(pb.State_STATE_SUCCESS)}}, []string{done}},
+		{"finished or pending", db.WorkflowFilter{States: []int32{int32(pb.State_STATE_SUCCESS), int32(pb.State_STATE_PENDING)}}, sortedStrings(pending, done)},
+		{"of a template", db.WorkflowFilter{Template: otherTemplateID}, []string{done}},
+		{"of a hardware", db.WorkflowFilter{Hardware: first.mac}, sortedStrings(pending, done)},
+		{"of a hardware in upper case", db.WorkflowFilter{Hardware: "08:00:27:00:00:0A"}, []string{running}},
+		{"of a part of a hardware", db.WorkflowFilter{Hardware: "08:00:27:00:00:0"}, nil},
+	} {
+		if got := sortedStrings(list(tt.filter, db.ListOptions{})...); !equalStrings(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	all := sortedStrings(pending, running, done)
+	if got := list(db.WorkflowFilter{}, db.ListOptions{OrderBy: "id"}); !equalStrings(got, all) {
+		t.Errorf("expected all the workflows ordered by id, got %v", got)
+	}
+	if got := list(db.WorkflowFilter{}, db.ListOptions{OrderBy: "id desc", Offset: 1, Limit: 1}); !equalStrings(got, all[1:2]) {
+		t.Errorf("expected the second workflow by id, got %v", got)
+	}
+	for _, order := range []string{"created_at", "updated_at desc", "id"} {
+		if got := listPages(t, func(opts db.ListOptions) []string { return list(db.WorkflowFilter{}, opts) }, order); !equalStrings(sortedStrings(got...), all) {
+			t.Errorf("expected the pages ordered by %s to hold every workflow once, got %v", order, got)
+		}
+	}
+
+	err := d.ListWorkflows(ctx, db.WorkflowFilter{}, db.ListOptions{OrderBy: "devices"}, func(db.Workflow) error { return nil })
+	expectCode(t, err, codes.InvalidArgument)
+}
+
+func testWorkflowDataVersions(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+	id := createWorkflow(t, d, createTemplate(t, d, "conformance"), h.mac)
+
+	version, err := d.GetWorkflowDataVersion(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 0 {
+		t.Errorf("expected no data version, got %d", version)
+	}
+
+	kept := db.DefaultMaxDataVersions
+	total := int32(kept + 2)
+	insertWorkflowData(t, d, id, total)
+
+	version, err = d.GetWorkflowDataVersion(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != total {
+		t.Errorf("expected the data version %d, got %d", total, version)
+	}
+
+	latest, err := d.GetfromWfDataTable(ctx, &pb.GetWorkflowDataRequest{WorkflowId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf(`{"data":%d}`, total); string(latest) != want {
+		t.Errorf("expected the latest data %s, got %s", want, latest)
+	}
+
+	// only the data of the last versions is kept, the metadata of all of
+	// them is
+	for v := int32(1); v <= total; v++ {
+		req := &pb.GetWorkflowDataRequest{WorkflowId: id, Version: v}
+		data, err := d.GetfromWfDataTable(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pruned := v <= total-int32(kept)
+		if pruned && len(data) != 0 {
+			t.Errorf("expected the data of version %d to be pruned, got %s", v, data)
+		}
+		if want := fmt.Sprintf(`{"data":%d}`, v); !pruned && string(data) != want {
+			t.Errorf("expected the data of version %d to be %s, got %s", v, want, data)
+		}
+
+		metadata, err := d.GetWorkflowMetadata(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf(`{"version":%d}`, v); string(metadata) != want {
+			t.Errorf("expected the metadata of version %d to be %s, got %s", v, want, metadata)
+		}
+	}
+}
+
+func testWorkflowEvents(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+	templateID := createTemplate(t, d, "conformance")
+	id := createWorkflow(t, d, templateID, h.mac)
+	other := createWorkflow(t, d, templateID, h.mac)
+
+	start := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	insert := func(wfID, action string, at time.Time) {
+		t.Helper()
+		err := d.InsertIntoWorkflowEventTable(ctx, &pb.WorkflowActionStatus{
+			WorkflowId:   wfID,
+			WorkerId:     h.id,
+			TaskName:     "provision",
+			ActionName:   action,
+			ActionStatus: pb.State_STATE_RUNNING,
+			Seconds:      1,
+			Message:      "started " + action,
+		}, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// recorded out of order, listed by time
+	insert(id, "install", start.Add(2*time.Minute))
+	insert(id, "partition", start.Add(time.Minute))
+	insert(other, "partition", start)
+
+	var events []string
+	err := d.ShowWorkflowEvents(ctx, id, func(ev *pb.WorkflowActionStatus) error {
+		events = append(events, ev.ActionName)
+		if ev.WorkerId != h.id || ev.ActionStatus != pb.State_STATE_RUNNING || ev.Message != "started "+ev.ActionName {
+			t.Errorf("unexpected event %v", ev)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalStrings(events, []string{"partition", "install"}) {
+		t.Errorf("expected the events of the workflow by time, got %v", events)
+	}
+
+	// the events are read again from the one with the given id, the ones
+	// recorded later at the same time come after it
+	insert(id, "reboot", start.Add(2*time.Minute))
+	eventsFrom := func(from int64) ([]string, []int64) {
+		t.Helper()
+		var (
+			actions []string
+			ids     []int64
+		)
+		err := d.GetWorkflowEventsFrom(ctx, id, from, func(id int64, ev *pb.WorkflowActionStatus) error {
+			actions = append(actions, ev.ActionName)
+			ids = append(ids, id)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return actions, ids
+	}
+	actions, ids := eventsFrom(0)
+	if !equalStrings(actions, []string{"partition", "install", "reboot"}) {
+		t.Fatalf("expected the events of the workflow by time, got %v", actions)
+	}
+	actions, _ = eventsFrom(ids[1])
+	if !equalStrings(actions, []string{"install", "reboot"}) {
+		t.Errorf("expected the events from install, got %v", actions)
+	}
+	actions, _ = eventsFrom(ids[2])
+	if !equalStrings(actions, []string{"reboot"}) {
+		t.Errorf("expected the events from reboot, got %v", actions)
+	}
+
+	// an event which is gone, or of another workflow, can not be resumed
+	// from
+	for _, from := range []int64{ids[2] + 100, firstEventID(t, d, other)} {
+		err := d.GetWorkflowEventsFrom(ctx, id, from, func(int64, *pb.WorkflowActionStatus) error {
+			t.Error("expected no event to be read")
+			return nil
+		})
+		expectCode(t, err, codes.OutOfRange)
+	}
+}
+
+// firstEventID returns the id of the first event of the workflow
+func firstEventID(t *testing.T, d db.Database, wfID string) int64 {
+	t.Helper()
+	var first int64
+	err := d.GetWorkflowEventsFrom(context.Background(), wfID, 0, func(id int64, _ *pb.WorkflowActionStatus) error {
+		if first == 0 {
+			first = id
+		}
+		return nil
+	})
+	if err != nil || first == 0 {
+		t.Fatalf("expected an event for workflow %s: %v", wfID, err)
+	}
+	return first
+}
+
+// insertWorkflowData inserts the versions of the data of the workflow up to
+// the given one
+func insertWorkflowData(t *testing.T, d db.Database, id string, versions int32) {
+	t.Helper()
+	for v := int32(1); v <= versions; v++ {
+		err := d.InsertIntoWfDataTable(context.Background(), &pb.UpdateWorkflowDataRequest{
+			WorkflowId: id,
+			Metadata:   []byte(fmt.Sprintf(`{"version":%d}`, v)),
+			Data:       []byte(fmt.Sprintf(`{"data":%d}`, v)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// retainedVersions returns the versions of the data of the workflow which
+// are not pruned
+func retainedVersions(t *testing.T, d db.Database, id string) []int32 {
+	t.Helper()
+	latest, err := d.GetWorkflowDataVersion(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var retained []int32
+	for v := int32(1); v <= latest; v++ {
+		rev, err := d.GetWorkflowDataRevision(context.Background(), id, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rev.Data != nil {
+			retained = append(retained, v)
+		}
+	}
+	return retained
+}
+
+func testWorkflowDataRetention(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+
+	// the template keeps more versions than the database
+	tmpl := "max_data_versions: 5\n" + templateData
+	templateID := uuid.New()
+	if err := d.CreateTemplate(ctx, "retained", tmpl, templateID); err != nil {
+		t.Fatal(err)
+	}
+	devices := `{"device_1":"` + h.mac + `"}`
+	data, err := workflow.RenderTemplate(templateID.String(), tmpl, []byte(devices))
+	if err != nil {
+		t.Fatal(err)
+	}
+	retained := uuid.New().String()
+	wf := db.Workflow{ID: retained, Template: templateID.String(), Hardware: devices}
+	if err := d.CreateWorkflow(ctx, wf, data, uuid.MustParse(retained)); err != nil {
+		t.Fatal(err)
+	}
+	insertWorkflowData(t, d, retained, 7)
+	if got := retainedVersions(t, d, retained); fmt.Sprint(got) != "[3 4 5 6 7]" {
+		t.Errorf("expected the template to keep the data of the last 5 versions, got %v", got)
+	}
+
+	// the database sets the default of the workflows
+	setter, ok := d.(interface{ SetMaxDataVersions(int) })
+	if !ok {
+		t.Skip("the database does not set how many versions are kept")
+	}
+	setter.SetMaxDataVersions(1)
+	defer setter.SetMaxDataVersions(0)
+	other := createWorkflow(t, d, createTemplate(t, d, "conformance"), h.mac)
+	insertWorkflowData(t, d, other, 3)
+	if got := retainedVersions(t, d, other); fmt.Sprint(got) != "[3]" {
+		t.Errorf("expected the database to keep the data of the last version, got %v", got)
+	}
+	insertWorkflowData(t, d, retained, 1)
+	if got := retainedVersions(t, d, retained); fmt.Sprint(got) != "[4 5 6 7 8]" {
+		t.Errorf("expected the template to keep the data of the last 5 versions, got %v", got)
+	}
+}
+
+func testWorkflowDataRevision(t *testing.T, d db.Database) {
+	ctx := context.Background()
+	h := newHardware("08:00:27:00:00:01", "192.168.1.5")
+	insertHardware(t, d, h)
+	id := createWorkflow(t, d, createTemplate(t, d, "conformance"), h.mac)
+
+	_, err := d.GetWorkflowDataRevision(ctx, id, 0)
+	expectNoRows(t, err)
+
+	total := int32(db.DefaultMaxDataVersions + 1)
+	insertWorkflowData(t, d, id, total)
+
+	for _, tt := range []struct {
+		name     string
+		version  int32
+		expected int32
+		pruned   bool
+	}{
+		{"latest", 0, total, false},
+		{"retained", 2, 2, false},
+		{"pruned", 1, 1, true},
+	} {
+		rev, err := d.GetWorkflowDataRevision(ctx, id, tt.version)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if rev.WorkflowID != id || rev.Version != tt.expected {
+			t.Errorf("%s: expected the version %d of %s, got %d of %s", tt.name, tt.expected, id, rev.Version, rev.WorkflowID)
+		}
+		if want := fmt.Sprintf(`{"version":%d}`, tt.expected); string(rev.Metadata) != want {
+			t.Errorf("%s: expected the metadata %s, got %s", tt.name, want, rev.Metadata)
+		}
+		want := fmt.Sprintf(`{"data":%d}`, tt.expected)
+		if tt.pruned && rev.Data != nil {
+			t.Errorf("%s: expected the data to be pruned, got %s", tt.name, rev.Data)
+		}
+		if !tt.pruned && string(rev.Data) != want {
+			t.Errorf("%s: expected the data %s, got %s", tt.name, want, rev.Data)
+		}
+	}
+
+	_, err = d.GetWorkflowDataRevision(ctx, id, total+1)
+	expectNoRows(t, err)
+}
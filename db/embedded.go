@@ -0,0 +1,381 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/packethost/pkg/log"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EmbeddedDB implements the Database interface on top of an embedded
+// key/value store, for the setups where running PostgreSQL is too heavy:
+// a bbolt file for labs, CI and edge sites, or the memory for unit tests.
+//
+// It keeps the semantics of TinkDB: the rows are soft deleted, the hardware
+// history and the events log are numbered in commit order, and the errors
+// are the same ones, so the callers do not have to tell the two apart. The
+// stores run a single write transaction at a time, which is what the
+// serializable transactions and the lock on the events table of TinkDB
+// achieve.
+type EmbeddedDB struct {
+	instance kvStore
+	logger   log.Logger
+	// maxDataVersions is the one of TinkDB
+	maxDataVersions int
+}
+
+// Every table of the PostgreSQL schema is a bucket
+var (
+	bucketHardware         = []byte("hardware")
+	bucketHardwareRevision = []byte("hardware_revision")
+	bucketTemplate         = []byte("template")
+	bucketWorkflow         = []byte("workflow")
+	bucketWorkflowState    = []byte("workflow_state")
+	bucketWorkflowWorker   = []byte("workflow_worker_map")
+	bucketWorkflowData     = []byte("workflow_data")
+	bucketWorkflowEvent    = []byte("workflow_event")
+	bucketEvents           = []byte("events")
+	bucketWebhook          = []byte("webhook")
+	bucketWebhookFailure   = []byte("webhook_failure")
+	// bucketHardwareCompaction holds the last revision purged of every
+	// hardware, by hardware id
+	bucketHardwareCompaction = []byte("hardware_revision_compaction")
+
+	kvBuckets = [][]byte{
+		bucketHardware,
+		bucketHardwareRevision,
+		bucketTemplate,
+		bucketWorkflow,
+		bucketWorkflowState,
+		bucketWorkflowWorker,
+		bucketWorkflowData,
+		bucketWorkflowEvent,
+		bucketEvents,
+		bucketWebhook,
+		bucketWebhookFailure,
+		bucketHardwareCompaction,
+	}
+)
+
+// OpenBolt opens the bbolt file at path, creating it when it does not exist.
+// There are no migrations to apply, the buckets are created on open.
+func OpenBolt(path string, lg log.Logger) (*EmbeddedDB, error) {
+	instance, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "open bolt database")
+	}
+	err = instance.Update(func(tx *bolt.Tx) error {
+		for _, name := range kvBuckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return errors.Wrapf(err, "create bucket %s", name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = instance.Close()
+		return nil, err
+	}
+	return &EmbeddedDB{instance: boltStore{instance}, logger: lg}, nil
+}
+
+// NewMemoryDB returns an empty database kept in memory. It is the reference
+// implementation of the Database interface for the unit tests, nothing
+// survives the process.
+func NewMemoryDB(lg log.Logger) *EmbeddedDB {
+	return &EmbeddedDB{instance: newMemoryStore(kvBuckets), logger: lg}
+}
+
+// SetMaxDataVersions sets how many versions of the workflow data keep their
+// data when the template of the workflow does not say
+func (d *EmbeddedDB) SetMaxDataVersions(n int) {
+	d.maxDataVersions = n
+}
+
+// Close releases the store
+func (d *EmbeddedDB) Close() error {
+	return d.instance.Close()
+}
+
+func (d EmbeddedDB) update(ctx context.Context, fn func(kvTx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return d.instance.Update(fn)
+}
+
+func (d EmbeddedDB) view(ctx context.Context, fn func(kvTx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return d.instance.View(fn)
+}
+
+// getJSON decodes the value stored at key in v, it returns false when there
+// is none
+func getJSON(b kvBucket, key []byte, v interface{}) (bool, error) {
+	buf := b.Get(key)
+	if buf == nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(buf, v); err != nil {
+		return true, errors.Wrapf(err, "invalid %s", key)
+	}
+	return true, nil
+}
+
+func putJSON(b kvBucket, key []byte, v interface{}) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, buf)
+}
+
+// forEachJSON decodes the values of the keys starting with prefix, in the
+// order of the keys. decode returns where to decode the value to, fn gets
+// called after it.
+func forEachJSON(b kvBucket, prefix []byte, decode func() interface{}, fn func(k []byte) error) error {
+	c := b.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if err := json.Unmarshal(v, decode()); err != nil {
+			return errors.Wrapf(err, "invalid %s", k)
+		}
+		if err := fn(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seqKey is the key of the rows numbered by the sequence of their bucket,
+// big endian so the keys sort like the numbers
+func seqKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
+
+// lastSeq returns the number of the last row of a bucket keyed by seqKey, 0
+// when it is empty
+func lastSeq(b kvBucket) int64 {
+	k, _ := b.Cursor().Last()
+	if len(k) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(k))
+}
+
+// uuidKey returns the key of a row identified by a UUID. The ids are
+// compared as UUIDs by PostgreSQL, so they are stored in their canonical
+// form. An id that is not a UUID matches no row.
+func uuidKey(id string) []byte {
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return []byte(id)
+	}
+	return []byte(u.String())
+}
+
+// uniqueViolation is the error PostgreSQL returns when a write breaks a
+// unique index, so the callers checking for it do not depend on the backend
+func uniqueViolation(index string) error {
+	return &pq.Error{
+		Code:       "23505",
+		Message:    fmt.Sprintf("duplicate key value violates unique constraint %q", index),
+		Constraint: index,
+	}
+}
+
+// jsonContains tells if doc contains match like the jsonb @> operator does:
+// objects match on a subset of their keys, arrays on a subset of their
+// elements in any order, and the other values have to be equal. Both are
+// JSON documents.
+func jsonContains(doc, match []byte) (bool, error) {
+	var d, m interface{}
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return false, errors.Wrap(err, "invalid JSON document")
+	}
+	if err := json.Unmarshal(match, &m); err != nil {
+		return false, errors.Wrap(err, "invalid JSON document")
+	}
+	return containsValue(d, m), nil
+}
+
+func containsValue(doc, match interface{}) bool {
+	switch m := match.(type) {
+	case map[string]interface{}:
+		d, ok := doc.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range m {
+			if dv, ok := d[k]; !ok || !containsValue(dv, v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		d, ok := doc.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, v := range m {
+			found := false
+			for _, dv := range d {
+				if containsValue(dv, v) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return doc == match
+}
+
+// likePattern compiles the pattern of an ILIKE condition: % matches any
+// sequence of characters, _ any single one, and a backslash escapes the
+// character following it
+func likePattern(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString(`(?is)^`)
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			expr.WriteString(`.*`)
+		case r == '_':
+			expr.WriteString(`.`)
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString(`$`)
+	return regexp.Compile(expr.String())
+}
+
+// page sorts n rows like the clauses returned by listClauses and returns
+// the indexes of the rows selected by the options, in order, with the
+// function reporting the cursor of a row to opts.Cursor. fields maps the
+// accepted OrderBy fields to the value of a row for that field, a time.Time
+// or a string, def is the field used when OrderBy is empty and tieBreaker
+// the one making the order stable across pages.
+func (o ListOptions) page(n int, fields map[string]func(i int) interface{}, def, tieBreaker string) ([]int, func(i int), error) {
+	field, desc, err := parseOrderBy(o.OrderBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if field == "" {
+		field = def
+	}
+	value, ok := fields[field]
+	if !ok {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "can not order by %q", field)
+	}
+	id := func(i int) string {
+		return fields[tieBreaker](i).(string)
+	}
+	// compare orders the row i against the position (v, tie)
+	compare := func(i int, v interface{}, tie string) int {
+		var c int
+		switch v := v.(type) {
+		case time.Time:
+			c = compareTimes(value(i).(time.Time), v)
+		case string:
+			c = strings.Compare(value(i).(string), v)
+		}
+		if c == 0 {
+			c = strings.Compare(id(i), tie)
+		}
+		if desc {
+			return -c
+		}
+		return c
+	}
+
+	rows := make([]int, 0, n)
+	if o.After == nil {
+		for i := 0; i < n; i++ {
+			rows = append(rows, i)
+		}
+	} else {
+		var after interface{} = o.After.Value
+		if n > 0 {
+			if _, ok := value(0).(time.Time); ok {
+				after, err = time.Parse(time.RFC3339Nano, o.After.Value)
+				if err != nil {
+					return nil, nil, status.Errorf(codes.InvalidArgument, "invalid cursor %q for %s", o.After.Value, field)
+				}
+			}
+		}
+		for i := 0; i < n; i++ {
+			if compare(i, after, o.After.ID) > 0 {
+				rows = append(rows, i)
+			}
+		}
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		return compare(rows[a], value(rows[b]), id(rows[b])) < 0
+	})
+
+	if o.Offset > 0 {
+		if o.Offset >= len(rows) {
+			return nil, nil, nil
+		}
+		rows = rows[o.Offset:]
+	}
+	if o.Limit > 0 && o.Limit < len(rows) {
+		rows = rows[:o.Limit]
+	}
+	return rows, func(i int) { o.cursor(value(i), id(i)) }, nil
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// insertKVEvent records a change in the events log, in the transaction
+// making the change
+func insertKVEvent(ctx context.Context, tx kvTx, resourceType, resourceID, eventType string) error {
+	b := tx.Bucket(bucketEvents)
+	id, err := b.NextSequence()
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to events")
+	}
+	return putJSON(b, seqKey(id), Event{
+		ID:           int64(id),
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		EventType:    eventType,
+		Actor:        ActorFromContext(ctx),
+		CreatedAt:    time.Now(),
+	})
+}
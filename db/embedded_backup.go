@@ -0,0 +1,315 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Export reads all the rows of the database, like TinkDB.Export does
+func (d EmbeddedDB) Export(ctx context.Context) (Backup, error) {
+	var b Backup
+	err := d.view(ctx, func(tx kvTx) error {
+		var hw kvHardware
+		err := forEachJSON(tx.Bucket(bucketHardware), nil, func() interface{} {
+			hw = kvHardware{}
+			return &hw
+		}, func(k []byte) error {
+			b.Hardware = append(b.Hardware, BackupHardware{ID: string(k), Data: hw.Data, InsertedAt: hw.InsertedAt, DeletedAt: hw.DeletedAt})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var rev HardwareRevision
+		err = forEachJSON(tx.Bucket(bucketHardwareRevision), nil, func() interface{} {
+			rev = HardwareRevision{}
+			return &rev
+		}, func([]byte) error {
+			b.HardwareRevisions = append(b.HardwareRevisions, BackupHardwareRevision{
+				Revision:   rev.Revision,
+				HardwareID: rev.HardwareID,
+				Version:    rev.Version,
+				EventType:  rev.EventType,
+				Actor:      rev.Actor,
+				CreatedAt:  rev.CreatedAt,
+				Data:       json.RawMessage(rev.Data),
+				Diff:       rev.Diff,
+			})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var c HardwareRevision
+		err = forEachJSON(tx.Bucket(bucketHardwareCompaction), nil, func() interface{} {
+			c = HardwareRevision{}
+			return &c
+		}, func([]byte) error {
+			b.HardwareCompactions = append(b.HardwareCompactions, BackupHardwareCompaction{
+				HardwareID: c.HardwareID,
+				Revision:   c.Revision,
+				Version:    c.Version,
+				CreatedAt:  c.CreatedAt,
+				Data:       json.RawMessage(c.Data),
+			})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// in the order of the revisions, like TinkDB.Export
+		sort.Slice(b.HardwareCompactions, func(i, j int) bool {
+			return b.HardwareCompactions[i].Revision < b.HardwareCompactions[j].Revision
+		})
+
+		var t kvTemplate
+		err = forEachJSON(tx.Bucket(bucketTemplate), nil, func() interface{} {
+			t = kvTemplate{}
+			return &t
+		}, func([]byte) error {
+			b.Templates = append(b.Templates, BackupTemplate(t))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var wf kvWorkflow
+		err = forEachJSON(tx.Bucket(bucketWorkflow), nil, func() interface{} {
+			wf = kvWorkflow{}
+			return &wf
+		}, func([]byte) error {
+			b.Workflows = append(b.Workflows, BackupWorkflow(wf))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var ws kvWorkflowState
+		err = forEachJSON(tx.Bucket(bucketWorkflowState), nil, func() interface{} {
+			ws = kvWorkflowState{}
+			return &ws
+		}, func(k []byte) error {
+			b.WorkflowStates = append(b.WorkflowStates, BackupWorkflowState{
+				WorkflowID:           string(k),
+				CurrentWorker:        ws.CurrentWorker,
+				CurrentTaskName:      ws.CurrentTaskName,
+				CurrentActionName:    ws.CurrentActionName,
+				CurrentActionState:   ws.CurrentActionState,
+				ActionList:           ws.ActionList,
+				CurrentActionIndex:   ws.CurrentActionIndex,
+				TotalNumberOfActions: ws.TotalNumberOfActions,
+			})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = tx.Bucket(bucketWorkflowWorker).ForEach(func(k, _ []byte) error {
+			workerID, workflowID := splitPrefixedKey(k)
+			b.WorkflowWorkers = append(b.WorkflowWorkers, BackupWorkflowWorker{WorkflowID: string(workflowID), WorkerID: workerID})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var ev kvWorkflowEvent
+		err = forEachJSON(tx.Bucket(bucketWorkflowEvent), nil, func() interface{} {
+			ev = kvWorkflowEvent{}
+			return &ev
+		}, func(k []byte) error {
+			workflowID, _ := splitPrefixedKey(k)
+			b.WorkflowEvents = append(b.WorkflowEvents, BackupWorkflowEvent{
+				WorkflowID:    workflowID,
+				WorkerID:      ev.WorkerID,
+				TaskName:      ev.TaskName,
+				ActionName:    ev.ActionName,
+				ExecutionTime: ev.ExecutionTime,
+				Message:       ev.Message,
+				Status:        ev.Status,
+				CreatedAt:     ev.CreatedAt,
+			})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var wd kvWorkflowData
+		err = forEachJSON(tx.Bucket(bucketWorkflowData), nil, func() interface{} {
+			wd = kvWorkflowData{}
+			return &wd
+		}, func(k []byte) error {
+			workflowID, version := splitPrefixedKey(k)
+			b.WorkflowData = append(b.WorkflowData, BackupWorkflowData{
+				WorkflowID: workflowID,
+				Version:    int32(binary.BigEndian.Uint64(version)),
+				Metadata:   wd.Metadata,
+				Data:       wd.Data,
+			})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var e Event
+		return forEachJSON(tx.Bucket(bucketEvents), nil, func() interface{} {
+			e = Event{}
+			return &e
+		}, func([]byte) error {
+			b.Events = append(b.Events, BackupEvent(e))
+			return nil
+		})
+	})
+	if err != nil {
+		err = errors.Wrap(err, "SELECT")
+		d.logger.Error(err)
+		return Backup{}, err
+	}
+	return b, nil
+}
+
+// Import restores a backup made by Export in an empty database, like
+// TinkDB.Import does
+func (d EmbeddedDB) Import(ctx context.Context, b Backup) error {
+	return d.update(ctx, func(tx kvTx) error {
+		for _, name := range [][]byte{
+			bucketHardware, bucketHardwareRevision, bucketHardwareCompaction, bucketTemplate, bucketWorkflow,
+			bucketWorkflowState, bucketWorkflowWorker, bucketWorkflowEvent, bucketWorkflowData, bucketEvents,
+		} {
+			if k, _ := tx.Bucket(name).Cursor().Seek(nil); k != nil {
+				return errNotEmpty
+			}
+		}
+
+		// put stops at the first error, err tells it once all the rows went
+		var err error
+		put := func(bucket, key []byte, v interface{}) {
+			if err == nil {
+				err = putJSON(tx.Bucket(bucket), key, v)
+			}
+		}
+		for _, hw := range b.Hardware {
+			put(bucketHardware, uuidKey(hw.ID), kvHardware{Data: hw.Data, InsertedAt: hw.InsertedAt, DeletedAt: hw.DeletedAt})
+		}
+		// the revisions are in order, the previous data of an update is the
+		// data of the last revision of the same hardware
+		last := map[string]string{}
+		for _, rev := range b.HardwareRevisions {
+			hwRev := HardwareRevision{
+				Revision:   rev.Revision,
+				HardwareID: string(uuidKey(rev.HardwareID)),
+				Version:    rev.Version,
+				EventType:  rev.EventType,
+				Actor:      rev.Actor,
+				CreatedAt:  rev.CreatedAt,
+				Data:       string(rev.Data),
+				Diff:       rev.Diff,
+			}
+			if hwRev.EventType == HardwareUpdated {
+				hwRev.PreviousData = last[hwRev.HardwareID]
+			}
+			last[hwRev.HardwareID] = hwRev.Data
+			put(bucketHardwareRevision, seqKey(uint64(rev.Revision)), hwRev)
+		}
+		for _, c := range b.HardwareCompactions {
+			id := string(uuidKey(c.HardwareID))
+			put(bucketHardwareCompaction, []byte(id), HardwareRevision{
+				Revision:   c.Revision,
+				HardwareID: id,
+				Version:    c.Version,
+				EventType:  HardwareCompacted,
+				CreatedAt:  c.CreatedAt,
+				Data:       string(c.Data),
+			})
+		}
+		for _, t := range b.Templates {
+			t.ID, t.Namespace = string(uuidKey(t.ID)), normalizeNamespace(t.Namespace)
+			put(bucketTemplate, []byte(t.ID), kvTemplate(t))
+		}
+		for _, wf := range b.Workflows {
+			wf.ID = string(uuidKey(wf.ID))
+			wf.Template = string(uuidKey(wf.Template))
+			wf.Namespace = normalizeNamespace(wf.Namespace)
+			put(bucketWorkflow, []byte(wf.ID), kvWorkflow(wf))
+		}
+		for _, ws := range b.WorkflowStates {
+			put(bucketWorkflowState, uuidKey(ws.WorkflowID), kvWorkflowState{
+				CurrentWorker:        ws.CurrentWorker,
+				CurrentTaskName:      ws.CurrentTaskName,
+				CurrentActionName:    ws.CurrentActionName,
+				CurrentActionState:   ws.CurrentActionState,
+				ActionList:           ws.ActionList,
+				CurrentActionIndex:   ws.CurrentActionIndex,
+				TotalNumberOfActions: ws.TotalNumberOfActions,
+			})
+		}
+		for _, ww := range b.WorkflowWorkers {
+			if err == nil {
+				err = tx.Bucket(bucketWorkflowWorker).Put(workerMapKey(ww.WorkerID, ww.WorkflowID), []byte{})
+			}
+		}
+		for _, ev := range b.WorkflowEvents {
+			var seq uint64
+			if err == nil {
+				seq, err = tx.Bucket(bucketWorkflowEvent).NextSequence()
+			}
+			put(bucketWorkflowEvent, append(keyPrefix(ev.WorkflowID), seqKey(seq)...), kvWorkflowEvent{
+				WorkerID:      ev.WorkerID,
+				TaskName:      ev.TaskName,
+				ActionName:    ev.ActionName,
+				ExecutionTime: ev.ExecutionTime,
+				Message:       ev.Message,
+				Status:        ev.Status,
+				CreatedAt:     ev.CreatedAt,
+			})
+		}
+		for _, wd := range b.WorkflowData {
+			put(bucketWorkflowData, workflowDataKey(wd.WorkflowID, wd.Version), kvWorkflowData{Metadata: wd.Metadata, Data: wd.Data})
+		}
+		for _, e := range b.Events {
+			e.ResourceID = string(uuidKey(e.ResourceID))
+			put(bucketEvents, seqKey(uint64(e.ID)), Event(e))
+		}
+		if err != nil {
+			return errors.Wrap(err, "INSERT")
+		}
+
+		// the next revisions and events follow the imported ones, the purged
+		// revisions included
+		revision := lastSeq(tx.Bucket(bucketHardwareRevision))
+		for _, c := range b.HardwareCompactions {
+			if c.Revision > revision {
+				revision = c.Revision
+			}
+		}
+		if err := tx.Bucket(bucketHardwareRevision).SetSequence(uint64(revision)); err != nil {
+			return errors.Wrap(err, "UPDATE sequence")
+		}
+		if err := tx.Bucket(bucketEvents).SetSequence(uint64(lastSeq(tx.Bucket(bucketEvents)))); err != nil {
+			return errors.Wrap(err, "UPDATE sequence")
+		}
+		return nil
+	})
+}
+
+// splitPrefixedKey splits a key grouped by keyPrefix in the id it is
+// grouped by and the rest
+func splitPrefixedKey(k []byte) (string, []byte) {
+	i := bytes.IndexByte(k, '/')
+	if i < 0 {
+		return string(k), nil
+	}
+	return string(k[:i]), k[i+1:]
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetEvents : get at most limit events matching the filter recorded after
+// the since event, oldest first
+func (d EmbeddedDB) GetEvents(ctx context.Context, filter EventFilter, since int64, limit int, fn func(Event) error) error {
+	in := func(values []string, v string) bool {
+		if len(values) == 0 {
+			return true
+		}
+		for _, value := range values {
+			if value == v {
+				return true
+			}
+		}
+		return false
+	}
+	return d.view(ctx, func(tx kvTx) error {
+		c := tx.Bucket(bucketEvents).Cursor()
+		n := 0
+		for k, v := c.Seek(seqKey(uint64(since + 1))); k != nil && n < limit; k, v = c.Next() {
+			var ev Event
+			if err := json.Unmarshal(v, &ev); err != nil {
+				return errors.Wrap(err, "invalid event")
+			}
+			if !in(filter.ResourceTypes, ev.ResourceType) || !in(filter.ResourceIDs, ev.ResourceID) || !in(filter.EventTypes, ev.EventType) {
+				continue
+			}
+			n++
+			if err := fn(ev); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LatestEventID : get the id of the last recorded event, 0 when there is
+// none
+func (d EmbeddedDB) LatestEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := d.view(ctx, func(tx kvTx) error {
+		id = lastSeq(tx.Bucket(bucketEvents))
+		return nil
+	})
+	return id, err
+}
+
+// kvWebhook is a row of the webhook bucket
+type kvWebhook struct {
+	Webhook
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CreateWebhook : store a webhook subscription
+func (d EmbeddedDB) CreateWebhook(ctx context.Context, wh Webhook) error {
+	id, err := uuid.Parse(wh.ID)
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to webhook")
+	}
+	wh.ID = id.String()
+	if wh.EventTypes == nil {
+		wh.EventTypes = []string{}
+	}
+	return d.update(ctx, func(tx kvTx) error {
+		b := tx.Bucket(bucketWebhook)
+		if b.Get([]byte(wh.ID)) != nil {
+			return errors.Wrap(uniqueViolation("webhook_id_key"), "INSERT in to webhook")
+		}
+		if err := putJSON(b, []byte(wh.ID), kvWebhook{Webhook: wh}); err != nil {
+			return errors.Wrap(err, "INSERT in to webhook")
+		}
+		return nil
+	})
+}
+
+// DeleteWebhook : remove a webhook subscription
+func (d EmbeddedDB) DeleteWebhook(ctx context.Context, id string) error {
+	return d.update(ctx, func(tx kvTx) error {
+		b := tx.Bucket(bucketWebhook)
+		var wh kvWebhook
+		found, err := getJSON(b, uuidKey(id), &wh)
+		if err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		if !found || wh.DeletedAt != nil {
+			return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+		}
+		now := time.Now()
+		wh.DeletedAt = &now
+		if err := putJSON(b, uuidKey(id), wh); err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		return nil
+	})
+}
+
+// ListWebhooks : get the webhook subscriptions, oldest first
+func (d EmbeddedDB) ListWebhooks(ctx context.Context, fn func(Webhook) error) error {
+	var webhooks []Webhook
+	err := d.view(ctx, func(tx kvTx) error {
+		var wh kvWebhook
+		return forEachJSON(tx.Bucket(bucketWebhook), nil, func() interface{} {
+			wh = kvWebhook{}
+			return &wh
+		}, func([]byte) error {
+			if wh.DeletedAt == nil {
+				webhooks = append(webhooks, wh.Webhook)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		err = errors.Wrap(err, "SELECT")
+		d.logger.Error(err)
+		return err
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+	for _, wh := range webhooks {
+		if err := fn(wh); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertWebhookFailure : record an event that could not be delivered
+func (d EmbeddedDB) InsertWebhookFailure(ctx context.Context, f WebhookFailure) error {
+	return d.update(ctx, func(tx kvTx) error {
+		b := tx.Bucket(bucketWebhookFailure)
+		seq, err := b.NextSequence()
+		if err != nil {
+			return errors.Wrap(err, "INSERT in to webhook_failure")
+		}
+		if err := putJSON(b, seqKey(seq), f); err != nil {
+			return errors.Wrap(err, "INSERT in to webhook_failure")
+		}
+		return nil
+	})
+}
+
+// ListWebhookFailures : get the events that could not be delivered to a
+// webhook, to any of them when webhookID is empty, oldest first
+func (d EmbeddedDB) ListWebhookFailures(ctx context.Context, webhookID string, fn func(WebhookFailure) error) error {
+	var failures []WebhookFailure
+	err := d.view(ctx, func(tx kvTx) error {
+		var f WebhookFailure
+		return forEachJSON(tx.Bucket(bucketWebhookFailure), nil, func() interface{} {
+			f = WebhookFailure{}
+			return &f
+		}, func([]byte) error {
+			if webhookID == "" || f.WebhookID == webhookID {
+				failures = append(failures, f)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		err = errors.Wrap(err, "SELECT")
+		d.logger.Error(err)
+		return err
+	}
+
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].CreatedAt.Before(failures[j].CreatedAt)
+	})
+	for _, f := range failures {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
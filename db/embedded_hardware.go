@@ -0,0 +1,530 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	hpb "github.com/tinkerbell/tink/protos/hardware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// kvHardware is a row of the hardware bucket
+type kvHardware struct {
+	Data       json.RawMessage `json:"data"`
+	InsertedAt time.Time       `json:"inserted_at"`
+	DeletedAt  *time.Time      `json:"deleted_at,omitempty"`
+}
+
+// DeleteFromDB : delete data from hardware table
+func (d EmbeddedDB) DeleteFromDB(ctx context.Context, id string) error {
+	return d.update(ctx, func(tx kvTx) error {
+		b := tx.Bucket(bucketHardware)
+		var hw kvHardware
+		found, err := getJSON(b, uuidKey(id), &hw)
+		if err != nil {
+			return errors.Wrap(err, "SELECT")
+		}
+		if !found || !inNamespace(ctx, hardwareNamespace(hw.Data)) {
+			return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+		}
+
+		deleted := hw.DeletedAt != nil
+		now := time.Now()
+		hw.DeletedAt = &now
+		if err := putJSON(b, uuidKey(id), hw); err != nil {
+			return errors.Wrap(err, "DELETE")
+		}
+
+		if !deleted {
+			return insertKVHardwareRevision(ctx, tx, HardwareDeleted, nil, hw.Data)
+		}
+		return nil
+	})
+}
+
+// InsertIntoDB : insert data into hardware table
+//
+// It behaves like TinkDB.InsertIntoDB, versions and history included.
+func (d EmbeddedDB) InsertIntoDB(ctx context.Context, data string) error {
+	return d.update(ctx, func(tx kvTx) error {
+		return insertKVHardware(ctx, tx, data)
+	})
+}
+
+// InsertHardwareBatch : insert many hardware in a single transaction. When
+// one of them fails nothing is written and the error is a *BatchError.
+func (d EmbeddedDB) InsertHardwareBatch(ctx context.Context, data []string) error {
+	return d.update(ctx, func(tx kvTx) error {
+		for i, hw := range data {
+			if err := insertKVHardware(ctx, tx, hw); err != nil {
+				return &BatchError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// insertKVHardware writes the hardware and its revision in tx
+func insertKVHardware(ctx context.Context, tx kvTx, data string) error {
+	var in struct {
+		ID      string `json:"id"`
+		Version int64  `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(data), &in); err != nil {
+		return errors.Wrap(err, "invalid hardware data")
+	}
+	id, err := uuid.Parse(in.ID)
+	if err != nil {
+		return errors.Wrap(err, "invalid hardware id")
+	}
+	key := []byte(id.String())
+
+	b := tx.Bucket(bucketHardware)
+	var stored kvHardware
+	found, err := getJSON(b, key, &stored)
+	if err != nil {
+		return errors.Wrap(err, "SELECT")
+	}
+	var (
+		event   = HardwareUpdated
+		old     []byte
+		version int64
+	)
+	if found {
+		version, err = hardwareVersion(stored.Data)
+		if err != nil {
+			return err
+		}
+		old = stored.Data
+	}
+	if !found || stored.DeletedAt != nil {
+		event = HardwareCreated
+		old = nil
+	}
+
+	data, err = keepHardwareState(data, old)
+	if err != nil {
+		return err
+	}
+	data, err = setHardwareNamespace(ctx, data, old)
+	if err != nil {
+		return err
+	}
+	if old != nil && in.Version != version && !forceFromContext(ctx) {
+		return versionConflict(data)
+	}
+
+	err = checkHardwareConflicts(data, func(id string, match []byte) (string, error) {
+		return findKVHardware(tx, func(k []byte, _ kvHardware) bool {
+			return string(k) != string(uuidKey(id))
+		}, match)
+	})
+	if err != nil {
+		return err
+	}
+
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		return errors.Wrap(err, "invalid hardware data")
+	}
+	doc["version"] = json.RawMessage(strconv.FormatInt(version+1, 10))
+	buf, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	err = putJSON(b, key, kvHardware{Data: buf, InsertedAt: time.Now()})
+	if err != nil {
+		return errors.Wrap(err, "INSERT")
+	}
+	return insertKVHardwareRevision(ctx, tx, event, old, buf)
+}
+
+// hardwareVersion returns the version stored in the hardware data
+func hardwareVersion(data []byte) (int64, error) {
+	var hw struct {
+		Version int64 `json:"version"`
+	}
+	if err := json.Unmarshal(data, &hw); err != nil {
+		return 0, errors.Wrap(err, "invalid hardware data")
+	}
+	return hw.Version, nil
+}
+
+// findKVHardware returns the id of the first hardware which is not
+// deleted, is selected by the filter and contains one of the match
+// documents, or an empty string
+func findKVHardware(tx kvTx, filter func(k []byte, hw kvHardware) bool, match ...[]byte) (string, error) {
+	var (
+		hw    kvHardware
+		found string
+	)
+	err := forEachJSON(tx.Bucket(bucketHardware), nil, func() interface{} {
+		hw = kvHardware{}
+		return &hw
+	}, func(k []byte) error {
+		if found != "" || hw.DeletedAt != nil || !filter(k, hw) {
+			return nil
+		}
+		for _, m := range match {
+			ok, err := jsonContains(hw.Data, m)
+			if err != nil {
+				return err
+			}
+			if ok {
+				found = string(k)
+				return nil
+			}
+		}
+		return nil
+	})
+	return found, err
+}
+
+// inNamespaceFilter is the findKVHardware filter selecting the hardware
+// visible with ctx
+func inNamespaceFilter(ctx context.Context) func([]byte, kvHardware) bool {
+	return func(_ []byte, hw kvHardware) bool {
+		return inNamespace(ctx, hardwareNamespace(hw.Data))
+	}
+}
+
+// getKVHardware returns the data of the hardware visible with ctx found by
+// findKVHardware, or the same error TinkDB returns when there is none
+func getKVHardware(ctx context.Context, tx kvTx, match ...[]byte) (string, error) {
+	id, err := findKVHardware(tx, inNamespaceFilter(ctx), match...)
+	if err != nil {
+		return "", errors.Wrap(err, "SELECT")
+	}
+	if id == "" {
+		return "", errors.Wrap(sql.ErrNoRows, "SELECT")
+	}
+	var hw kvHardware
+	if _, err := getJSON(tx.Bucket(bucketHardware), []byte(id), &hw); err != nil {
+		return "", errors.Wrap(err, "SELECT")
+	}
+	return string(hw.Data), nil
+}
+
+// GetByMAC : get data by machine mac
+func (d EmbeddedDB) GetByMAC(ctx context.Context, mac string) (string, error) {
+	var data string
+	err := d.view(ctx, func(tx kvTx) (err error) {
+		data, err = getKVHardware(ctx, tx, macMatch(mac))
+		return err
+	})
+	return data, err
+}
+
+// GetByIP : get data by machine ip
+func (d EmbeddedDB) GetByIP(ctx context.Context, ip string) (string, error) {
+	var data string
+	err := d.view(ctx, func(tx kvTx) (err error) {
+		data, err = getKVHardware(ctx, tx, ipMatches(ip)...)
+		return err
+	})
+	return data, err
+}
+
+// GetByID : get data by machine id
+func (d EmbeddedDB) GetByID(ctx context.Context, id string) (string, error) {
+	var data string
+	err := d.view(ctx, func(tx kvTx) error {
+		var hw kvHardware
+		found, err := getJSON(tx.Bucket(bucketHardware), uuidKey(id), &hw)
+		if err != nil {
+			return errors.Wrap(err, "SELECT")
+		}
+		if !found || hw.DeletedAt != nil || !inNamespace(ctx, hardwareNamespace(hw.Data)) {
+			return errors.Wrap(sql.ErrNoRows, "SELECT")
+		}
+		data = string(hw.Data)
+		return nil
+	})
+	return data, err
+}
+
+// GetAll : get data for all machine
+func (d EmbeddedDB) GetAll(ctx context.Context, fn func([]byte) error) error {
+	return d.view(ctx, func(tx kvTx) error {
+		var hw kvHardware
+		return forEachJSON(tx.Bucket(bucketHardware), nil, func() interface{} {
+			hw = kvHardware{}
+			return &hw
+		}, func([]byte) error {
+			if hw.DeletedAt != nil || !inNamespace(ctx, hardwareNamespace(hw.Data)) {
+				return nil
+			}
+			return fn(hw.Data)
+		})
+	})
+}
+
+// ListHardware : get data for the machines having all the given labels
+//
+// The machines can be ordered by id (default) or inserted_at, like with
+// TinkDB.ListHardware.
+func (d EmbeddedDB) ListHardware(ctx context.Context, labels map[string]string, opts ListOptions, fn func([]byte) error) error {
+	if !opts.CreatedAfter.IsZero() {
+		return errCreatedAfter
+	}
+	match, err := json.Marshal(map[string]interface{}{"labels": labels})
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 {
+		match = []byte(`{}`)
+	}
+
+	var (
+		ids  []string
+		rows []kvHardware
+	)
+	err = d.view(ctx, func(tx kvTx) error {
+		var hw kvHardware
+		return forEachJSON(tx.Bucket(bucketHardware), nil, func() interface{} {
+			hw = kvHardware{}
+			return &hw
+		}, func(k []byte) error {
+			if hw.DeletedAt != nil || !inNamespace(ctx, hardwareNamespace(hw.Data)) {
+				return nil
+			}
+			ok, err := jsonContains(hw.Data, match)
+			if err != nil || !ok {
+				return err
+			}
+			ids = append(ids, string(k))
+			rows = append(rows, hw)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	page, reached, err := opts.page(len(rows), map[string]func(i int) interface{}{
+		"id":          func(i int) interface{} { return ids[i] },
+		"inserted_at": func(i int) interface{} { return rows[i].InsertedAt },
+	}, "id", "id")
+	if err != nil {
+		return err
+	}
+	for _, i := range page {
+		reached(i)
+		if err := fn(rows[i].Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetHardwareState : move a machine to a new lifecycle state, like
+// TinkDB.SetHardwareState
+func (d EmbeddedDB) SetHardwareState(ctx context.Context, id string, from []int32, to int32) (string, error) {
+	var data []byte
+	err := d.update(ctx, func(tx kvTx) error {
+		b := tx.Bucket(bucketHardware)
+		var hw kvHardware
+		found, err := getJSON(b, uuidKey(id), &hw)
+		if err != nil {
+			return errors.Wrap(err, "SELECT")
+		}
+		if !found || hw.DeletedAt != nil || !inNamespace(ctx, hardwareNamespace(hw.Data)) {
+			return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+		}
+
+		doc := map[string]json.RawMessage{}
+		if err := json.Unmarshal(hw.Data, &doc); err != nil {
+			return errors.Wrap(err, "invalid hardware data")
+		}
+		var current, version int64
+		if s, ok := doc["state"]; ok {
+			if err := json.Unmarshal(s, &current); err != nil {
+				return errors.Wrap(err, "invalid hardware state")
+			}
+		}
+		if v, ok := doc["version"]; ok {
+			if err := json.Unmarshal(v, &version); err != nil {
+				return errors.Wrap(err, "invalid hardware version")
+			}
+		}
+
+		if from != nil && !containsState(from, int32(current)) {
+			allowed := make([]string, len(from))
+			for i, s := range from {
+				allowed[i] = hpb.State(s).String()
+			}
+			return status.Errorf(codes.FailedPrecondition, "hardware %s is %s, it has to be one of %s", id, hpb.State(current), strings.Join(allowed, ", "))
+		}
+
+		doc["state"] = json.RawMessage(strconv.FormatInt(int64(to), 10))
+		doc["version"] = json.RawMessage(strconv.FormatInt(version+1, 10))
+		data, err = json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		old := hw.Data
+		hw.Data = data
+		if err := putJSON(b, uuidKey(id), hw); err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		return insertKVHardwareRevision(ctx, tx, HardwareUpdated, old, data)
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetHardwareHistory : get the revisions of a machine, oldest first
+func (d EmbeddedDB) GetHardwareHistory(ctx context.Context, id string, fn func(HardwareRevision) error) error {
+	return d.view(ctx, func(tx kvTx) error {
+		var rev HardwareRevision
+		return forEachJSON(tx.Bucket(bucketHardwareRevision), nil, func() interface{} {
+			rev = HardwareRevision{}
+			return &rev
+		}, func([]byte) error {
+			if rev.HardwareID != string(uuidKey(id)) || !inNamespace(ctx, hardwareNamespace([]byte(rev.Data))) {
+				return nil
+			}
+			return fn(rev)
+		})
+	})
+}
+
+// GetHardwareChanges : get at most limit revisions of any machine made after
+// the since revision, oldest first, like TinkDB.GetHardwareChanges
+func (d EmbeddedDB) GetHardwareChanges(ctx context.Context, since int64, limit int, fn func(HardwareRevision) error) error {
+	return d.view(ctx, func(tx kvTx) error {
+		// the compactions are merged with the changes, in the order of
+		// their revisions
+		var (
+			compactions []HardwareRevision
+			c           HardwareRevision
+		)
+		err := forEachJSON(tx.Bucket(bucketHardwareCompaction), nil, func() interface{} {
+			c = HardwareRevision{}
+			return &c
+		}, func([]byte) error {
+			if c.Revision > since && inNamespace(ctx, hardwareNamespace([]byte(c.Data))) {
+				compactions = append(compactions, c)
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "invalid hardware compaction")
+		}
+		sort.Slice(compactions, func(i, j int) bool {
+			return compactions[i].Revision < compactions[j].Revision
+		})
+
+		n := 0
+		send := func(rev HardwareRevision) error {
+			n++
+			return fn(rev)
+		}
+		cur := tx.Bucket(bucketHardwareRevision).Cursor()
+		for k, v := cur.Seek(seqKey(uint64(since + 1))); k != nil && n < limit; k, v = cur.Next() {
+			var rev HardwareRevision
+			if err := json.Unmarshal(v, &rev); err != nil {
+				return errors.Wrap(err, "invalid hardware revision")
+			}
+			if !inNamespace(ctx, hardwareNamespace([]byte(rev.Data))) {
+				continue
+			}
+			for len(compactions) > 0 && compactions[0].Revision < rev.Revision && n < limit {
+				if err := send(compactions[0]); err != nil {
+					return err
+				}
+				compactions = compactions[1:]
+			}
+			if n == limit {
+				return nil
+			}
+			if err := send(rev); err != nil {
+				return err
+			}
+		}
+		for _, rev := range compactions {
+			if n == limit {
+				return nil
+			}
+			if err := send(rev); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LatestHardwareRevision : get the revision of the last hardware change, 0
+// when nothing changed yet
+func (d EmbeddedDB) LatestHardwareRevision(ctx context.Context) (int64, error) {
+	var revision int64
+	err := d.view(ctx, func(tx kvTx) error {
+		revision = lastSeq(tx.Bucket(bucketHardwareRevision))
+		// the last revisions can be purged, the compactions keep them
+		var c HardwareRevision
+		return forEachJSON(tx.Bucket(bucketHardwareCompaction), nil, func() interface{} {
+			c = HardwareRevision{}
+			return &c
+		}, func([]byte) error {
+			if c.Revision > revision {
+				revision = c.Revision
+			}
+			return nil
+		})
+	})
+	return revision, err
+}
+
+// insertKVHardwareRevision appends a revision to the history of the
+// hardware. old is the data before the event, empty for a creation.
+func insertKVHardwareRevision(ctx context.Context, tx kvTx, event string, old, data []byte) error {
+	var hw struct {
+		ID      string `json:"id"`
+		Version int64  `json:"version"`
+	}
+	if err := json.Unmarshal(data, &hw); err != nil {
+		return errors.Wrap(err, "invalid hardware data")
+	}
+
+	rev := HardwareRevision{
+		HardwareID: string(uuidKey(hw.ID)),
+		Version:    hw.Version,
+		EventType:  event,
+		Actor:      ActorFromContext(ctx),
+		CreatedAt:  time.Now(),
+		Data:       string(data),
+	}
+	if event == HardwareUpdated {
+		rev.PreviousData = string(old)
+	}
+	if event != HardwareDeleted {
+		changes, err := diffHardware(old, data)
+		if err != nil {
+			return err
+		}
+		rev.Diff = changes
+	}
+
+	b := tx.Bucket(bucketHardwareRevision)
+	seq, err := b.NextSequence()
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to hardware_revision")
+	}
+	rev.Revision = int64(seq)
+	if err := putJSON(b, seqKey(seq), rev); err != nil {
+		return errors.Wrap(err, "INSERT in to hardware_revision")
+	}
+	return insertKVEvent(ctx, tx, ResourceHardware, rev.HardwareID, event)
+}
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+)
+
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+// Purge removes what is not needed anymore, like TinkDB.Purge does
+func (d EmbeddedDB) Purge(ctx context.Context, before time.Time, dryRun bool) (PurgeReport, error) {
+	var report PurgeReport
+	err := d.update(ctx, func(tx kvTx) error {
+		report = PurgeReport{}
+		expired, kept, err := kvPurgedWorkflows(tx, before)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range expired {
+			if report.WorkflowEvents, err = addDeleted(report.WorkflowEvents, tx.Bucket(bucketWorkflowEvent), keyPrefix(id), nil); err != nil {
+				return errors.Wrap(err, "DELETE")
+			}
+			if report.WorkflowData, err = addDeleted(report.WorkflowData, tx.Bucket(bucketWorkflowData), keyPrefix(id), nil); err != nil {
+				return errors.Wrap(err, "DELETE")
+			}
+		}
+
+		// the deleted workflows, the deleted templates none of the kept
+		// workflows uses
+		report.Workflows, err = addDeleted(0, tx.Bucket(bucketWorkflow), nil, func(_, v []byte) (bool, error) {
+			var wf kvWorkflow
+			if err := json.Unmarshal(v, &wf); err != nil {
+				return false, err
+			}
+			return wf.DeletedAt != nil && wf.DeletedAt.Before(before), nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "DELETE")
+		}
+		report.Templates, err = addDeleted(0, tx.Bucket(bucketTemplate), nil, func(k, v []byte) (bool, error) {
+			var t kvTemplate
+			if err := json.Unmarshal(v, &t); err != nil {
+				return false, err
+			}
+			return t.DeletedAt != nil && t.DeletedAt.Before(before) && !kept[string(k)], nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "DELETE")
+		}
+
+		// the deleted hardware and its history
+		deleted := map[string]bool{}
+		report.Hardware, err = addDeleted(0, tx.Bucket(bucketHardware), nil, func(k, v []byte) (bool, error) {
+			var hw kvHardware
+			if err := json.Unmarshal(v, &hw); err != nil {
+				return false, err
+			}
+			if hw.DeletedAt == nil || !hw.DeletedAt.Before(before) {
+				return false, nil
+			}
+			deleted[string(k)] = true
+			return true, nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "DELETE")
+		}
+		// the last revision removed of every hardware is kept as its
+		// compaction
+		compactions := map[string]HardwareRevision{}
+		report.HardwareRevisions, err = addDeleted(0, tx.Bucket(bucketHardwareRevision), nil, func(_, v []byte) (bool, error) {
+			var rev HardwareRevision
+			if err := json.Unmarshal(v, &rev); err != nil {
+				return false, err
+			}
+			if !deleted[rev.HardwareID] {
+				return false, nil
+			}
+			if rev.Revision > compactions[rev.HardwareID].Revision {
+				compactions[rev.HardwareID] = rev
+			}
+			return true, nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "DELETE")
+		}
+		for id, rev := range compactions {
+			if err := putKVCompaction(tx, id, rev); err != nil {
+				return errors.Wrap(err, "UPDATE")
+			}
+		}
+
+		// a dry run removes the same rows, and rolls back
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err == errDryRun {
+		err = nil
+	}
+	return report, err
+}
+
+// kvPurgedWorkflows returns the workflows deleted or finished before the
+// given time, and the templates of the workflows which are not deleted
+// before it
+func kvPurgedWorkflows(tx kvTx, before time.Time) ([]string, map[string]bool, error) {
+	var (
+		expired []string
+		kept    = map[string]bool{}
+		wf      kvWorkflow
+	)
+	states := tx.Bucket(bucketWorkflowState)
+	events := tx.Bucket(bucketWorkflowEvent)
+	err := forEachJSON(tx.Bucket(bucketWorkflow), nil, func() interface{} {
+		wf = kvWorkflow{}
+		return &wf
+	}, func(k []byte) error {
+		if wf.DeletedAt != nil {
+			if wf.DeletedAt.Before(before) {
+				expired = append(expired, wf.ID)
+			} else {
+				kept[string(uuidKey(wf.Template))] = true
+			}
+			return nil
+		}
+		kept[string(uuidKey(wf.Template))] = true
+
+		var ws kvWorkflowState
+		found, err := getJSON(states, k, &ws)
+		if err != nil || !found || !workflowFinished(ws) {
+			return err
+		}
+		var (
+			last time.Time
+			ev   kvWorkflowEvent
+		)
+		err = forEachJSON(events, keyPrefix(wf.ID), func() interface{} {
+			ev = kvWorkflowEvent{}
+			return &ev
+		}, func([]byte) error {
+			if ev.CreatedAt.After(last) {
+				last = ev.CreatedAt
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if !last.IsZero() && last.Before(before) {
+			expired = append(expired, wf.ID)
+		}
+		return nil
+	})
+	return expired, kept, errors.Wrap(err, "SELECT")
+}
+
+// workflowFinished tells if the workflow failed, timed out or its last action
+// succeeded
+func workflowFinished(ws kvWorkflowState) bool {
+	switch listedWorkflowState(ws) {
+	case int32(pb.State_STATE_FAILED), int32(pb.State_STATE_TIMEOUT), int32(pb.State_STATE_SUCCESS):
+		return true
+	}
+	return false
+}
+
+// addDeleted deletes the keys of the bucket starting with prefix, the ones
+// for which match returns true when it is set, and adds their number to n
+func addDeleted(n int64, b kvBucket, prefix []byte, match func(k, v []byte) (bool, error)) (int64, error) {
+	var keys [][]byte
+	c := b.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if match != nil {
+			ok, err := match(k, v)
+			if err != nil {
+				return n, errors.Wrapf(err, "invalid %s", k)
+			}
+			if !ok {
+				continue
+			}
+		}
+		keys = append(keys, append([]byte{}, k...))
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return n, err
+		}
+	}
+	return n + int64(len(keys)), nil
+}
+
+// putKVCompaction records rev as the last revision removed of the history
+// of a hardware, unless a later one already is
+func putKVCompaction(tx kvTx, hardwareID string, rev HardwareRevision) error {
+	b := tx.Bucket(bucketHardwareCompaction)
+	var previous HardwareRevision
+	if _, err := getJSON(b, []byte(hardwareID), &previous); err != nil {
+		return err
+	}
+	if previous.Revision > rev.Revision {
+		return nil
+	}
+	rev.EventType = HardwareCompacted
+	rev.Actor, rev.PreviousData, rev.Diff = "", "", nil
+	return putJSON(b, []byte(hardwareID), rev)
+}
@@ -0,0 +1,223 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	tb "github.com/tinkerbell/tink/protos/template"
+	wflow "github.com/tinkerbell/tink/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// kvTemplate is a row of the template bucket
+type kvTemplate struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Data      string     `json:"data"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Namespace is empty for the templates stored before the namespaces,
+	// they are in the default one
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// putKVTemplate writes a template, checking that no other template of its
+// namespace which is not deleted has the same name
+func putKVTemplate(tx kvTx, t kvTemplate) error {
+	b := tx.Bucket(bucketTemplate)
+	if t.DeletedAt == nil {
+		var other kvTemplate
+		err := forEachJSON(b, nil, func() interface{} {
+			other = kvTemplate{}
+			return &other
+		}, func([]byte) error {
+			if other.ID != t.ID && other.DeletedAt == nil && other.Name == t.Name && normalizeNamespace(other.Namespace) == normalizeNamespace(t.Namespace) {
+				return uniqueViolation("uidx_template_name")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return putJSON(b, []byte(t.ID), t)
+}
+
+// CreateTemplate creates a new workflow template, in the namespace of ctx
+func (d EmbeddedDB) CreateTemplate(ctx context.Context, name string, data string, id uuid.UUID) error {
+	_, err := wflow.Parse([]byte(data))
+	if err != nil {
+		return err
+	}
+
+	return d.update(ctx, func(tx kvTx) error {
+		now := time.Now()
+		t := kvTemplate{ID: id.String(), CreatedAt: now, Namespace: namespaceOrDefault(ctx)}
+		if _, err := getJSON(tx.Bucket(bucketTemplate), []byte(t.ID), &t); err != nil {
+			return errors.Wrap(err, "INSERT")
+		}
+		t.Name, t.Data, t.UpdatedAt, t.DeletedAt = name, data, now, nil
+		if err := putKVTemplate(tx, t); err != nil {
+			return errors.Wrap(err, "INSERT")
+		}
+		return insertKVEvent(ctx, tx, ResourceTemplate, t.ID, EventCreated)
+	})
+}
+
+// GetTemplate returns template which is not deleted, like
+// TinkDB.GetTemplate
+func (d EmbeddedDB) GetTemplate(ctx context.Context, fields map[string]string, deleted bool) (*tb.WorkflowTemplate, error) {
+	column, value, err := getField(fields)
+	if err != nil {
+		return &tb.WorkflowTemplate{}, errors.Wrap(err, "failed to get template")
+	}
+	var match func(t kvTemplate) bool
+	switch column {
+	case "id":
+		match = func(t kvTemplate) bool { return t.ID == string(uuidKey(value)) && inNamespace(ctx, t.Namespace) }
+	case "name":
+		ns := namespaceOrDefault(ctx)
+		match = func(t kvTemplate) bool { return t.Name == value && normalizeNamespace(t.Namespace) == ns }
+	default:
+		return &tb.WorkflowTemplate{}, errors.Wrap(fmt.Errorf("column %q does not exist", column), "SELECT")
+	}
+
+	var found *kvTemplate
+	err = d.view(ctx, func(tx kvTx) error {
+		var t kvTemplate
+		return forEachJSON(tx.Bucket(bucketTemplate), nil, func() interface{} {
+			t = kvTemplate{}
+			return &t
+		}, func([]byte) error {
+			if !match(t) || (!deleted && t.DeletedAt != nil) {
+				return nil
+			}
+			// the template which is not deleted wins over the deleted
+			// ones with the same name
+			if found == nil || (found.DeletedAt != nil && t.DeletedAt == nil) {
+				row := t
+				found = &row
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		err = errors.Wrap(err, "SELECT")
+		d.logger.Error(err)
+		return &tb.WorkflowTemplate{}, err
+	}
+	if found == nil {
+		return &tb.WorkflowTemplate{}, sql.ErrNoRows
+	}
+	return &tb.WorkflowTemplate{
+		Id:        found.ID,
+		Name:      found.Name,
+		Data:      found.Data,
+		CreatedAt: timestamppb.New(found.CreatedAt),
+		UpdatedAt: timestamppb.New(found.UpdatedAt),
+		Namespace: normalizeNamespace(found.Namespace),
+	}, nil
+}
+
+// DeleteTemplate deletes a workflow template by id
+func (d EmbeddedDB) DeleteTemplate(ctx context.Context, id string) error {
+	return d.update(ctx, func(tx kvTx) error {
+		var t kvTemplate
+		found, err := getJSON(tx.Bucket(bucketTemplate), uuidKey(id), &t)
+		if err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		if !found || !inNamespace(ctx, t.Namespace) {
+			return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+		}
+		now := time.Now()
+		t.DeletedAt = &now
+		if err := putKVTemplate(tx, t); err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		return insertKVEvent(ctx, tx, ResourceTemplate, id, EventDeleted)
+	})
+}
+
+// ListTemplates returns all saved templates
+//
+// The templates can be ordered by created_at (default), updated_at, name or
+// id.
+func (d EmbeddedDB) ListTemplates(ctx context.Context, filter string, opts ListOptions, fn func(id, n string, in, del *timestamp.Timestamp) error) error {
+	pattern, err := likePattern(filter)
+	if err != nil {
+		return err
+	}
+
+	var rows []kvTemplate
+	err = d.view(ctx, func(tx kvTx) error {
+		var t kvTemplate
+		return forEachJSON(tx.Bucket(bucketTemplate), nil, func() interface{} {
+			t = kvTemplate{}
+			return &t
+		}, func([]byte) error {
+			if t.DeletedAt != nil || !pattern.MatchString(t.Name) || !inNamespace(ctx, t.Namespace) {
+				return nil
+			}
+			if !opts.CreatedAfter.IsZero() && !t.CreatedAt.After(opts.CreatedAfter) {
+				return nil
+			}
+			rows = append(rows, t)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	page, reached, err := opts.page(len(rows), map[string]func(i int) interface{}{
+		"created_at": func(i int) interface{} { return rows[i].CreatedAt },
+		"updated_at": func(i int) interface{} { return rows[i].UpdatedAt },
+		"name":       func(i int) interface{} { return rows[i].Name },
+		"id":         func(i int) interface{} { return rows[i].ID },
+	}, "created_at", "id")
+	if err != nil {
+		return err
+	}
+	for _, i := range page {
+		reached(i)
+		t := rows[i]
+		if err := fn(t.ID, t.Name, timestamppb.New(t.CreatedAt), timestamppb.New(t.UpdatedAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateTemplate update a given template
+func (d EmbeddedDB) UpdateTemplate(ctx context.Context, name string, data string, id uuid.UUID) error {
+	return d.update(ctx, func(tx kvTx) error {
+		var t kvTemplate
+		found, err := getJSON(tx.Bucket(bucketTemplate), []byte(id.String()), &t)
+		if err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		if found && inNamespace(ctx, t.Namespace) {
+			if data == "" && name != "" {
+				t.Name = name
+			} else if data != "" && name == "" {
+				t.Data = data
+			} else {
+				t.Name, t.Data = name, data
+			}
+			t.UpdatedAt = time.Now()
+			if err := putKVTemplate(tx, t); err != nil {
+				return errors.Wrap(err, "UPDATE")
+			}
+		}
+		return insertKVEvent(ctx, tx, ResourceTemplate, id.String(), EventUpdated)
+	})
+}
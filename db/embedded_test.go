@@ -0,0 +1,40 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/packethost/pkg/log"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/db/dbtest"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T) (db.Database, func()) {
+		memoryDB := db.NewMemoryDB(log.Test(t, "db-test"))
+		return memoryDB, func() { _ = memoryDB.Close() }
+	})
+}
+
+func TestBoltConformance(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T) (db.Database, func()) {
+		boltDB, cl := NewBoltDatabaseClient(t)
+		return boltDB, func() {
+			if err := cl(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+}
+
+func TestPostgresConformance(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T) (db.Database, func()) {
+		ctx := context.Background()
+		_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewDatabaseRequest{ApplyMigration: true})
+		return tinkDB, func() {
+			if err := cl(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+}
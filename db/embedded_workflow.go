@@ -0,0 +1,782 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// kvWorkflow is a row of the workflow bucket
+type kvWorkflow struct {
+	ID        string     `json:"id"`
+	Template  string     `json:"template"`
+	Devices   string     `json:"devices"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// MaxDataVersions is the number of versions of its data the template
+	// of the workflow keeps, zero when it does not say
+	MaxDataVersions int `json:"max_data_versions,omitempty"`
+	// Namespace is empty for the workflows stored before the namespaces,
+	// they are in the default one
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// kvWorkflowState is a row of the workflow_state bucket, keyed by the id
+// of the workflow
+type kvWorkflowState struct {
+	CurrentWorker        string          `json:"current_worker"`
+	CurrentTaskName      string          `json:"current_task_name"`
+	CurrentActionName    string          `json:"current_action_name"`
+	CurrentActionState   int32           `json:"current_action_state"`
+	ActionList           json.RawMessage `json:"action_list"`
+	CurrentActionIndex   int64           `json:"current_action_index"`
+	TotalNumberOfActions int64           `json:"total_number_of_actions"`
+}
+
+// kvWorkflowData is a row of the workflow_data bucket, keyed by the id of
+// the workflow and the version. Data is nil once the version got pruned.
+type kvWorkflowData struct {
+	Metadata json.RawMessage  `json:"metadata"`
+	Data     *json.RawMessage `json:"data"`
+}
+
+// kvWorkflowEvent is a row of the workflow_event bucket, keyed by the id
+// of the workflow and the order of insertion
+type kvWorkflowEvent struct {
+	WorkerID      string    `json:"worker_id"`
+	TaskName      string    `json:"task_name"`
+	ActionName    string    `json:"action_name"`
+	ExecutionTime int64     `json:"execution_time"`
+	Message       string    `json:"message"`
+	Status        int32     `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// The workflow_worker_map, workflow_data and workflow_event rows are grouped
+// by a prefix of their key
+func keyPrefix(id string) []byte {
+	return append(uuidKey(id), '/')
+}
+
+func workerMapKey(workerID, workflowID string) []byte {
+	return append(keyPrefix(workerID), uuidKey(workflowID)...)
+}
+
+func workflowDataKey(workflowID string, version int32) []byte {
+	return append(keyPrefix(workflowID), seqKey(uint64(version))...)
+}
+
+// CreateWorkflow creates a new workflow
+func (d EmbeddedDB) CreateWorkflow(ctx context.Context, wf Workflow, data string, id uuid.UUID) error {
+	ns, err := workflowNamespace(ctx, wf)
+	if err != nil {
+		return err
+	}
+	wf.Namespace = ns
+	ctx = WithNamespace(ctx, ns)
+
+	return d.update(ctx, func(tx kvTx) error {
+		err := insertKVActionList(ctx, tx, data, id)
+		if err != nil {
+			return errors.Wrap(err, "failed to create workflow")
+		}
+		err = insertKVWorkflow(ctx, tx, wf, templateMaxDataVersions(data))
+		if err != nil {
+			return errors.Wrap(err, "failed to create workflow")
+		}
+		return nil
+	})
+}
+
+// CreateWorkflows creates all the given workflows in a single transaction,
+// data holds the rendered template of the workflow with the same index
+func (d EmbeddedDB) CreateWorkflows(ctx context.Context, wfs []Workflow, data []string) error {
+	if len(wfs) != len(data) {
+		return errors.New("every workflow requires its data")
+	}
+	return d.update(ctx, func(tx kvTx) error {
+		for i, wf := range wfs {
+			id, err := uuid.Parse(wf.ID)
+			if err != nil {
+				return errors.Wrapf(err, "invalid workflow id %s", wf.ID)
+			}
+			wf.Namespace, err = workflowNamespace(ctx, wf)
+			if err != nil {
+				return err
+			}
+			wctx := WithNamespace(ctx, wf.Namespace)
+			err = insertKVActionList(wctx, tx, data[i], id)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create workflow %s", wf.ID)
+			}
+			err = insertKVWorkflow(wctx, tx, wf, templateMaxDataVersions(data[i]))
+			if err != nil {
+				return errors.Wrapf(err, "failed to create workflow %s", wf.ID)
+			}
+		}
+		return nil
+	})
+}
+
+func insertKVWorkflow(ctx context.Context, tx kvTx, wf Workflow, maxDataVersions int) error {
+	id, err := uuid.Parse(wf.ID)
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to workflow")
+	}
+	template, err := uuid.Parse(wf.Template)
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to workflow")
+	}
+	if !json.Valid([]byte(wf.Hardware)) {
+		return errors.Wrap(errors.New("invalid input syntax for type json"), "INSERT in to workflow")
+	}
+	b := tx.Bucket(bucketWorkflow)
+	now := time.Now()
+	row := kvWorkflow{ID: id.String(), CreatedAt: now}
+	if _, err := getJSON(b, []byte(row.ID), &row); err != nil {
+		return errors.Wrap(err, "INSERT in to workflow")
+	}
+	row.UpdatedAt, row.DeletedAt, row.Template, row.Devices = now, nil, template.String(), wf.Hardware
+	row.MaxDataVersions, row.Namespace = maxDataVersions, wf.Namespace
+	if err := putJSON(b, []byte(row.ID), row); err != nil {
+		return errors.Wrap(err, "INSERT in to workflow")
+	}
+	return insertKVEvent(ctx, tx, ResourceWorkflow, row.ID, EventCreated)
+}
+
+// insertKVActionList writes the actions of the workflow and maps it to its
+// workers, the hardware in the namespace of ctx
+func insertKVActionList(ctx context.Context, tx kvTx, yamlData string, id uuid.UUID) error {
+	actionList, workers, err := parseActionList(yamlData, func(addr string) (string, error) {
+		return findWorkerID(addr, func(mac string) (string, error) {
+			return kvWorkerID(ctx, tx, "mac", mac, macMatch(mac))
+		}, func(ip string) (string, error) {
+			return kvWorkerID(ctx, tx, "ip", ip, ipMatches(ip)...)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, workerID := range workers {
+		err = tx.Bucket(bucketWorkflowWorker).Put(workerMapKey(workerID.String(), id.String()), []byte{})
+		if err != nil {
+			return errors.Wrap(err, "INSERT in to workflow_worker_map")
+		}
+	}
+	actionData, err := json.Marshal(actionList)
+	if err != nil {
+		return err
+	}
+
+	err = putJSON(tx.Bucket(bucketWorkflowState), []byte(id.String()), kvWorkflowState{
+		ActionList:           actionData,
+		TotalNumberOfActions: int64(len(actionList)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to workflow_state")
+	}
+	return nil
+}
+
+// kvWorkerID returns the id of the hardware of the worker at addr visible
+// with ctx, found with the match documents, with the same errors as
+// getWorkerIDbyMac and getWorkerIDbyIP. kind is the kind of address, mac or
+// ip.
+func kvWorkerID(ctx context.Context, tx kvTx, kind, addr string, match ...[]byte) (string, error) {
+	id, err := findKVHardware(tx, inNamespaceFilter(ctx), match...)
+	if err != nil {
+		return "", errors.Wrap(err, "SELECT")
+	}
+	if id == "" {
+		return "", errors.WithMessage(errors.New(addr), kind)
+	}
+	return id, nil
+}
+
+// InsertIntoWfDataTable : Insert ephemeral data in workflow_data table
+func (d EmbeddedDB) InsertIntoWfDataTable(ctx context.Context, req *pb.UpdateWorkflowDataRequest) error {
+	for _, doc := range [][]byte{req.GetMetadata(), req.GetData()} {
+		if !json.Valid(doc) {
+			return errors.Wrap(errors.New("invalid input syntax for type json"), "INSERT Into workflow_data")
+		}
+	}
+
+	return d.update(ctx, func(tx kvTx) error {
+		if err := checkKVWorkflowNamespace(ctx, tx, req.GetWorkflowId()); err != nil {
+			return err
+		}
+		b := tx.Bucket(bucketWorkflowData)
+		version := kvWorkflowDataVersion(tx, req.GetWorkflowId()) + 1
+		data := json.RawMessage(req.GetData())
+		err := putJSON(b, workflowDataKey(req.GetWorkflowId(), version), kvWorkflowData{
+			Metadata: req.GetMetadata(),
+			Data:     &data,
+		})
+		if err != nil {
+			return errors.Wrap(err, "INSERT Into workflow_data")
+		}
+
+		var wf kvWorkflow
+		if _, err := getJSON(tx.Bucket(bucketWorkflow), uuidKey(req.GetWorkflowId()), &wf); err != nil {
+			return errors.Wrap(err, "SELECT")
+		}
+		maxVersions := int32(maxDataVersions(wf.MaxDataVersions, d.maxDataVersions))
+		for v := version - maxVersions; v > 0; v-- {
+			key := workflowDataKey(req.GetWorkflowId(), v)
+			var row kvWorkflowData
+			found, err := getJSON(b, key, &row)
+			if err != nil {
+				return errors.Wrap(err, "UPDATE")
+			}
+			// the older versions got pruned already
+			if !found || row.Data == nil {
+				break
+			}
+			row.Data = nil
+			if err := putJSON(b, key, row); err != nil {
+				return errors.Wrap(err, "UPDATE")
+			}
+		}
+		return nil
+	})
+}
+
+// getKVWorkflowData returns the row of a version of the data of the
+// workflow and its version, the latest one when the version is 0
+func (d EmbeddedDB) getKVWorkflowData(ctx context.Context, req *pb.GetWorkflowDataRequest) (kvWorkflowData, int32, bool, error) {
+	var (
+		row   kvWorkflowData
+		found bool
+	)
+	version := req.GetVersion()
+	err := d.view(ctx, func(tx kvTx) (err error) {
+		if version == 0 {
+			version = kvWorkflowDataVersion(tx, req.GetWorkflowId())
+		}
+		found, err = getJSON(tx.Bucket(bucketWorkflowData), workflowDataKey(req.GetWorkflowId(), version), &row)
+		return err
+	})
+	return row, version, found, err
+}
+
+// GetfromWfDataTable : Give you the ephemeral data from workflow_data table
+func (d EmbeddedDB) GetfromWfDataTable(ctx context.Context, req *pb.GetWorkflowDataRequest) ([]byte, error) {
+	if err := d.checkWorkflowNamespace(ctx, req.GetWorkflowId()); err != nil {
+		return []byte{}, err
+	}
+	row, _, found, err := d.getKVWorkflowData(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "SELECT")
+		d.logger.Error(err)
+	}
+	if !found {
+		return []byte{}, nil
+	}
+	if row.Data == nil {
+		return nil, nil
+	}
+	return []byte(*row.Data), nil
+}
+
+// GetWorkflowMetadata returns metadata wrt to the ephemeral data of a workflow
+func (d EmbeddedDB) GetWorkflowMetadata(ctx context.Context, req *pb.GetWorkflowDataRequest) ([]byte, error) {
+	if err := d.checkWorkflowNamespace(ctx, req.GetWorkflowId()); err != nil {
+		return []byte{}, err
+	}
+	row, _, found, err := d.getKVWorkflowData(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "SELECT from workflow_data")
+		d.logger.Error(err)
+	}
+	if !found {
+		return []byte{}, nil
+	}
+	return []byte(row.Metadata), nil
+}
+
+// GetWorkflowDataRevision returns a version of the data of a workflow with
+// its metadata, like TinkDB.GetWorkflowDataRevision
+func (d EmbeddedDB) GetWorkflowDataRevision(ctx context.Context, workflowID string, version int32) (WorkflowDataRevision, error) {
+	if err := d.checkWorkflowNamespace(ctx, workflowID); err != nil {
+		return WorkflowDataRevision{WorkflowID: workflowID, Version: version}, err
+	}
+	row, version, found, err := d.getKVWorkflowData(ctx, &pb.GetWorkflowDataRequest{WorkflowId: workflowID, Version: version})
+	rev := WorkflowDataRevision{WorkflowID: workflowID, Version: version}
+	if err != nil {
+		return rev, errors.Wrap(err, "SELECT from workflow_data")
+	}
+	if !found {
+		return rev, errors.Wrap(sql.ErrNoRows, "SELECT from workflow_data")
+	}
+	rev.Metadata = []byte(row.Metadata)
+	if row.Data != nil {
+		rev.Data = []byte(*row.Data)
+	}
+	return rev, nil
+}
+
+// GetWorkflowDataVersion returns the latest version of data for a workflow
+func (d EmbeddedDB) GetWorkflowDataVersion(ctx context.Context, workflowID string) (int32, error) {
+	var version int32
+	err := d.view(ctx, func(tx kvTx) error {
+		if err := checkKVWorkflowNamespace(ctx, tx, workflowID); err != nil {
+			return err
+		}
+		version = kvWorkflowDataVersion(tx, workflowID)
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	return version, nil
+}
+
+// kvWorkflowDataVersion counts the versions of the data of a workflow,
+// like getLatestVersionWfData
+func kvWorkflowDataVersion(tx kvTx, workflowID string) int32 {
+	var version int32
+	prefix := keyPrefix(workflowID)
+	c := tx.Bucket(bucketWorkflowData).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		version++
+	}
+	return version
+}
+
+// GetWorkflowsForWorker : returns the list of workflows for a particular worker
+func (d EmbeddedDB) GetWorkflowsForWorker(ctx context.Context, id string) ([]string, error) {
+	var wfID []string
+	err := d.view(ctx, func(tx kvTx) error {
+		prefix := keyPrefix(id)
+		c := tx.Bucket(bucketWorkflowWorker).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			workflowID := string(k[len(prefix):])
+			if NamespaceFromContext(ctx) != "" {
+				var wf kvWorkflow
+				if _, err := getJSON(tx.Bucket(bucketWorkflow), []byte(workflowID), &wf); err != nil {
+					return err
+				}
+				if !inNamespace(ctx, wf.Namespace) {
+					continue
+				}
+			}
+			wfID = append(wfID, workflowID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wfID, nil
+}
+
+// GetWorkflow returns a workflow
+func (d EmbeddedDB) GetWorkflow(ctx context.Context, id string) (Workflow, error) {
+	var (
+		row   kvWorkflow
+		found bool
+	)
+	err := d.view(ctx, func(tx kvTx) (err error) {
+		found, err = getJSON(tx.Bucket(bucketWorkflow), uuidKey(id), &row)
+		return err
+	})
+	if err != nil {
+		err = errors.Wrap(err, "SELECT")
+		d.logger.Error(err)
+		return Workflow{}, err
+	}
+	if !found || row.DeletedAt != nil || !inNamespace(ctx, row.Namespace) {
+		return Workflow{}, errors.New("Workflow with id " + id + " does not exist")
+	}
+	return Workflow{
+		ID:        id,
+		Template:  row.Template,
+		Hardware:  row.Devices,
+		CreatedAt: timestamppb.New(row.CreatedAt),
+		UpdatedAt: timestamppb.New(row.UpdatedAt),
+		Namespace: normalizeNamespace(row.Namespace),
+	}, nil
+}
+
+// DeleteWorkflow deletes a workflow
+func (d EmbeddedDB) DeleteWorkflow(ctx context.Context, id string, state int32) error {
+	return d.update(ctx, func(tx kvTx) error {
+		if err := checkKVWorkflowNamespace(ctx, tx, id); err != nil {
+			return err
+		}
+		workers := tx.Bucket(bucketWorkflowWorker)
+		var keys [][]byte
+		suffix := append([]byte{'/'}, uuidKey(id)...)
+		err := workers.ForEach(func(k, _ []byte) error {
+			if bytes.HasSuffix(k, suffix) {
+				keys = append(keys, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "Delete Workflow Error")
+		}
+		for _, k := range keys {
+			if err := workers.Delete(k); err != nil {
+				return errors.Wrap(err, "Delete Workflow Error")
+			}
+		}
+		if err := tx.Bucket(bucketWorkflowState).Delete(uuidKey(id)); err != nil {
+			return errors.Wrap(err, "Delete Workflow Error")
+		}
+
+		b := tx.Bucket(bucketWorkflow)
+		var row kvWorkflow
+		found, err := getJSON(b, uuidKey(id), &row)
+		if err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		if !found {
+			return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+		}
+		now := time.Now()
+		row.DeletedAt = &now
+		if err := putJSON(b, uuidKey(id), row); err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		return insertKVEvent(ctx, tx, ResourceWorkflow, id, EventDeleted)
+	})
+}
+
+// ListWorkflows returns all workflows matching the filter
+//
+// The workflows can be ordered by created_at (default), updated_at or id.
+func (d EmbeddedDB) ListWorkflows(ctx context.Context, filter WorkflowFilter, opts ListOptions, fn func(wf Workflow) error) error {
+	var rows []kvWorkflow
+	err := d.view(ctx, func(tx kvTx) error {
+		states := tx.Bucket(bucketWorkflowState)
+		var wf kvWorkflow
+		return forEachJSON(tx.Bucket(bucketWorkflow), nil, func() interface{} {
+			wf = kvWorkflow{}
+			return &wf
+		}, func(k []byte) error {
+			if wf.DeletedAt != nil || !inNamespace(ctx, wf.Namespace) {
+				return nil
+			}
+			if len(filter.States) > 0 {
+				var ws kvWorkflowState
+				if _, err := getJSON(states, k, &ws); err != nil {
+					return err
+				}
+				if !containsState(filter.States, listedWorkflowState(ws)) {
+					return nil
+				}
+			}
+			if filter.Template != "" && wf.Template != strings.ToLower(filter.Template) {
+				return nil
+			}
+			if filter.Hardware != "" && !hasDevice(wf.Devices, filter.Hardware) {
+				return nil
+			}
+			if !opts.CreatedAfter.IsZero() && !wf.CreatedAt.After(opts.CreatedAfter) {
+				return nil
+			}
+			rows = append(rows, wf)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	page, reached, err := opts.page(len(rows), map[string]func(i int) interface{}{
+		"created_at": func(i int) interface{} { return rows[i].CreatedAt },
+		"updated_at": func(i int) interface{} { return rows[i].UpdatedAt },
+		"id":         func(i int) interface{} { return rows[i].ID },
+	}, "created_at", "id")
+	if err != nil {
+		return err
+	}
+	for _, i := range page {
+		reached(i)
+		wf := rows[i]
+		err := fn(Workflow{
+			ID:        wf.ID,
+			Template:  wf.Template,
+			Hardware:  wf.Devices,
+			CreatedAt: timestamppb.New(wf.CreatedAt),
+			UpdatedAt: timestamppb.New(wf.UpdatedAt),
+			Namespace: normalizeNamespace(wf.Namespace),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listedWorkflowState is the state ListWorkflows filters on: the state of
+// the current action, but a successful action is not the end of the
+// workflow until it is the last one
+func listedWorkflowState(ws kvWorkflowState) int32 {
+	if ws.CurrentActionState == int32(pb.State_STATE_SUCCESS) && ws.CurrentActionIndex != ws.TotalNumberOfActions-1 {
+		return int32(pb.State_STATE_RUNNING)
+	}
+	return ws.CurrentActionState
+}
+
+// hasDevice tells whether hardware is one of the devices of a workflow,
+// compared case insensitively
+func hasDevice(devices, hardware string) bool {
+	var m map[string]string
+	if err := json.Unmarshal([]byte(devices), &m); err != nil {
+		return false
+	}
+	for _, device := range m {
+		if strings.EqualFold(device, hardware) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateWorkflow updates a given workflow
+func (d EmbeddedDB) UpdateWorkflow(ctx context.Context, wf Workflow, state int32) error {
+	return d.update(ctx, func(tx kvTx) error {
+		b := tx.Bucket(bucketWorkflow)
+		var row kvWorkflow
+		found, err := getJSON(b, uuidKey(wf.ID), &row)
+		if err != nil {
+			return errors.Wrap(err, "UPDATE")
+		}
+		if found && inNamespace(ctx, row.Namespace) {
+			if wf.Hardware == "" && wf.Template != "" {
+				row.Template = wf.Template
+			} else if wf.Hardware != "" && wf.Template == "" {
+				row.Devices = wf.Hardware
+			} else {
+				row.Template, row.Devices = wf.Template, wf.Hardware
+			}
+			row.UpdatedAt = time.Now()
+			if err := putJSON(b, uuidKey(wf.ID), row); err != nil {
+				return errors.Wrap(err, "UPDATE")
+			}
+		}
+		return insertKVEvent(ctx, tx, ResourceWorkflow, wf.ID, EventUpdated)
+	})
+}
+
+// UpdateWorkflowState : update the current workflow state
+func (d EmbeddedDB) UpdateWorkflowState(ctx context.Context, wfContext *pb.WorkflowContext) error {
+	return d.update(ctx, func(tx kvTx) error {
+		if err := checkKVWorkflowNamespace(ctx, tx, wfContext.WorkflowId); err != nil {
+			return err
+		}
+		b := tx.Bucket(bucketWorkflowState)
+		var ws kvWorkflowState
+		found, err := getJSON(b, uuidKey(wfContext.WorkflowId), &ws)
+		if err != nil {
+			return errors.Wrap(err, "INSERT in to workflow_state")
+		}
+		if found {
+			ws.CurrentTaskName = wfContext.CurrentTask
+			ws.CurrentActionName = wfContext.CurrentAction
+			ws.CurrentActionState = int32(wfContext.CurrentActionState)
+			ws.CurrentWorker = wfContext.CurrentWorker
+			ws.CurrentActionIndex = wfContext.CurrentActionIndex
+			if err := putJSON(b, uuidKey(wfContext.WorkflowId), ws); err != nil {
+				return errors.Wrap(err, "INSERT in to workflow_state")
+			}
+		}
+		return insertKVEvent(ctx, tx, ResourceWorkflow, wfContext.WorkflowId, EventUpdated)
+	})
+}
+
+// GetWorkflowContexts : gives you the current workflow context
+func (d EmbeddedDB) GetWorkflowContexts(ctx context.Context, wfID string) (*pb.WorkflowContext, error) {
+	if err := d.checkWorkflowNamespace(ctx, wfID); err != nil {
+		return &pb.WorkflowContext{}, err
+	}
+	var (
+		ws    kvWorkflowState
+		found bool
+	)
+	err := d.view(ctx, func(tx kvTx) (err error) {
+		found, err = getJSON(tx.Bucket(bucketWorkflowState), uuidKey(wfID), &ws)
+		return err
+	})
+	if err != nil {
+		err = errors.Wrap(err, "SELECT from worflow_state")
+		d.logger.Error(err)
+		return &pb.WorkflowContext{}, err
+	}
+	if !found {
+		return &pb.WorkflowContext{}, errors.New("Workflow with id " + wfID + " does not exist")
+	}
+	return &pb.WorkflowContext{
+		WorkflowId:           wfID,
+		CurrentWorker:        ws.CurrentWorker,
+		CurrentTask:          ws.CurrentTaskName,
+		CurrentAction:        ws.CurrentActionName,
+		CurrentActionIndex:   ws.CurrentActionIndex,
+		CurrentActionState:   pb.State(ws.CurrentActionState),
+		TotalNumberOfActions: ws.TotalNumberOfActions}, nil
+}
+
+// GetWorkflowActions : gives you the action list of workflow
+func (d EmbeddedDB) GetWorkflowActions(ctx context.Context, wfID string) (*pb.WorkflowActionList, error) {
+	if err := d.checkWorkflowNamespace(ctx, wfID); err != nil {
+		return &pb.WorkflowActionList{}, err
+	}
+	var (
+		ws    kvWorkflowState
+		found bool
+	)
+	err := d.view(ctx, func(tx kvTx) (err error) {
+		found, err = getJSON(tx.Bucket(bucketWorkflowState), uuidKey(wfID), &ws)
+		return err
+	})
+	if err != nil {
+		err = errors.Wrap(err, "SELECT from worflow_state")
+		d.logger.Error(err)
+	}
+	if !found {
+		return &pb.WorkflowActionList{}, nil
+	}
+	actions := []*pb.WorkflowAction{}
+	if err := json.Unmarshal(ws.ActionList, &actions); err != nil {
+		return nil, err
+	}
+	return &pb.WorkflowActionList{
+		ActionList: actions}, nil
+}
+
+// InsertIntoWorkflowEventTable : insert workflow event table
+func (d EmbeddedDB) InsertIntoWorkflowEventTable(ctx context.Context, wfEvent *pb.WorkflowActionStatus, time time.Time) error {
+	return d.update(ctx, func(tx kvTx) error {
+		if err := checkKVWorkflowNamespace(ctx, tx, wfEvent.WorkflowId); err != nil {
+			return err
+		}
+		b := tx.Bucket(bucketWorkflowEvent)
+		seq, err := b.NextSequence()
+		if err != nil {
+			return errors.Wrap(err, "INSERT in to workflow_event")
+		}
+		err = putJSON(b, append(keyPrefix(wfEvent.WorkflowId), seqKey(seq)...), kvWorkflowEvent{
+			WorkerID:      wfEvent.WorkerId,
+			TaskName:      wfEvent.TaskName,
+			ActionName:    wfEvent.ActionName,
+			ExecutionTime: wfEvent.Seconds,
+			Message:       wfEvent.Message,
+			Status:        int32(wfEvent.ActionStatus),
+			CreatedAt:     time,
+		})
+		if err != nil {
+			return errors.Wrap(err, "INSERT in to workflow_event")
+		}
+		return nil
+	})
+}
+
+// ShowWorkflowEvents returns all workflows
+func (d EmbeddedDB) ShowWorkflowEvents(ctx context.Context, wfID string, fn func(wfs *pb.WorkflowActionStatus) error) error {
+	return d.GetWorkflowEventsFrom(ctx, wfID, 0, func(_ int64, wfs *pb.WorkflowActionStatus) error {
+		return fn(wfs)
+	})
+}
+
+// GetWorkflowEventsFrom : get the events of a workflow by time, starting
+// with the one with the from id, like TinkDB.GetWorkflowEventsFrom. The id
+// of an event is the sequence in its key.
+func (d EmbeddedDB) GetWorkflowEventsFrom(ctx context.Context, wfID string, from int64, fn func(id int64, wfs *pb.WorkflowActionStatus) error) error {
+	if err := d.checkWorkflowNamespace(ctx, wfID); err != nil {
+		return err
+	}
+	type event struct {
+		id int64
+		kvWorkflowEvent
+	}
+	var events []event
+	prefix := keyPrefix(wfID)
+	err := d.view(ctx, func(tx kvTx) error {
+		var ev kvWorkflowEvent
+		return forEachJSON(tx.Bucket(bucketWorkflowEvent), prefix, func() interface{} {
+			ev = kvWorkflowEvent{}
+			return &ev
+		}, func(k []byte) error {
+			events = append(events, event{id: int64(binary.BigEndian.Uint64(k[len(prefix):])), kvWorkflowEvent: ev})
+			return nil
+		})
+	})
+	if err != nil {
+		err = errors.Wrap(err, "SELECT")
+		d.logger.Error(err)
+		return err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	start := 0
+	if from != 0 {
+		start = -1
+		for i, ev := range events {
+			if ev.id == from {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return status.Errorf(codes.OutOfRange, "the event %d of workflow %s is gone, read the events from the first one", from, wfID)
+		}
+	}
+	for _, ev := range events[start:] {
+		err := fn(ev.id, &pb.WorkflowActionStatus{
+			WorkerId:     ev.WorkerID,
+			TaskName:     ev.TaskName,
+			ActionName:   ev.ActionName,
+			Seconds:      ev.ExecutionTime,
+			Message:      ev.Message,
+			ActionStatus: pb.State(ev.Status),
+			CreatedAt:    timestamppb.New(ev.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkWorkflowNamespace returns a NotFound error when the workflow is not
+// in the namespace of ctx, like TinkDB does for the tables keyed by the
+// workflow id
+func (d EmbeddedDB) checkWorkflowNamespace(ctx context.Context, id string) error {
+	return d.view(ctx, func(tx kvTx) error {
+		return checkKVWorkflowNamespace(ctx, tx, id)
+	})
+}
+
+// checkKVWorkflowNamespace is checkWorkflowNamespace in tx
+func checkKVWorkflowNamespace(ctx context.Context, tx kvTx, id string) error {
+	if NamespaceFromContext(ctx) == "" {
+		return nil
+	}
+	var wf kvWorkflow
+	found, err := getJSON(tx.Bucket(bucketWorkflow), uuidKey(id), &wf)
+	if err != nil {
+		return errors.Wrap(err, "SELECT")
+	}
+	if !found || !inNamespace(ctx, wf.Namespace) {
+		return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// The kinds of resources the events are about
+const (
+	ResourceHardware = "hardware"
+	ResourceTemplate = "template"
+	ResourceWorkflow = "workflow"
+)
+
+// The changes recorded by the events
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+// Event is an entry of the events log
+type Event struct {
+	ID           int64
+	ResourceType string
+	ResourceID   string
+	EventType    string
+	Actor        string
+	CreatedAt    time.Time
+}
+
+// EventFilter selects the events returned by GetEvents, the zero value
+// selects all of them
+type EventFilter struct {
+	ResourceTypes []string
+	ResourceIDs   []string
+	EventTypes    []string
+}
+
+// GetEvents : get at most limit events matching the filter recorded after
+// the since event, oldest first
+func (d TinkDB) GetEvents(ctx context.Context, filter EventFilter, since int64, limit int, fn func(Event) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	array := func(values []string) interface{} {
+		if len(values) == 0 {
+			return nil
+		}
+		return pq.Array(values)
+	}
+	rows, err := d.instance.QueryContext(ctx, `
+	SELECT id, resource_type, resource_id, event_type, actor, created_at
+	FROM events
+	WHERE
+		id > $1
+	AND
+		($2::text[] IS NULL OR resource_type = ANY($2::text[]))
+	AND
+		($3::text[] IS NULL OR resource_id::text = ANY($3::text[]))
+	AND
+		($4::text[] IS NULL OR event_type = ANY($4::text[]))
+	ORDER BY id ASC
+	LIMIT $5
+	`, since, array(filter.ResourceTypes), array(filter.ResourceIDs), array(filter.EventTypes), limit)
+	queried()
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var ev Event
+		err = rows.Scan(&ev.ID, &ev.ResourceType, &ev.ResourceID, &ev.EventType, &ev.Actor, &ev.CreatedAt)
+		if err != nil {
+			err = errors.Wrap(err, "SELECT")
+			d.logger.Error(err)
+			return err
+		}
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// LatestEventID : get the id of the last recorded event, 0 when there is
+// none
+func (d TinkDB) LatestEventID(ctx context.Context) (int64, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	var id int64
+	err := d.instance.QueryRowContext(ctx, `
+	SELECT COALESCE(MAX(id), 0)
+	FROM events
+	`).Scan(&id)
+	if err != nil {
+		return 0, errors.Wrap(err, "SELECT")
+	}
+	return id, nil
+}
+
+// The tables numbering their rows for the clients following them. A change
+// locks the ones it writes to with beginChange.
+const (
+	// eventsTable numbers the events, along with the revisions of the
+	// hardware written with them
+	eventsTable = "events"
+	// workflowEventsTable numbers the events of the workflows
+	workflowEventsTable = "workflow_event"
+)
+
+// beginChange begins a transaction writing the rows of the given numbered
+// tables. They are numbered in the order their transactions commit, so who
+// follows them by number never skips one committed late: the transactions
+// take an advisory lock per table before anything else, in a fixed order.
+// The ones writing the same tables queue on it instead of deadlocking on the
+// rows they lock, and as the lock comes before the first query each one
+// reads what the previous one committed. The others, and the reads, do not
+// wait for it.
+func (d TinkDB) beginChange(ctx context.Context, tables ...string) (*sql.Tx, error) {
+	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, errors.Wrap(err, "BEGIN transaction")
+	}
+	tables = append([]string{}, tables...)
+	sort.Strings(tables)
+	for _, table := range tables {
+		// the lock is released with the transaction
+		_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "tink."+table)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, errors.Wrapf(err, "LOCK %s", table)
+		}
+	}
+	return tx, nil
+}
+
+// insertEvent records a change in the events log, in a transaction begun
+// with beginChange locking eventsTable
+func insertEvent(ctx context.Context, tx *sql.Tx, resourceType, resourceID, eventType string) error {
+	_, err := tx.ExecContext(ctx, `
+	INSERT INTO
+		events (resource_id, resource_type, event_type, actor, created_at)
+	VALUES
+		($1, $2, $3, $4, $5)
+	`, resourceID, resourceType, eventType, ActorFromContext(ctx), time.Now())
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to events")
+	}
+	return nil
+}
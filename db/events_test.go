@@ -0,0 +1,115 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/workflow"
+)
+
+func TestGetEvents(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	defer func() {
+		err := cl()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	latest, err := tinkDB.LatestEventID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 0 {
+		t.Fatalf("expected no event, got %d", latest)
+	}
+
+	hw := readHardwareData("./testdata/hardware.json")
+	if err := createHardware(ctx, tinkDB, hw); err != nil {
+		t.Fatal(err)
+	}
+	w := workflow.MustParseFromFile("./testdata/template_happy_path_1.yaml")
+	w.ID = uuid.New().String()
+	w.Name = fmt.Sprintf("id_%d", rand.Int())
+	if err := createTemplateFromWorkflowType(ctx, tinkDB, w); err != nil {
+		t.Fatal(err)
+	}
+	if err := tinkDB.DeleteTemplate(ctx, w.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := tinkDB.DeleteFromDB(ctx, hw.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	type event struct {
+		ResourceType string
+		ResourceID   string
+		EventType    string
+	}
+	testCases := map[string]struct {
+		filter   db.EventFilter
+		since    int64
+		expected []event
+	}{
+		"all": {
+			expected: []event{
+				{db.ResourceHardware, hw.Id, db.EventCreated},
+				{db.ResourceTemplate, w.ID, db.EventCreated},
+				{db.ResourceTemplate, w.ID, db.EventDeleted},
+				{db.ResourceHardware, hw.Id, db.EventDeleted},
+			},
+		},
+		"since": {
+			since: 2,
+			expected: []event{
+				{db.ResourceTemplate, w.ID, db.EventDeleted},
+				{db.ResourceHardware, hw.Id, db.EventDeleted},
+			},
+		},
+		"by-resource-type": {
+			filter: db.EventFilter{ResourceTypes: []string{db.ResourceTemplate}},
+			expected: []event{
+				{db.ResourceTemplate, w.ID, db.EventCreated},
+				{db.ResourceTemplate, w.ID, db.EventDeleted},
+			},
+		},
+		"by-resource-id-and-event-type": {
+			filter:   db.EventFilter{ResourceIDs: []string{hw.Id}, EventTypes: []string{db.EventDeleted}},
+			expected: []event{{db.ResourceHardware, hw.Id, db.EventDeleted}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			events := []event{}
+			err := tinkDB.GetEvents(ctx, tc.filter, tc.since, 10, func(ev db.Event) error {
+				events = append(events, event{ev.ResourceType, ev.ResourceID, ev.EventType})
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(tc.expected, events); d != "" {
+				t.Errorf("unexpected events: %s", d)
+			}
+		})
+	}
+
+	latest, err = tinkDB.LatestEventID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 4 {
+		t.Errorf("expected the last event to be 4, got %d", latest)
+	}
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+)
+
+// SetReplicaWithLag is SetReplica with the lag of the replica given by lag
+// instead of the databases, unless it is negative
+func (t *TinkDB) SetReplicaWithLag(db *sql.DB, maxLag time.Duration, lag func() time.Duration) {
+	t.setReplica(db, maxLag, func(ctx context.Context, primary, replica *sql.DB) (sql.NullFloat64, error) {
+		if l := lag(); l >= 0 {
+			return sql.NullFloat64{Float64: l.Seconds(), Valid: true}, nil
+		}
+		return measureReplicaLag(ctx, primary, replica)
+	})
+}
+
+// WaitReplicaCheck waits for a measure of the lag of the replica started
+// after the call
+func (t *TinkDB) WaitReplicaCheck() {
+	// the measure running during the call may be an old one
+	n := atomic.LoadInt32(&t.replica.checks) + 2
+	for atomic.LoadInt32(&t.replica.checks) < n {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
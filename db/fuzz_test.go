@@ -0,0 +1,153 @@
+//go:build go1.18
+// +build go1.18
+
+package db_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/protos/hardware"
+	"github.com/tinkerbell/tink/workflow"
+)
+
+// hostile are identifiers trying to break out of the queries they end up in
+var hostile = []string{
+	"' OR '1'='1",
+	"x' OR name <> '",
+	"'; DROP TABLE template; --",
+	`"}]}}`,
+	`08:00:27:00:00:01"}}, {"dhcp": {"mac": "08:00:27:00:00:01`,
+	`192.168.1.5"}}}]}, "instance": {"ip_addresses": [{"address": "x`,
+	`\`,
+	`\"`,
+	"%",
+	"\x00",
+	"\xff",
+}
+
+// newFuzzDatabase returns a database of the backend selected with the
+// -db-backend flag, shared by all the inputs of a fuzz test
+func newFuzzDatabase(f *testing.F) db.Database {
+	tinkDB, cl := NewDatabaseClient(f, context.Background(), NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	f.Cleanup(func() {
+		if err := cl(); err != nil {
+			f.Error(err)
+		}
+	})
+	return tinkDB
+}
+
+func FuzzGetTemplate(f *testing.F) {
+	ctx := context.Background()
+	tinkDB := newFuzzDatabase(f)
+
+	tmpl := workflow.MustParseFromFile("./testdata/template_happy_path_1.yaml")
+	tmpl.ID = uuid.New().String()
+	if err := createTemplateFromWorkflowType(ctx, tinkDB, tmpl); err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(tmpl.ID)
+	f.Add(tmpl.Name)
+	for _, s := range hostile {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		got, err := tinkDB.GetTemplate(ctx, map[string]string{"name": value}, false)
+		if value == tmpl.Name && err != nil {
+			t.Fatal(err)
+		}
+		if err == nil && got.Name != value {
+			t.Fatalf("got the template %q getting the name %q", got.Name, value)
+		}
+
+		// PostgreSQL rejects the ids which are not UUIDs, the other
+		// backends do not find them
+		id, parseErr := uuid.Parse(value)
+		got, err = tinkDB.GetTemplate(ctx, map[string]string{"id": value}, false)
+		if value == tmpl.ID && err != nil {
+			t.Fatal(err)
+		}
+		if err == nil && (parseErr != nil || got.Id != id.String()) {
+			t.Fatalf("got the template %s getting the id %q", got.Id, value)
+		}
+
+		if _, err := tinkDB.GetTemplate(ctx, map[string]string{"id": tmpl.ID}, false); err != nil {
+			t.Fatalf("lost the template getting %q: %v", value, err)
+		}
+	})
+}
+
+func FuzzGetByMAC(f *testing.F) {
+	ctx := context.Background()
+	tinkDB, hw := newFuzzHardware(f)
+	mac := hw.Network.Interfaces[0].Dhcp.Mac
+
+	f.Add(mac)
+	for _, s := range hostile {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		data, err := tinkDB.GetByMAC(ctx, value)
+		if value == mac && err != nil {
+			t.Fatal(err)
+		}
+		if err != nil {
+			return
+		}
+		if got := decodeFuzzHardware(t, data); got.Network.Interfaces[0].Dhcp.Mac != value {
+			t.Fatalf("got the hardware %s getting the MAC address %q", got.Id, value)
+		}
+	})
+}
+
+func FuzzGetByIP(f *testing.F) {
+	ctx := context.Background()
+	tinkDB, hw := newFuzzHardware(f)
+	ip := hw.Network.Interfaces[0].Dhcp.Ip.Address
+
+	f.Add(ip)
+	for _, s := range hostile {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		data, err := tinkDB.GetByIP(ctx, value)
+		if value == ip && err != nil {
+			t.Fatal(err)
+		}
+		if err != nil {
+			return
+		}
+		if got := decodeFuzzHardware(t, data); got.Network.Interfaces[0].Dhcp.Ip.Address != value {
+			t.Fatalf("got the hardware %s getting the IP address %q", got.Id, value)
+		}
+		if net.ParseIP(value) == nil {
+			t.Fatalf("got a hardware getting the invalid IP address %q", value)
+		}
+	})
+}
+
+func newFuzzHardware(f *testing.F) (db.Database, *hardware.Hardware) {
+	tinkDB := newFuzzDatabase(f)
+	hw := readHardwareData("./testdata/hardware.json")
+	hw.Id = uuid.New().String()
+	if err := createHardware(context.Background(), tinkDB, hw); err != nil {
+		f.Fatal(err)
+	}
+	return tinkDB, hw
+}
+
+func decodeFuzzHardware(t *testing.T, data string) *hardware.Hardware {
+	hw := &hardware.Hardware{}
+	if err := json.Unmarshal([]byte(data), hw); err != nil {
+		t.Fatal(err)
+	}
+	return hw
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -13,25 +14,53 @@ import (
 
 // DeleteFromDB : delete data from hardware table
 func (d TinkDB) DeleteFromDB(ctx context.Context, id string) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
 	}
 
-	res, err := tx.Exec(`
+	var (
+		data    []byte
+		deleted bool
+	)
+	err = tx.QueryRowContext(ctx, `
+	SELECT data, deleted_at IS NOT NULL
+	FROM hardware
+	WHERE
+		id = $1
+	AND
+		data @> $2
+	FOR UPDATE;
+	`, id, string(namespaceMatch(ctx))).Scan(&data, &deleted)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "SELECT")
+	}
+
+	_, err = tx.ExecContext(ctx, `
 	UPDATE hardware
 	SET
 		deleted_at = NOW()
 	WHERE
 		id = $1;
 	`, id)
-
 	if err != nil {
+		_ = tx.Rollback()
 		return errors.Wrap(err, "DELETE")
 	}
 
-	if count, _ := res.RowsAffected(); count == int64(0) {
-		return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+	if !deleted {
+		if err := insertHardwareRevision(ctx, tx, HardwareDeleted, nil, data); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
 	}
 
 	err = tx.Commit()
@@ -42,48 +71,191 @@ func (d TinkDB) DeleteFromDB(ctx context.Context, id string) error {
 }
 
 // InsertIntoDB : insert data into hardware table
+//
+// The version stored with the hardware data gets incremented on every write.
+// The incoming data has to carry the stored version to update a hardware,
+// otherwise the write is rejected with codes.Aborted, or with
+// codes.FailedPrecondition when it has no version. Only a new hardware can
+// be written without a version, unless the context comes from WithForce.
+//
+// Every write is recorded in the hardware history, along with the actor
+// found in the context.
 func (d TinkDB) InsertIntoDB(ctx context.Context, data string) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable)
+	if err != nil {
+		return err
+	}
+
+	if err := insertHardware(ctx, tx, data); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "COMMIT")
+	}
+	return nil
+}
+
+// errCreatedAfter is returned by ListHardware when filtered by creation
+// time, which is not tracked
+var errCreatedAfter = status.Error(codes.InvalidArgument, "the hardware can not be filtered by creation time")
+
+type forceKey struct{}
+
+// WithForce returns a context making the hardware writes overwrite what is
+// stored, whatever the version of the incoming data
+func WithForce(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceKey{}, true)
+}
+
+// forceFromContext tells whether the context comes from WithForce
+func forceFromContext(ctx context.Context) bool {
+	force, _ := ctx.Value(forceKey{}).(bool)
+	return force
+}
+
+// versionConflict returns the error of a write of the hardware data whose
+// version does not match the stored one
+func versionConflict(data string) error {
+	if v, err := hardwareVersion([]byte(data)); err == nil && v == 0 {
+		return status.Error(codes.FailedPrecondition, "the version of the hardware is required to update it")
+	}
+	return status.Error(codes.Aborted, "version conflict, the hardware has been modified since it was read")
+}
+
+// BatchError is returned by a batch write failing on one of its items, in
+// which case none of them gets written
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Cause returns the error of the failed item
+func (e *BatchError) Cause() error {
+	return e.Err
+}
+
+// InsertHardwareBatch : insert many hardware in a single transaction. It
+// behaves like InsertIntoDB for every item. When one of them fails nothing
+// is written and the error is a *BatchError.
+func (d TinkDB) InsertHardwareBatch(ctx context.Context, data []string) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable)
+	if err != nil {
+		return err
+	}
+
+	for i, hw := range data {
+		if err := insertHardware(ctx, tx, hw); err != nil {
+			_ = tx.Rollback()
+			return &BatchError{Index: i, Err: err}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "COMMIT")
+	}
+	return nil
+}
+
+// insertHardware writes the hardware and its revision in tx, the caller
+// rolls back on error
+func insertHardware(ctx context.Context, tx *sql.Tx, data string) error {
+	var (
+		old     []byte
+		deleted bool
+	)
+	err := tx.QueryRowContext(ctx, `
+	SELECT data, deleted_at IS NOT NULL
+	FROM hardware
+	WHERE
+		id = ($1::jsonb ->> 'id')::uuid
+	FOR UPDATE;
+	`, data).Scan(&old, &deleted)
+	if err != nil && err != sql.ErrNoRows {
+		return errors.Wrap(err, "SELECT")
+	}
+	event := HardwareUpdated
+	if err == sql.ErrNoRows || deleted {
+		event = HardwareCreated
+		old = nil
+	}
+	data, err = keepHardwareState(data, old)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
+	}
+	data, err = setHardwareNamespace(ctx, data, old)
+	if err != nil {
+		return err
 	}
 
-	_, err = tx.Exec(`
+	err = checkHardwareConflicts(data, func(id string, match []byte) (string, error) {
+		var other string
+		err := tx.QueryRowContext(ctx, `
+		SELECT id
+		FROM hardware
+		WHERE
+			deleted_at IS NULL
+		AND
+			id::text <> $1
+		AND
+			data @> $2
+		LIMIT 1
+		`, id, string(match)).Scan(&other)
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return other, errors.Wrap(err, "SELECT")
+	})
+	if err != nil {
+		return err
+	}
+
+	var stored []byte
+	err = tx.QueryRowContext(ctx, `
 	INSERT INTO
 		hardware (inserted_at, id, data)
 	VALUES
-		($1, ($2::jsonb ->> 'id')::uuid, $2)
+		($1, ($2::jsonb ->> 'id')::uuid, jsonb_set($2::jsonb, '{version}', '1'))
 	ON CONFLICT (id)
 	DO
 	UPDATE SET
-		(inserted_at, deleted_at, data) = ($1, NULL, $2);
-	`, time.Now(), data)
+		(inserted_at, deleted_at, data) = ($1, NULL, jsonb_set($2::jsonb, '{version}', to_jsonb(COALESCE((hardware.data ->> 'version')::bigint, 0) + 1)))
+	WHERE
+		hardware.deleted_at IS NOT NULL
+	OR
+		$3
+	OR
+		COALESCE(($2::jsonb ->> 'version')::bigint, 0) = COALESCE((hardware.data ->> 'version')::bigint, 0)
+	RETURNING data;
+	`, time.Now(), data, forceFromContext(ctx)).Scan(&stored)
+	if err == sql.ErrNoRows {
+		return versionConflict(data)
+	}
 	if err != nil {
 		return errors.Wrap(err, "INSERT")
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return errors.Wrap(err, "COMMIT")
-	}
-	return nil
+	return insertHardwareRevision(ctx, tx, event, old, stored)
 }
 
 // GetByMAC : get data by machine mac
 func (d TinkDB) GetByMAC(ctx context.Context, mac string) (string, error) {
-	arg := `
-	{
-		"network": {
-			"interfaces": [
-				{
-					"dhcp": {
-						"mac": "` + mac + `"
-					}
-				}
-			]
-		}
-	}
-	`
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
 	query := `
 	SELECT data
 	FROM hardware
@@ -91,40 +263,19 @@ func (d TinkDB) GetByMAC(ctx context.Context, mac string) (string, error) {
 		deleted_at IS NULL
 	AND
 		data @> $1
+	AND
+		data @> $2
 	`
 
-	return get(ctx, d.instance, query, arg)
+	return get(ctx, d.reader(ctx), query, string(macMatch(mac)), string(namespaceMatch(ctx)))
 }
 
 // GetByIP : get data by machine ip
 func (d TinkDB) GetByIP(ctx context.Context, ip string) (string, error) {
-	instance := `
-	{
-	  "instance": {
-	    "ip_addresses": [
-	      {
-		    "address": "` + ip + `"
-	      }
-	    ]
-	  }
-	}
-	`
-	hardwareOrManagement := `
-	{
-		"network": {
-			"interfaces": [
-				{
-					"dhcp": {
-						"ip": {
-							"address": "` + ip + `"
-						}
-					}
-				}
-			]
-		}
-	}
-	`
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
 
+	matches := ipMatches(ip)
 	query := `
 	SELECT data
 	FROM hardware
@@ -135,13 +286,51 @@ func (d TinkDB) GetByIP(ctx context.Context, ip string) (string, error) {
 		OR
 		data @> $2
 	)
+	AND
+		data @> $3
 	`
 
-	return get(ctx, d.instance, query, instance, hardwareOrManagement)
+	return get(ctx, d.reader(ctx), query, string(matches[0]), string(matches[1]), string(namespaceMatch(ctx)))
+}
+
+// macMatch returns the JSON document contained in the data of the hardware
+// having a MAC address. It is marshaled rather than concatenated, so the
+// address can not alter its structure.
+func macMatch(mac string) []byte {
+	match, _ := json.Marshal(map[string]interface{}{
+		"network": map[string]interface{}{
+			"interfaces": []interface{}{
+				map[string]interface{}{"dhcp": map[string]string{"mac": mac}},
+			},
+		},
+	})
+	return match
+}
+
+// ipMatches returns the JSON documents one of which is contained in the data
+// of the hardware having an IP address: one for the instance and one for the
+// network interfaces
+func ipMatches(ip string) [][]byte {
+	instance, _ := json.Marshal(map[string]interface{}{
+		"instance": map[string]interface{}{
+			"ip_addresses": []interface{}{map[string]string{"address": ip}},
+		},
+	})
+	hardwareOrManagement, _ := json.Marshal(map[string]interface{}{
+		"network": map[string]interface{}{
+			"interfaces": []interface{}{
+				map[string]interface{}{"dhcp": map[string]interface{}{"ip": map[string]string{"address": ip}}},
+			},
+		},
+	})
+	return [][]byte{instance, hardwareOrManagement}
 }
 
 // GetByID : get data by machine id
 func (d TinkDB) GetByID(ctx context.Context, id string) (string, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
 	arg := id
 
 	query := `
@@ -151,18 +340,26 @@ func (d TinkDB) GetByID(ctx context.Context, id string) (string, error) {
 		deleted_at IS NULL
 	AND
 		id = $1
+	AND
+		data @> $2
 	`
-	return get(ctx, d.instance, query, arg)
+	return get(ctx, d.reader(ctx), query, arg, string(namespaceMatch(ctx)))
 }
 
 // GetAll : get data for all machine
-func (d TinkDB) GetAll(fn func([]byte) error) error {
-	rows, err := d.instance.Query(`
+func (d TinkDB) GetAll(ctx context.Context, fn func([]byte) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := d.reader(ctx).QueryContext(ctx, `
 	SELECT data
 	FROM hardware
 	WHERE
 		deleted_at IS NULL
-	`)
+	AND
+		data @> $1
+	`, string(namespaceMatch(ctx)))
+	queried()
 
 	if err != nil {
 		return err
@@ -191,3 +388,133 @@ func (d TinkDB) GetAll(fn func([]byte) error) error {
 	}
 	return err
 }
+
+// ListHardware : get data for the machines having all the given labels
+//
+// The labels are matched with a containment query, so it hits the GIN index
+// on the data column. An empty set of labels returns every machine. The
+// machines can be ordered by id (default) or inserted_at, the time of the
+// last write, the hardware table does not track the creation time so
+// opts.CreatedAfter is rejected.
+func (d TinkDB) ListHardware(ctx context.Context, labels map[string]string, opts ListOptions, fn func([]byte) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	if !opts.CreatedAfter.IsZero() {
+		return errCreatedAfter
+	}
+	arg, err := json.Marshal(map[string]interface{}{"labels": labels})
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 {
+		arg = []byte(`{}`)
+	}
+	clauses, err := opts.listClauses(map[string]string{
+		"id":          "id",
+		"inserted_at": "inserted_at",
+	}, "id", "id", 3)
+	if err != nil {
+		return err
+	}
+
+	rows, err := d.reader(ctx).QueryContext(ctx, `
+	SELECT data, `+clauses.column+`, id
+	FROM hardware
+	WHERE
+		deleted_at IS NULL
+	AND
+		data @> $1
+	AND
+		data @> $2
+	AND
+		`+clauses.after+`
+	`+clauses.order, append([]interface{}{string(arg), string(namespaceMatch(ctx))}, clauses.args...)...)
+	queried()
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	var (
+		buf   []byte
+		value interface{}
+		id    string
+	)
+	for rows.Next() {
+		err = rows.Scan(&buf, &value, &id)
+		if err != nil {
+			err = errors.Wrap(err, "SELECT")
+			d.logger.Error(err)
+			return err
+		}
+		opts.cursor(value, id)
+
+		err = fn(buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// checkHardwareConflicts returns an AlreadyExists error when another
+// hardware uses one of the MAC addresses, IP addresses or hostnames of data.
+// find returns the id of a hardware, other than the one with the given id,
+// containing the match document, or an empty string. It runs in the insert
+// transaction, so two concurrent writes can not both claim the same address.
+// The error does not tell the id of the other hardware, it can be in another
+// namespace.
+func checkHardwareConflicts(data string, find func(id string, match []byte) (string, error)) error {
+	var hw struct {
+		ID      string `json:"id"`
+		Network struct {
+			Interfaces []struct {
+				DHCP struct {
+					MAC      string `json:"mac"`
+					Hostname string `json:"hostname"`
+					IP       struct {
+						Address string `json:"address"`
+					} `json:"ip"`
+				} `json:"dhcp"`
+			} `json:"interfaces"`
+		} `json:"network"`
+	}
+	if err := json.Unmarshal([]byte(data), &hw); err != nil {
+		return errors.Wrap(err, "invalid hardware data")
+	}
+
+	type dhcp map[string]interface{}
+	check := func(field, value string, match dhcp) error {
+		if value == "" {
+			return nil
+		}
+		arg, err := json.Marshal(map[string]interface{}{
+			"network": map[string]interface{}{
+				"interfaces": []interface{}{map[string]interface{}{"dhcp": match}},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		id, err := find(hw.ID, arg)
+		if err != nil || id == "" {
+			return err
+		}
+		return status.Errorf(codes.AlreadyExists, "%s %s is already used by another hardware", field, value)
+	}
+
+	for _, iface := range hw.Network.Interfaces {
+		if err := check("MAC address", iface.DHCP.MAC, dhcp{"mac": iface.DHCP.MAC}); err != nil {
+			return err
+		}
+		if err := check("IP address", iface.DHCP.IP.Address, dhcp{"ip": map[string]string{"address": iface.DHCP.IP.Address}}); err != nil {
+			return err
+		}
+		if err := check("hostname", iface.DHCP.Hostname, dhcp{"hostname": iface.DHCP.Hostname}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
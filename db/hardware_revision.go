@@ -0,0 +1,250 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinkerbell/tink/pkg"
+)
+
+// The events recorded in the hardware history
+const (
+	HardwareCreated = "created"
+	HardwareUpdated = "updated"
+	HardwareDeleted = "deleted"
+	// HardwareCompacted is not recorded, GetHardwareChanges returns it in
+	// place of the history of a purged hardware. Its data is the hardware
+	// as it was last recorded.
+	HardwareCompacted = "compacted"
+)
+
+// HardwareRevision is an entry of the hardware history
+type HardwareRevision struct {
+	Revision   int64
+	HardwareID string
+	Version    int64
+	EventType  string
+	Actor      string
+	CreatedAt  time.Time
+	// Data is the hardware as it is after the event
+	Data string
+	// PreviousData is the hardware as it was before an update, it is empty
+	// for the other events and when the previous revision got purged
+	PreviousData string
+	// Diff lists the fields changed by the event, it is empty for a
+	// deletion
+	Diff []pkg.Change
+}
+
+type actorKey struct{}
+
+// WithActor returns a context carrying who is changing the data, it gets
+// recorded in the hardware history
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set with WithActor
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// GetHardwareHistory : get the revisions of a machine, oldest first
+func (d TinkDB) GetHardwareHistory(ctx context.Context, id string, fn func(HardwareRevision) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := d.reader(ctx).QueryContext(ctx, `
+	SELECT `+hardwareRevisionColumns+`
+	WHERE
+		r.hardware_id = $1
+	AND
+		r.data @> $2
+	ORDER BY r.revision ASC
+	`, id, string(namespaceMatch(ctx)))
+	queried()
+	if err != nil {
+		return err
+	}
+	return d.scanHardwareRevisions(rows, fn)
+}
+
+// GetHardwareChanges : get at most limit revisions of any machine made after
+// the since revision, oldest first. With a namespace in ctx, the revisions
+// of the machines of the other namespaces are skipped. The history of a
+// purged machine is replaced by a revision of type HardwareCompacted, at the
+// last revision removed.
+func (d TinkDB) GetHardwareChanges(ctx context.Context, since int64, limit int, fn func(HardwareRevision) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	// the compactions and the changes are read from the same snapshot, a
+	// purge does not remove changes in between
+	rows, err := d.instance.QueryContext(ctx, `
+	SELECT *
+	FROM (
+		SELECT `+hardwareRevisionColumns+`
+		WHERE
+			r.revision > $1
+		AND
+			r.data @> $3
+		UNION ALL
+		SELECT c.revision, c.hardware_id, c.version, '`+HardwareCompacted+`', '', c.created_at, c.data, NULL, NULL
+		FROM hardware_revision_compaction c
+		WHERE
+			c.revision > $1
+		AND
+			c.data @> $3
+	) changes
+	ORDER BY revision ASC
+	LIMIT $2
+	`, since, limit, string(namespaceMatch(ctx)))
+	queried()
+	if err != nil {
+		return err
+	}
+	return d.scanHardwareRevisions(rows, fn)
+}
+
+// LatestHardwareRevision : get the revision of the last hardware change, 0
+// when nothing changed yet
+func (d TinkDB) LatestHardwareRevision(ctx context.Context) (int64, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	// the last revisions can be purged, the compactions keep them
+	var revision int64
+	err := d.instance.QueryRowContext(ctx, `
+	SELECT GREATEST(
+		(SELECT COALESCE(MAX(revision), 0) FROM hardware_revision),
+		(SELECT COALESCE(MAX(revision), 0) FROM hardware_revision_compaction)
+	)
+	`).Scan(&revision)
+	if err != nil {
+		return 0, errors.Wrap(err, "SELECT")
+	}
+	return revision, nil
+}
+
+// hardwareRevisionColumns selects the columns scanned by
+// scanHardwareRevisions from the hardware_revision table aliased as r, along
+// with the data of the previous revision of an update
+const hardwareRevisionColumns = `r.revision, r.hardware_id, r.version, r.event_type, r.actor, r.created_at, r.data, r.diff, p.data
+	FROM hardware_revision r
+	LEFT JOIN LATERAL (
+		SELECT data
+		FROM hardware_revision
+		WHERE
+			hardware_id = r.hardware_id
+		AND
+			revision < r.revision
+		AND
+			r.event_type = '` + HardwareUpdated + `'
+		ORDER BY revision DESC
+		LIMIT 1
+	) p ON true`
+
+func (d TinkDB) scanHardwareRevisions(rows *sql.Rows, fn func(HardwareRevision) error) error {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			rev      HardwareRevision
+			data     []byte
+			diff     []byte
+			previous []byte
+		)
+		err := rows.Scan(&rev.Revision, &rev.HardwareID, &rev.Version, &rev.EventType, &rev.Actor, &rev.CreatedAt, &data, &diff, &previous)
+		if err != nil {
+			err = errors.Wrap(err, "SELECT")
+			d.logger.Error(err)
+			return err
+		}
+		rev.Data, rev.PreviousData = string(data), string(previous)
+		if len(diff) > 0 {
+			if err := json.Unmarshal(diff, &rev.Diff); err != nil {
+				return errors.Wrap(err, "invalid hardware revision diff")
+			}
+		}
+
+		err = fn(rev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// insertHardwareRevision appends a revision to the history of the hardware.
+// old is the data before the event, empty for a creation. The transaction
+// has to be begun with beginChange locking eventsTable.
+func insertHardwareRevision(ctx context.Context, tx *sql.Tx, event string, old, data []byte) error {
+	var hw struct {
+		ID      string `json:"id"`
+		Version int64  `json:"version"`
+	}
+	if err := json.Unmarshal(data, &hw); err != nil {
+		return errors.Wrap(err, "invalid hardware data")
+	}
+
+	var diff interface{}
+	if event != HardwareDeleted {
+		changes, err := diffHardware(old, data)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(changes)
+		if err != nil {
+			return err
+		}
+		diff = string(b)
+	}
+
+	_, err := tx.ExecContext(ctx, `
+	INSERT INTO
+		hardware_revision (hardware_id, version, event_type, actor, created_at, data, diff)
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7)
+	`, hw.ID, hw.Version, event, ActorFromContext(ctx), time.Now(), string(data), diff)
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to hardware_revision")
+	}
+	return insertEvent(ctx, tx, ResourceHardware, hw.ID, event)
+}
+
+// diffHardware returns the fields changed between two versions of the
+// hardware data. The version is not part of the diff, and the metadata is
+// compared field by field when it is a JSON document.
+func diffHardware(old, data []byte) ([]pkg.Change, error) {
+	decode := func(b []byte) (map[string]interface{}, error) {
+		doc := map[string]interface{}{}
+		if len(b) == 0 {
+			return doc, nil
+		}
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return nil, errors.Wrap(err, "invalid hardware data")
+		}
+		delete(doc, "version")
+		if s, ok := doc["metadata"].(string); ok {
+			var metadata interface{}
+			if json.Unmarshal([]byte(s), &metadata) == nil {
+				doc["metadata"] = metadata
+			}
+		}
+		return doc, nil
+	}
+	o, err := decode(old)
+	if err != nil {
+		return nil, err
+	}
+	n, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkg.DiffJSON(o, n), nil
+}
@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	hpb "github.com/tinkerbell/tink/protos/hardware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetHardwareState : move a machine to a new lifecycle state. The machine has
+// to be in one of the from states, any state when from is nil. It returns
+// the hardware data as it is after the change.
+func (d TinkDB) SetHardwareState(ctx context.Context, id string, from []int32, to int32) (string, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		old     []byte
+		current sql.NullInt32
+	)
+	err = tx.QueryRowContext(ctx, `
+	SELECT data, (data ->> 'state')::int
+	FROM hardware
+	WHERE
+		id = $1
+	AND
+		deleted_at IS NULL
+	AND
+		data @> $2
+	FOR UPDATE;
+	`, id, string(namespaceMatch(ctx))).Scan(&old, &current)
+	if err == sql.ErrNoRows {
+		_ = tx.Rollback()
+		return "", status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return "", errors.Wrap(err, "SELECT")
+	}
+
+	if from != nil && !containsState(from, current.Int32) {
+		_ = tx.Rollback()
+		allowed := make([]string, len(from))
+		for i, s := range from {
+			allowed[i] = hpb.State(s).String()
+		}
+		return "", status.Errorf(codes.FailedPrecondition, "hardware %s is %s, it has to be one of %s", id, hpb.State(current.Int32), strings.Join(allowed, ", "))
+	}
+
+	var data []byte
+	err = tx.QueryRowContext(ctx, `
+	UPDATE hardware
+	SET
+		data = jsonb_set(jsonb_set(data, '{state}', to_jsonb($2::int)), '{version}', to_jsonb(COALESCE((data ->> 'version')::bigint, 0) + 1))
+	WHERE
+		id = $1
+	RETURNING data;
+	`, id, to).Scan(&data)
+	if err != nil {
+		_ = tx.Rollback()
+		return "", errors.Wrap(err, "UPDATE")
+	}
+
+	if err := insertHardwareRevision(ctx, tx, HardwareUpdated, old, data); err != nil {
+		_ = tx.Rollback()
+		return "", err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", errors.Wrap(err, "COMMIT")
+	}
+	return string(data), nil
+}
+
+// keepHardwareState returns the data to store for a push. The state of
+// existing hardware is the one in old, whatever the push says, while new
+// hardware starts available unless it is pushed as discovered.
+func keepHardwareState(data string, old []byte) (string, error) {
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		return "", errors.Wrap(err, "invalid hardware data")
+	}
+
+	if old == nil {
+		var state int32
+		if s, ok := doc["state"]; ok {
+			if err := json.Unmarshal(s, &state); err != nil {
+				return "", errors.Wrap(err, "invalid hardware state")
+			}
+		}
+		if state != int32(hpb.State_STATE_DISCOVERED) {
+			state = int32(hpb.State_STATE_AVAILABLE)
+		}
+		doc["state"] = json.RawMessage(fmt.Sprint(state))
+	} else {
+		stored := map[string]json.RawMessage{}
+		if err := json.Unmarshal(old, &stored); err != nil {
+			return "", errors.Wrap(err, "invalid hardware data")
+		}
+		if s, ok := stored["state"]; ok {
+			doc["state"] = s
+		} else {
+			delete(doc, "state")
+		}
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func containsState(states []int32, s int32) bool {
+	for _, v := range states {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
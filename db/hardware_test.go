@@ -15,6 +15,8 @@ import (
 	"github.com/tinkerbell/tink/db"
 	"github.com/tinkerbell/tink/pkg"
 	"github.com/tinkerbell/tink/protos/hardware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestCreateHardware(t *testing.T) {
@@ -26,9 +28,11 @@ func TestCreateHardware(t *testing.T) {
 		InputAsync bool
 		// Input is a hardware that will be used to pre-populate the database
 		Input []*hardware.Hardware
+		// Force pushes the input with db.WithForce, whatever its version
+		Force bool
 		// Expectation is the function used to apply the assertions.
 		// You can use it to validate if the Input are created as you expect
-		Expectation func(*testing.T, []*hardware.Hardware, *db.TinkDB)
+		Expectation func(*testing.T, []*hardware.Hardware, db.Database)
 		// ExpectedErr is used to check for error during
 		// CreateTemplate execution. If you expect a particular error
 		// and you want to assert it, you can use this function
@@ -37,7 +41,7 @@ func TestCreateHardware(t *testing.T) {
 		{
 			Name:  "create-single-hardware",
 			Input: []*hardware.Hardware{readHardwareData("./testdata/hardware.json")},
-			Expectation: func(t *testing.T, input []*hardware.Hardware, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, input []*hardware.Hardware, tinkDB db.Database) {
 				data, err := tinkDB.GetByID(ctx, input[0].Id)
 				if err != nil {
 					t.Error(err)
@@ -65,17 +69,48 @@ func TestCreateHardware(t *testing.T) {
 					return hw
 				}(),
 			},
-			ExpectedErr: func(t *testing.T, err error) {
-				if err == nil {
-					t.Error("expected error, got nil")
-				}
-				if !strings.Contains(err.Error(), "conflicting hardware MAC address 08:00:27:00:00:01 provided with hardware data/info") {
-					t.Errorf("\nexpected err: %s\ngot: %s", "conflicting hardware MAC address 08:00:27:00:00:01 provided with hardware data/info", err)
-				}
+			Expectation: expectHardwareCount(1),
+			ExpectedErr: expectConflict("MAC address 08:00:27:00:00:01"),
+		},
+		{
+			Name: "two-hardware-with-same-ip",
+			Input: []*hardware.Hardware{
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware.json")
+					hw.Id = uuid.New().String()
+					return hw
+				}(),
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware_2.json")
+					hw.Id = uuid.New().String()
+					hw.Network.Interfaces[0].Dhcp.Ip.Address = "192.168.1.5"
+					return hw
+				}(),
+			},
+			Expectation: expectHardwareCount(1),
+			ExpectedErr: expectConflict("IP address 192.168.1.5"),
+		},
+		{
+			Name: "two-hardware-with-same-hostname",
+			Input: []*hardware.Hardware{
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware.json")
+					hw.Id = uuid.New().String()
+					return hw
+				}(),
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware_2.json")
+					hw.Id = uuid.New().String()
+					hw.Network.Interfaces[0].Dhcp.Hostname = "server001"
+					return hw
+				}(),
 			},
+			Expectation: expectHardwareCount(1),
+			ExpectedErr: expectConflict("hostname server001"),
 		},
 		{
-			Name: "update-on-create",
+			Name:  "update-on-create",
+			Force: true,
 			Input: []*hardware.Hardware{
 				func() *hardware.Hardware {
 					hw := readHardwareData("./testdata/hardware.json")
@@ -89,7 +124,7 @@ func TestCreateHardware(t *testing.T) {
 					return hw
 				}(),
 			},
-			Expectation: func(t *testing.T, input []*hardware.Hardware, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, input []*hardware.Hardware, tinkDB db.Database) {
 				data, err := tinkDB.GetByID(ctx, input[0].Id)
 				if err != nil {
 					t.Error(err)
@@ -104,6 +139,84 @@ func TestCreateHardware(t *testing.T) {
 				}
 			},
 		},
+		{
+			Name: "update-with-matching-version",
+			Input: []*hardware.Hardware{
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware.json")
+					hw.Id = "6a1e2ef2-5e16-4c1f-8f0e-3d3b7ad33b0a"
+					return hw
+				}(),
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware.json")
+					hw.Id = "6a1e2ef2-5e16-4c1f-8f0e-3d3b7ad33b0a"
+					hw.Version = 1
+					hw.Network.Interfaces[0].Dhcp.Hostname = "updated-hostname"
+					return hw
+				}(),
+			},
+			Expectation: func(t *testing.T, input []*hardware.Hardware, tinkDB db.Database) {
+				data, err := tinkDB.GetByID(ctx, input[0].Id)
+				if err != nil {
+					t.Error(err)
+				}
+				hw := &hardware.Hardware{}
+				if err := json.Unmarshal([]byte(data), hw); err != nil {
+					t.Error(err)
+				}
+				if hw.Version != 2 {
+					t.Errorf("expected version to be %d, got %d", 2, hw.Version)
+				}
+				hostName := hw.Network.Interfaces[0].Dhcp.Hostname
+				if hostName != "updated-hostname" {
+					t.Errorf("expected hostname to be \"%s\", got \"%s\"", "updated-hostname", hostName)
+				}
+			},
+		},
+		{
+			Name: "update-with-stale-version",
+			Input: []*hardware.Hardware{
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware.json")
+					hw.Id = "f2d0b43c-0f8e-4a8e-9a4c-1c5cfb1b9a2e"
+					return hw
+				}(),
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware.json")
+					hw.Id = "f2d0b43c-0f8e-4a8e-9a4c-1c5cfb1b9a2e"
+					hw.Version = 1
+					return hw
+				}(),
+				func() *hardware.Hardware {
+					hw := readHardwareData("./testdata/hardware.json")
+					hw.Id = "f2d0b43c-0f8e-4a8e-9a4c-1c5cfb1b9a2e"
+					hw.Version = 1
+					hw.Network.Interfaces[0].Dhcp.Hostname = "stale-hostname"
+					return hw
+				}(),
+			},
+			Expectation: func(t *testing.T, input []*hardware.Hardware, tinkDB db.Database) {
+				data, err := tinkDB.GetByID(ctx, input[0].Id)
+				if err != nil {
+					t.Error(err)
+				}
+				hw := &hardware.Hardware{}
+				if err := json.Unmarshal([]byte(data), hw); err != nil {
+					t.Error(err)
+				}
+				if hw.Version != 2 {
+					t.Errorf("expected version to be %d, got %d", 2, hw.Version)
+				}
+				if hw.Network.Interfaces[0].Dhcp.Hostname == "stale-hostname" {
+					t.Error("expected the stale update to be rejected")
+				}
+			},
+			ExpectedErr: func(t *testing.T, err error) {
+				if status.Code(err) != codes.Aborted {
+					t.Errorf("expected error code %s, got %s", codes.Aborted, status.Code(err))
+				}
+			},
+		},
 		{
 			Name:       "create-stress-test",
 			InputAsync: true,
@@ -113,12 +226,15 @@ func TestCreateHardware(t *testing.T) {
 					hw := readHardwareData("./testdata/hardware.json")
 					hw.Id = uuid.New().String()
 					hw.Network.Interfaces[0].Dhcp.Mac = strings.Replace(hw.Network.Interfaces[0].Dhcp.Mac, "00", fmt.Sprintf("0%d", ii), 1)
+					hw.Network.Interfaces[0].Dhcp.Ip.Address = fmt.Sprintf("192.168.2.%d", ii+1)
+					hw.Network.Interfaces[0].Dhcp.Hostname = fmt.Sprintf("server%03d", ii+100)
+					input = append(input, hw)
 				}
 				return input
 			}(),
-			Expectation: func(t *testing.T, input []*hardware.Hardware, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, input []*hardware.Hardware, tinkDB db.Database) {
 				count := 0
-				err := tinkDB.GetAll(func(b []byte) error {
+				err := tinkDB.GetAll(ctx, func(b []byte) error {
 					count = count + 1
 					return nil
 				})
@@ -138,9 +254,10 @@ func TestCreateHardware(t *testing.T) {
 	}
 
 	for _, s := range tests {
+		s := s
 		t.Run(s.Name, func(t *testing.T) {
 			t.Parallel()
-			_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 				ApplyMigration: true,
 			})
 			defer func() {
@@ -150,11 +267,15 @@ func TestCreateHardware(t *testing.T) {
 				}
 			}()
 
+			ctx := ctx
+			if s.Force {
+				ctx = db.WithForce(ctx)
+			}
 			var wg sync.WaitGroup
 			wg.Add(len(s.Input))
 			for _, hw := range s.Input {
 				if s.InputAsync {
-					go func(ctx context.Context, tinkDB *db.TinkDB, hw *hardware.Hardware) {
+					go func(ctx context.Context, tinkDB db.Database, hw *hardware.Hardware) {
 						defer wg.Done()
 						err := createHardware(ctx, tinkDB, hw)
 						if err != nil {
@@ -178,7 +299,7 @@ func TestCreateHardware(t *testing.T) {
 func TestDeleteHardware(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 		ApplyMigration: true,
 	})
 	defer func() {
@@ -199,7 +320,7 @@ func TestDeleteHardware(t *testing.T) {
 	}
 
 	count := 0
-	err = tinkDB.GetAll(func(b []byte) error {
+	err = tinkDB.GetAll(ctx, func(b []byte) error {
 		count = count + 1
 		return nil
 	})
@@ -213,7 +334,7 @@ func TestDeleteHardware(t *testing.T) {
 
 func TestGetByID(t *testing.T) {
 	ctx := context.Background()
-	expectation := func(t *testing.T, input *hardware.Hardware, tinkDB *db.TinkDB) {
+	expectation := func(t *testing.T, input *hardware.Hardware, tinkDB db.Database) {
 		data, err := tinkDB.GetByID(ctx, input.Id)
 		if err != nil {
 			t.Error(err)
@@ -236,7 +357,7 @@ func TestGetByID(t *testing.T) {
 		Input []*hardware.Hardware
 		// Expectation is the function used to apply the assertions.
 		// You can use it to validate if you get hardware you expected
-		Expectation func(*testing.T, *hardware.Hardware, *db.TinkDB)
+		Expectation func(*testing.T, *hardware.Hardware, db.Database)
 	}{
 		{
 			Name:        "get-hardware-by-id",
@@ -260,9 +381,10 @@ func TestGetByID(t *testing.T) {
 	}
 
 	for _, s := range tests {
+		s := s
 		t.Run(s.Name, func(t *testing.T) {
 			t.Parallel()
-			_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 				ApplyMigration: true,
 			})
 			defer func() {
@@ -283,7 +405,7 @@ func TestGetByID(t *testing.T) {
 			wg.Add(len(s.Input))
 			for _, hw := range s.Input {
 				if s.GetAsync {
-					go func(t *testing.T, h *hardware.Hardware, db *db.TinkDB) {
+					go func(t *testing.T, h *hardware.Hardware, db db.Database) {
 						defer wg.Done()
 						s.Expectation(t, h, db)
 					}(t, hw, tinkDB)
@@ -300,7 +422,7 @@ func TestGetByID(t *testing.T) {
 func TestGetByID_WithNonExistingID(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 		ApplyMigration: true,
 	})
 	defer func() {
@@ -323,7 +445,7 @@ func TestGetByID_WithNonExistingID(t *testing.T) {
 
 func TestGetByIP(t *testing.T) {
 	ctx := context.Background()
-	expectation := func(t *testing.T, input *hardware.Hardware, tinkDB *db.TinkDB) {
+	expectation := func(t *testing.T, input *hardware.Hardware, tinkDB db.Database) {
 		data, err := tinkDB.GetByIP(ctx, input.Network.Interfaces[0].Dhcp.Ip.Address)
 		if err != nil {
 			t.Error(err)
@@ -346,7 +468,7 @@ func TestGetByIP(t *testing.T) {
 		Input []*hardware.Hardware
 		// Expectation is the function used to apply the assertions.
 		// You can use it to validate if you get hardware you expected
-		Expectation func(*testing.T, *hardware.Hardware, *db.TinkDB)
+		Expectation func(*testing.T, *hardware.Hardware, db.Database)
 	}{
 		{
 			Name:        "get-hardware-by-ip",
@@ -373,9 +495,10 @@ func TestGetByIP(t *testing.T) {
 	}
 
 	for _, s := range tests {
+		s := s
 		t.Run(s.Name, func(t *testing.T) {
 			t.Parallel()
-			_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 				ApplyMigration: true,
 			})
 			defer func() {
@@ -396,7 +519,7 @@ func TestGetByIP(t *testing.T) {
 			wg.Add(len(s.Input))
 			for _, hw := range s.Input {
 				if s.GetAsync {
-					go func(t *testing.T, h *hardware.Hardware, db *db.TinkDB) {
+					go func(t *testing.T, h *hardware.Hardware, db db.Database) {
 						defer wg.Done()
 						s.Expectation(t, h, db)
 					}(t, hw, tinkDB)
@@ -413,7 +536,7 @@ func TestGetByIP(t *testing.T) {
 func TestGetByIP_WithNonExistingIP(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 		ApplyMigration: true,
 	})
 	defer func() {
@@ -436,7 +559,7 @@ func TestGetByIP_WithNonExistingIP(t *testing.T) {
 
 func TestGetByMAC(t *testing.T) {
 	ctx := context.Background()
-	expectation := func(t *testing.T, input *hardware.Hardware, tinkDB *db.TinkDB) {
+	expectation := func(t *testing.T, input *hardware.Hardware, tinkDB db.Database) {
 		data, err := tinkDB.GetByMAC(ctx, input.Network.Interfaces[0].Dhcp.Mac)
 		if err != nil {
 			t.Error(err)
@@ -459,7 +582,7 @@ func TestGetByMAC(t *testing.T) {
 		Input []*hardware.Hardware
 		// Expectation is the function used to apply the assertions.
 		// You can use it to validate if you get hardware you expected
-		Expectation func(*testing.T, *hardware.Hardware, *db.TinkDB)
+		Expectation func(*testing.T, *hardware.Hardware, db.Database)
 	}{
 		{
 			Name:        "get-hardware-by-mac",
@@ -484,9 +607,10 @@ func TestGetByMAC(t *testing.T) {
 	}
 
 	for _, s := range tests {
+		s := s
 		t.Run(s.Name, func(t *testing.T) {
 			t.Parallel()
-			_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 				ApplyMigration: true,
 			})
 			defer func() {
@@ -507,7 +631,7 @@ func TestGetByMAC(t *testing.T) {
 			wg.Add(len(s.Input))
 			for _, hw := range s.Input {
 				if s.GetAsync {
-					go func(t *testing.T, h *hardware.Hardware, db *db.TinkDB) {
+					go func(t *testing.T, h *hardware.Hardware, db db.Database) {
 						defer wg.Done()
 						s.Expectation(t, h, db)
 					}(t, hw, tinkDB)
@@ -524,7 +648,7 @@ func TestGetByMAC(t *testing.T) {
 func TestGetByMAC_WithNonExistingMAC(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 		ApplyMigration: true,
 	})
 	defer func() {
@@ -545,6 +669,107 @@ func TestGetByMAC_WithNonExistingMAC(t *testing.T) {
 	}
 }
 
+func TestListHardware(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	defer func() {
+		err := cl()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	labels := []map[string]string{
+		{"arch": "arm64", "rack": "r1"},
+		{"arch": "arm64", "rack": "r2"},
+		{"arch": "x86_64", "rack": "r1"},
+		nil,
+	}
+	for ii, l := range labels {
+		hw := readHardwareData("./testdata/hardware.json")
+		hw.Id = uuid.New().String()
+		hw.Network.Interfaces[0].Dhcp.Mac = strings.Replace(hw.Network.Interfaces[0].Dhcp.Mac, "00", fmt.Sprintf("0%d", ii), 1)
+		hw.Network.Interfaces[0].Dhcp.Ip.Address = fmt.Sprintf("192.168.2.%d", ii)
+		hw.Network.Interfaces[0].Dhcp.Hostname = fmt.Sprintf("server10%d", ii)
+		hw.Labels = l
+		if err := createHardware(ctx, tinkDB, hw); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		Name     string
+		Labels   map[string]string
+		Expected int
+	}{
+		{Name: "no-labels", Expected: 4},
+		{Name: "one-label", Labels: map[string]string{"arch": "arm64"}, Expected: 2},
+		{Name: "two-labels", Labels: map[string]string{"arch": "arm64", "rack": "r1"}, Expected: 1},
+		{Name: "no-match", Labels: map[string]string{"arch": "riscv"}, Expected: 0},
+	}
+	for _, s := range tests {
+		t.Run(s.Name, func(t *testing.T) {
+			count := 0
+			err := tinkDB.ListHardware(ctx, s.Labels, db.ListOptions{}, func(b []byte) error {
+				hw := &hardware.Hardware{}
+				if err := json.Unmarshal(b, hw); err != nil {
+					return err
+				}
+				for k, v := range s.Labels {
+					if hw.Labels[k] != v {
+						t.Errorf("expected label %s=%s, got %v", k, v, hw.Labels)
+					}
+				}
+				count++
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+			if count != s.Expected {
+				t.Errorf("expected %d hardware, got %d", s.Expected, count)
+			}
+		})
+	}
+}
+
+// expectHardwareCount returns an Expectation checking how many hardware are
+// stored
+func expectHardwareCount(expected int) func(*testing.T, []*hardware.Hardware, db.Database) {
+	return func(t *testing.T, input []*hardware.Hardware, tinkDB db.Database) {
+		count := 0
+		err := tinkDB.GetAll(context.Background(), func(b []byte) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		if count != expected {
+			t.Errorf("expected %d hardware stored in the database but we got %d", expected, count)
+		}
+	}
+}
+
+// expectConflict returns an ExpectedErr checking that the last hardware got
+// rejected because it reuses the given address of the first one
+func expectConflict(address string) func(*testing.T, error) {
+	return func(t *testing.T, err error) {
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if status.Code(err) != codes.AlreadyExists {
+			t.Errorf("expected code %s, got %s", codes.AlreadyExists, status.Code(err))
+		}
+		if !strings.Contains(err.Error(), address+" is already used by another hardware") {
+			t.Errorf("expected a conflict on %s, got: %s", address, err)
+		}
+	}
+}
+
 func readHardwareData(file string) *hardware.Hardware {
 	data, err := ioutil.ReadFile(file)
 	if err != nil {
@@ -558,12 +783,140 @@ func readHardwareData(file string) *hardware.Hardware {
 	return hw.Hardware
 }
 
-func createHardware(ctx context.Context, db *db.TinkDB, hw *hardware.Hardware) error {
+// createHardware stores the hardware and updates its version with the one
+// assigned by the database, so it can be compared with what gets read back.
+func TestInsertHardwareBatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	defer func() {
+		err := cl()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	encode := func(hws ...*hardware.Hardware) []string {
+		data := []string{}
+		for _, hw := range hws {
+			b, err := json.Marshal(hw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = append(data, string(b))
+		}
+		return data
+	}
+
+	first := readHardwareData("./testdata/hardware.json")
+	second := readHardwareData("./testdata/hardware_2.json")
+	if err := tinkDB.InsertHardwareBatch(ctx, encode(first, second)); err != nil {
+		t.Fatal(err)
+	}
+	expectHardwareCount(2)(t, nil, tinkDB)
+
+	// the second item reuses the MAC address of the first one, so none of
+	// them gets written
+	third := readHardwareData("./testdata/hardware.json")
+	third.Id = uuid.New().String()
+	third.Network.Interfaces[0].Dhcp.Mac = "08:00:27:00:00:99"
+	third.Network.Interfaces[0].Dhcp.Ip.Address = "192.168.1.99"
+	third.Network.Interfaces[0].Dhcp.Hostname = "server099"
+	fourth := readHardwareData("./testdata/hardware.json")
+	fourth.Id = uuid.New().String()
+	fourth.Network.Interfaces[0].Dhcp.Mac = "08:00:27:00:00:99"
+	err := tinkDB.InsertHardwareBatch(ctx, encode(third, fourth))
+	batchErr, ok := err.(*db.BatchError)
+	if !ok {
+		t.Fatalf("expected a batch error, got %v", err)
+	}
+	if batchErr.Index != 1 {
+		t.Errorf("expected the second item to fail, got %d", batchErr.Index)
+	}
+	if status.Code(batchErr.Err) != codes.AlreadyExists {
+		t.Errorf("expected code %s, got %s", codes.AlreadyExists, status.Code(batchErr.Err))
+	}
+	expectHardwareCount(2)(t, nil, tinkDB)
+}
+
+func TestHardwareHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	defer func() {
+		err := cl()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	hw := readHardwareData("./testdata/hardware.json")
+	if err := createHardware(db.WithActor(ctx, "alice"), tinkDB, hw); err != nil {
+		t.Fatal(err)
+	}
+	hw.Network.Interfaces[0].Netboot.AllowWorkflow = !hw.Network.Interfaces[0].Netboot.AllowWorkflow
+	hw.Labels = map[string]string{"rack": "r1"}
+	if err := createHardware(db.WithActor(ctx, "bob"), tinkDB, hw); err != nil {
+		t.Fatal(err)
+	}
+	if err := tinkDB.DeleteFromDB(ctx, hw.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	revs := []db.HardwareRevision{}
+	err := tinkDB.GetHardwareHistory(ctx, hw.Id, func(rev db.HardwareRevision) error {
+		revs = append(revs, rev)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revs))
+	}
+
+	events := []string{revs[0].EventType, revs[1].EventType, revs[2].EventType}
+	if d := cmp.Diff([]string{db.HardwareCreated, db.HardwareUpdated, db.HardwareDeleted}, events); d != "" {
+		t.Errorf("unexpected events: %s", d)
+	}
+	if revs[0].Actor != "alice" || revs[1].Actor != "bob" {
+		t.Errorf("unexpected actors %q and %q", revs[0].Actor, revs[1].Actor)
+	}
+	if revs[1].Version <= revs[0].Version {
+		t.Errorf("expected the version to grow, got %d then %d", revs[0].Version, revs[1].Version)
+	}
+
+	paths := []string{}
+	for _, c := range revs[1].Diff {
+		paths = append(paths, c.Path)
+	}
+	if d := cmp.Diff([]string{"labels", "network.interfaces[0].netboot.allow_workflow"}, paths); d != "" {
+		t.Errorf("unexpected diff: %s", d)
+	}
+	if len(revs[2].Diff) != 0 {
+		t.Errorf("expected no diff for a deletion, got %v", revs[2].Diff)
+	}
+}
+
+func createHardware(ctx context.Context, db db.Database, hw *hardware.Hardware) error {
 	data, err := json.Marshal(hw)
 	if err != nil {
 		return err
 	}
-	return db.InsertIntoDB(ctx, string(data))
+	if err := db.InsertIntoDB(ctx, string(data)); err != nil {
+		return err
+	}
+	stored, err := db.GetByID(ctx, hw.Id)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(stored), &struct {
+		Version *int64 `json:"version"`
+	}{Version: &hw.Version})
 }
 
 func hardwareComparer(in *hardware.Hardware, hw *hardware.Hardware) bool {
@@ -572,3 +925,125 @@ func hardwareComparer(in *hardware.Hardware, hw *hardware.Hardware) bool {
 		strings.EqualFold(in.Metadata, hw.Metadata) &&
 		strings.EqualFold(in.Network.Interfaces[0].Dhcp.Mac, hw.Network.Interfaces[0].Dhcp.Mac)
 }
+
+func TestSetHardwareState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	defer func() {
+		err := cl()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	stateOf := func(id string) hardware.State {
+		data, err := tinkDB.GetByID(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hw := &hardware.Hardware{}
+		if err := json.Unmarshal([]byte(data), hw); err != nil {
+			t.Fatal(err)
+		}
+		return hw.State
+	}
+
+	hw := readHardwareData("./testdata/hardware.json")
+	if err := createHardware(ctx, tinkDB, hw); err != nil {
+		t.Fatal(err)
+	}
+	if s := stateOf(hw.Id); s != hardware.State_STATE_AVAILABLE {
+		t.Fatalf("expected new hardware to be available, got %s", s)
+	}
+
+	available := []int32{int32(hardware.State_STATE_AVAILABLE)}
+	if _, err := tinkDB.SetHardwareState(ctx, hw.Id, available, int32(hardware.State_STATE_PROVISIONING)); err != nil {
+		t.Fatal(err)
+	}
+	// a push does not change the state
+	hw.Version = 2
+	hw.Labels = map[string]string{"rack": "r1"}
+	if err := createHardware(ctx, tinkDB, hw); err != nil {
+		t.Fatal(err)
+	}
+	if s := stateOf(hw.Id); s != hardware.State_STATE_PROVISIONING {
+		t.Fatalf("expected the hardware to be provisioning, got %s", s)
+	}
+
+	_, err := tinkDB.SetHardwareState(ctx, hw.Id, available, int32(hardware.State_STATE_PROVISIONING))
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected a FailedPrecondition error, got %v", err)
+	}
+	_, err = tinkDB.SetHardwareState(ctx, uuid.New().String(), nil, int32(hardware.State_STATE_BROKEN))
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected a NotFound error, got %v", err)
+	}
+}
+
+func TestGetHardwareChanges(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	defer func() {
+		err := cl()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	latest, err := tinkDB.LatestHardwareRevision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 0 {
+		t.Fatalf("expected no revision, got %d", latest)
+	}
+
+	hw := readHardwareData("./testdata/hardware.json")
+	if err := createHardware(ctx, tinkDB, hw); err != nil {
+		t.Fatal(err)
+	}
+	if err := tinkDB.DeleteFromDB(ctx, hw.Id); err != nil {
+		t.Fatal(err)
+	}
+	hw.Id = uuid.New().String()
+	hw.Version = 0
+	if err := createHardware(ctx, tinkDB, hw); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err = tinkDB.LatestHardwareRevision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revs := []db.HardwareRevision{}
+	collect := func(rev db.HardwareRevision) error {
+		revs = append(revs, rev)
+		return nil
+	}
+	if err := tinkDB.GetHardwareChanges(ctx, 0, 2, collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(revs))
+	}
+	if err := tinkDB.GetHardwareChanges(ctx, revs[1].Revision, 2, collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revs))
+	}
+
+	events := []string{revs[0].EventType, revs[1].EventType, revs[2].EventType}
+	if d := cmp.Diff([]string{db.HardwareCreated, db.HardwareDeleted, db.HardwareCreated}, events); d != "" {
+		t.Errorf("unexpected events: %s", d)
+	}
+	if revs[2].HardwareID != hw.Id || revs[2].Revision != latest {
+		t.Errorf("expected the last revision to be %d for %s, got %d for %s", latest, hw.Id, revs[2].Revision, revs[2].HardwareID)
+	}
+}
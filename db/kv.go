@@ -0,0 +1,250 @@
+package db
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// kvStore is the key/value store under EmbeddedDB. It follows the bbolt
+// API: the keys of a bucket are sorted bytewise, and a single write
+// transaction runs at a time, rolled back when fn returns an error.
+type kvStore interface {
+	Update(fn func(kvTx) error) error
+	View(fn func(kvTx) error) error
+	Close() error
+}
+
+type kvTx interface {
+	Bucket(name []byte) kvBucket
+}
+
+type kvBucket interface {
+	Get(key []byte) []byte
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	NextSequence() (uint64, error)
+	SetSequence(v uint64) error
+	ForEach(fn func(k, v []byte) error) error
+	Cursor() kvCursor
+}
+
+// kvCursor walks the keys of a bucket in order, it returns a nil key past
+// the last one
+type kvCursor interface {
+	Seek(prefix []byte) (k, v []byte)
+	Next() (k, v []byte)
+	Last() (k, v []byte)
+}
+
+// boltStore is a kvStore in a bbolt file
+type boltStore struct {
+	db *bolt.DB
+}
+
+func (s boltStore) Update(fn func(kvTx) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error { return fn(boltTx{tx}) })
+}
+
+func (s boltStore) View(fn func(kvTx) error) error {
+	return s.db.View(func(tx *bolt.Tx) error { return fn(boltTx{tx}) })
+}
+
+func (s boltStore) Close() error {
+	return s.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t boltTx) Bucket(name []byte) kvBucket {
+	return boltBucket{t.tx.Bucket(name)}
+}
+
+type boltBucket struct {
+	*bolt.Bucket
+}
+
+func (b boltBucket) Cursor() kvCursor {
+	return b.Bucket.Cursor()
+}
+
+// memoryStore is a kvStore kept in memory. A write transaction records how
+// to undo every change, and undoes them when it fails.
+type memoryStore struct {
+	lock    sync.RWMutex
+	buckets map[string]*memoryBucket
+}
+
+type memoryBucket struct {
+	rows map[string][]byte
+	seq  uint64
+}
+
+func newMemoryStore(buckets [][]byte) *memoryStore {
+	s := &memoryStore{buckets: map[string]*memoryBucket{}}
+	for _, name := range buckets {
+		s.buckets[string(name)] = &memoryBucket{rows: map[string][]byte{}}
+	}
+	return s
+}
+
+func (s *memoryStore) Update(fn func(kvTx) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	tx := &memoryTx{store: s, writable: true}
+	err := fn(tx)
+	if err != nil {
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+	}
+	return err
+}
+
+func (s *memoryStore) View(fn func(kvTx) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return fn(&memoryTx{store: s})
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+type memoryTx struct {
+	store    *memoryStore
+	writable bool
+	undo     []func()
+}
+
+func (t *memoryTx) Bucket(name []byte) kvBucket {
+	b, ok := t.store.buckets[string(name)]
+	if !ok {
+		return nil
+	}
+	return memoryTxBucket{tx: t, b: b}
+}
+
+// memoryTxBucket is a bucket of the memory store, as seen by a transaction
+type memoryTxBucket struct {
+	tx *memoryTx
+	b  *memoryBucket
+}
+
+var errMemoryTxNotWritable = errors.New("tx not writable")
+
+func (b memoryTxBucket) Get(key []byte) []byte {
+	return b.b.rows[string(key)]
+}
+
+func (b memoryTxBucket) Put(key, value []byte) error {
+	if !b.tx.writable {
+		return errMemoryTxNotWritable
+	}
+	k := string(key)
+	old, existed := b.b.rows[k]
+	b.tx.undo = append(b.tx.undo, func() {
+		if existed {
+			b.b.rows[k] = old
+		} else {
+			delete(b.b.rows, k)
+		}
+	})
+	b.b.rows[k] = append([]byte{}, value...)
+	return nil
+}
+
+func (b memoryTxBucket) Delete(key []byte) error {
+	if !b.tx.writable {
+		return errMemoryTxNotWritable
+	}
+	k := string(key)
+	old, existed := b.b.rows[k]
+	if !existed {
+		return nil
+	}
+	b.tx.undo = append(b.tx.undo, func() {
+		b.b.rows[k] = old
+	})
+	delete(b.b.rows, k)
+	return nil
+}
+
+func (b memoryTxBucket) NextSequence() (uint64, error) {
+	if !b.tx.writable {
+		return 0, errMemoryTxNotWritable
+	}
+	old := b.b.seq
+	b.tx.undo = append(b.tx.undo, func() {
+		b.b.seq = old
+	})
+	b.b.seq++
+	return b.b.seq, nil
+}
+
+func (b memoryTxBucket) SetSequence(v uint64) error {
+	if !b.tx.writable {
+		return errMemoryTxNotWritable
+	}
+	old := b.b.seq
+	b.tx.undo = append(b.tx.undo, func() {
+		b.b.seq = old
+	})
+	b.b.seq = v
+	return nil
+}
+
+func (b memoryTxBucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	for k, v := c.Seek(nil); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cursor walks the keys the bucket has when it is created
+func (b memoryTxBucket) Cursor() kvCursor {
+	keys := make([]string, 0, len(b.b.rows))
+	for k := range b.b.rows {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return &memoryCursor{rows: b.b.rows, keys: keys}
+}
+
+type memoryCursor struct {
+	rows map[string][]byte
+	keys []string
+	i    int
+}
+
+func (c *memoryCursor) Seek(prefix []byte) ([]byte, []byte) {
+	c.i = sort.SearchStrings(c.keys, string(prefix))
+	return c.current()
+}
+
+func (c *memoryCursor) Next() ([]byte, []byte) {
+	c.i++
+	return c.current()
+}
+
+func (c *memoryCursor) Last() ([]byte, []byte) {
+	c.i = len(c.keys) - 1
+	return c.current()
+}
+
+func (c *memoryCursor) current() ([]byte, []byte) {
+	if c.i < 0 || c.i >= len(c.keys) {
+		return nil, nil
+	}
+	k := c.keys[c.i]
+	return []byte(k), c.rows[k]
+}
@@ -0,0 +1,91 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tinkerbell/tink/db"
+)
+
+func TestMigrateDownAndTo(t *testing.T) {
+	ctx := context.Background()
+	dbCon, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewDatabaseRequest{ApplyMigration: true})
+	defer func() {
+		if err := cl(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	applied := func() []db.MigrationStatus {
+		t.Helper()
+		status, err := tinkDB.MigrationStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var applied []db.MigrationStatus
+		for _, s := range status {
+			if !s.AppliedAt.IsZero() {
+				applied = append(applied, s)
+			}
+		}
+		return applied
+	}
+	all := applied()
+	if pending, err := tinkDB.CheckRequiredMigrations(); err != nil || pending != 0 {
+		t.Fatalf("expected all the migrations to be applied, got %d pending: %v", pending, err)
+	}
+
+	n, err := tinkDB.MigrateDown(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := applied(); n != 2 || len(got) != len(all)-2 {
+		t.Fatalf("expected 2 migrations rolled back, got %d and %d applied", n, len(got))
+	}
+	if pending, _ := tinkDB.CheckRequiredMigrations(); pending != 2 {
+		t.Errorf("expected 2 pending migrations, got %d", pending)
+	}
+
+	// back up to the last one
+	last := all[len(all)-1].ID
+	if n, err := tinkDB.MigrateTo(last); err != nil || n != 2 {
+		t.Fatalf("expected 2 migrations applied, got %d: %v", n, err)
+	}
+	if n, err := tinkDB.MigrateTo(last); err != nil || n != 0 {
+		t.Errorf("expected no migration to apply, got %d: %v", n, err)
+	}
+
+	// rolling back stops at the migrations which can not be
+	var irreversible string
+	for _, s := range all {
+		if !s.Reversible {
+			irreversible = s.ID
+		}
+	}
+	if irreversible != "" {
+		if _, err := tinkDB.MigrateDown(len(all)); err == nil {
+			t.Errorf("expected an error rolling back %s", irreversible)
+		}
+		if got := applied(); len(got) != len(all) {
+			t.Errorf("expected nothing to be rolled back, got %d applied", len(got))
+		}
+	}
+
+	// a migration pending before the target and others applied after it
+	if _, err := dbCon.Exec(`DELETE FROM gorp_migrations WHERE id = $1`, all[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tinkDB.MigrateTo(all[1].ID); err == nil {
+		t.Error("expected an error migrating with pending migrations before the target and applied ones after it")
+	}
+	if got := applied(); len(got) != len(all)-1 {
+		t.Errorf("expected nothing to be migrated, got %d applied", len(got))
+	}
+	if _, err := dbCon.Exec(`INSERT INTO gorp_migrations (id, applied_at) VALUES ($1, $2)`, all[0].ID, all[0].AppliedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tinkDB.MigrateTo("unknown"); err == nil {
+		t.Error("expected an error migrating to an unknown migration")
+	}
+}
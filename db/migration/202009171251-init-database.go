@@ -80,5 +80,14 @@ CREATE TABLE IF NOT EXISTS workflow_data (
         , metadata JSONB
         , data JSONB
 );`},
+		Down: []string{`
+DROP TABLE IF EXISTS workflow_data;
+DROP TABLE IF EXISTS workflow_worker_map;
+DROP TABLE IF EXISTS workflow_event;
+DROP TABLE IF EXISTS workflow_state;
+DROP TABLE IF EXISTS workflow;
+DROP TABLE IF EXISTS template;
+DROP TABLE IF EXISTS hardware;
+`},
 	}
 }
@@ -115,6 +115,18 @@ DROP TRIGGER IF EXISTS workflow_event_trigger ON workflow;
 CREATE TRIGGER workflow_event_trigger
 AFTER INSERT OR UPDATE ON workflow
 FOR EACH ROW EXECUTE PROCEDURE insert_workflow_event();
+`},
+		Down: []string{`
+DROP TRIGGER IF EXISTS workflow_event_trigger ON workflow;
+DROP TRIGGER IF EXISTS template_event_trigger ON template;
+DROP TRIGGER IF EXISTS hardware_event_trigger ON hardware;
+DROP FUNCTION IF EXISTS insert_workflow_event();
+DROP FUNCTION IF EXISTS insert_template_event();
+DROP FUNCTION IF EXISTS insert_hardware_event();
+DROP TABLE IF EXISTS events CASCADE;
+DROP FUNCTION IF EXISTS events_notify_changes();
+DROP TYPE IF EXISTS event_type;
+DROP TYPE IF EXISTS resource_type;
 `},
 	}
 }
@@ -2,11 +2,16 @@ package migration
 
 import migrate "github.com/rubenv/sql-migrate"
 
+// Get202012041103 lets the templates share a name. Rolling it back fails
+// when some of them do.
 func Get202012041103() *migrate.Migration {
 	return &migrate.Migration{
 		Id: "202012041103-template-with-same-name-are-acceptable",
 		Up: []string{`
                 ALTER TABLE template DROP CONSTRAINT template_name_key;
                 `},
+		Down: []string{`
+                ALTER TABLE template ADD CONSTRAINT template_name_key UNIQUE (name);
+                `},
 	}
 }
@@ -8,5 +8,8 @@ func Get202012091055() *migrate.Migration {
 		Up: []string{`
                 CREATE UNIQUE INDEX uidx_template_name ON template (name) WHERE deleted_at IS NULL;
                 `},
+		Down: []string{`
+                DROP INDEX IF EXISTS uidx_template_name;
+                `},
 	}
 }
@@ -12,6 +12,7 @@ import migrate "github.com/rubenv/sql-migrate"
 //
 // The migration changes the primary key on events table from 'created_at' to `id`,
 // which will always be unique for each event generated at any point in time.
+// Rolling it back fails when some events share their creation time.
 func Get2020121691335() *migrate.Migration {
 	return &migrate.Migration{
 		Id: "2020121691335-update-events-primary-key",
@@ -20,5 +21,10 @@ func Get2020121691335() *migrate.Migration {
 			ALTER TABLE events ADD PRIMARY KEY (id);
 			CREATE INDEX IF NOT EXISTS idx_events_created_at ON events (created_at);
 		`},
+		Down: []string{`
+			DROP INDEX IF EXISTS idx_events_created_at;
+			ALTER TABLE events DROP CONSTRAINT events_pkey;
+			ALTER TABLE events ADD PRIMARY KEY (created_at);
+		`},
 	}
 }
@@ -9,6 +9,8 @@ import migrate "github.com/rubenv/sql-migrate"
 // The event system in place relies on triggers.
 // It causes many problems because of its 8k characters limitation.
 // CAPT is suffering from this limitation.
+//
+// It can not be rolled back, the events it dropped are gone.
 func Get2021032610300() *migrate.Migration {
 	return &migrate.Migration{
 		Id: "2021032610300-drop-events-system",
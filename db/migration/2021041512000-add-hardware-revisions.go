@@ -0,0 +1,31 @@
+package migration
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Get2021041512000 adds the hardware_revision table.
+//
+// Every write to the hardware table appends a row with the data as it is
+// after the write, the fields that changed, who did it and when. Rows are
+// never updated, they are the audit trail of the hardware.
+func Get2021041512000() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "2021041512000-add-hardware-revisions",
+		Up: []string{`
+CREATE TABLE IF NOT EXISTS hardware_revision (
+	revision BIGSERIAL PRIMARY KEY
+	, hardware_id UUID NOT NULL
+	, version BIGINT NOT NULL
+	, event_type VARCHAR(20) NOT NULL
+	, actor VARCHAR(200) NOT NULL DEFAULT ''
+	, created_at TIMESTAMPTZ NOT NULL
+	, data JSONB NOT NULL
+	, diff JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_hardware_revision_hardware_id ON hardware_revision (hardware_id, revision);
+`},
+		Down: []string{`
+DROP TABLE IF EXISTS hardware_revision;
+`},
+	}
+}
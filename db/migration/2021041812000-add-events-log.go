@@ -0,0 +1,32 @@
+package migration
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Get2021041812000 adds the events table, the log of the changes made to
+// hardware, templates and workflows.
+//
+// It replaces the trigger based events system dropped by
+// 2021032610300-drop-events-system. The rows are written by tink-server in
+// the transaction making the change and they carry only the ID of the
+// resource, so there is no payload to limit: who reads an event fetches
+// the resource when it needs it.
+func Get2021041812000() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "2021041812000-add-events-log",
+		Up: []string{`
+CREATE TABLE IF NOT EXISTS events (
+	id BIGSERIAL PRIMARY KEY
+	, resource_id UUID NOT NULL
+	, resource_type VARCHAR(20) NOT NULL
+	, event_type VARCHAR(20) NOT NULL
+	, actor VARCHAR(200) NOT NULL DEFAULT ''
+	, created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_resource ON events (resource_type, resource_id);
+`},
+		Down: []string{`
+DROP TABLE IF EXISTS events;
+`},
+	}
+}
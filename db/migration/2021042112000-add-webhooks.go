@@ -0,0 +1,37 @@
+package migration
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Get2021042112000 adds the webhook subscriptions and the record of the
+// events that could not be delivered to them.
+func Get2021042112000() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "2021042112000-add-webhooks",
+		Up: []string{`
+CREATE TABLE IF NOT EXISTS webhook (
+	id UUID UNIQUE NOT NULL
+	, url TEXT NOT NULL
+	, secret TEXT NOT NULL
+	, event_types TEXT[] NOT NULL DEFAULT '{}'
+	, created_at TIMESTAMPTZ NOT NULL
+	, deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS webhook_failure (
+	id UUID UNIQUE NOT NULL
+	, webhook_id UUID NOT NULL
+	, event_type VARCHAR(50) NOT NULL
+	, payload TEXT NOT NULL
+	, attempts INT NOT NULL
+	, error TEXT NOT NULL
+	, created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_failure_webhook ON webhook_failure (webhook_id, created_at);
+`},
+		Down: []string{`
+DROP TABLE IF EXISTS webhook_failure;
+DROP TABLE IF EXISTS webhook;
+`},
+	}
+}
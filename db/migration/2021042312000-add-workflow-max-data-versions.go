@@ -0,0 +1,17 @@
+package migration
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Get2021042312000 records how many versions of its data every workflow
+// keeps, as its template says. Zero applies the setting of the server.
+func Get2021042312000() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "2021042312000-add-workflow-max-data-versions",
+		Up: []string{`
+ALTER TABLE workflow ADD COLUMN IF NOT EXISTS max_data_versions INT NOT NULL DEFAULT 0;
+`},
+		Down: []string{`
+ALTER TABLE workflow DROP COLUMN IF EXISTS max_data_versions;
+`},
+	}
+}
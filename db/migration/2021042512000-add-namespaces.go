@@ -0,0 +1,36 @@
+package migration
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Get2021042512000 puts the hardware, the templates and the workflows in
+// namespaces, the existing ones in the default namespace. The namespace of
+// the hardware is part of its data, like its version and its state.
+//
+// The template names are unique within a namespace.
+func Get2021042512000() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "2021042512000-add-namespaces",
+		Up: []string{`
+ALTER TABLE template ADD COLUMN IF NOT EXISTS namespace VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE workflow ADD COLUMN IF NOT EXISTS namespace VARCHAR(63) NOT NULL DEFAULT 'default';
+
+UPDATE hardware SET data = jsonb_set(data, '{namespace}', '"default"') WHERE NOT data ? 'namespace';
+UPDATE hardware_revision SET data = jsonb_set(data, '{namespace}', '"default"') WHERE NOT data ? 'namespace';
+
+DROP INDEX IF EXISTS uidx_template_name;
+CREATE UNIQUE INDEX uidx_template_name ON template (namespace, name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_workflow_namespace ON workflow (namespace);
+`},
+		Down: []string{`
+DROP INDEX IF EXISTS idx_workflow_namespace;
+DROP INDEX IF EXISTS uidx_template_name;
+CREATE UNIQUE INDEX uidx_template_name ON template (name) WHERE deleted_at IS NULL;
+
+UPDATE hardware_revision SET data = data - 'namespace';
+UPDATE hardware SET data = data - 'namespace';
+
+ALTER TABLE workflow DROP COLUMN IF EXISTS namespace;
+ALTER TABLE template DROP COLUMN IF EXISTS namespace;
+`},
+	}
+}
@@ -0,0 +1,23 @@
+package migration
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Get2021042612000 numbers the workflow events, the watchers resume after
+// the last one they read
+func Get2021042612000() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "2021042612000-add-workflow-event-ids",
+		Up: []string{`
+ALTER TABLE workflow_event ADD COLUMN IF NOT EXISTS id BIGSERIAL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workflow_event_id ON workflow_event (id);
+CREATE INDEX IF NOT EXISTS idx_workflow_event_workflow_id ON workflow_event (workflow_id, created_at, id);
+`},
+		Down: []string{`
+DROP INDEX IF EXISTS idx_workflow_event_workflow_id;
+DROP INDEX IF EXISTS idx_workflow_event_id;
+
+ALTER TABLE workflow_event DROP COLUMN IF EXISTS id;
+`},
+	}
+}
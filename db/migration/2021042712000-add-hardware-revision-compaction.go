@@ -0,0 +1,26 @@
+package migration
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Get2021042712000 keeps, for every hardware purged, the last revision of
+// its history removed by the purge: the watchers of that hardware resuming
+// from an older revision missed changes
+func Get2021042712000() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "2021042712000-add-hardware-revision-compaction",
+		Up: []string{`
+CREATE TABLE IF NOT EXISTS hardware_revision_compaction (
+	hardware_id UUID PRIMARY KEY
+	, revision BIGINT NOT NULL
+	, version BIGINT NOT NULL
+	, created_at TIMESTAMPTZ NOT NULL
+	, data JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_hardware_revision_compaction_revision ON hardware_revision_compaction (revision);
+`},
+		Down: []string{`
+DROP TABLE IF EXISTS hardware_revision_compaction;
+`},
+	}
+}
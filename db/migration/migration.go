@@ -12,6 +12,13 @@ var migrations = []func() *migrate.Migration{
 	Get202012091055,
 	Get2020121691335,
 	Get2021032610300,
+	Get2021041512000,
+	Get2021041812000,
+	Get2021042112000,
+	Get2021042312000,
+	Get2021042512000,
+	Get2021042612000,
+	Get2021042712000,
 }
 
 func GetMigrations() *migrate.MemoryMigrationSource {
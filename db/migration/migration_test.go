@@ -72,3 +72,13 @@ func TestGetMigrations(t *testing.T) {
 	m := GetMigrations()
 	assert.Len(t, m.Migrations, len(migrations))
 }
+
+// Every migration can be rolled back, but the ones dropping data for good
+func TestMigrationsAreReversible(t *testing.T) {
+	irreversible := map[string]bool{
+		"2021032610300-drop-events-system": true,
+	}
+	for _, m := range GetMigrations().Migrations {
+		assert.Equal(t, !irreversible[m.Id], len(m.Down) > 0, "unexpected down steps of migration %s", m.Id)
+	}
+}
@@ -0,0 +1,23 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/tinkerbell/tink/db"
+)
+
+// Export : read all the rows of the database
+func (d DB) Export(ctx context.Context) (db.Backup, error) {
+	if d.ExportFunc == nil {
+		return db.Backup{}, nil
+	}
+	return d.ExportFunc(ctx)
+}
+
+// Import : restore a backup in an empty database
+func (d DB) Import(ctx context.Context, b db.Backup) error {
+	if d.ImportFunc == nil {
+		return nil
+	}
+	return d.ImportFunc(ctx, b)
+}
@@ -0,0 +1,23 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/tinkerbell/tink/db"
+)
+
+// GetEvents : get the events recorded after since
+func (d DB) GetEvents(ctx context.Context, filter db.EventFilter, since int64, limit int, fn func(db.Event) error) error {
+	if d.GetEventsFunc == nil {
+		return nil
+	}
+	return d.GetEventsFunc(ctx, filter, since, limit, fn)
+}
+
+// LatestEventID : get the id of the last recorded event
+func (d DB) LatestEventID(ctx context.Context) (int64, error) {
+	if d.LatestEventIDFunc == nil {
+		return 0, nil
+	}
+	return d.LatestEventIDFunc(ctx)
+}
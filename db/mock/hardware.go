@@ -2,6 +2,8 @@ package mock
 
 import (
 	"context"
+
+	"github.com/tinkerbell/tink/db"
 )
 
 // DeleteFromDB : delete data from hardware table
@@ -11,25 +13,73 @@ func (d DB) DeleteFromDB(ctx context.Context, id string) error {
 
 // InsertIntoDB : insert data into hardware table
 func (d DB) InsertIntoDB(ctx context.Context, data string) error {
-	return nil
+	return d.InsertIntoDBFunc(ctx, data)
+}
+
+// InsertHardwareBatch : insert many hardware in a single transaction
+func (d DB) InsertHardwareBatch(ctx context.Context, data []string) error {
+	return d.InsertHardwareBatchFunc(ctx, data)
 }
 
 // GetByMAC : get data by machine mac
 func (d DB) GetByMAC(ctx context.Context, mac string) (string, error) {
-	return "", nil
+	if d.GetByMACFunc == nil {
+		return "", nil
+	}
+	return d.GetByMACFunc(ctx, mac)
 }
 
 // GetByIP : get data by machine ip
 func (d DB) GetByIP(ctx context.Context, ip string) (string, error) {
-	return "", nil
+	if d.GetByIPFunc == nil {
+		return "", nil
+	}
+	return d.GetByIPFunc(ctx, ip)
 }
 
 // GetByID : get data by machine id
 func (d DB) GetByID(ctx context.Context, id string) (string, error) {
-	return "", nil
+	return d.GetByIDFunc(ctx, id)
 }
 
 // GetAll : get data for all machine
-func (d DB) GetAll(fn func([]byte) error) error {
+func (d DB) GetAll(ctx context.Context, fn func([]byte) error) error {
 	return nil
 }
+
+// ListHardware : get data for the machines having all the given labels
+func (d DB) ListHardware(ctx context.Context, labels map[string]string, opts db.ListOptions, fn func([]byte) error) error {
+	return d.ListHardwareFunc(ctx, labels, opts, fn)
+}
+
+// GetHardwareHistory : get the revisions of a machine
+func (d DB) GetHardwareHistory(ctx context.Context, id string, fn func(db.HardwareRevision) error) error {
+	if d.GetHardwareHistoryFunc == nil {
+		return nil
+	}
+	return d.GetHardwareHistoryFunc(ctx, id, fn)
+}
+
+// GetHardwareChanges : get the revisions of any machine made after since
+func (d DB) GetHardwareChanges(ctx context.Context, since int64, limit int, fn func(db.HardwareRevision) error) error {
+	if d.GetHardwareChangesFunc == nil {
+		return nil
+	}
+	return d.GetHardwareChangesFunc(ctx, since, limit, fn)
+}
+
+// LatestHardwareRevision : get the revision of the last hardware change
+func (d DB) LatestHardwareRevision(ctx context.Context) (int64, error) {
+	if d.LatestHardwareRevisionFunc == nil {
+		return 0, nil
+	}
+	return d.LatestHardwareRevisionFunc(ctx)
+}
+
+// SetHardwareState : move a machine to a new lifecycle state
+func (d DB) SetHardwareState(ctx context.Context, id string, from []int32, to int32) (string, error) {
+	if d.SetHardwareStateFunc == nil {
+		return "", nil
+	}
+	return d.SetHardwareStateFunc(ctx, id, from, to)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/uuid"
 	"github.com/tinkerbell/tink/db"
 	tb "github.com/tinkerbell/tink/protos/template"
@@ -12,19 +13,50 @@ import (
 
 // DB is the mocked implementation of Database interface
 type DB struct {
+	// hardware
+	InsertIntoDBFunc           func(ctx context.Context, data string) error
+	InsertHardwareBatchFunc    func(ctx context.Context, data []string) error
+	GetByMACFunc               func(ctx context.Context, mac string) (string, error)
+	GetByIPFunc                func(ctx context.Context, ip string) (string, error)
+	GetByIDFunc                func(ctx context.Context, id string) (string, error)
+	ListHardwareFunc           func(ctx context.Context, labels map[string]string, opts db.ListOptions, fn func([]byte) error) error
+	GetHardwareHistoryFunc     func(ctx context.Context, id string, fn func(db.HardwareRevision) error) error
+	GetHardwareChangesFunc     func(ctx context.Context, since int64, limit int, fn func(db.HardwareRevision) error) error
+	LatestHardwareRevisionFunc func(ctx context.Context) (int64, error)
+	SetHardwareStateFunc       func(ctx context.Context, id string, from []int32, to int32) (string, error)
 	// workflow
 	CreateWorkflowFunc               func(ctx context.Context, wf db.Workflow, data string, id uuid.UUID) error
+	CreateWorkflowsFunc              func(ctx context.Context, wfs []db.Workflow, data []string) error
 	GetWorkflowFunc                  func(ctx context.Context, id string) (db.Workflow, error)
 	GetfromWfDataTableFunc           func(ctx context.Context, req *pb.GetWorkflowDataRequest) ([]byte, error)
 	InsertIntoWfDataTableFunc        func(ctx context.Context, req *pb.UpdateWorkflowDataRequest) error
 	GetWorkflowMetadataFunc          func(ctx context.Context, req *pb.GetWorkflowDataRequest) ([]byte, error)
 	GetWorkflowDataVersionFunc       func(ctx context.Context, workflowID string) (int32, error)
-	GetWorkflowsForWorkerFunc        func(id string) ([]string, error)
+	GetWorkflowDataRevisionFunc      func(ctx context.Context, workflowID string, version int32) (db.WorkflowDataRevision, error)
+	GetWorkflowsForWorkerFunc        func(ctx context.Context, id string) ([]string, error)
 	GetWorkflowContextsFunc          func(ctx context.Context, wfID string) (*pb.WorkflowContext, error)
 	GetWorkflowActionsFunc           func(ctx context.Context, wfID string) (*pb.WorkflowActionList, error)
 	UpdateWorkflowStateFunc          func(ctx context.Context, wfContext *pb.WorkflowContext) error
 	InsertIntoWorkflowEventTableFunc func(ctx context.Context, wfEvent *pb.WorkflowActionStatus, time time.Time) error
+	ListWorkflowsFunc                func(ctx context.Context, filter db.WorkflowFilter, opts db.ListOptions, fn func(wf db.Workflow) error) error
+	ShowWorkflowEventsFunc           func(ctx context.Context, wfID string, fn func(wfs *pb.WorkflowActionStatus) error) error
+	GetWorkflowEventsFromFunc        func(ctx context.Context, wfID string, from int64, fn func(id int64, wfs *pb.WorkflowActionStatus) error) error
 	// template
-	TemplateDB      map[string]interface{}
-	GetTemplateFunc func(ctx context.Context, fields map[string]string, deleted bool) (*tb.WorkflowTemplate, error)
+	TemplateDB        map[string]interface{}
+	GetTemplateFunc   func(ctx context.Context, fields map[string]string, deleted bool) (*tb.WorkflowTemplate, error)
+	ListTemplatesFunc func(ctx context.Context, in string, opts db.ListOptions, fn func(id, n string, in, del *timestamp.Timestamp) error) error
+	// events
+	GetEventsFunc     func(ctx context.Context, filter db.EventFilter, since int64, limit int, fn func(db.Event) error) error
+	LatestEventIDFunc func(ctx context.Context) (int64, error)
+	// webhooks
+	CreateWebhookFunc        func(ctx context.Context, wh db.Webhook) error
+	DeleteWebhookFunc        func(ctx context.Context, id string) error
+	ListWebhooksFunc         func(ctx context.Context, fn func(db.Webhook) error) error
+	InsertWebhookFailureFunc func(ctx context.Context, f db.WebhookFailure) error
+	ListWebhookFailuresFunc  func(ctx context.Context, webhookID string, fn func(db.WebhookFailure) error) error
+	// retention
+	PurgeFunc func(ctx context.Context, before time.Time, dryRun bool) (db.PurgeReport, error)
+	// backup
+	ExportFunc func(ctx context.Context) (db.Backup, error)
+	ImportFunc func(ctx context.Context, b db.Backup) error
 }
@@ -0,0 +1,16 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/tinkerbell/tink/db"
+)
+
+// Purge : remove the rows deleted or finished before the given time
+func (d DB) Purge(ctx context.Context, before time.Time, dryRun bool) (db.PurgeReport, error) {
+	if d.PurgeFunc == nil {
+		return db.PurgeReport{}, nil
+	}
+	return d.PurgeFunc(ctx, before, dryRun)
+}
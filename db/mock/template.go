@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/uuid"
+	"github.com/tinkerbell/tink/db"
 	tb "github.com/tinkerbell/tink/protos/template"
 )
 
@@ -56,8 +57,11 @@ func (d DB) DeleteTemplate(ctx context.Context, name string) error {
 }
 
 // ListTemplates returns all saved templates
-func (d DB) ListTemplates(in string, fn func(id, n string, in, del *timestamp.Timestamp) error) error {
-	return nil
+func (d DB) ListTemplates(ctx context.Context, in string, opts db.ListOptions, fn func(id, n string, in, del *timestamp.Timestamp) error) error {
+	if d.ListTemplatesFunc == nil {
+		return nil
+	}
+	return d.ListTemplatesFunc(ctx, in, opts, fn)
 }
 
 // UpdateTemplate update a given template
@@ -0,0 +1,47 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/tinkerbell/tink/db"
+)
+
+// CreateWebhook : store a webhook subscription
+func (d DB) CreateWebhook(ctx context.Context, wh db.Webhook) error {
+	if d.CreateWebhookFunc == nil {
+		return nil
+	}
+	return d.CreateWebhookFunc(ctx, wh)
+}
+
+// DeleteWebhook : remove a webhook subscription
+func (d DB) DeleteWebhook(ctx context.Context, id string) error {
+	if d.DeleteWebhookFunc == nil {
+		return nil
+	}
+	return d.DeleteWebhookFunc(ctx, id)
+}
+
+// ListWebhooks : get the webhook subscriptions
+func (d DB) ListWebhooks(ctx context.Context, fn func(db.Webhook) error) error {
+	if d.ListWebhooksFunc == nil {
+		return nil
+	}
+	return d.ListWebhooksFunc(ctx, fn)
+}
+
+// InsertWebhookFailure : record an event that could not be delivered
+func (d DB) InsertWebhookFailure(ctx context.Context, f db.WebhookFailure) error {
+	if d.InsertWebhookFailureFunc == nil {
+		return nil
+	}
+	return d.InsertWebhookFailureFunc(ctx, f)
+}
+
+// ListWebhookFailures : get the events that could not be delivered
+func (d DB) ListWebhookFailures(ctx context.Context, webhookID string, fn func(db.WebhookFailure) error) error {
+	if d.ListWebhookFailuresFunc == nil {
+		return nil
+	}
+	return d.ListWebhookFailuresFunc(ctx, webhookID, fn)
+}
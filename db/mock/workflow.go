@@ -14,6 +14,11 @@ func (d DB) CreateWorkflow(ctx context.Context, wf db.Workflow, data string, id
 	return d.CreateWorkflowFunc(ctx, wf, data, id)
 }
 
+// CreateWorkflows creates all the given workflows in a single transaction
+func (d DB) CreateWorkflows(ctx context.Context, wfs []db.Workflow, data []string) error {
+	return d.CreateWorkflowsFunc(ctx, wfs, data)
+}
+
 // InsertIntoWfDataTable : Insert ephemeral data in workflow_data table
 func (d DB) InsertIntoWfDataTable(ctx context.Context, req *pb.UpdateWorkflowDataRequest) error {
 	return d.InsertIntoWfDataTableFunc(ctx, req)
@@ -34,13 +39,21 @@ func (d DB) GetWorkflowDataVersion(ctx context.Context, workflowID string) (int3
 	return d.GetWorkflowDataVersionFunc(ctx, workflowID)
 }
 
+// GetWorkflowDataRevision returns a version of the data of a workflow with its metadata
+func (d DB) GetWorkflowDataRevision(ctx context.Context, workflowID string, version int32) (db.WorkflowDataRevision, error) {
+	return d.GetWorkflowDataRevisionFunc(ctx, workflowID, version)
+}
+
 // GetWorkflowsForWorker : returns the list of workflows for a particular worker
-func (d DB) GetWorkflowsForWorker(id string) ([]string, error) {
-	return d.GetWorkflowsForWorkerFunc(id)
+func (d DB) GetWorkflowsForWorker(ctx context.Context, id string) ([]string, error) {
+	return d.GetWorkflowsForWorkerFunc(ctx, id)
 }
 
 // GetWorkflow returns a workflow
 func (d DB) GetWorkflow(ctx context.Context, id string) (db.Workflow, error) {
+	if d.GetWorkflowFunc == nil {
+		return db.Workflow{}, nil
+	}
 	return d.GetWorkflowFunc(ctx, id)
 }
 
@@ -50,8 +63,11 @@ func (d DB) DeleteWorkflow(ctx context.Context, id string, state int32) error {
 }
 
 // ListWorkflows returns all workflows
-func (d DB) ListWorkflows(fn func(wf db.Workflow) error) error {
-	return nil
+func (d DB) ListWorkflows(ctx context.Context, filter db.WorkflowFilter, opts db.ListOptions, fn func(wf db.Workflow) error) error {
+	if d.ListWorkflowsFunc == nil {
+		return nil
+	}
+	return d.ListWorkflowsFunc(ctx, filter, opts, fn)
 }
 
 // UpdateWorkflow updates a given workflow
@@ -80,6 +96,17 @@ func (d DB) InsertIntoWorkflowEventTable(ctx context.Context, wfEvent *pb.Workfl
 }
 
 // ShowWorkflowEvents returns all workflows
-func (d DB) ShowWorkflowEvents(wfID string, fn func(wfs *pb.WorkflowActionStatus) error) error {
-	return nil
+func (d DB) ShowWorkflowEvents(ctx context.Context, wfID string, fn func(wfs *pb.WorkflowActionStatus) error) error {
+	if d.ShowWorkflowEventsFunc == nil {
+		return nil
+	}
+	return d.ShowWorkflowEventsFunc(ctx, wfID, fn)
+}
+
+// GetWorkflowEventsFrom returns the events of a workflow from the given one
+func (d DB) GetWorkflowEventsFrom(ctx context.Context, wfID string, from int64, fn func(id int64, wfs *pb.WorkflowActionStatus) error) error {
+	if d.GetWorkflowEventsFromFunc == nil {
+		return nil
+	}
+	return d.GetWorkflowEventsFromFunc(ctx, wfID, from, fn)
 }
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultNamespace is the namespace of the resources created by a request
+// without a namespace, and of the ones created before the namespaces
+const DefaultNamespace = "default"
+
+// namespacePattern is what a namespace looks like, a DNS label
+var namespacePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// ValidateNamespace returns an InvalidArgument error when ns is not a valid
+// namespace: lower case letters, digits and dashes, at most 63 of them
+func ValidateNamespace(ns string) error {
+	if !namespacePattern.MatchString(ns) {
+		return status.Errorf(codes.InvalidArgument, "invalid namespace %q, it has to be at most 63 lower case letters, digits and dashes", ns)
+	}
+	return nil
+}
+
+type namespaceKey struct{}
+
+// WithNamespace returns a context restricting the calls to the database to
+// the hardware, templates and workflows of a namespace. The others look like
+// they do not exist, and the resources get created in it.
+func WithNamespace(ctx context.Context, ns string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, ns)
+}
+
+// NamespaceFromContext returns the namespace set with WithNamespace, empty
+// when the calls are not restricted to one
+func NamespaceFromContext(ctx context.Context) string {
+	ns, _ := ctx.Value(namespaceKey{}).(string)
+	return ns
+}
+
+// namespaceOrDefault returns the namespace of ctx, the default one when it
+// has none. The resources get created in it, and the names get looked up in
+// it since they are only unique within a namespace.
+func namespaceOrDefault(ctx context.Context) string {
+	if ns := NamespaceFromContext(ctx); ns != "" {
+		return ns
+	}
+	return DefaultNamespace
+}
+
+// normalizeNamespace returns the namespace of a resource stored with ns,
+// the default one for the resources stored before the namespaces
+func normalizeNamespace(ns string) string {
+	if ns == "" {
+		return DefaultNamespace
+	}
+	return ns
+}
+
+// inNamespace tells whether a resource of namespace ns is visible with ctx
+func inNamespace(ctx context.Context, ns string) bool {
+	scope := NamespaceFromContext(ctx)
+	return scope == "" || scope == normalizeNamespace(ns)
+}
+
+// namespaceMatch returns the JSON document contained in the data of the
+// hardware visible with ctx, an empty document when ctx has no namespace
+func namespaceMatch(ctx context.Context) []byte {
+	ns := NamespaceFromContext(ctx)
+	if ns == "" {
+		return []byte(`{}`)
+	}
+	match, _ := json.Marshal(map[string]string{"namespace": ns})
+	return match
+}
+
+// hardwareNamespace returns the namespace in the hardware data, the default
+// one when it has none
+func hardwareNamespace(data []byte) string {
+	var hw struct {
+		Namespace string `json:"namespace"`
+	}
+	_ = json.Unmarshal(data, &hw)
+	return normalizeNamespace(hw.Namespace)
+}
+
+// setHardwareNamespace sets the namespace of the hardware data written over
+// old, the stored data when the hardware exists. The namespace is the one of
+// the data, otherwise the one of ctx, otherwise the stored one or the
+// default one. The hardware can not move to another namespace.
+func setHardwareNamespace(ctx context.Context, data string, old []byte) (string, error) {
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		return "", errors.Wrap(err, "invalid hardware data")
+	}
+	var ns string
+	if v, ok := doc["namespace"]; ok {
+		if err := json.Unmarshal(v, &ns); err != nil {
+			return "", errors.Wrap(err, "invalid hardware namespace")
+		}
+	}
+	scope := NamespaceFromContext(ctx)
+	switch {
+	case ns == "" && scope != "":
+		ns = scope
+	case ns == "" && old != nil:
+		ns = hardwareNamespace(old)
+	case ns == "":
+		ns = DefaultNamespace
+	case scope != "" && ns != scope:
+		return "", status.Errorf(codes.InvalidArgument, "the hardware is in namespace %s, the request in %s", ns, scope)
+	}
+	if err := ValidateNamespace(ns); err != nil {
+		return "", err
+	}
+	if old != nil && hardwareNamespace(old) != ns {
+		return "", status.Error(codes.AlreadyExists, "the id is already used by another hardware")
+	}
+
+	doc["namespace"], _ = json.Marshal(ns)
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// workflowNamespace returns the namespace a workflow gets created in: its
+// own, otherwise the one of ctx or the default one
+func workflowNamespace(ctx context.Context, wf Workflow) (string, error) {
+	ns := wf.Namespace
+	if ns == "" {
+		ns = namespaceOrDefault(ctx)
+	}
+	if !inNamespace(ctx, ns) {
+		return "", status.Errorf(codes.InvalidArgument, "the workflow is in namespace %s, the request in %s", ns, NamespaceFromContext(ctx))
+	}
+	return ns, ValidateNamespace(ns)
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/packethost/pkg/log"
+)
+
+const (
+	// replicaCheckInterval is how often the lag of the replica is measured
+	replicaCheckInterval = time.Second
+	// replicaCheckTimeout bounds the measure of the lag
+	replicaCheckTimeout = time.Second
+)
+
+// replica is a read replica of the database. The list and get methods read
+// from it, unless it lags too far behind the primary or can not be reached.
+// The lag is measured in the background, the reads do not wait for it.
+type replica struct {
+	instance *sql.DB
+	// primary is the database the replica follows
+	primary *sql.DB
+	// maxLag is how far behind the primary the replica can be, zero means
+	// no bound
+	maxLag time.Duration
+	logger log.Logger
+	// lag measures how far behind the primary the replica is, in seconds,
+	// measureReplicaLag unless a test replaces it
+	lag func(ctx context.Context, primary, replica *sql.DB) (sql.NullFloat64, error)
+
+	// usable is 1 when the last measure found the replica usable
+	usable int32
+	// checks counts the measures of the lag
+	checks int32
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// SetReplica makes the list and get methods read from a replica of the
+// database, while it is no more than maxLag behind the primary: GetByMAC,
+// GetByIP, GetByID, GetAll, ListHardware, GetHardwareHistory, GetTemplate,
+// ListTemplates, GetWorkflow, ListWorkflows, ShowWorkflowEvents and
+// GetWorkflowEventsFrom. The others keep using the primary, the workers see
+// their own writes. Zero maxLag does not bound the lag. The replica belongs
+// to t from then on, it gets closed with t or when another one is set.
+func (t *TinkDB) SetReplica(db *sql.DB, maxLag time.Duration) {
+	t.setReplica(db, maxLag, measureReplicaLag)
+}
+
+// setReplica is SetReplica with the lag measured by lag
+func (t *TinkDB) setReplica(db *sql.DB, maxLag time.Duration, lag func(ctx context.Context, primary, replica *sql.DB) (sql.NullFloat64, error)) {
+	if t.replica != nil {
+		t.replica.stop()
+		if err := t.replica.instance.Close(); err != nil {
+			t.logger.Error(err)
+		}
+	}
+	r := &replica{instance: db, primary: t.instance, maxLag: maxLag, logger: t.logger, lag: lag, done: make(chan struct{})}
+	t.replica = r
+	go r.run()
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a context making the calls to the database read from
+// the primary, for a caller which has to see its own writes
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// reader returns the database the read-only methods use: the replica when
+// there is one and it is usable, the primary otherwise
+func (t TinkDB) reader(ctx context.Context) *sql.DB {
+	if t.replica == nil {
+		return t.instance
+	}
+	if primary, _ := ctx.Value(primaryKey{}).(bool); primary {
+		return t.instance
+	}
+	if atomic.LoadInt32(&t.replica.usable) == 0 {
+		return t.instance
+	}
+	return t.replica.instance
+}
+
+// run measures the lag of the replica every replicaCheckInterval, until the
+// replica is stopped
+func (r *replica) run() {
+	ticker := time.NewTicker(replicaCheckInterval)
+	defer ticker.Stop()
+	for {
+		r.check()
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stop ends the measures of the lag
+func (r *replica) stop() {
+	r.stopOnce.Do(func() {
+		close(r.done)
+	})
+}
+
+// measureReplicaLag returns how far behind primary the replica is. A replica
+// which replayed everything the primary wrote up to now is up to date,
+// however old its last transaction is. Otherwise the lag is the age of the
+// last transaction it replayed, which keeps growing when it is cut off from
+// the primary.
+func measureReplicaLag(ctx context.Context, primary, replica *sql.DB) (sql.NullFloat64, error) {
+	var lag sql.NullFloat64
+	var lsn string
+	if err := primary.QueryRowContext(ctx, "SELECT pg_current_wal_lsn()").Scan(&lsn); err != nil {
+		return lag, err
+	}
+	err := replica.QueryRowContext(ctx, `
+	SELECT
+		CASE
+			WHEN NOT pg_is_in_recovery() OR pg_last_wal_replay_lsn() >= $1::pg_lsn THEN 0
+			ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+		END;
+	`, lsn).Scan(&lag)
+	return lag, err
+}
+
+// check measures whether the replica is reachable and close enough to the
+// primary, and records it for the reads
+func (r *replica) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), replicaCheckTimeout)
+	defer cancel()
+	lag, err := r.lag(ctx, r.primary, r.instance)
+	usable := err == nil && lag.Valid && (r.maxLag <= 0 || time.Duration(lag.Float64*float64(time.Second)) <= r.maxLag)
+
+	var v int32
+	if usable {
+		v = 1
+	}
+	previous := atomic.SwapInt32(&r.usable, v)
+	if atomic.AddInt32(&r.checks, 1) > 1 && previous == v {
+		return
+	}
+	l := r.logger.With("lag", lag.Float64, "maxLag", r.maxLag.String())
+	if err != nil {
+		l = l.With("error", err.Error())
+	}
+	if usable {
+		l.Info("reading from the replica")
+	} else {
+		l.Info("the replica is not usable, reading from the primary")
+	}
+}
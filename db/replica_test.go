@@ -0,0 +1,94 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tinkerbell/tink/db"
+)
+
+func TestReplica(t *testing.T) {
+	const (
+		primaryID = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a94"
+		replicaID = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+	)
+	ctx := context.Background()
+	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewDatabaseRequest{ApplyMigration: true})
+	defer func() {
+		if err := cl(); err != nil {
+			t.Error(err)
+		}
+	}()
+	// the replica is another database, with other data, so the test can
+	// tell which one answered
+	replicaCon, replicaDB, replicaCl := NewPostgresDatabaseClient(t, ctx, NewDatabaseRequest{ApplyMigration: true})
+	defer func() {
+		if err := replicaCl(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err := tinkDB.InsertIntoDB(ctx, `{"id":"`+primaryID+`"}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := replicaDB.InsertIntoDB(ctx, `{"id":"`+replicaID+`"}`); err != nil {
+		t.Fatal(err)
+	}
+	expectRead := func(ctx context.Context, expected string) {
+		t.Helper()
+		var ids []string
+		err := tinkDB.GetAll(ctx, func(data []byte) error {
+			var hw struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(data, &hw); err != nil {
+				return err
+			}
+			ids = append(ids, hw.ID)
+			return nil
+		})
+		if err != nil || len(ids) != 1 || ids[0] != expected {
+			t.Errorf("expected to read %s, got %v: %v", expected, ids, err)
+		}
+	}
+
+	// a negative lag is measured by the databases
+	lag := int64(-1)
+	tinkDB.SetReplicaWithLag(replicaCon, time.Second, func() time.Duration {
+		return time.Duration(atomic.LoadInt64(&lag))
+	})
+	setLag := func(d time.Duration) {
+		atomic.StoreInt64(&lag, int64(d))
+		tinkDB.WaitReplicaCheck()
+	}
+
+	// a database which is not in recovery is up to date
+	setLag(-1)
+	expectRead(ctx, replicaID)
+	expectRead(db.WithPrimary(ctx), primaryID)
+
+	setLag(500 * time.Millisecond)
+	expectRead(ctx, replicaID)
+
+	setLag(2 * time.Second)
+	expectRead(ctx, primaryID)
+
+	setLag(0)
+	expectRead(ctx, replicaID)
+
+	// the reads fall back to the primary when the replica is down, and the
+	// replica it replaces gets closed
+	down, err := sql.Open("postgres", "host=localhost port=1 user=tinkerbell password=tinkerbell dbname=tinkerbell sslmode=disable connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tinkDB.SetReplica(down, time.Second)
+	tinkDB.WaitReplicaCheck()
+	expectRead(ctx, primaryID)
+	if err := replicaCon.PingContext(ctx); err == nil {
+		t.Error("expected the replaced replica to be closed")
+	}
+}
@@ -0,0 +1,302 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+)
+
+// PurgeReport counts the rows removed by Purge, or that it would remove
+type PurgeReport struct {
+	Hardware          int64
+	HardwareRevisions int64
+	Templates         int64
+	Workflows         int64
+	WorkflowEvents    int64
+	WorkflowData      int64
+}
+
+// purgeBatchSize bounds the rows removed by a transaction of Purge, the
+// writers do not wait for the whole purge
+const purgeBatchSize = 1000
+
+// finishedWorkflows selects the workflows which are not deleted and ended
+// before $1: they failed, timed out or their last action succeeded, and
+// their last event was recorded before $1
+var finishedWorkflows = fmt.Sprintf(`
+	SELECT w.id
+	FROM workflow w
+	JOIN workflow_state ws ON ws.workflow_id = w.id
+	WHERE
+		w.deleted_at IS NULL
+	AND (
+		ws.current_action_state IN (%d, %d)
+		OR
+		(ws.current_action_state = %d AND ws.current_action_index = ws.total_number_of_actions - 1)
+	)
+	AND
+		(SELECT MAX(created_at) FROM workflow_event we WHERE we.workflow_id = w.id) < $1
+	`, int32(pb.State_STATE_FAILED), int32(pb.State_STATE_TIMEOUT), int32(pb.State_STATE_SUCCESS))
+
+// purgedRows are the rows of a table Purge removes, the ones matching
+// where, with the table aliased as alias and $1 the time given to Purge
+type purgedRows struct {
+	count *int64
+	table string
+	alias string
+	where string
+}
+
+// Purge removes what is not needed anymore:
+//
+// - the hardware, templates and workflows soft deleted before the given time,
+// along with the history of the hardware. The deleted templates still used
+// by a workflow which is kept are kept as well, they render the workflow.
+//
+// - the events and the data of the workflows deleted or finished before the
+// given time.
+//
+// The last revision removed of every hardware is kept, GetHardwareChanges
+// returns it in place of the history.
+//
+// The rows are removed by batches of purgeBatchSize, each one in its own
+// transaction bounded by the query timeout, the writers only wait for the
+// current batch. With dryRun nothing is removed and nothing is locked, the
+// report counts what would be.
+func (d TinkDB) Purge(ctx context.Context, before time.Time, dryRun bool) (PurgeReport, error) {
+	var report PurgeReport
+	workflows, err := d.purgedWorkflows(ctx, before)
+	if err != nil {
+		return report, err
+	}
+
+	tables := []purgedRows{
+		{&report.Workflows, "workflow", "w", "w.deleted_at < $1"},
+		// the templates of the workflows removed before them are unused
+		{&report.Templates, "template", "t", `
+			t.deleted_at < $1
+		AND NOT EXISTS (
+			SELECT 1
+			FROM workflow w
+			WHERE
+				w.template = t.id
+			AND
+				(w.deleted_at IS NULL OR w.deleted_at >= $1)
+		)`},
+	}
+
+	if dryRun {
+		err := d.countPurged(ctx, &report, workflows, before, tables)
+		return report, err
+	}
+
+	// the events and the data of the workflows go first, by batches of
+	// workflows
+	for len(workflows) > 0 {
+		batch := workflows
+		if len(batch) > purgeBatchSize {
+			batch = batch[:purgeBatchSize]
+		}
+		workflows = workflows[len(batch):]
+		err := d.purgeBatch(ctx, func(tx *sql.Tx) (bool, error) {
+			err := execCount(ctx, tx, &report.WorkflowEvents, `DELETE FROM workflow_event WHERE workflow_id = ANY($1::uuid[])`, pq.Array(batch))
+			if err != nil {
+				return false, err
+			}
+			return false, execCount(ctx, tx, &report.WorkflowData, `DELETE FROM workflow_data WHERE workflow_id = ANY($1::uuid[])`, pq.Array(batch))
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	for _, rows := range tables {
+		rows := rows
+		err := d.purgeBatch(ctx, func(tx *sql.Tx) (bool, error) {
+			var n int64
+			err := execCount(ctx, tx, &n, `
+			DELETE FROM `+rows.table+`
+			WHERE ctid = ANY(ARRAY(
+				SELECT `+rows.alias+`.ctid
+				FROM `+rows.table+` `+rows.alias+`
+				WHERE `+rows.where+`
+				LIMIT $2
+			))`, before, purgeBatchSize)
+			*rows.count += n
+			return n == purgeBatchSize, err
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	err = d.purgeBatch(ctx, func(tx *sql.Tx) (bool, error) {
+		return purgeHardware(ctx, tx, &report, before)
+	})
+	return report, err
+}
+
+// purgedWorkflows returns the workflows deleted or finished before the given
+// time, the ones Purge removes the events and the data of
+func (d TinkDB) purgedWorkflows(ctx context.Context, before time.Time) ([]string, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := d.instance.QueryContext(ctx, `
+	SELECT id
+	FROM workflow
+	WHERE
+		deleted_at < $1
+	UNION
+	`+finishedWorkflows, before)
+	if err != nil {
+		return nil, errors.Wrap(err, "SELECT")
+	}
+	defer rows.Close()
+	var workflows []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, "SELECT")
+		}
+		workflows = append(workflows, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "SELECT")
+	}
+	return workflows, nil
+}
+
+// countPurged fills the report of a dry run, out of any transaction
+func (d TinkDB) countPurged(ctx context.Context, report *PurgeReport, workflows []string, before time.Time, tables []purgedRows) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	type purgeCount struct {
+		count *int64
+		query string
+		arg   interface{}
+	}
+	counts := []purgeCount{
+		{&report.WorkflowEvents, `SELECT COUNT(*) FROM workflow_event WHERE workflow_id = ANY($1::uuid[])`, pq.Array(workflows)},
+		{&report.WorkflowData, `SELECT COUNT(*) FROM workflow_data WHERE workflow_id = ANY($1::uuid[])`, pq.Array(workflows)},
+		{&report.HardwareRevisions, `
+		SELECT COUNT(*)
+		FROM hardware_revision r
+		JOIN hardware h ON h.id = r.hardware_id
+		WHERE
+			h.deleted_at < $1`, before},
+		{&report.Hardware, `SELECT COUNT(*) FROM hardware WHERE deleted_at < $1`, before},
+	}
+	for _, rows := range tables {
+		counts = append(counts, purgeCount{rows.count, `SELECT COUNT(*) FROM ` + rows.table + ` ` + rows.alias + ` WHERE ` + rows.where, before})
+	}
+	for _, c := range counts {
+		if err := d.instance.QueryRowContext(ctx, c.query, c.arg).Scan(c.count); err != nil {
+			return errors.Wrap(err, "SELECT")
+		}
+	}
+	return nil
+}
+
+// purgeBatch runs batch in its own transaction taking the change locks, then
+// again while it tells there are rows left
+func (d TinkDB) purgeBatch(ctx context.Context, batch func(tx *sql.Tx) (bool, error)) error {
+	for {
+		more, err := func() (bool, error) {
+			ctx, cancel := d.withTimeout(ctx)
+			defer cancel()
+
+			tx, err := d.beginChange(ctx, eventsTable, workflowEventsTable)
+			if err != nil {
+				return false, err
+			}
+			more, err := batch(tx)
+			if err != nil {
+				_ = tx.Rollback()
+				return false, err
+			}
+			return more, errors.Wrap(tx.Commit(), "COMMIT")
+		}()
+		if err != nil || !more {
+			return err
+		}
+	}
+}
+
+// purgeHardware removes a batch of the hardware deleted before the given
+// time with its history, and records the last revision removed of each one
+// as its compaction. It tells whether there is more hardware to remove.
+func purgeHardware(ctx context.Context, tx *sql.Tx, report *PurgeReport, before time.Time) (bool, error) {
+	rows, err := tx.QueryContext(ctx, `
+	SELECT id
+	FROM hardware
+	WHERE
+		deleted_at < $1
+	LIMIT $2
+	`, before, purgeBatchSize)
+	if err != nil {
+		return false, errors.Wrap(err, "SELECT")
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return false, errors.Wrap(err, "SELECT")
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, errors.Wrap(err, "SELECT")
+	}
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO
+		hardware_revision_compaction (hardware_id, revision, version, created_at, data)
+	SELECT DISTINCT ON (hardware_id) hardware_id, revision, version, created_at, data
+	FROM hardware_revision
+	WHERE
+		hardware_id = ANY($1::uuid[])
+	ORDER BY hardware_id, revision DESC
+	ON CONFLICT (hardware_id)
+	DO
+	UPDATE SET
+		(revision, version, created_at, data) = (EXCLUDED.revision, EXCLUDED.version, EXCLUDED.created_at, EXCLUDED.data)
+	WHERE
+		hardware_revision_compaction.revision < EXCLUDED.revision
+	`, pq.Array(ids))
+	if err != nil {
+		return false, errors.Wrap(err, "INSERT in to hardware_revision_compaction")
+	}
+	if err := execCount(ctx, tx, &report.HardwareRevisions, `DELETE FROM hardware_revision WHERE hardware_id = ANY($1::uuid[])`, pq.Array(ids)); err != nil {
+		return false, err
+	}
+	if err := execCount(ctx, tx, &report.Hardware, `DELETE FROM hardware WHERE id = ANY($1::uuid[])`, pq.Array(ids)); err != nil {
+		return false, err
+	}
+	return len(ids) == purgeBatchSize, nil
+}
+
+// execCount runs a DELETE and adds the rows it removed to count
+func execCount(ctx context.Context, tx *sql.Tx, count *int64, query string, args ...interface{}) error {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "DELETE")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "DELETE")
+	}
+	*count += n
+	return nil
+}
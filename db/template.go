@@ -16,30 +16,36 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// CreateTemplate creates a new workflow template
+// CreateTemplate creates a new workflow template, in the namespace of ctx
 func (d TinkDB) CreateTemplate(ctx context.Context, name string, data string, id uuid.UUID) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
 	_, err := wflow.Parse([]byte(data))
 	if err != nil {
 		return err
 	}
 
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	tx, err := d.beginChange(ctx, eventsTable)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
 	}
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 	INSERT INTO
-		template (created_at, updated_at, name, data, id)
+		template (created_at, updated_at, name, data, id, namespace)
 	VALUES
-		($1, $1, $2, $3, $4)
+		($1, $1, $2, $3, $4, $5)
 	ON CONFLICT (id)
 	DO
 	UPDATE SET
 		(updated_at, deleted_at, name, data) = ($1, NULL, $2, $3);
-	`, time.Now(), name, data, id)
+	`, time.Now(), name, data, id, namespaceOrDefault(ctx))
 	if err != nil {
 		return errors.Wrap(err, "INSERT")
 	}
+	if err := insertEvent(ctx, tx, ResourceTemplate, id.String(), EventCreated); err != nil {
+		return err
+	}
 
 	err = tx.Commit()
 	if err != nil {
@@ -49,39 +55,52 @@ func (d TinkDB) CreateTemplate(ctx context.Context, name string, data string, id
 }
 
 // GetTemplate returns template which is not deleted
+//
+// The names are unique within a namespace, a template is looked up by name
+// in the default namespace when ctx has none.
 func (d TinkDB) GetTemplate(ctx context.Context, fields map[string]string, deleted bool) (*tb.WorkflowTemplate, error) {
-	getCondition, err := buildGetCondition(fields)
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	getCondition, value, err := buildGetCondition(fields)
 	if err != nil {
 		return &tb.WorkflowTemplate{}, errors.Wrap(err, "failed to get template")
 	}
+	namespace := NamespaceFromContext(ctx)
+	if fields["id"] == "" {
+		namespace = namespaceOrDefault(ctx)
+	}
 
 	var query string
 	if !deleted {
 		query = `
-	SELECT id, name, data, created_at, updated_at
+	SELECT id, name, data, created_at, updated_at, namespace
 	FROM template
 	WHERE
 		` + getCondition + ` AND
+		($2::text = '' OR namespace = $2) AND
 		deleted_at IS NULL
 	`
 	} else {
 		query = `
-	SELECT id, name, data, created_at, updated_at
+	SELECT id, name, data, created_at, updated_at, namespace
 	FROM template
 	WHERE
-		` + getCondition + `
+		` + getCondition + ` AND
+		($2::text = '' OR namespace = $2)
 	`
 	}
 
-	row := d.instance.QueryRowContext(ctx, query)
+	row := d.reader(ctx).QueryRowContext(ctx, query, value, namespace)
 	var (
 		id        string
 		name      string
 		data      string
 		createdAt time.Time
 		updatedAt time.Time
+		ns        string
 	)
-	err = row.Scan(&id, &name, &data, &createdAt, &updatedAt)
+	err = row.Scan(&id, &name, &data, &createdAt, &updatedAt, &ns)
 	if err == nil {
 		crAt := timestamppb.New(createdAt)
 		upAt := timestamppb.New(updatedAt)
@@ -91,6 +110,7 @@ func (d TinkDB) GetTemplate(ctx context.Context, fields map[string]string, delet
 			Data:      data,
 			CreatedAt: crAt,
 			UpdatedAt: upAt,
+			Namespace: ns,
 		}, nil
 	}
 	if err != sql.ErrNoRows {
@@ -102,18 +122,23 @@ func (d TinkDB) GetTemplate(ctx context.Context, fields map[string]string, delet
 
 // DeleteTemplate deletes a workflow template by id
 func (d TinkDB) DeleteTemplate(ctx context.Context, id string) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
 	}
 
-	res, err := tx.Exec(`
+	res, err := tx.ExecContext(ctx, `
 	UPDATE template
 	SET
 		deleted_at = NOW()
 	WHERE
-		id = $1;
-	`, id)
+		id = $1
+	AND
+		($2::text = '' OR namespace = $2);
+	`, id, NamespaceFromContext(ctx))
 	if err != nil {
 		return errors.Wrap(err, "UPDATE")
 	}
@@ -121,6 +146,9 @@ func (d TinkDB) DeleteTemplate(ctx context.Context, id string) error {
 	if count, _ := res.RowsAffected(); count == int64(0) {
 		return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
 	}
+	if err := insertEvent(ctx, tx, ResourceTemplate, id, EventDeleted); err != nil {
+		return err
+	}
 
 	err = tx.Commit()
 	if err != nil {
@@ -130,15 +158,42 @@ func (d TinkDB) DeleteTemplate(ctx context.Context, id string) error {
 }
 
 // ListTemplates returns all saved templates
-func (d TinkDB) ListTemplates(filter string, fn func(id, n string, in, del *timestamp.Timestamp) error) error {
-	rows, err := d.instance.Query(`
-	SELECT id, name, created_at, updated_at
+//
+// The templates can be ordered by created_at (default), updated_at, name or
+// id.
+func (d TinkDB) ListTemplates(ctx context.Context, filter string, opts ListOptions, fn func(id, n string, in, del *timestamp.Timestamp) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	clauses, err := opts.listClauses(map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+		"name":       "name",
+		"id":         "id",
+	}, "created_at", "id", 4)
+	if err != nil {
+		return err
+	}
+	var createdAfter interface{}
+	if !opts.CreatedAfter.IsZero() {
+		createdAfter = opts.CreatedAfter
+	}
+
+	rows, err := d.reader(ctx).QueryContext(ctx, `
+	SELECT id, name, created_at, updated_at, `+clauses.column+`
 	FROM template
 	WHERE
 		name ILIKE $1
 	AND
-		deleted_at IS NULL;
-	`, filter)
+		deleted_at IS NULL
+	AND
+		($2::timestamptz IS NULL OR created_at > $2)
+	AND
+		($3::text = '' OR namespace = $3)
+	AND
+		`+clauses.after+`
+	`+clauses.order, append([]interface{}{filter, createdAfter, NamespaceFromContext(ctx)}, clauses.args...)...)
+	queried()
 
 	if err != nil {
 		return err
@@ -150,15 +205,17 @@ func (d TinkDB) ListTemplates(filter string, fn func(id, n string, in, del *time
 		name      string
 		createdAt time.Time
 		updatedAt time.Time
+		value     interface{}
 	)
 
 	for rows.Next() {
-		err = rows.Scan(&id, &name, &createdAt, &updatedAt)
+		err = rows.Scan(&id, &name, &createdAt, &updatedAt, &value)
 		if err != nil {
 			err = errors.Wrap(err, "SELECT")
 			d.logger.Error(err)
 			return err
 		}
+		opts.cursor(value, id)
 
 		tCr := timestamppb.New(createdAt)
 		tUp := timestamppb.New(updatedAt)
@@ -177,38 +234,44 @@ func (d TinkDB) ListTemplates(filter string, fn func(id, n string, in, del *time
 
 // UpdateTemplate update a given template
 func (d TinkDB) UpdateTemplate(ctx context.Context, name string, data string, id uuid.UUID) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
 	}
 
 	if data == "" && name != "" {
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 		UPDATE template
 		SET
 			updated_at = NOW(), name = $2
 		WHERE
-			id = $1;`, id, name)
+			id = $1 AND ($3::text = '' OR namespace = $3);`, id, name, NamespaceFromContext(ctx))
 	} else if data != "" && name == "" {
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 		UPDATE template
 		SET
 			updated_at = NOW(), data = $2
 		WHERE
-			id = $1;`, id, data)
+			id = $1 AND ($3::text = '' OR namespace = $3);`, id, data, NamespaceFromContext(ctx))
 	} else {
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 		UPDATE template
 		SET
 			updated_at = NOW(), name = $2, data = $3
 		WHERE
-			id = $1;
-		`, id, name, data)
+			id = $1 AND ($4::text = '' OR namespace = $4);
+		`, id, name, data, NamespaceFromContext(ctx))
 	}
 
 	if err != nil {
 		return errors.Wrap(err, "UPDATE")
 	}
+	if err := insertEvent(ctx, tx, ResourceTemplate, id.String(), EventUpdated); err != nil {
+		return err
+	}
 
 	err = tx.Commit()
 	if err != nil {
@@ -29,7 +29,7 @@ func TestCreateTemplate(t *testing.T) {
 		InputAsync bool
 		// Expectation is the function used to apply the assertions.
 		// You can use it to validate if the Input are created as you expect
-		Expectation func(*testing.T, []*workflow.Workflow, *db.TinkDB)
+		Expectation func(*testing.T, []*workflow.Workflow, db.Database)
 		// ExpectedErr is used to check for error during
 		// CreateTemplate execution. If you expect a particular error
 		// and you want to assert it, you can use this function
@@ -44,7 +44,7 @@ func TestCreateTemplate(t *testing.T) {
 					return w
 				}(),
 			},
-			Expectation: func(t *testing.T, input []*workflow.Workflow, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, input []*workflow.Workflow, tinkDB db.Database) {
 				wtmpl, err := tinkDB.GetTemplate(ctx, map[string]string{"id": input[0].ID}, false)
 				if err != nil {
 					t.Error(err)
@@ -95,7 +95,7 @@ func TestCreateTemplate(t *testing.T) {
 					return w
 				}(),
 			},
-			Expectation: func(t *testing.T, input []*workflow.Workflow, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, input []*workflow.Workflow, tinkDB db.Database) {
 				wtmpl, err := tinkDB.GetTemplate(context.Background(), map[string]string{"id": input[0].ID}, false)
 				if err != nil {
 					t.Error(err)
@@ -124,9 +124,9 @@ func TestCreateTemplate(t *testing.T) {
 					t.Error(err)
 				}
 			},
-			Expectation: func(t *testing.T, input []*workflow.Workflow, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, input []*workflow.Workflow, tinkDB db.Database) {
 				count := 0
-				err := tinkDB.ListTemplates("%", func(id, n string, in, del *timestamp.Timestamp) error {
+				err := tinkDB.ListTemplates(ctx, "%", db.ListOptions{}, func(id, n string, in, del *timestamp.Timestamp) error {
 					count = count + 1
 					return nil
 				})
@@ -143,7 +143,7 @@ func TestCreateTemplate(t *testing.T) {
 	for _, s := range table {
 		t.Run(s.Name, func(t *testing.T) {
 			t.Parallel()
-			_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 				ApplyMigration: true,
 			})
 			defer func() {
@@ -156,7 +156,7 @@ func TestCreateTemplate(t *testing.T) {
 			wg.Add(len(s.Input))
 			for _, tt := range s.Input {
 				if s.InputAsync {
-					go func(ctx context.Context, tinkDB *db.TinkDB, tt *workflow.Workflow) {
+					go func(ctx context.Context, tinkDB db.Database, tt *workflow.Workflow) {
 						defer wg.Done()
 						err := createTemplateFromWorkflowType(ctx, tinkDB, tt)
 						if err != nil {
@@ -180,7 +180,7 @@ func TestCreateTemplate(t *testing.T) {
 func TestCreateTemplate_TwoTemplateWithSameNameButFirstOneIsDeleted(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 		ApplyMigration: true,
 	})
 	defer func() {
@@ -212,7 +212,7 @@ func TestCreateTemplate_TwoTemplateWithSameNameButFirstOneIsDeleted(t *testing.T
 func TestDeleteTemplate(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 		ApplyMigration: true,
 	})
 	defer func() {
@@ -236,7 +236,7 @@ func TestDeleteTemplate(t *testing.T) {
 	}
 
 	count := 0
-	err = tinkDB.ListTemplates("%", func(id, n string, in, del *timestamp.Timestamp) error {
+	err = tinkDB.ListTemplates(ctx, "%", db.ListOptions{}, func(id, n string, in, del *timestamp.Timestamp) error {
 		count = count + 1
 		return nil
 	})
@@ -250,7 +250,7 @@ func TestDeleteTemplate(t *testing.T) {
 
 func TestGetTemplate(t *testing.T) {
 	ctx := context.Background()
-	expectation := func(t *testing.T, input *workflow.Workflow, tinkDB *db.TinkDB) {
+	expectation := func(t *testing.T, input *workflow.Workflow, tinkDB db.Database) {
 		wtmpl, err := tinkDB.GetTemplate(ctx, map[string]string{"id": input.ID}, false)
 		if err != nil {
 			t.Error(err)
@@ -271,7 +271,7 @@ func TestGetTemplate(t *testing.T) {
 		GetAsync bool
 		// Expectation is the function used to apply the assertions.
 		// You can use it to validate if you get template you expected
-		Expectation func(*testing.T, *workflow.Workflow, *db.TinkDB)
+		Expectation func(*testing.T, *workflow.Workflow, db.Database)
 	}{
 		{
 			Name: "get-template",
@@ -302,9 +302,10 @@ func TestGetTemplate(t *testing.T) {
 		},
 	}
 	for _, s := range tests {
+		s := s
 		t.Run(s.Name, func(t *testing.T) {
 			t.Parallel()
-			_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 				ApplyMigration: true,
 			})
 			defer func() {
@@ -325,7 +326,7 @@ func TestGetTemplate(t *testing.T) {
 			wg.Add(len(s.Input))
 			for _, in := range s.Input {
 				if s.GetAsync {
-					go func(t *testing.T, wf *workflow.Workflow, db *db.TinkDB) {
+					go func(t *testing.T, wf *workflow.Workflow, db db.Database) {
 						defer wg.Done()
 						s.Expectation(t, wf, db)
 					}(t, in, tinkDB)
@@ -342,7 +343,7 @@ func TestGetTemplate(t *testing.T) {
 func TestGetTemplateWithInvalidID(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 		ApplyMigration: true,
 	})
 	defer func() {
@@ -364,7 +365,7 @@ func TestGetTemplateWithInvalidID(t *testing.T) {
 	}
 }
 
-func createTemplateFromWorkflowType(ctx context.Context, tinkDB *db.TinkDB, tt *workflow.Workflow) error {
+func createTemplateFromWorkflowType(ctx context.Context, tinkDB db.Database, tt *workflow.Workflow) error {
 	uID := uuid.MustParse(tt.ID)
 	content, err := yaml.Marshal(tt)
 	if err != nil {
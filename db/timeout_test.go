@@ -0,0 +1,40 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	ctx := context.Background()
+	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewDatabaseRequest{ApplyMigration: true})
+	defer func() {
+		if err := cl(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tinkDB.SetQueryTimeout(time.Nanosecond)
+	if err := tinkDB.GetAll(ctx, func([]byte) error { return nil }); err == nil {
+		t.Error("expected the query to time out")
+	}
+
+	tinkDB.SetQueryTimeout(time.Minute)
+	if err := tinkDB.GetAll(ctx, func([]byte) error { return nil }); err != nil {
+		t.Error(err)
+	}
+
+	// the time spent streaming the rows does not count
+	if err := tinkDB.InsertIntoDB(ctx, `{"id":"0eba0bf8-3772-4b4a-ab9f-6ebe93b90a94"}`); err != nil {
+		t.Fatal(err)
+	}
+	tinkDB.SetQueryTimeout(time.Second)
+	err := tinkDB.GetAll(ctx, func([]byte) error {
+		time.Sleep(2 * time.Second)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("expected a slow callback not to time out: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Webhook is a subscription of an HTTP endpoint to the workflow events
+type Webhook struct {
+	ID     string
+	URL    string
+	Secret string
+	// EventTypes are the events sent to the endpoint, all of them when it
+	// is empty
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+// WebhookFailure is an event that could not be delivered to a webhook
+type WebhookFailure struct {
+	ID        string
+	WebhookID string
+	EventType string
+	Payload   string
+	Attempts  int32
+	Error     string
+	CreatedAt time.Time
+}
+
+// CreateWebhook : store a webhook subscription
+func (d TinkDB) CreateWebhook(ctx context.Context, wh Webhook) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	eventTypes := wh.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	_, err := d.instance.ExecContext(ctx, `
+	INSERT INTO
+		webhook (id, url, secret, event_types, created_at)
+	VALUES
+		($1, $2, $3, $4, $5)
+	`, wh.ID, wh.URL, wh.Secret, pq.Array(eventTypes), wh.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to webhook")
+	}
+	return nil
+}
+
+// DeleteWebhook : remove a webhook subscription
+func (d TinkDB) DeleteWebhook(ctx context.Context, id string) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	res, err := d.instance.ExecContext(ctx, `
+	UPDATE webhook
+	SET
+		deleted_at = NOW()
+	WHERE
+		id = $1 AND deleted_at IS NULL;
+	`, id)
+	if err != nil {
+		return errors.Wrap(err, "UPDATE")
+	}
+	if count, _ := res.RowsAffected(); count == int64(0) {
+		return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+	}
+	return nil
+}
+
+// ListWebhooks : get the webhook subscriptions, oldest first
+func (d TinkDB) ListWebhooks(ctx context.Context, fn func(Webhook) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := d.instance.QueryContext(ctx, `
+	SELECT id, url, secret, event_types, created_at
+	FROM webhook
+	WHERE
+		deleted_at IS NULL
+	ORDER BY created_at ASC
+	`)
+	queried()
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var wh Webhook
+		err = rows.Scan(&wh.ID, &wh.URL, &wh.Secret, pq.Array(&wh.EventTypes), &wh.CreatedAt)
+		if err != nil {
+			err = errors.Wrap(err, "SELECT")
+			d.logger.Error(err)
+			return err
+		}
+		if err := fn(wh); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// InsertWebhookFailure : record an event that could not be delivered
+func (d TinkDB) InsertWebhookFailure(ctx context.Context, f WebhookFailure) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	_, err := d.instance.ExecContext(ctx, `
+	INSERT INTO
+		webhook_failure (id, webhook_id, event_type, payload, attempts, error, created_at)
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7)
+	`, f.ID, f.WebhookID, f.EventType, f.Payload, f.Attempts, f.Error, f.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to webhook_failure")
+	}
+	return nil
+}
+
+// ListWebhookFailures : get the events that could not be delivered to a
+// webhook, to any of them when webhookID is empty, oldest first
+func (d TinkDB) ListWebhookFailures(ctx context.Context, webhookID string, fn func(WebhookFailure) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := d.instance.QueryContext(ctx, `
+	SELECT id, webhook_id, event_type, payload, attempts, error, created_at
+	FROM webhook_failure
+	WHERE
+		$1 = '' OR webhook_id::text = $1
+	ORDER BY created_at ASC
+	`, webhookID)
+	queried()
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var f WebhookFailure
+		err = rows.Scan(&f.ID, &f.WebhookID, &f.EventType, &f.Payload, &f.Attempts, &f.Error, &f.CreatedAt)
+		if err != nil {
+			err = errors.Wrap(err, "SELECT")
+			d.logger.Error(err)
+			return err
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
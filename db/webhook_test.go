@@ -0,0 +1,87 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/tinkerbell/tink/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWebhooks(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	defer func() {
+		err := cl()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	all := db.Webhook{ID: uuid.New().String(), URL: "https://tickets.example.com/all", Secret: "s3cr3t", CreatedAt: time.Now().Add(-time.Minute)}
+	failed := db.Webhook{ID: uuid.New().String(), URL: "https://tickets.example.com/failed", Secret: "s3cr3t", EventTypes: []string{"action_failed"}, CreatedAt: time.Now()}
+	for _, wh := range []db.Webhook{all, failed} {
+		if err := tinkDB.CreateWebhook(ctx, wh); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tinkDB.DeleteWebhook(ctx, all.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := tinkDB.DeleteWebhook(ctx, all.ID); status.Code(err) != codes.NotFound {
+		t.Errorf("expected a second delete to be NotFound, got %v", err)
+	}
+
+	webhooks := []string{}
+	err := tinkDB.ListWebhooks(ctx, func(wh db.Webhook) error {
+		webhooks = append(webhooks, wh.ID)
+		if d := cmp.Diff(failed.EventTypes, wh.EventTypes); d != "" {
+			t.Errorf("unexpected event types: %s", d)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff([]string{failed.ID}, webhooks); d != "" {
+		t.Errorf("unexpected webhooks: %s", d)
+	}
+
+	f := db.WebhookFailure{
+		ID:        uuid.New().String(),
+		WebhookID: failed.ID,
+		EventType: "action_failed",
+		Payload:   `{"type":"action_failed"}`,
+		Attempts:  5,
+		Error:     "webhook responded 503 Service Unavailable",
+		CreatedAt: time.Now(),
+	}
+	if err := tinkDB.InsertWebhookFailure(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"", failed.ID, all.ID} {
+		failures := []string{}
+		err := tinkDB.ListWebhookFailures(ctx, id, func(got db.WebhookFailure) error {
+			failures = append(failures, got.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{f.ID}
+		if id == all.ID {
+			expected = []string{}
+		}
+		if d := cmp.Diff(expected, failures); d != "" {
+			t.Errorf("unexpected failures for %q: %s", id, d)
+		}
+	}
+}
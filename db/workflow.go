@@ -6,13 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
-	"os"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	pb "github.com/tinkerbell/tink/protos/workflow"
 	wflow "github.com/tinkerbell/tink/workflow"
@@ -26,18 +25,76 @@ type Workflow struct {
 	State                  int32
 	ID, Hardware, Template string
 	CreatedAt, UpdatedAt   *timestamp.Timestamp
+	// Namespace is the namespace of the workflow, the one of the context
+	// when it is created without
+	Namespace string
 }
 
-var (
-	defaultMaxVersions = 3
-	maxVersions        = defaultMaxVersions // maximum number of workflow data versions to be kept in database
-)
+// WorkflowFilter selects the workflows returned by ListWorkflows, the zero
+// value selects all of them
+type WorkflowFilter struct {
+	// States the workflow has to be in, the same state reported by the
+	// workflow service
+	States []int32
+	// Template is the id of the template the workflow got created from
+	Template string
+	// Hardware has to be one of the devices of the workflow, like a MAC
+	// address, compared case insensitively
+	Hardware string
+}
+
+// DefaultMaxDataVersions is how many versions of the workflow data keep
+// their data when neither the workflow nor the database says otherwise, the
+// data of the older ones gets pruned
+const DefaultMaxDataVersions = 3
+
+// WorkflowDataRevision is a version of the data of a workflow
+type WorkflowDataRevision struct {
+	WorkflowID string
+	Version    int32
+	Metadata   []byte
+	// Data is nil when the version got pruned
+	Data []byte
+}
+
+// maxDataVersions returns how many versions of its data a workflow keeps:
+// the number its template sets, otherwise the one of the database
+func maxDataVersions(workflow, database int) int {
+	if workflow > 0 {
+		return workflow
+	}
+	if database > 0 {
+		return database
+	}
+	return DefaultMaxDataVersions
+}
+
+// templateMaxDataVersions returns the number of versions of its data the
+// rendered template of a workflow keeps, zero when it does not set it
+func templateMaxDataVersions(yamlData string) int {
+	wf, err := wflow.Parse([]byte(yamlData))
+	if err != nil {
+		return 0
+	}
+	return wf.MaxDataVersions
+}
 
 // CreateWorkflow creates a new workflow
 func (d TinkDB) CreateWorkflow(ctx context.Context, wf Workflow, data string, id uuid.UUID) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	ns, err := workflowNamespace(ctx, wf)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
+	}
+	wf.Namespace = ns
+	// the workers are looked up in the namespace of the workflow
+	ctx = WithNamespace(ctx, ns)
+
+	tx, err := d.beginChange(ctx, eventsTable)
+	if err != nil {
+		return err
 	}
 
 	err = insertActionList(ctx, d.instance, data, id, tx)
@@ -45,7 +102,7 @@ func (d TinkDB) CreateWorkflow(ctx context.Context, wf Workflow, data string, id
 		return errors.Wrap(err, "failed to create workflow")
 
 	}
-	err = insertInWorkflow(ctx, d.instance, wf, tx)
+	err = insertInWorkflow(ctx, d.instance, wf, templateMaxDataVersions(data), tx)
 	if err != nil {
 		return errors.Wrap(err, "failed to create workflow")
 
@@ -57,25 +114,69 @@ func (d TinkDB) CreateWorkflow(ctx context.Context, wf Workflow, data string, id
 	return nil
 }
 
-func insertInWorkflow(ctx context.Context, db *sql.DB, wf Workflow, tx *sql.Tx) error {
-	_, err := tx.Exec(`
+// CreateWorkflows creates all the given workflows in a single transaction,
+// data holds the rendered template of the workflow with the same index
+func (d TinkDB) CreateWorkflows(ctx context.Context, wfs []Workflow, data []string) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if len(wfs) != len(data) {
+		return errors.New("every workflow requires its data")
+	}
+	tx, err := d.beginChange(ctx, eventsTable)
+	if err != nil {
+		return err
+	}
+
+	for i, wf := range wfs {
+		id, err := uuid.Parse(wf.ID)
+		if err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "invalid workflow id %s", wf.ID)
+		}
+		wf.Namespace, err = workflowNamespace(ctx, wf)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		wctx := WithNamespace(ctx, wf.Namespace)
+		err = insertActionList(wctx, d.instance, data[i], id, tx)
+		if err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "failed to create workflow %s", wf.ID)
+		}
+		err = insertInWorkflow(wctx, d.instance, wf, templateMaxDataVersions(data[i]), tx)
+		if err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "failed to create workflow %s", wf.ID)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "COMMIT")
+	}
+	return nil
+}
+
+func insertInWorkflow(ctx context.Context, db *sql.DB, wf Workflow, maxDataVersions int, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
 	INSERT INTO
-		workflow (created_at, updated_at, template, devices, id)
+		workflow (created_at, updated_at, template, devices, id, max_data_versions, namespace)
 	VALUES
-		($1, $1, $2, $3, $4)
+		($1, $1, $2, $3, $4, $5, $6)
 	ON CONFLICT (id)
 	DO
 	UPDATE SET
-		(updated_at, deleted_at, template, devices) = ($1, NULL, $2, $3);
-	`, time.Now(), wf.Template, wf.Hardware, wf.ID)
+		(updated_at, deleted_at, template, devices, max_data_versions) = ($1, NULL, $2, $3, $5);
+	`, time.Now(), wf.Template, wf.Hardware, wf.ID, maxDataVersions, wf.Namespace)
 	if err != nil {
 		return errors.Wrap(err, "INSERT in to workflow")
 	}
-	return nil
+	return insertEvent(ctx, tx, ResourceWorkflow, wf.ID, EventCreated)
 }
 
 func insertIntoWfWorkerTable(ctx context.Context, db *sql.DB, wfID uuid.UUID, workerID uuid.UUID, tx *sql.Tx) error {
-	_, err := tx.Exec(`
+	_, err := tx.ExecContext(ctx, `
 	INSERT INTO
 		workflow_worker_map (workflow_id, worker_id)
 	VALUES
@@ -91,12 +192,51 @@ func insertIntoWfWorkerTable(ctx context.Context, db *sql.DB, wfID uuid.UUID, wo
 
 // Insert actions in the workflow_state table
 func insertActionList(ctx context.Context, db *sql.DB, yamlData string, id uuid.UUID, tx *sql.Tx) error {
-	wf, err := wflow.Parse([]byte(yamlData))
+	actionList, workers, err := parseActionList(yamlData, func(addr string) (string, error) {
+		return getWorkerID(ctx, db, addr)
+	})
+	if err != nil {
+		return err
+	}
+	for _, workerID := range workers {
+		err = insertIntoWfWorkerTable(ctx, db, id, workerID, tx)
+		if err != nil {
+			return err
+		}
+	}
+	totalActions := int64(len(actionList))
+	actionData, err := json.Marshal(actionList)
 	if err != nil {
 		return err
 	}
 
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO
+		workflow_state (workflow_id, current_worker, current_task_name, current_action_name, current_action_state, action_list, current_action_index, total_number_of_actions)
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (workflow_id)
+	DO
+	UPDATE SET
+		(workflow_id, current_worker, current_task_name, current_action_name, current_action_state, action_list, current_action_index, total_number_of_actions) = ($1, $2, $3, $4, $5, $6, $7, $8);
+	`, id, "", "", "", 0, actionData, 0, totalActions)
+	if err != nil {
+		return errors.Wrap(err, "INSERT in to workflow_state")
+	}
+	return nil
+}
+
+// parseActionList returns the actions of a rendered template and the
+// workers running them. workerID finds the hardware of the worker address of
+// a task.
+func parseActionList(yamlData string, workerID func(addr string) (string, error)) ([]*pb.WorkflowAction, []uuid.UUID, error) {
+	wf, err := wflow.Parse([]byte(yamlData))
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var actionList []*pb.WorkflowAction
+	var workers []uuid.UUID
 	var uniqueWorkerID uuid.UUID
 	for _, task := range wf.Tasks {
 		taskEnvs := map[string]string{}
@@ -109,19 +249,16 @@ func insertActionList(ctx context.Context, db *sql.DB, yamlData string, id uuid.
 			taskEnvs[key] = val
 		}
 
-		workerID, err := getWorkerID(ctx, db, task.WorkerAddr)
+		workerID, err := workerID(task.WorkerAddr)
 		if err != nil {
-			return errors.WithMessage(err, "unable to insert into action list")
+			return nil, nil, errors.WithMessage(err, "unable to insert into action list")
 		}
 		workerUID, err := uuid.Parse(workerID)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		if uniqueWorkerID != workerUID {
-			err = insertIntoWfWorkerTable(ctx, db, id, workerUID, tx)
-			if err != nil {
-				return err
-			}
+			workers = append(workers, workerUID)
 			uniqueWorkerID = workerUID
 		}
 		for _, ac := range task.Actions {
@@ -169,30 +306,18 @@ func insertActionList(ctx context.Context, db *sql.DB, yamlData string, id uuid.
 			actionList = append(actionList, &action)
 		}
 	}
-	totalActions := int64(len(actionList))
-	actionData, err := json.Marshal(actionList)
-	if err != nil {
-		return err
-	}
-
-	_, err = tx.Exec(`
-	INSERT INTO
-		workflow_state (workflow_id, current_worker, current_task_name, current_action_name, current_action_state, action_list, current_action_index, total_number_of_actions)
-	VALUES
-		($1, $2, $3, $4, $5, $6, $7, $8)
-	ON CONFLICT (workflow_id)
-	DO
-	UPDATE SET
-		(workflow_id, current_worker, current_task_name, current_action_name, current_action_state, action_list, current_action_index, total_number_of_actions) = ($1, $2, $3, $4, $5, $6, $7, $8);
-	`, id, "", "", "", 0, actionData, 0, totalActions)
-	if err != nil {
-		return errors.Wrap(err, "INSERT in to workflow_state")
-	}
-	return nil
+	return actionList, workers, nil
 }
 
 // InsertIntoWfDataTable : Insert ephemeral data in workflow_data table
 func (d TinkDB) InsertIntoWfDataTable(ctx context.Context, req *pb.UpdateWorkflowDataRequest) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, req.GetWorkflowId()); err != nil {
+		return err
+	}
+
 	version, err := getLatestVersionWfData(ctx, d.instance, req.GetWorkflowId())
 	if err != nil {
 		return err
@@ -205,26 +330,41 @@ func (d TinkDB) InsertIntoWfDataTable(ctx context.Context, req *pb.UpdateWorkflo
 		return errors.Wrap(err, "BEGIN transaction")
 	}
 
-	_, err = tx.Exec(`
+	var workflowMax int
+	err = tx.QueryRowContext(ctx, `
+	SELECT max_data_versions
+	FROM workflow
+	WHERE
+		id = $1;
+	`, req.GetWorkflowId()).Scan(&workflowMax)
+	if err != nil && err != sql.ErrNoRows {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "SELECT")
+	}
+	maxVersions := maxDataVersions(workflowMax, d.maxDataVersions)
+
+	_, err = tx.ExecContext(ctx, `
 	INSERT INTO
 		workflow_data (workflow_id, version, metadata, data)
 	VALUES
 		($1, $2, $3, $4);
 	`, req.GetWorkflowId(), version, string(req.GetMetadata()), string(req.GetData()))
 	if err != nil {
+		_ = tx.Rollback()
 		return errors.Wrap(err, "INSERT Into workflow_data")
 	}
 
 	if version > int32(maxVersions) {
 		cleanVersion := version - int32(maxVersions)
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 		UPDATE workflow_data
 		SET
 			data = NULL
 		WHERE
-			workflow_id = $1 AND version = $2;
+			workflow_id = $1 AND version <= $2 AND data IS NOT NULL;
 		`, req.GetWorkflowId(), cleanVersion)
 		if err != nil {
+			_ = tx.Rollback()
 			return errors.Wrap(err, "UPDATE")
 		}
 	}
@@ -238,6 +378,13 @@ func (d TinkDB) InsertIntoWfDataTable(ctx context.Context, req *pb.UpdateWorkflo
 
 // GetfromWfDataTable : Give you the ephemeral data from workflow_data table
 func (d TinkDB) GetfromWfDataTable(ctx context.Context, req *pb.GetWorkflowDataRequest) ([]byte, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, req.GetWorkflowId()); err != nil {
+		return []byte{}, err
+	}
+
 	version := req.GetVersion()
 	if req.Version == 0 {
 		v, err := getLatestVersionWfData(ctx, d.instance, req.GetWorkflowId())
@@ -269,6 +416,13 @@ func (d TinkDB) GetfromWfDataTable(ctx context.Context, req *pb.GetWorkflowDataR
 
 // GetWorkflowMetadata returns metadata wrt to the ephemeral data of a workflow
 func (d TinkDB) GetWorkflowMetadata(ctx context.Context, req *pb.GetWorkflowDataRequest) ([]byte, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, req.GetWorkflowId()); err != nil {
+		return []byte{}, err
+	}
+
 	version := req.GetVersion()
 	if req.Version == 0 {
 		v, err := getLatestVersionWfData(ctx, d.instance, req.GetWorkflowId())
@@ -298,19 +452,65 @@ func (d TinkDB) GetWorkflowMetadata(ctx context.Context, req *pb.GetWorkflowData
 	return []byte{}, nil
 }
 
+// GetWorkflowDataRevision returns a version of the data of a workflow with
+// its metadata, the latest one when the version is 0. It returns
+// sql.ErrNoRows when the version does not exist.
+func (d TinkDB) GetWorkflowDataRevision(ctx context.Context, workflowID string, version int32) (WorkflowDataRevision, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	rev := WorkflowDataRevision{WorkflowID: workflowID, Version: version}
+	if err := checkWorkflowNamespace(ctx, d.instance, workflowID); err != nil {
+		return rev, err
+	}
+	if version == 0 {
+		v, err := getLatestVersionWfData(ctx, d.instance, workflowID)
+		if err != nil {
+			return rev, err
+		}
+		rev.Version = v
+	}
+	var metadata, data sql.NullString
+	err := d.instance.QueryRowContext(ctx, `
+	SELECT metadata, data
+	FROM workflow_data
+	WHERE
+		workflow_id = $1 AND version = $2
+	`, workflowID, rev.Version).Scan(&metadata, &data)
+	if err != nil {
+		return rev, errors.Wrap(err, "SELECT from workflow_data")
+	}
+	rev.Metadata = []byte(metadata.String)
+	if data.Valid {
+		rev.Data = []byte(data.String)
+	}
+	return rev, nil
+}
+
 // GetWorkflowDataVersion returns the latest version of data for a workflow
 func (d TinkDB) GetWorkflowDataVersion(ctx context.Context, workflowID string) (int32, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, workflowID); err != nil {
+		return -1, err
+	}
 	return getLatestVersionWfData(ctx, d.instance, workflowID)
 }
 
 // GetWorkflowsForWorker : returns the list of workflows for a particular worker
-func (d TinkDB) GetWorkflowsForWorker(id string) ([]string, error) {
-	rows, err := d.instance.Query(`
+func (d TinkDB) GetWorkflowsForWorker(ctx context.Context, id string) ([]string, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := d.instance.QueryContext(ctx, `
 	SELECT workflow_id
 	FROM workflow_worker_map
 	WHERE
-		worker_id = $1;
-	`, id)
+		worker_id = $1
+	AND
+		($2::text = '' OR workflow_id IN (SELECT id FROM workflow WHERE namespace = $2));
+	`, id, NamespaceFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -336,20 +536,25 @@ func (d TinkDB) GetWorkflowsForWorker(id string) ([]string, error) {
 
 // GetWorkflow returns a workflow
 func (d TinkDB) GetWorkflow(ctx context.Context, id string) (Workflow, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
 	query := `
-	SELECT template, devices, created_at, updated_at
+	SELECT template, devices, created_at, updated_at, namespace
 	FROM workflow
 	WHERE
 		id = $1
 	AND
-		deleted_at IS NULL;
+		deleted_at IS NULL
+	AND
+		($2::text = '' OR namespace = $2);
 	`
-	row := d.instance.QueryRowContext(ctx, query, id)
+	row := d.reader(ctx).QueryRowContext(ctx, query, id, NamespaceFromContext(ctx))
 	var (
-		tmp, tar   string
-		crAt, upAt time.Time
+		tmp, tar, ns string
+		crAt, upAt   time.Time
 	)
-	err := row.Scan(&tmp, &tar, &crAt, &upAt)
+	err := row.Scan(&tmp, &tar, &crAt, &upAt, &ns)
 	if err == nil {
 		createdAt := timestamppb.New(crAt)
 		updatedAt := timestamppb.New(upAt)
@@ -359,6 +564,7 @@ func (d TinkDB) GetWorkflow(ctx context.Context, id string) (Workflow, error) {
 			Hardware:  tar,
 			CreatedAt: createdAt,
 			UpdatedAt: updatedAt,
+			Namespace: ns,
 		}, nil
 	}
 	if err != sql.ErrNoRows {
@@ -372,12 +578,15 @@ func (d TinkDB) GetWorkflow(ctx context.Context, id string) (Workflow, error) {
 
 // DeleteWorkflow deletes a workflow
 func (d TinkDB) DeleteWorkflow(ctx context.Context, id string, state int32) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
 	}
 
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 	DELETE FROM workflow_worker_map
 	WHERE
 		workflow_id = $1;
@@ -386,7 +595,7 @@ func (d TinkDB) DeleteWorkflow(ctx context.Context, id string, state int32) erro
 		return errors.Wrap(err, "Delete Workflow Error")
 	}
 
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 	DELETE FROM workflow_state
 	WHERE
 		workflow_id = $1;
@@ -395,20 +604,27 @@ func (d TinkDB) DeleteWorkflow(ctx context.Context, id string, state int32) erro
 		return errors.Wrap(err, "Delete Workflow Error")
 	}
 
-	res, err := tx.Exec(`
+	res, err := tx.ExecContext(ctx, `
 	UPDATE workflow
 	SET
 		deleted_at = NOW()
 	WHERE
-		id = $1;
-	`, id)
+		id = $1
+	AND
+		($2::text = '' OR namespace = $2);
+	`, id, NamespaceFromContext(ctx))
 	if err != nil {
 		return errors.Wrap(err, "UPDATE")
 	}
 
 	if count, _ := res.RowsAffected(); count == int64(0) {
+		// the workflow may be in another namespace, its state stays
+		_ = tx.Rollback()
 		return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
 	}
+	if err := insertEvent(ctx, tx, ResourceWorkflow, id, EventDeleted); err != nil {
+		return err
+	}
 
 	err = tx.Commit()
 	if err != nil {
@@ -417,14 +633,58 @@ func (d TinkDB) DeleteWorkflow(ctx context.Context, id string, state int32) erro
 	return nil
 }
 
-// ListWorkflows returns all workflows
-func (d TinkDB) ListWorkflows(fn func(wf Workflow) error) error {
-	rows, err := d.instance.Query(`
-	SELECT id, template, devices, created_at, updated_at
-	FROM workflow
+// ListWorkflows returns all workflows matching the filter
+//
+// The workflows can be ordered by created_at (default), updated_at or id.
+func (d TinkDB) ListWorkflows(ctx context.Context, filter WorkflowFilter, opts ListOptions, fn func(wf Workflow) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	clauses, err := opts.listClauses(map[string]string{
+		"created_at": "w.created_at",
+		"updated_at": "w.updated_at",
+		"id":         "w.id",
+	}, "created_at", "w.id", 9)
+	if err != nil {
+		return err
+	}
+	var createdAfter interface{}
+	if !opts.CreatedAfter.IsZero() {
+		createdAfter = opts.CreatedAfter
+	}
+	var states interface{}
+	if len(filter.States) > 0 {
+		states = pq.Array(filter.States)
+	}
+
+	// the state of the workflow is the state of the current action, but a
+	// successful action is not the end of the workflow until it is the last
+	// one, a workflow without a state is pending
+	rows, err := d.reader(ctx).QueryContext(ctx, `
+	SELECT w.id, w.template, w.devices, w.created_at, w.updated_at, w.namespace, `+clauses.column+`
+	FROM workflow w
+	LEFT JOIN workflow_state ws ON ws.workflow_id = w.id
 	WHERE
-		deleted_at IS NULL;
-	`)
+		w.deleted_at IS NULL
+	AND
+		($1::smallint[] IS NULL OR COALESCE(
+			CASE
+				WHEN ws.current_action_state = $6 AND ws.current_action_index <> ws.total_number_of_actions - 1 THEN $7
+				ELSE ws.current_action_state
+			END, $8) = ANY($1::smallint[]))
+	AND
+		($2 = '' OR w.template::text = lower($2))
+	AND
+		($3 = '' OR EXISTS (SELECT 1 FROM jsonb_each_text(w.devices) d WHERE lower(d.value) = lower($3)))
+	AND
+		($4::timestamptz IS NULL OR w.created_at > $4)
+	AND
+		($5::text = '' OR w.namespace = $5)
+	AND
+		`+clauses.after+`
+	`+clauses.order, append([]interface{}{states, filter.Template, filter.Hardware, createdAfter, NamespaceFromContext(ctx),
+		int32(pb.State_STATE_SUCCESS), int32(pb.State_STATE_RUNNING), int32(pb.State_STATE_PENDING)}, clauses.args...)...)
+	queried()
 
 	if err != nil {
 		return err
@@ -432,22 +692,25 @@ func (d TinkDB) ListWorkflows(fn func(wf Workflow) error) error {
 
 	defer rows.Close()
 	var (
-		id, tmp, tar string
-		crAt, upAt   time.Time
+		id, tmp, tar, ns string
+		crAt, upAt       time.Time
+		value            interface{}
 	)
 
 	for rows.Next() {
-		err = rows.Scan(&id, &tmp, &tar, &crAt, &upAt)
+		err = rows.Scan(&id, &tmp, &tar, &crAt, &upAt, &ns, &value)
 		if err != nil {
 			err = errors.Wrap(err, "SELECT")
 			d.logger.Error(err)
 			return err
 		}
+		opts.cursor(value, id)
 
 		wf := Workflow{
-			ID:       id,
-			Template: tmp,
-			Hardware: tar,
+			ID:        id,
+			Template:  tmp,
+			Hardware:  tar,
+			Namespace: ns,
 		}
 		wf.CreatedAt = timestamppb.New(crAt)
 		wf.UpdatedAt = timestamppb.New(upAt)
@@ -465,40 +728,46 @@ func (d TinkDB) ListWorkflows(fn func(wf Workflow) error) error {
 
 // UpdateWorkflow updates a given workflow
 func (d TinkDB) UpdateWorkflow(ctx context.Context, wf Workflow, state int32) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := d.beginChange(ctx, eventsTable)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
 	}
 
 	if wf.Hardware == "" && wf.Template != "" {
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 		UPDATE workflow
 		SET
 			updated_at = NOW(), template = $2
 		WHERE
-			id = $1;
-		`, wf.ID, wf.Template)
+			id = $1 AND ($3::text = '' OR namespace = $3);
+		`, wf.ID, wf.Template, NamespaceFromContext(ctx))
 	} else if wf.Hardware != "" && wf.Template == "" {
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 		UPDATE workflow
 		SET
 			updated_at = NOW(), devices = $2
 		WHERE
-			id = $1;
-		`, wf.ID, wf.Hardware)
+			id = $1 AND ($3::text = '' OR namespace = $3);
+		`, wf.ID, wf.Hardware, NamespaceFromContext(ctx))
 	} else {
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 		UPDATE workflow
 		SET
 			updated_at = NOW(), template = $2, devices = $3
 		WHERE
-			id = $1;
-		`, wf.ID, wf.Template, wf.Hardware)
+			id = $1 AND ($4::text = '' OR namespace = $4);
+		`, wf.ID, wf.Template, wf.Hardware, NamespaceFromContext(ctx))
 	}
 
 	if err != nil {
 		return errors.Wrap(err, "UPDATE")
 	}
+	if err := insertEvent(ctx, tx, ResourceWorkflow, wf.ID, EventUpdated); err != nil {
+		return err
+	}
 
 	err = tx.Commit()
 	if err != nil {
@@ -509,12 +778,19 @@ func (d TinkDB) UpdateWorkflow(ctx context.Context, wf Workflow, state int32) er
 
 // UpdateWorkflowState : update the current workflow state
 func (d TinkDB) UpdateWorkflowState(ctx context.Context, wfContext *pb.WorkflowContext) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, wfContext.WorkflowId); err != nil {
+		return err
+	}
+
+	tx, err := d.beginChange(ctx, eventsTable)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
 	}
 
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 	UPDATE workflow_state
 	SET current_task_name = $2,
 		current_action_name = $3,
@@ -527,6 +803,9 @@ func (d TinkDB) UpdateWorkflowState(ctx context.Context, wfContext *pb.WorkflowC
 	if err != nil {
 		return errors.Wrap(err, "INSERT in to workflow_state")
 	}
+	if err := insertEvent(ctx, tx, ResourceWorkflow, wfContext.WorkflowId, EventUpdated); err != nil {
+		return err
+	}
 	err = tx.Commit()
 	if err != nil {
 		return errors.Wrap(err, "COMMIT")
@@ -536,6 +815,13 @@ func (d TinkDB) UpdateWorkflowState(ctx context.Context, wfContext *pb.WorkflowC
 
 // GetWorkflowContexts : gives you the current workflow context
 func (d TinkDB) GetWorkflowContexts(ctx context.Context, wfID string) (*pb.WorkflowContext, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, wfID); err != nil {
+		return &pb.WorkflowContext{}, err
+	}
+
 	query := `
 	SELECT current_worker, current_task_name, current_action_name, current_action_index, current_action_state, total_number_of_actions
 	FROM workflow_state
@@ -567,6 +853,13 @@ func (d TinkDB) GetWorkflowContexts(ctx context.Context, wfID string) (*pb.Workf
 
 // GetWorkflowActions : gives you the action list of workflow
 func (d TinkDB) GetWorkflowActions(ctx context.Context, wfID string) (*pb.WorkflowActionList, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, wfID); err != nil {
+		return &pb.WorkflowActionList{}, err
+	}
+
 	query := `
 	SELECT action_list
 	FROM workflow_state
@@ -593,19 +886,28 @@ func (d TinkDB) GetWorkflowActions(ctx context.Context, wfID string) (*pb.Workfl
 
 // InsertIntoWorkflowEventTable : insert workflow event table
 func (d TinkDB) InsertIntoWorkflowEventTable(ctx context.Context, wfEvent *pb.WorkflowActionStatus, time time.Time) error {
-	tx, err := d.instance.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, wfEvent.WorkflowId); err != nil {
+		return err
+	}
+
+	// the id of the event is the cursor of GetWorkflowEventsFrom
+	tx, err := d.beginChange(ctx, workflowEventsTable)
 	if err != nil {
-		return errors.Wrap(err, "BEGIN transaction")
+		return err
 	}
 
 	// TODO "created_at" field should be set in worker and come in the request
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 	INSERT INTO
 		workflow_event (workflow_id, worker_id, task_name, action_name, execution_time, message, status, created_at)
 	VALUES
 		($1, $2, $3, $4, $5, $6, $7, $8);
 	`, wfEvent.WorkflowId, wfEvent.WorkerId, wfEvent.TaskName, wfEvent.ActionName, wfEvent.Seconds, wfEvent.Message, wfEvent.ActionStatus, time)
 	if err != nil {
+		_ = tx.Rollback()
 		return errors.Wrap(err, "INSERT in to workflow_event")
 	}
 	err = tx.Commit()
@@ -616,15 +918,58 @@ func (d TinkDB) InsertIntoWorkflowEventTable(ctx context.Context, wfEvent *pb.Wo
 }
 
 // ShowWorkflowEvents returns all workflows
-func (d TinkDB) ShowWorkflowEvents(wfID string, fn func(wfs *pb.WorkflowActionStatus) error) error {
-	rows, err := d.instance.Query(`
-       SELECT worker_id, task_name, action_name, execution_time, message, status, created_at
-	   FROM workflow_event
-	   WHERE
-			   workflow_id = $1
-		ORDER BY
-				created_at ASC;
-	   `, wfID)
+func (d TinkDB) ShowWorkflowEvents(ctx context.Context, wfID string, fn func(wfs *pb.WorkflowActionStatus) error) error {
+	return d.GetWorkflowEventsFrom(ctx, wfID, 0, func(_ int64, wfs *pb.WorkflowActionStatus) error {
+		return fn(wfs)
+	})
+}
+
+// GetWorkflowEventsFrom : get the events of a workflow by time, starting
+// with the one with the from id, or with the first one when it is 0. The
+// watchers resume from the last event they read, without reading again the
+// ones before it. When the event with the from id is gone, purged or of
+// another workflow, it returns an OutOfRange error.
+func (d TinkDB) GetWorkflowEventsFrom(ctx context.Context, wfID string, from int64, fn func(id int64, wfs *pb.WorkflowActionStatus) error) error {
+	ctx, queried, cancel := d.withQueryTimeout(ctx)
+	defer cancel()
+
+	if err := checkWorkflowNamespace(ctx, d.instance, wfID); err != nil {
+		return err
+	}
+
+	// the events are sorted by time, the ones after the from one are the
+	// ones recorded later or at the same time with a greater id
+	var fromTime time.Time
+	if from != 0 {
+		err := d.reader(ctx).QueryRowContext(ctx, `
+		SELECT created_at
+		FROM workflow_event
+		WHERE
+			id = $1
+		AND
+			workflow_id = $2
+		`, from, wfID).Scan(&fromTime)
+		if err == sql.ErrNoRows {
+			queried()
+			return status.Errorf(codes.OutOfRange, "the event %d of workflow %s is gone, read the events from the first one", from, wfID)
+		}
+		if err != nil {
+			queried()
+			return errors.Wrap(err, "SELECT")
+		}
+	}
+
+	rows, err := d.reader(ctx).QueryContext(ctx, `
+	SELECT e.id, e.worker_id, e.task_name, e.action_name, e.execution_time, e.message, e.status, e.created_at
+	FROM workflow_event e
+	WHERE
+		e.workflow_id = $1
+	AND
+		($2::bigint = 0 OR (e.created_at, e.id) >= ($3, $2))
+	ORDER BY
+		e.created_at ASC, e.id ASC;
+	`, wfID, from, fromTime)
+	queried()
 
 	if err != nil {
 		return err
@@ -632,6 +977,7 @@ func (d TinkDB) ShowWorkflowEvents(wfID string, fn func(wfs *pb.WorkflowActionSt
 
 	defer rows.Close()
 	var (
+		eventID               int64
 		status                int32
 		secs                  int64
 		id, tName, aName, msg string
@@ -639,7 +985,7 @@ func (d TinkDB) ShowWorkflowEvents(wfID string, fn func(wfs *pb.WorkflowActionSt
 	)
 
 	for rows.Next() {
-		err = rows.Scan(&id, &tName, &aName, &secs, &msg, &status, &evTime)
+		err = rows.Scan(&eventID, &id, &tName, &aName, &secs, &msg, &status, &evTime)
 		if err != nil {
 			err = errors.Wrap(err, "SELECT")
 			d.logger.Error(err)
@@ -655,7 +1001,7 @@ func (d TinkDB) ShowWorkflowEvents(wfID string, fn func(wfs *pb.WorkflowActionSt
 			ActionStatus: pb.State(status),
 			CreatedAt:    createdAt,
 		}
-		err = fn(wfs)
+		err = fn(eventID, wfs)
 		if err != nil {
 			return err
 		}
@@ -667,6 +1013,29 @@ func (d TinkDB) ShowWorkflowEvents(wfID string, fn func(wfs *pb.WorkflowActionSt
 	return err
 }
 
+// checkWorkflowNamespace returns a NotFound error when the workflow is not
+// in the namespace of ctx. The tables keyed by the workflow id use it, the
+// workflow table checks its namespace column itself.
+func checkWorkflowNamespace(ctx context.Context, db *sql.DB, id string) error {
+	ns := NamespaceFromContext(ctx)
+	if ns == "" {
+		return nil
+	}
+	var found bool
+	err := db.QueryRowContext(ctx, `
+	SELECT true
+	FROM workflow
+	WHERE
+		id = $1
+	AND
+		namespace = $2;
+	`, id, ns).Scan(&found)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, fmt.Sprintf("not found, id:%s", id))
+	}
+	return errors.Wrap(err, "SELECT")
+}
+
 func getLatestVersionWfData(ctx context.Context, db *sql.DB, wfID string) (int32, error) {
 	query := `
 	SELECT COUNT(*)
@@ -684,19 +1053,6 @@ func getLatestVersionWfData(ctx context.Context, db *sql.DB, wfID string) (int32
 }
 
 func getWorkerIDbyMac(ctx context.Context, db *sql.DB, mac string) (string, error) {
-	arg := `
-	{
-		"network": {
-			"interfaces": [
-				{
-					"dhcp": {
-						"mac": "` + mac + `"
-					}
-				}
-			]
-		}
-	}
-	`
 	query := `
 	SELECT id
 	FROM hardware
@@ -704,9 +1060,11 @@ func getWorkerIDbyMac(ctx context.Context, db *sql.DB, mac string) (string, erro
 		deleted_at IS NULL
 	AND
 		data @> $1
+	AND
+		data @> $2
 	`
 
-	id, err := get(ctx, db, query, arg)
+	id, err := get(ctx, db, query, string(macMatch(mac)), string(namespaceMatch(ctx)))
 	if errors.Cause(err) == sql.ErrNoRows {
 		err = errors.WithMessage(errors.New(mac), "mac")
 	}
@@ -714,34 +1072,7 @@ func getWorkerIDbyMac(ctx context.Context, db *sql.DB, mac string) (string, erro
 }
 
 func getWorkerIDbyIP(ctx context.Context, db *sql.DB, ip string) (string, error) {
-	// update for instance (under metadata)
-	instance := `
-        {
-          "instance": {
-            "ip_addresses": [
-              {
-                "address": "` + ip + `"
-              }
-            ]
-          }
-        }
-        `
-	hardwareOrManagement := `
-	{
-		"network": {
-			"interfaces": [
-				{
-					"dhcp": {
-						"ip": {
-							"address": "` + ip + `"
-						}
-					}
-				}
-			]
-		}
-	}
-	`
-
+	matches := ipMatches(ip)
 	query := `
         SELECT id
         FROM hardware
@@ -752,9 +1083,11 @@ func getWorkerIDbyIP(ctx context.Context, db *sql.DB, ip string) (string, error)
                 OR
                 data @> $2
         )
+        AND
+                data @> $3
         `
 
-	id, err := get(ctx, db, query, instance, hardwareOrManagement)
+	id, err := get(ctx, db, query, string(matches[0]), string(matches[1]), string(namespaceMatch(ctx)))
 	if errors.Cause(err) == sql.ErrNoRows {
 		err = errors.WithMessage(errors.New(ip), "ip")
 	}
@@ -762,23 +1095,26 @@ func getWorkerIDbyIP(ctx context.Context, db *sql.DB, ip string) (string, error)
 }
 
 func getWorkerID(ctx context.Context, db *sql.DB, addr string) (string, error) {
+	return findWorkerID(addr, func(mac string) (string, error) {
+		return getWorkerIDbyMac(ctx, db, mac)
+	}, func(ip string) (string, error) {
+		return getWorkerIDbyIP(ctx, db, ip)
+	})
+}
+
+// findWorkerID returns the id of the hardware of a worker address, a MAC or
+// an IPv4 address, looked up with byMAC or byIP
+func findWorkerID(addr string, byMAC, byIP func(string) (string, error)) (string, error) {
 	parsedMAC, err := net.ParseMAC(addr)
 	if err != nil {
 		ip := net.ParseIP(addr)
 		if ip == nil || ip.To4() == nil {
 			return "", fmt.Errorf("invalid worker address: %s", addr)
 		}
-		id, err := getWorkerIDbyIP(ctx, db, addr)
+		id, err := byIP(addr)
 		return id, errors.WithMessage(err, "no worker found")
 
 	}
-	id, err := getWorkerIDbyMac(ctx, db, parsedMAC.String())
+	id, err := byMAC(parsedMAC.String())
 	return id, errors.WithMessage(err, "no worker found")
 }
-
-func init() {
-	val := os.Getenv("MAX_WORKFLOW_DATA_VERSIONS")
-	if v, err := strconv.Atoi(val); err == nil {
-		maxVersions = v
-	}
-}
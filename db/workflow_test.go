@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -33,7 +34,7 @@ func TestCreateWorkflow(t *testing.T) {
 		Input *input
 		// Expectation is the function used to apply the assertions.
 		// You can use it to validate if the Input are created as you expect
-		Expectation func(t *testing.T, in *input, tinkDB *db.TinkDB)
+		Expectation func(t *testing.T, in *input, tinkDB db.Database)
 		// ExpectedErr is used to check for error during
 		// CreateWorkflow execution. If you expect a particular error
 		// and you want to assert it, you can use this function
@@ -52,9 +53,9 @@ func TestCreateWorkflow(t *testing.T) {
 					return tmp
 				}(),
 			},
-			Expectation: func(t *testing.T, in *input, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, in *input, tinkDB db.Database) {
 				count := 0
-				err := tinkDB.ListWorkflows(func(wf db.Workflow) error {
+				err := tinkDB.ListWorkflows(context.Background(), db.WorkflowFilter{}, db.ListOptions{}, func(wf db.Workflow) error {
 					count = count + 1
 					return nil
 				})
@@ -79,9 +80,9 @@ func TestCreateWorkflow(t *testing.T) {
 					return tmp
 				}(),
 			},
-			Expectation: func(t *testing.T, in *input, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, in *input, tinkDB db.Database) {
 				count := 0
-				err := tinkDB.ListWorkflows(func(wf db.Workflow) error {
+				err := tinkDB.ListWorkflows(context.Background(), db.WorkflowFilter{}, db.ListOptions{}, func(wf db.Workflow) error {
 					count = count + 1
 					return nil
 				})
@@ -106,9 +107,9 @@ func TestCreateWorkflow(t *testing.T) {
 					return tmp
 				}(),
 			},
-			Expectation: func(t *testing.T, in *input, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, in *input, tinkDB db.Database) {
 				count := 0
-				err := tinkDB.ListWorkflows(func(wf db.Workflow) error {
+				err := tinkDB.ListWorkflows(context.Background(), db.WorkflowFilter{}, db.ListOptions{}, func(wf db.Workflow) error {
 					count = count + 1
 					return nil
 				})
@@ -134,9 +135,9 @@ func TestCreateWorkflow(t *testing.T) {
 					return tmp
 				}(),
 			},
-			Expectation: func(t *testing.T, in *input, tinkDB *db.TinkDB) {
+			Expectation: func(t *testing.T, in *input, tinkDB db.Database) {
 				count := 0
-				err := tinkDB.ListWorkflows(func(wf db.Workflow) error {
+				err := tinkDB.ListWorkflows(context.Background(), db.WorkflowFilter{}, db.ListOptions{}, func(wf db.Workflow) error {
 					count = count + 1
 					return nil
 				})
@@ -152,9 +153,10 @@ func TestCreateWorkflow(t *testing.T) {
 
 	ctx := context.Background()
 	for _, s := range tests {
+		s := s
 		t.Run(s.Name, func(t *testing.T) {
 			t.Parallel()
-			_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 				ApplyMigration: true,
 			})
 			defer func() {
@@ -177,7 +179,7 @@ func TestCreateWorkflow(t *testing.T) {
 			wg.Add(s.Input.workflowCount)
 			for i := 0; i < s.Input.workflowCount; i++ {
 				if s.InputAsync {
-					go func(ctx context.Context, tinkDB *db.TinkDB, in *input) {
+					go func(ctx context.Context, tinkDB db.Database, in *input) {
 						defer wg.Done()
 						_, err := createWorkflow(ctx, tinkDB, in)
 						if err != nil {
@@ -198,10 +200,196 @@ func TestCreateWorkflow(t *testing.T) {
 	}
 }
 
+func TestCreateWorkflows(t *testing.T) {
+	tests := []struct {
+		// Name identifies the single test in a table test scenario
+		Name string
+		// Devices holds the devices of every workflow created at once
+		Devices []string
+		// ExpectedCount is the number of workflows stored afterwards
+		ExpectedCount int
+		// ExpectedErr tells if CreateWorkflows has to fail
+		ExpectedErr bool
+	}{
+		{
+			Name:          "create-many-workflows",
+			Devices:       []string{`{"device_1":"08:00:27:00:00:01"}`, `{"device_1":"08:00:27:00:00:01"}`},
+			ExpectedCount: 2,
+		},
+		{
+			Name:          "nothing-created-when-one-fails",
+			Devices:       []string{`{"device_1":"08:00:27:00:00:01"}`, `{"device_1":"08:00:27:00:00:99"}`},
+			ExpectedCount: 0,
+			ExpectedErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, s := range tests {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			t.Parallel()
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+				ApplyMigration: true,
+			})
+			defer func() {
+				err := cl()
+				if err != nil {
+					t.Error(err)
+				}
+			}()
+
+			err := createHardware(ctx, tinkDB, readHardwareData("./testdata/hardware.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tmp := workflow.MustParseFromFile("./testdata/template_happy_path_1.yaml")
+			tmp.ID = uuid.New().String()
+			tmp.Name = fmt.Sprintf("id_%d", rand.Int())
+			err = createTemplateFromWorkflowType(ctx, tinkDB, tmp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wtmpl, err := tinkDB.GetTemplate(ctx, map[string]string{"id": tmp.ID}, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wfs := []db.Workflow{}
+			data := []string{}
+			for _, devices := range s.Devices {
+				d, err := workflow.RenderTemplate(tmp.ID, wtmpl.GetData(), []byte(devices))
+				if err != nil {
+					t.Fatal(err)
+				}
+				wfs = append(wfs, db.Workflow{ID: uuid.New().String(), Template: tmp.ID, Hardware: devices})
+				data = append(data, d)
+			}
+
+			err = tinkDB.CreateWorkflows(ctx, wfs, data)
+			if s.ExpectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			count := 0
+			err = tinkDB.ListWorkflows(ctx, db.WorkflowFilter{}, db.ListOptions{}, func(wf db.Workflow) error {
+				count = count + 1
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+			if count != s.ExpectedCount {
+				t.Errorf("expected %d workflows stored in the database but we got %d", s.ExpectedCount, count)
+			}
+		})
+	}
+}
+
+func TestListWorkflowsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
+		ApplyMigration: true,
+	})
+	defer func() {
+		err := cl()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	in := &input{
+		devices:  "{\"device_1\":\"08:00:27:00:00:01\"}",
+		hardware: readHardwareData("./testdata/hardware.json"),
+		template: func() *workflow.Workflow {
+			tmp := workflow.MustParseFromFile("./testdata/template_happy_path_1.yaml")
+			tmp.ID = uuid.New().String()
+			tmp.Name = fmt.Sprintf("id_%d", rand.Int())
+			return tmp
+		}(),
+	}
+	if err := createHardware(ctx, tinkDB, in.hardware); err != nil {
+		t.Fatal(err)
+	}
+	if err := createTemplateFromWorkflowType(ctx, tinkDB, in.template); err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{}
+	for i := 0; i < 3; i++ {
+		id, err := createWorkflow(ctx, tinkDB, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	tests := []struct {
+		Name     string
+		Filter   db.WorkflowFilter
+		Options  db.ListOptions
+		Expected []string
+	}{
+		{
+			Name:     "first-page",
+			Options:  db.ListOptions{Limit: 2},
+			Expected: ids[:2],
+		},
+		{
+			Name:     "second-page",
+			Options:  db.ListOptions{Limit: 2, Offset: 2},
+			Expected: ids[2:],
+		},
+		{
+			Name:     "descending",
+			Options:  db.ListOptions{OrderBy: "created_at desc", Limit: 1},
+			Expected: ids[2:],
+		},
+		{
+			Name:     "pending-for-template-and-hardware",
+			Filter:   db.WorkflowFilter{States: []int32{int32(pb.State_STATE_PENDING)}, Template: in.template.ID, Hardware: "08:00:27:00:00:01"},
+			Expected: ids,
+		},
+		{
+			Name:   "no-failed",
+			Filter: db.WorkflowFilter{States: []int32{int32(pb.State_STATE_FAILED)}},
+		},
+		{
+			Name:   "other-hardware",
+			Filter: db.WorkflowFilter{Hardware: "08:00:27:00:00:02"},
+		},
+		{
+			Name:    "created-in-the-future",
+			Options: db.ListOptions{CreatedAfter: time.Now().Add(time.Hour)},
+		},
+	}
+	for _, s := range tests {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			got := []string{}
+			err := tinkDB.ListWorkflows(ctx, s.Filter, s.Options, func(wf db.Workflow) error {
+				got = append(got, wf.ID)
+				return nil
+			})
+			assert.NoError(t, err)
+			if s.Expected == nil {
+				s.Expected = []string{}
+			}
+			assert.Equal(t, s.Expected, got)
+		})
+	}
+
+	err := tinkDB.ListWorkflows(ctx, db.WorkflowFilter{}, db.ListOptions{OrderBy: "devices"}, func(wf db.Workflow) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
+
 func TestDeleteWorkflow(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+	tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 		ApplyMigration: true,
 	})
 	defer func() {
@@ -241,7 +429,7 @@ func TestDeleteWorkflow(t *testing.T) {
 	}
 
 	count := 0
-	err = tinkDB.ListWorkflows(func(wf db.Workflow) error {
+	err = tinkDB.ListWorkflows(ctx, db.WorkflowFilter{}, db.ListOptions{}, func(wf db.Workflow) error {
 		count = count + 1
 		return nil
 	})
@@ -264,7 +452,7 @@ func TestGetWorkflow(t *testing.T) {
 		Input *input
 		// Expectation is the function used to apply the assertions.
 		// You can use it to validate if you get workflow you expected
-		Expectation func(t *testing.T, tinkDB *db.TinkDB, id string)
+		Expectation func(t *testing.T, tinkDB db.Database, id string)
 	}{
 		{
 			Name: "get-workflow",
@@ -279,7 +467,7 @@ func TestGetWorkflow(t *testing.T) {
 					return tmp
 				}(),
 			},
-			Expectation: func(t *testing.T, tinkDB *db.TinkDB, id string) {
+			Expectation: func(t *testing.T, tinkDB db.Database, id string) {
 				_, err := tinkDB.GetWorkflow(ctx, id)
 				if err != nil {
 					t.Error(err)
@@ -298,10 +486,10 @@ func TestGetWorkflow(t *testing.T) {
 					return tmp
 				}(),
 			},
-			Expectation: func(t *testing.T, tinkDB *db.TinkDB, id string) {
+			Expectation: func(t *testing.T, tinkDB db.Database, id string) {
 				wf, err := tinkDB.GetWorkflow(ctx, uuid.New().String())
-				if err != nil {
-					t.Error(err)
+				if err == nil {
+					t.Error("expected an error for a workflow that does not exist")
 				}
 				assert.Empty(t, wf)
 			},
@@ -320,7 +508,7 @@ func TestGetWorkflow(t *testing.T) {
 					return tmp
 				}(),
 			},
-			Expectation: func(t *testing.T, tinkDB *db.TinkDB, id string) {
+			Expectation: func(t *testing.T, tinkDB db.Database, id string) {
 				_, err := tinkDB.GetWorkflow(ctx, id)
 				if err != nil {
 					t.Error(err)
@@ -330,9 +518,10 @@ func TestGetWorkflow(t *testing.T) {
 	}
 
 	for _, s := range tests {
+		s := s
 		t.Run(s.Name, func(t *testing.T) {
 			t.Parallel()
-			_, tinkDB, cl := NewPostgresDatabaseClient(t, ctx, NewPostgresDatabaseRequest{
+			tinkDB, cl := NewDatabaseClient(t, ctx, NewDatabaseRequest{
 				ApplyMigration: true,
 			})
 			defer func() {
@@ -369,7 +558,7 @@ func TestGetWorkflow(t *testing.T) {
 			wg.Add(s.Input.workflowCount)
 			for i := 0; i < s.Input.workflowCount; i++ {
 				if s.GetAsync {
-					go func(t *testing.T, tinkDB *db.TinkDB, wfID string) {
+					go func(t *testing.T, tinkDB db.Database, wfID string) {
 						defer wg.Done()
 						s.Expectation(t, tinkDB, wfID)
 					}(t, tinkDB, wfIDs[i])
@@ -383,7 +572,7 @@ func TestGetWorkflow(t *testing.T) {
 	}
 }
 
-func createWorkflow(ctx context.Context, tinkDB *db.TinkDB, in *input) (string, error) {
+func createWorkflow(ctx context.Context, tinkDB db.Database, in *input) (string, error) {
 	wtmpl, err := tinkDB.GetTemplate(context.Background(), map[string]string{"id": in.template.ID}, false)
 	if err != nil {
 		return "", err
@@ -0,0 +1,31 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// actorUnaryInterceptor stores in the context who is making the request, so
+// the database can record it
+func actorUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(db.WithActor(ctx, actorFromRequest(ctx)), req)
+}
+
+// actorFromRequest returns the actor the client sent in the metadata,
+// falling back to the address of the client
+func actorFromRequest(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(pkg.ActorMetadataKey); len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestActorFromRequest(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("192.168.1.5"), Port: 51234}
+
+	testCases := map[string]struct {
+		ctx      context.Context
+		expected string
+	}{
+		"metadata": {
+			ctx:      metadata.NewIncomingContext(peer.NewContext(context.Background(), &peer.Peer{Addr: addr}), metadata.Pairs("tink-actor", "alice@laptop")),
+			expected: "alice@laptop",
+		},
+		"peer-address": {
+			ctx:      peer.NewContext(context.Background(), &peer.Peer{Addr: addr}),
+			expected: "192.168.1.5:51234",
+		},
+		"unknown": {
+			ctx: context.Background(),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var actor string
+			_, err := actorUnaryInterceptor(tc.ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				actor = db.ActorFromContext(ctx)
+				return nil, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actor)
+		})
+	}
+}
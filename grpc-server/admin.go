@@ -0,0 +1,185 @@
+package grpcserver
+
+import (
+	"bufio"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/metrics"
+	"github.com/tinkerbell/tink/protos/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultGCInterval = time.Hour
+
+// archiveChunkSize keeps the chunks of an archive well under the size limit
+// of the gRPC messages
+const archiveChunkSize = 1 << 20
+
+// GarbageCollect implements admin.GarbageCollect
+func (s *server) GarbageCollect(ctx context.Context, in *admin.GarbageCollectRequest) (*admin.GarbageCollectResponse, error) {
+	s.logger.With("dryRun", in.GetDryRun()).Info("garbagecollect")
+	labels := prometheus.Labels{"method": "GarbageCollect", "op": "delete"}
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	days := int(in.GetRetentionDays())
+	if days < 0 {
+		metrics.CacheErrors.With(labels).Inc()
+		return &admin.GarbageCollectResponse{}, status.Error(codes.InvalidArgument, "retention_days can not be negative")
+	}
+	if days == 0 {
+		days = s.retentionDays
+	}
+	if days == 0 {
+		metrics.CacheErrors.With(labels).Inc()
+		return &admin.GarbageCollectResponse{}, status.Error(codes.InvalidArgument, "no retention policy is configured, retention_days must be set")
+	}
+
+	report, err := s.db.Purge(ctx, retentionCutoff(days), in.GetDryRun())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return &admin.GarbageCollectResponse{}, err
+	}
+	return &admin.GarbageCollectResponse{
+		Hardware:          report.Hardware,
+		HardwareRevisions: report.HardwareRevisions,
+		Templates:         report.Templates,
+		Workflows:         report.Workflows,
+		WorkflowEvents:    report.WorkflowEvents,
+		WorkflowData:      report.WorkflowData,
+	}, nil
+}
+
+// retentionCutoff returns the time before which the rows are not retained
+// anymore
+func retentionCutoff(days int) time.Time {
+	return time.Now().AddDate(0, 0, -days)
+}
+
+// collectGarbage purges what the retention policy does not keep anymore,
+// every interval until the server shuts down
+func (s *server) collectGarbage(ctx context.Context, interval time.Duration) {
+	if interval == 0 {
+		interval = defaultGCInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.db.Purge(ctx, retentionCutoff(s.retentionDays), false)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error(errors.Wrap(err, "garbage collection"))
+		} else if err == nil && report != (db.PurgeReport{}) {
+			s.logger.With(
+				"hardware", report.Hardware,
+				"hardwareRevisions", report.HardwareRevisions,
+				"templates", report.Templates,
+				"workflows", report.Workflows,
+				"workflowEvents", report.WorkflowEvents,
+				"workflowData", report.WorkflowData,
+			).Info("garbage collected")
+		}
+
+		select {
+		case <-s.quit:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Export implements admin.Export
+func (s *server) Export(in *admin.ExportRequest, stream admin.AdminService_ExportServer) error {
+	s.logger.Info("export")
+	labels := prometheus.Labels{"method": "Export", "op": "list"}
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	b, err := s.db.Export(stream.Context())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return err
+	}
+	w := bufio.NewWriterSize(chunkWriter(stream.Send), archiveChunkSize)
+	if err := db.WriteArchive(w, b); err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return err
+	}
+	return w.Flush()
+}
+
+// Import implements admin.Import
+func (s *server) Import(stream admin.AdminService_ImportServer) error {
+	s.logger.Info("import")
+	labels := prometheus.Labels{"method": "Import", "op": "insert"}
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	b, err := db.ReadArchive(&chunkReader{recv: stream.Recv})
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.db.Import(stream.Context(), b); err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return err
+	}
+	return stream.SendAndClose(&admin.ImportResponse{
+		Hardware:          int64(len(b.Hardware)),
+		HardwareRevisions: int64(len(b.HardwareRevisions)),
+		Templates:         int64(len(b.Templates)),
+		Workflows:         int64(len(b.Workflows)),
+		WorkflowEvents:    int64(len(b.WorkflowEvents)),
+		WorkflowData:      int64(len(b.WorkflowData)),
+		Events:            int64(len(b.Events)),
+	})
+}
+
+// chunkWriter sends what is written to it as archive chunks
+type chunkWriter func(*admin.ArchiveChunk) error
+
+func (send chunkWriter) Write(p []byte) (int, error) {
+	for n := 0; n < len(p); n += archiveChunkSize {
+		end := n + archiveChunkSize
+		if end > len(p) {
+			end = len(p)
+		}
+		if err := send(&admin.ArchiveChunk{Data: p[n:end]}); err != nil {
+			return n, err
+		}
+	}
+	return len(p), nil
+}
+
+// chunkReader reads the archive chunks it receives, until the end of the
+// stream
+type chunkReader struct {
+	recv func() (*admin.ArchiveChunk, error)
+	buf  []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.GetData()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
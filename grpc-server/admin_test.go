@@ -0,0 +1,200 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/db/mock"
+	"github.com/tinkerbell/tink/protos/admin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGarbageCollect(t *testing.T) {
+	report := db.PurgeReport{Hardware: 1, HardwareRevisions: 3, Templates: 2, Workflows: 4, WorkflowEvents: 20, WorkflowData: 5}
+	testCases := map[string]struct {
+		retentionDays int
+		req           *admin.GarbageCollectRequest
+		expectedDays  int
+		expectedCode  codes.Code
+	}{
+		"server-policy": {
+			retentionDays: 30,
+			req:           &admin.GarbageCollectRequest{},
+			expectedDays:  30,
+		},
+		"requested-days": {
+			retentionDays: 30,
+			req:           &admin.GarbageCollectRequest{RetentionDays: 7, DryRun: true},
+			expectedDays:  7,
+		},
+		"no-policy": {
+			req:          &admin.GarbageCollectRequest{},
+			expectedCode: codes.InvalidArgument,
+		},
+		"negative-days": {
+			retentionDays: 30,
+			req:           &admin.GarbageCollectRequest{RetentionDays: -1},
+			expectedCode:  codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				called bool
+				before time.Time
+				dryRun bool
+			)
+			s := testServer(t, &mock.DB{
+				PurgeFunc: func(ctx context.Context, b time.Time, d bool) (db.PurgeReport, error) {
+					called, before, dryRun = true, b, d
+					return report, nil
+				},
+			})
+			s.retentionDays = tc.retentionDays
+
+			res, err := s.GarbageCollect(context.Background(), tc.req)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.False(t, called)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, called)
+			assert.Equal(t, tc.req.DryRun, dryRun)
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, -tc.expectedDays), before, time.Minute)
+			assert.Equal(t, &admin.GarbageCollectResponse{
+				Hardware:          1,
+				HardwareRevisions: 3,
+				Templates:         2,
+				Workflows:         4,
+				WorkflowEvents:    20,
+				WorkflowData:      5,
+			}, res)
+		})
+	}
+}
+
+func TestCollectGarbage(t *testing.T) {
+	purged := make(chan time.Time, 10)
+	quit := make(chan struct{})
+	s := testServer(t, &mock.DB{
+		PurgeFunc: func(ctx context.Context, before time.Time, dryRun bool) (db.PurgeReport, error) {
+			assert.False(t, dryRun)
+			purged <- before
+			return db.PurgeReport{Workflows: 1}, nil
+		},
+	})
+	s.retentionDays = 2
+	s.quit = quit
+
+	done := make(chan struct{})
+	go func() {
+		s.collectGarbage(context.Background(), time.Millisecond)
+		close(done)
+	}()
+
+	// right away, then every interval
+	for i := 0; i < 2; i++ {
+		select {
+		case before := <-purged:
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, -2), before, time.Minute)
+		case <-time.After(time.Second):
+			t.Fatal("expected the garbage to be collected")
+		}
+	}
+
+	close(quit)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the garbage collection to stop with the server")
+	}
+}
+
+type exportServer struct {
+	grpc.ServerStream
+	chunks []*admin.ArchiveChunk
+}
+
+func (e *exportServer) Context() context.Context {
+	return context.Background()
+}
+
+func (e *exportServer) Send(chunk *admin.ArchiveChunk) error {
+	e.chunks = append(e.chunks, chunk)
+	return nil
+}
+
+type importServer struct {
+	grpc.ServerStream
+	chunks []*admin.ArchiveChunk
+	res    *admin.ImportResponse
+}
+
+func (i *importServer) Context() context.Context {
+	return context.Background()
+}
+
+func (i *importServer) Recv() (*admin.ArchiveChunk, error) {
+	if len(i.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := i.chunks[0]
+	i.chunks = i.chunks[1:]
+	return chunk, nil
+}
+
+func (i *importServer) SendAndClose(res *admin.ImportResponse) error {
+	i.res = res
+	return nil
+}
+
+func TestExportImport(t *testing.T) {
+	backup := db.Backup{
+		Hardware:  []db.BackupHardware{{ID: "hw-1", Data: json.RawMessage(`{"id":"hw-1"}`), InsertedAt: time.Unix(1000, 0).UTC()}},
+		Templates: []db.BackupTemplate{{ID: "t-1", Name: "hello", Data: "version: '0.1'", CreatedAt: time.Unix(1000, 0).UTC(), UpdatedAt: time.Unix(2000, 0).UTC()}},
+		Events:    []db.BackupEvent{{ID: 7, ResourceType: db.ResourceHardware, ResourceID: "hw-1", EventType: db.EventCreated, CreatedAt: time.Unix(1000, 0).UTC()}},
+	}
+	var imported db.Backup
+	s := testServer(t, &mock.DB{
+		ExportFunc: func(ctx context.Context) (db.Backup, error) {
+			return backup, nil
+		},
+		ImportFunc: func(ctx context.Context, b db.Backup) error {
+			imported = b
+			return nil
+		},
+	})
+
+	export := &exportServer{}
+	err := s.Export(&admin.ExportRequest{}, export)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, export.chunks)
+
+	in := &importServer{chunks: export.chunks}
+	err = s.Import(in)
+	assert.NoError(t, err)
+	assert.Equal(t, backup, imported)
+	assert.Equal(t, &admin.ImportResponse{Hardware: 1, Templates: 1, Events: 1}, in.res)
+}
+
+func TestImportInvalidArchive(t *testing.T) {
+	called := false
+	s := testServer(t, &mock.DB{
+		ImportFunc: func(ctx context.Context, b db.Backup) error {
+			called = true
+			return nil
+		},
+	})
+	err := s.Import(&importServer{chunks: []*admin.ArchiveChunk{{Data: []byte("not an archive")}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, called)
+}
@@ -0,0 +1,152 @@
+package grpcserver
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/metrics"
+	"github.com/tinkerbell/tink/protos/events"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	resourceTypes = map[events.ResourceType]string{
+		events.ResourceType_RESOURCE_TYPE_HARDWARE: db.ResourceHardware,
+		events.ResourceType_RESOURCE_TYPE_TEMPLATE: db.ResourceTemplate,
+		events.ResourceType_RESOURCE_TYPE_WORKFLOW: db.ResourceWorkflow,
+	}
+	eventTypes = map[events.EventType]string{
+		events.EventType_EVENT_TYPE_CREATED: db.EventCreated,
+		events.EventType_EVENT_TYPE_UPDATED: db.EventUpdated,
+		events.EventType_EVENT_TYPE_DELETED: db.EventDeleted,
+	}
+)
+
+// Events implements events.Events
+func (s *server) Events(in *events.EventsRequest, stream events.EventsService_EventsServer) error {
+	labels := prometheus.Labels{"method": "Events", "op": "push"}
+	metrics.CacheInFlight.With(labels).Inc()
+	defer metrics.CacheInFlight.With(labels).Dec()
+
+	filter, err := eventFilter(in)
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return err
+	}
+	since, err := parseEventCursor(in.GetCursor())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return err
+	}
+	if in.GetFromNow() && in.GetCursor() != "" {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Error(codes.InvalidArgument, "from_now can not be combined with cursor")
+	}
+
+	ctx := stream.Context()
+	l := s.logger.With("cursor", in.GetCursor(), "follow", in.GetFollow())
+	if in.GetFromNow() {
+		since, err = s.db.LatestEventID(ctx)
+		if err != nil {
+			metrics.CacheErrors.With(labels).Inc()
+			l.Error(err)
+			return err
+		}
+	}
+
+	read := func() (int, error) {
+		n := 0
+		err := s.db.GetEvents(ctx, filter, since, watchBatchSize, func(ev db.Event) error {
+			n++
+			since = ev.ID
+			return sendEvent(stream, ev)
+		})
+		return n, err
+	}
+	if in.GetFollow() {
+		err = s.follow(ctx, read)
+	} else {
+		// without follow the stream ends with the last batch that is not
+		// full
+		n := watchBatchSize
+		for n == watchBatchSize && err == nil {
+			n, err = read()
+		}
+	}
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l.Error(err)
+		return err
+	}
+	return nil
+}
+
+func sendEvent(stream events.EventsService_EventsServer, ev db.Event) error {
+	ts, err := ptypes.TimestampProto(ev.CreatedAt)
+	if err != nil {
+		return err
+	}
+	out := &events.Event{
+		Cursor:     formatEventCursor(ev.ID),
+		ResourceId: ev.ResourceID,
+		Actor:      ev.Actor,
+		CreatedAt:  ts,
+	}
+	for t, name := range resourceTypes {
+		if name == ev.ResourceType {
+			out.ResourceType = t
+		}
+	}
+	for t, name := range eventTypes {
+		if name == ev.EventType {
+			out.EventType = t
+		}
+	}
+	return errors.Wrap(stream.Send(out), "stream send")
+}
+
+// eventFilter translates the criteria of the request to the database ones
+func eventFilter(in *events.EventsRequest) (db.EventFilter, error) {
+	filter := db.EventFilter{ResourceIDs: in.GetResourceIds()}
+	for _, t := range in.GetResourceTypes() {
+		name, ok := resourceTypes[t]
+		if !ok {
+			return db.EventFilter{}, status.Errorf(codes.InvalidArgument, "invalid resource type %s", t)
+		}
+		filter.ResourceTypes = append(filter.ResourceTypes, name)
+	}
+	for _, t := range in.GetEventTypes() {
+		name, ok := eventTypes[t]
+		if !ok {
+			return db.EventFilter{}, status.Errorf(codes.InvalidArgument, "invalid event type %s", t)
+		}
+		filter.EventTypes = append(filter.EventTypes, name)
+	}
+	return filter, nil
+}
+
+// The cursor of an event is its id in the events log. It is opaque to the
+// clients, so the way the events are numbered can change.
+func formatEventCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func parseEventCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	id, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || id < 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	return id, nil
+}
@@ -0,0 +1,165 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/db/mock"
+	"github.com/tinkerbell/tink/protos/events"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventsServer collects the events until it has received want of them
+type eventsServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	want   int
+	sent   []*events.Event
+}
+
+func (e *eventsServer) Context() context.Context {
+	return e.ctx
+}
+
+func (e *eventsServer) Send(ev *events.Event) error {
+	e.sent = append(e.sent, ev)
+	if len(e.sent) >= e.want {
+		e.cancel()
+	}
+	return nil
+}
+
+func TestEvents(t *testing.T) {
+	const id = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+	log := []db.Event{
+		{ID: 1, ResourceType: db.ResourceHardware, ResourceID: id, EventType: db.EventCreated},
+		{ID: 2, ResourceType: db.ResourceTemplate, ResourceID: id, EventType: db.EventCreated},
+		{ID: 3, ResourceType: db.ResourceWorkflow, ResourceID: id, EventType: db.EventUpdated},
+	}
+	type event struct {
+		Cursor       string
+		ResourceType events.ResourceType
+		EventType    events.EventType
+	}
+
+	testCases := map[string]struct {
+		req            *events.EventsRequest
+		latest         int64
+		want           int
+		expectedFilter db.EventFilter
+		expected       []event
+		expectedCode   codes.Code
+	}{
+		"all": {
+			req:  &events.EventsRequest{},
+			want: 3,
+			expected: []event{
+				{formatEventCursor(1), events.ResourceType_RESOURCE_TYPE_HARDWARE, events.EventType_EVENT_TYPE_CREATED},
+				{formatEventCursor(2), events.ResourceType_RESOURCE_TYPE_TEMPLATE, events.EventType_EVENT_TYPE_CREATED},
+				{formatEventCursor(3), events.ResourceType_RESOURCE_TYPE_WORKFLOW, events.EventType_EVENT_TYPE_UPDATED},
+			},
+		},
+		"from-cursor": {
+			req:      &events.EventsRequest{Cursor: formatEventCursor(2), Follow: true},
+			want:     1,
+			expected: []event{{formatEventCursor(3), events.ResourceType_RESOURCE_TYPE_WORKFLOW, events.EventType_EVENT_TYPE_UPDATED}},
+		},
+		"from-now": {
+			req:      &events.EventsRequest{FromNow: true, Follow: true},
+			latest:   2,
+			want:     1,
+			expected: []event{{formatEventCursor(3), events.ResourceType_RESOURCE_TYPE_WORKFLOW, events.EventType_EVENT_TYPE_UPDATED}},
+		},
+		"filtered": {
+			req: &events.EventsRequest{
+				ResourceTypes: []events.ResourceType{events.ResourceType_RESOURCE_TYPE_TEMPLATE},
+				ResourceIds:   []string{id},
+				EventTypes:    []events.EventType{events.EventType_EVENT_TYPE_CREATED, events.EventType_EVENT_TYPE_DELETED},
+			},
+			// the filtering is done by the database, the mock returns
+			// everything
+			want: 3,
+			expectedFilter: db.EventFilter{
+				ResourceTypes: []string{db.ResourceTemplate},
+				ResourceIDs:   []string{id},
+				EventTypes:    []string{db.EventCreated, db.EventDeleted},
+			},
+			expected: []event{
+				{formatEventCursor(1), events.ResourceType_RESOURCE_TYPE_HARDWARE, events.EventType_EVENT_TYPE_CREATED},
+				{formatEventCursor(2), events.ResourceType_RESOURCE_TYPE_TEMPLATE, events.EventType_EVENT_TYPE_CREATED},
+				{formatEventCursor(3), events.ResourceType_RESOURCE_TYPE_WORKFLOW, events.EventType_EVENT_TYPE_UPDATED},
+			},
+		},
+		"from-now-and-cursor": {
+			req:          &events.EventsRequest{FromNow: true, Cursor: formatEventCursor(2)},
+			expectedCode: codes.InvalidArgument,
+		},
+		"invalid-cursor": {
+			req:          &events.EventsRequest{Cursor: "not a cursor"},
+			expectedCode: codes.InvalidArgument,
+		},
+		"invalid-resource-type": {
+			req:          &events.EventsRequest{ResourceTypes: []events.ResourceType{events.ResourceType_RESOURCE_TYPE_UNSPECIFIED}},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := testServer(t, &mock.DB{
+				LatestEventIDFunc: func(ctx context.Context) (int64, error) {
+					return tc.latest, nil
+				},
+				GetEventsFunc: func(ctx context.Context, filter db.EventFilter, since int64, limit int, fn func(db.Event) error) error {
+					assert.Equal(t, tc.expectedFilter, filter)
+					assert.Equal(t, watchBatchSize, limit)
+					for _, ev := range log {
+						if ev.ID > since {
+							if err := fn(ev); err != nil {
+								return err
+							}
+						}
+					}
+					return nil
+				},
+			})
+			s.watchInterval = time.Millisecond
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			stream := &eventsServer{ctx: ctx, cancel: cancel, want: tc.want}
+			defer stream.cancel()
+			err := s.Events(tc.req, stream)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEqual(t, context.DeadlineExceeded, stream.ctx.Err())
+			sent := []event{}
+			for _, ev := range stream.sent {
+				assert.Equal(t, id, ev.ResourceId)
+				sent = append(sent, event{ev.Cursor, ev.ResourceType, ev.EventType})
+			}
+			assert.Equal(t, tc.expected, sent)
+		})
+	}
+}
+
+func TestEventCursor(t *testing.T) {
+	id, err := parseEventCursor(formatEventCursor(42))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+
+	id, err = parseEventCursor("")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), id)
+
+	_, err = parseEventCursor(formatEventCursor(-1))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -16,8 +16,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/tinkerbell/tink/db"
 	"github.com/tinkerbell/tink/metrics"
+	"github.com/tinkerbell/tink/protos/admin"
+	"github.com/tinkerbell/tink/protos/events"
 	"github.com/tinkerbell/tink/protos/hardware"
 	"github.com/tinkerbell/tink/protos/template"
+	"github.com/tinkerbell/tink/protos/webhook"
 	"github.com/tinkerbell/tink/protos/workflow"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -29,14 +32,28 @@ type server struct {
 	cert []byte
 	modT time.Time
 
-	db   db.Database
+	db db.Database
+	// quit is closed when the server shuts down
 	quit <-chan struct{}
 
 	dbLock  sync.RWMutex
 	dbReady bool
 
-	watchLock sync.RWMutex
-	watch     map[string]chan string
+	// watchChanged is closed, and replaced, when the data changes to wake
+	// up the watchers before their next poll
+	watchLock     sync.Mutex
+	watchChanged  chan struct{}
+	watchInterval time.Duration
+
+	// webhookDeliveries tracks the deliveries in progress
+	webhookDeliveries sync.WaitGroup
+	webhookAttempts   int
+	webhookTimeout    time.Duration
+	webhookBackoff    time.Duration
+
+	// retentionDays is how long the deleted rows and the events of the
+	// finished workflows are kept, zero keeps them forever
+	retentionDays int
 
 	logger log.Logger
 }
@@ -45,20 +62,48 @@ type ConfigGRPCServer struct {
 	Facility      string
 	TLSCert       string
 	GRPCAuthority string
-	DB            *db.TinkDB
+	// DB is the database of the server, PostgreSQL or an embedded one
+	DB db.Database
+	// WatchInterval is how often the hardware watchers look for changes
+	// made by the other servers, defaultWatchInterval when it is zero
+	WatchInterval time.Duration
+	// WebhookAttempts is how many times a webhook delivery is tried before
+	// it is recorded as failed, defaultWebhookAttempts when it is zero
+	WebhookAttempts int
+	// WebhookTimeout bounds every delivery attempt, defaultWebhookTimeout
+	// when it is zero
+	WebhookTimeout time.Duration
+	// RetentionDays is how many days the deleted rows, and the events and
+	// data of the finished workflows, are kept. Zero keeps them forever.
+	RetentionDays int
+	// GCInterval is how often the rows past the retention period are
+	// removed, defaultGCInterval when it is zero
+	GCInterval time.Duration
+	// AllowAllNamespaces lets the requests for pkg.AllNamespaces see the
+	// resources of all the namespaces, and use the events, the webhooks and
+	// the administration like the ones of the default namespace. The clients
+	// sending it have to be trusted.
+	AllowAllNamespaces bool
 }
 
 // SetupGRPC setup and return a gRPC server
 func SetupGRPC(ctx context.Context, logger log.Logger, config *ConfigGRPCServer, errCh chan<- error) ([]byte, time.Time) {
 	params := []grpc.ServerOption{
-		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
+		grpc.ChainUnaryInterceptor(grpc_prometheus.UnaryServerInterceptor, actorUnaryInterceptor, namespaceUnaryInterceptor(config.AllowAllNamespaces)),
+		grpc.ChainStreamInterceptor(grpc_prometheus.StreamServerInterceptor, namespaceStreamInterceptor(config.AllowAllNamespaces)),
 	}
 	metrics.SetupMetrics(config.Facility, logger)
 	server := &server{
-		db:      config.DB,
-		dbReady: true,
-		logger:  logger,
+		db:            config.DB,
+		quit:          ctx.Done(),
+		dbReady:       true,
+		logger:        logger,
+		watchInterval: config.WatchInterval,
+
+		webhookAttempts: config.WebhookAttempts,
+		webhookTimeout:  config.WebhookTimeout,
+
+		retentionDays: config.RetentionDays,
 	}
 	if cert := config.TLSCert; cert != "" {
 		server.cert = []byte(cert)
@@ -75,6 +120,9 @@ func SetupGRPC(ctx context.Context, logger log.Logger, config *ConfigGRPCServer,
 	template.RegisterTemplateServiceServer(s, server)
 	workflow.RegisterWorkflowServiceServer(s, server)
 	hardware.RegisterHardwareServiceServer(s, server)
+	events.RegisterEventsServiceServer(s, server)
+	webhook.RegisterWebhookServiceServer(s, server)
+	admin.RegisterAdminServiceServer(s, server)
 	reflection.Register(s)
 
 	grpc_prometheus.Register(s)
@@ -87,13 +135,21 @@ func SetupGRPC(ctx context.Context, logger log.Logger, config *ConfigGRPCServer,
 			panic(err)
 		}
 
-		errCh <- s.Serve(lis)
+		err = s.Serve(lis)
+		// the server stopped taking requests, the deliveries they
+		// triggered still have to complete or be recorded as failed
+		server.waitWebhookDeliveries()
+		errCh <- err
 	}()
 
 	go func() {
 		<-ctx.Done()
 		s.GracefulStop()
 	}()
+
+	if config.RetentionDays > 0 {
+		go server.collectGarbage(ctx, config.GCInterval)
+	}
 	return server.cert, server.modT
 }
 
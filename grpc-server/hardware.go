@@ -2,25 +2,26 @@ package grpcserver
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tinkerbell/tink/db"
 	"github.com/tinkerbell/tink/metrics"
+	"github.com/tinkerbell/tink/pkg"
+	"github.com/tinkerbell/tink/pkg/selector"
 	"github.com/tinkerbell/tink/protos/hardware"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-const (
-	conflictMACAddr = "conflicting hardware MAC address %v provided with hardware data/info"
-	duplicateMAC    = "Duplicate MAC address found"
-)
-
 func (s *server) Push(ctx context.Context, in *hardware.PushRequest) (*hardware.Empty, error) {
 	s.logger.Info("push")
 	labels := prometheus.Labels{"method": "Push", "op": ""}
@@ -47,12 +48,19 @@ func (s *server) Push(ctx context.Context, in *hardware.PushRequest) (*hardware.
 		return &hardware.Empty{}, err
 	}
 
+	if err := checkPushedState(hw); err != nil {
+		metrics.CacheTotals.With(labels).Inc()
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.With("id", hw.Id).Error(err)
+		return &hardware.Empty{}, err
+	}
+
 	// normalize data prior to storing in the database
 	normalizeHardwareData(hw)
-
-	// validate the hardware data to avoid duplicate mac address
-	err := s.validateHardwareData(ctx, hw)
-	if err != nil {
+	if err := prepareMetadata(hw); err != nil {
+		metrics.CacheTotals.With(labels).Inc()
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.With("id", hw.Id).Error(err)
 		return &hardware.Empty{}, err
 	}
 
@@ -68,6 +76,9 @@ func (s *server) Push(ctx context.Context, in *hardware.PushRequest) (*hardware.
 	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
 	defer timer.ObserveDuration()
 
+	if in.GetForce() {
+		ctx = db.WithForce(ctx)
+	}
 	s.logger.Info(msg)
 	err = s.db.InsertIntoDB(ctx, string(data))
 	s.logger.Info("done " + msg)
@@ -78,21 +89,272 @@ func (s *server) Push(ctx context.Context, in *hardware.PushRequest) (*hardware.
 			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
 		}
 		l.Error(err)
+		return &hardware.Empty{}, err
 	}
 	s.logger.With("id", hw.Id).Info("data pushed")
+	s.notifyWatchers()
 
-	s.watchLock.RLock()
-	if ch := s.watch[hw.Id]; ch != nil {
-		select {
-		case ch <- string(data):
-		default:
-			metrics.WatchMissTotal.Inc()
+	return &hardware.Empty{}, err
+}
+
+// PushBatch implements hardware.PushBatch
+func (s *server) PushBatch(ctx context.Context, in *hardware.PushBatchRequest) (*hardware.PushBatchResponse, error) {
+	labels := prometheus.Labels{"method": "PushBatch", "op": "insert"}
+	metrics.CacheTotals.With(labels).Inc()
+	metrics.CacheInFlight.With(labels).Inc()
+	defer metrics.CacheInFlight.With(labels).Dec()
+
+	res := &hardware.PushBatchResponse{}
+	data := make([]string, len(in.GetData()))
+	ids := map[string]int{}
+	valid := true
+	for i, hw := range in.GetData() {
+		result := &hardware.PushBatchResponse_Result{Id: hw.GetId()}
+		res.Results = append(res.Results, result)
+
+		err := validateHardware(hw)
+		if err == nil {
+			if j, ok := ids[hw.Id]; ok {
+				err = fmt.Errorf("id %s is already used by item %d", hw.Id, j)
+			}
+			ids[hw.Id] = i
+		}
+		if err == nil {
+			var b []byte
+			b, err = json.Marshal(hw)
+			data[i] = string(b)
+		}
+		if err != nil {
+			result.Error = status.Convert(err).Message()
+			valid = false
 		}
 	}
-	s.watchLock.RUnlock()
-	s.logger.With("id", hw.Id).Info("skipping blocked watcher")
+	if !valid || in.GetDryRun() {
+		return res, nil
+	}
 
-	return &hardware.Empty{}, err
+	s.dbLock.RLock()
+	ready := s.dbReady
+	s.dbLock.RUnlock()
+	if !ready {
+		metrics.CacheStalls.With(labels).Inc()
+		return nil, errors.New("DB is not ready")
+	}
+
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	if in.GetForce() {
+		ctx = db.WithForce(ctx)
+	}
+	err := s.db.InsertHardwareBatch(ctx, data)
+	if batchErr, ok := err.(*db.BatchError); ok {
+		res.Results[batchErr.Index].Error = status.Convert(batchErr.Err).Message()
+		return res, nil
+	}
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l := s.logger
+		if pqErr := db.Error(err); pqErr != nil {
+			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
+		}
+		l.Error(err)
+		return nil, err
+	}
+	res.Pushed = true
+
+	metrics.CacheHits.With(labels).Inc()
+	s.logger.With("count", len(data)).Info("data pushed")
+	s.notifyWatchers()
+	return res, nil
+}
+
+// validateHardware checks the hardware of a batch push, normalizing it for
+// the database
+func validateHardware(hw *hardware.Hardware) error {
+	if hw == nil {
+		return errors.New("expected data not to be nil")
+	}
+	if _, err := uuid.Parse(hw.GetId()); err != nil {
+		return errors.New("id must be set to a UUID, got id: " + hw.GetId())
+	}
+	if err := checkPushedState(hw); err != nil {
+		return err
+	}
+	normalizeHardwareData(hw)
+	return prepareMetadata(hw)
+}
+
+// Patch implements hardware.Patch
+func (s *server) Patch(ctx context.Context, in *hardware.PatchRequest) (*hardware.Hardware, error) {
+	s.logger.Info("patch")
+	labels := prometheus.Labels{"method": "Patch", "op": ""}
+	metrics.CacheInFlight.With(labels).Inc()
+	defer metrics.CacheInFlight.With(labels).Dec()
+
+	// must be a copy so deferred cacheInFlight.Dec matches the Inc
+	labels = prometheus.Labels{"method": "Patch", "op": ""}
+
+	if in.GetId() == "" {
+		metrics.CacheTotals.With(labels).Inc()
+		metrics.CacheErrors.With(labels).Inc()
+		err := status.Error(codes.InvalidArgument, "id must be set to a UUID")
+		s.logger.Error(err)
+		return &hardware.Hardware{}, err
+	}
+	if (in.GetMergePatch() == "") == (in.GetUpdateMask() == nil) {
+		metrics.CacheTotals.With(labels).Inc()
+		metrics.CacheErrors.With(labels).Inc()
+		err := status.Error(codes.InvalidArgument, "exactly one between merge_patch and update_mask has to be set")
+		s.logger.Error(err)
+		return &hardware.Hardware{}, err
+	}
+
+	labels["op"] = "patch"
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	l := s.logger.With("id", in.Id)
+	// the patch applies to the latest version, not to the one of a replica
+	stored, err := s.db.GetByID(db.WithPrimary(ctx), in.Id)
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		if errors.Cause(err) == sql.ErrNoRows {
+			return &hardware.Hardware{}, status.Errorf(codes.NotFound, "not found, id:%s", in.Id)
+		}
+		l.Error(err)
+		return &hardware.Hardware{}, err
+	}
+	current := &hardware.Hardware{}
+	if err := json.Unmarshal([]byte(stored), current); err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l.Error(err)
+		return &hardware.Hardware{}, err
+	}
+	if in.Version != 0 && in.Version != current.Version {
+		metrics.CacheErrors.With(labels).Inc()
+		return &hardware.Hardware{}, status.Error(codes.Aborted, "version conflict, the hardware has been modified since it was read")
+	}
+
+	hw, err := patchHardware(current, in)
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l.Error(err)
+		return &hardware.Hardware{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if hw.Id != current.Id {
+		metrics.CacheErrors.With(labels).Inc()
+		return &hardware.Hardware{}, status.Error(codes.InvalidArgument, "the hardware id can not be patched")
+	}
+	// the stored version makes sure nobody else wrote the hardware since it
+	// got read at the beginning of the patch
+	hw.Version = current.Version
+
+	normalizeHardwareData(hw)
+	if err := prepareMetadata(hw); err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return &hardware.Hardware{}, err
+	}
+
+	data, err := json.Marshal(hw)
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l.Error(err)
+		return &hardware.Hardware{}, err
+	}
+	if err := s.db.InsertIntoDB(ctx, string(data)); err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		if pqErr := db.Error(err); pqErr != nil {
+			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
+		}
+		l.Error(err)
+		return &hardware.Hardware{}, err
+	}
+	hw.Version++
+	setTypedMetadata(hw)
+	l.Info("data patched")
+	s.notifyWatchers()
+
+	return hw, nil
+}
+
+// selectHardware calls fn for every hardware matching the label selector and
+// the filters, sorted by orderBy. When p is not nil only the hardware in its
+// page is selected. A malformed selector or filter returns an
+// InvalidArgument error.
+func (s *server) selectHardware(ctx context.Context, labelSelector string, filters []string, orderBy string, p *pager, fn func(*hardware.Hardware) error) error {
+	m, err := newHardwareMatcher(labelSelector, filters)
+	if err != nil {
+		return err
+	}
+
+	// the label equalities are resolved by the database, everything else
+	// gets checked here
+	opts := db.ListOptions{OrderBy: orderBy}
+	if p != nil {
+		opts = p.options(opts, !m.sel.OnlyEqualities() || len(m.filters) > 0)
+	}
+	return s.db.ListHardware(ctx, m.sel.Equalities(), opts, func(j []byte) error {
+		hw := &hardware.Hardware{}
+		if err := json.Unmarshal(j, hw); err != nil {
+			return err
+		}
+		setTypedMetadata(hw)
+		if ok, err := m.matches(hw); !ok {
+			return err
+		}
+		if p != nil {
+			if ok, err := p.add(); !ok {
+				return err
+			}
+		}
+		return fn(hw)
+	})
+}
+
+// hardwareMatcher checks the hardware against a label selector and filters
+type hardwareMatcher struct {
+	sel     selector.Selector
+	filters []selector.Filter
+}
+
+// newHardwareMatcher parses the label selector and the filters. A malformed
+// one returns an InvalidArgument error.
+func newHardwareMatcher(labelSelector string, filters []string) (*hardwareMatcher, error) {
+	sel, err := selector.Parse(labelSelector)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	fs, err := selector.ParseFilters(filters)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &hardwareMatcher{sel: sel, filters: fs}, nil
+}
+
+func (m *hardwareMatcher) matches(hw *hardware.Hardware) (bool, error) {
+	if !m.sel.Matches(hw.Labels) {
+		return false, nil
+	}
+	if len(m.filters) == 0 {
+		return true, nil
+	}
+	doc, err := hardwareToMap(hw)
+	if err != nil {
+		// metadata is not valid JSON, the hardware can still be matched
+		// by the other fields
+		doc, err = hardwareToMap(&hardware.Hardware{Id: hw.Id, Version: hw.Version, Network: hw.Network, Labels: hw.Labels})
+		if err != nil {
+			return false, err
+		}
+	}
+	for _, f := range m.filters {
+		if !f.Matches(doc) {
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
 func (s *server) by(method string, fn func() (string, error)) (*hardware.Hardware, error) {
@@ -125,6 +387,7 @@ func (s *server) by(method string, fn func() (string, error)) (*hardware.Hardwar
 	if err := json.Unmarshal([]byte(j), hw); err != nil {
 		return nil, err
 	}
+	setTypedMetadata(hw)
 	return hw, nil
 }
 
@@ -148,13 +411,28 @@ func (s *server) ByID(ctx context.Context, in *hardware.GetRequest) (*hardware.H
 }
 
 // ALL implements hardware.All
-func (s *server) All(_ *hardware.Empty, stream hardware.HardwareService_AllServer) error {
-	labels := prometheus.Labels{"method": "All", "op": "get"}
+func (s *server) All(in *hardware.ListRequest, stream hardware.HardwareService_AllServer) error {
+	return s.listHardware("All", in, stream)
+}
+
+// List implements hardware.List
+func (s *server) List(in *hardware.ListRequest, stream hardware.HardwareService_ListServer) error {
+	return s.listHardware("List", in, stream)
+}
+
+func (s *server) listHardware(method string, in *hardware.ListRequest, stream hardware.HardwareService_ListServer) error {
+	labels := prometheus.Labels{"method": method, "op": "get"}
 
 	metrics.CacheTotals.With(labels).Inc()
 	metrics.CacheInFlight.With(labels).Inc()
 	defer metrics.CacheInFlight.With(labels).Dec()
 
+	p, err := newPager(in.GetPageSize(), in.GetPageToken(), in.GetOrderBy())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return err
+	}
+
 	s.dbLock.RLock()
 	ready := s.dbReady
 	s.dbLock.RUnlock()
@@ -165,13 +443,8 @@ func (s *server) All(_ *hardware.Empty, stream hardware.HardwareService_AllServe
 
 	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
 	defer timer.ObserveDuration()
-	err := s.db.GetAll(func(j []byte) error {
-		hw := &hardware.Hardware{}
-		if err := json.Unmarshal(j, hw); err != nil {
-			return err
-		}
-		return stream.Send(hw)
-	})
+	err = s.selectHardware(stream.Context(), in.GetLabelSelector(), in.GetFilters(), in.GetOrderBy(), p, stream.Send)
+	err = p.done(err, stream.SetTrailer)
 	if err != nil {
 		metrics.CacheErrors.With(labels).Inc()
 		return err
@@ -181,64 +454,84 @@ func (s *server) All(_ *hardware.Empty, stream hardware.HardwareService_AllServe
 	return nil
 }
 
-func (s *server) DeprecatedWatch(in *hardware.GetRequest, stream hardware.HardwareService_DeprecatedWatchServer) error {
-	l := s.logger.With("id", in.Id)
-
-	ch := make(chan string, 1)
-	s.watchLock.Lock()
-	old, ok := s.watch[in.Id]
-	if ok {
-		l.Info("evicting old watch")
-		close(old)
-	}
-	s.watch[in.Id] = ch
-	s.watchLock.Unlock()
+// History implements hardware.History
+func (s *server) History(in *hardware.GetRequest, stream hardware.HardwareService_HistoryServer) error {
+	labels := prometheus.Labels{"method": "History", "op": "get"}
 
-	labels := prometheus.Labels{"method": "Watch", "op": "push"}
+	metrics.CacheTotals.With(labels).Inc()
 	metrics.CacheInFlight.With(labels).Inc()
 	defer metrics.CacheInFlight.With(labels).Dec()
 
-	disconnect := true
-	defer func() {
-		if !disconnect {
-			return
-		}
-		s.watchLock.Lock()
-		delete(s.watch, in.Id)
-		s.watchLock.Unlock()
-		close(ch)
-	}()
+	if in.Id == "" {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Error(codes.InvalidArgument, "id must be set to a UUID")
+	}
 
-	hw := &hardware.Hardware{}
-	for {
-		select {
-		case <-s.quit:
-			l.Info("server is shutting down")
-			return status.Error(codes.OK, "server is shutting down")
-		case <-stream.Context().Done():
-			l.Info("client disconnected")
-			return status.Error(codes.OK, "client disconnected")
-		case j, ok := <-ch:
-			if !ok {
-				disconnect = false
-				l.Info("we are being evicted, goodbye")
-				// ch was replaced and already closed
-				return status.Error(codes.Unknown, "evicted")
-			}
+	s.dbLock.RLock()
+	ready := s.dbReady
+	s.dbLock.RUnlock()
+	if !ready {
+		metrics.CacheStalls.With(labels).Inc()
+		return errors.New("DB is not ready")
+	}
 
-			hw.Reset()
-			if err := json.Unmarshal([]byte(j), hw); err != nil {
-				return err
-			}
-			err := stream.Send(hw)
-			if err != nil {
-				metrics.CacheErrors.With(labels).Inc()
-				err = errors.Wrap(err, "stream send")
-				l.Error(err)
-				return err
-			}
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	found := false
+	err := s.db.GetHardwareHistory(stream.Context(), in.Id, func(rev db.HardwareRevision) error {
+		found = true
+		r, err := hardwareRevisionToProto(rev)
+		if err != nil {
+			return err
+		}
+		return stream.Send(r)
+	})
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		logger := s.logger.With("id", in.Id)
+		if pqErr := db.Error(err); pqErr != nil {
+			logger = logger.With("detail", pqErr.Detail, "where", pqErr.Where)
 		}
+		logger.Error(err)
+		return err
+	}
+	if !found {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Errorf(codes.NotFound, "no history found for hardware %s", in.Id)
+	}
+
+	metrics.CacheHits.With(labels).Inc()
+	return nil
+}
+
+func hardwareRevisionToProto(rev db.HardwareRevision) (*hardware.HardwareRevision, error) {
+	hw := &hardware.Hardware{}
+	if err := json.Unmarshal([]byte(rev.Data), hw); err != nil {
+		return nil, errors.Wrap(err, "invalid hardware revision data")
 	}
+	setTypedMetadata(hw)
+	createdAt, err := ptypes.TimestampProto(rev.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	r := &hardware.HardwareRevision{
+		Revision:   rev.Revision,
+		HardwareId: rev.HardwareID,
+		Version:    rev.Version,
+		EventType:  rev.EventType,
+		Actor:      rev.Actor,
+		CreatedAt:  createdAt,
+		Data:       hw,
+	}
+	for _, c := range rev.Diff {
+		r.Changes = append(r.Changes, &hardware.HardwareRevision_Change{
+			Path:     c.Path,
+			OldValue: string(c.Old),
+			NewValue: string(c.New),
+		})
+	}
+	return r, nil
 }
 
 // Cert returns the public cert that can be served to clients
@@ -289,50 +582,177 @@ func (s *server) Delete(ctx context.Context, in *hardware.DeleteRequest) (*hardw
 		logger.Error(err)
 	}
 
-	s.watchLock.RLock()
-	if ch := s.watch[in.Id]; ch != nil {
-		select {
-		case ch <- in.Id:
-		default:
-			metrics.WatchMissTotal.Inc()
-			s.logger.With("id", in.Id).Info("skipping blocked watcher")
-		}
-	}
-	s.watchLock.RUnlock()
+	s.notifyWatchers()
 
 	return &hardware.Empty{}, err
 }
 
-func (s *server) validateHardwareData(ctx context.Context, hw *hardware.Hardware) error {
+func normalizeHardwareData(hw *hardware.Hardware) {
+	// Ensure MAC is stored as lowercase
 	for _, iface := range hw.GetNetwork().GetInterfaces() {
-		mac := iface.GetDhcp().GetMac()
+		dhcp := iface.GetDhcp()
+		if mac := dhcp.GetMac(); mac != "" {
+			dhcp.Mac = strings.ToLower(mac)
+		}
+	}
+}
 
-		if data, _ := s.db.GetByMAC(ctx, mac); data != "" {
-			s.logger.With("MAC", mac).Info(duplicateMAC)
+// patchHardware returns a copy of hw with the patch described by the request
+// applied. Both the merge patch and the update mask work on the JSON
+// representation of the hardware, where metadata is an object.
+func patchHardware(hw *hardware.Hardware, in *hardware.PatchRequest) (*hardware.Hardware, error) {
+	doc, err := hardwareToMap(hw)
+	if err != nil {
+		return nil, err
+	}
 
-			newhw := hardware.Hardware{}
-			if err := json.Unmarshal([]byte(data), &newhw); err != nil {
-				s.logger.Error(err, "Failed to unmarshal hardware data")
-				return err
+	if in.GetMergePatch() != "" {
+		var patch interface{}
+		if err := json.Unmarshal([]byte(in.GetMergePatch()), &patch); err != nil {
+			return nil, errors.Wrap(err, "invalid merge patch")
+		}
+		if _, ok := patch.(map[string]interface{}); !ok {
+			return nil, errors.New("invalid merge patch, it has to be a JSON object")
+		}
+		doc = mergePatch(doc, patch).(map[string]interface{})
+	} else {
+		mask := in.GetUpdateMask()
+		if !mask.IsValid(&hardware.Hardware{}) {
+			return nil, fmt.Errorf("invalid update mask %v", mask.GetPaths())
+		}
+		data := in.GetData()
+		if data.GetTypedMetadata() != nil && data.GetMetadata() == "" {
+			m, err := pkg.MarshalMetadata(data.GetTypedMetadata())
+			if err != nil {
+				return nil, err
 			}
-
-			if newhw.Id == hw.Id {
-				return nil
+			// every other field of the request is kept, the mask can
+			// select any of them
+			data = proto.Clone(data).(*hardware.Hardware)
+			data.Metadata = m
+			data.TypedMetadata = nil
+		}
+		src, err := hardwareToMap(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range mask.GetPaths() {
+			// the typed metadata is stored as the metadata document
+			if path == "typed_metadata" || strings.HasPrefix(path, "typed_metadata.") {
+				path = "metadata" + strings.TrimPrefix(path, "typed_metadata")
 			}
-
-			return fmt.Errorf(conflictMACAddr, mac)
+			copyPath(doc, src, strings.Split(path, "."))
 		}
 	}
 
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	patched := pkg.HardwareWrapper{Hardware: &hardware.Hardware{}}
+	if err := json.Unmarshal(b, &patched); err != nil {
+		return nil, errors.Wrap(err, "the patched hardware is not valid")
+	}
+	return patched.Hardware, nil
+}
+
+// prepareMetadata validates the metadata against the schema from
+// packet.proto. The typed metadata, when set, is converted to the metadata
+// document, which is the only one getting stored.
+func prepareMetadata(hw *hardware.Hardware) error {
+	if hw.TypedMetadata != nil {
+		m, err := pkg.MarshalMetadata(hw.TypedMetadata)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if hw.Metadata == "" {
+			hw.Metadata = m
+		} else if parsed, err := pkg.ParseMetadata(hw.Metadata); err != nil || !proto.Equal(parsed, hw.TypedMetadata) {
+			return status.Error(codes.InvalidArgument, "metadata and typed_metadata are both set with different values, set only one of them")
+		}
+		hw.TypedMetadata = nil
+	}
+	if hw.Metadata == "" {
+		return nil
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(hw.Metadata), &doc); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid metadata, it has to be a JSON object")
+	}
+	if _, err := pkg.ParseMetadata(hw.Metadata); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
 	return nil
 }
 
-func normalizeHardwareData(hw *hardware.Hardware) {
-	// Ensure MAC is stored as lowercase
-	for _, iface := range hw.GetNetwork().GetInterfaces() {
-		dhcp := iface.GetDhcp()
-		if mac := dhcp.GetMac(); mac != "" {
-			dhcp.Mac = strings.ToLower(mac)
+// setTypedMetadata fills the typed metadata of a hardware read from the
+// database. It is left empty for the metadata not matching the schema,
+// written before it got validated.
+func setTypedMetadata(hw *hardware.Hardware) {
+	if hw.Metadata == "" {
+		return
+	}
+	if m, err := pkg.ParseMetadata(hw.Metadata); err == nil {
+		hw.TypedMetadata = m
+	}
+}
+
+func hardwareToMap(hw *hardware.Hardware) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+	if hw == nil {
+		return doc, nil
+	}
+	b, err := json.Marshal(pkg.HardwareWrapper{Hardware: hw})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// mergePatch applies patch to target following the JSON merge patch
+// semantic described in RFC 7386.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+// copyPath copies the value found at path in src to dst. When the value is
+// not set in src it gets removed from dst as well.
+func copyPath(dst, src map[string]interface{}, path []string) {
+	key := path[0]
+	if len(path) == 1 {
+		if v, ok := src[key]; ok {
+			dst[key] = v
+		} else {
+			delete(dst, key)
 		}
+		return
+	}
+	s, _ := src[key].(map[string]interface{})
+	if s == nil {
+		s = map[string]interface{}{}
+	}
+	d, _ := dst[key].(map[string]interface{})
+	if d == nil {
+		d = map[string]interface{}{}
+		dst[key] = d
 	}
+	copyPath(d, s, path[1:])
 }
@@ -1,10 +1,25 @@
 package grpcserver
 
 import (
+	"context"
+	"database/sql"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/db/mock"
+	"github.com/tinkerbell/tink/pkg"
 	"github.com/tinkerbell/tink/protos/hardware"
+	"github.com/tinkerbell/tink/protos/packet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func Test_server_normalizeHardwareData(t *testing.T) {
@@ -177,3 +192,459 @@ func Test_server_normalizeHardwareData(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchHardware(t *testing.T) {
+	const (
+		id     = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+		stored = `{"id":"0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95","version":3,"metadata":"{\"facility\":{\"facility_code\":\"onprem\"}}","network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:01","hostname":"server001"},"netboot":{"allow_pxe":true,"allow_workflow":true}}]}}`
+	)
+
+	testCases := map[string]struct {
+		req          *hardware.PatchRequest
+		expectedCode codes.Code
+		expectation  func(*testing.T, *hardware.Hardware)
+	}{
+		"merge-patch": {
+			req: &hardware.PatchRequest{
+				Id:         id,
+				MergePatch: `{"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:01","hostname":"server002"}}]},"metadata":{"facility":{"plan_slug":"c2.medium.x86"}}}`,
+			},
+			expectation: func(t *testing.T, hw *hardware.Hardware) {
+				assert.Equal(t, "server002", hw.Network.Interfaces[0].Dhcp.Hostname)
+				assert.Nil(t, hw.Network.Interfaces[0].Netboot)
+				assert.JSONEq(t, `{"facility":{"facility_code":"onprem","plan_slug":"c2.medium.x86"}}`, hw.Metadata)
+				assert.Equal(t, int64(4), hw.Version)
+			},
+		},
+		"merge-patch-removes-null-fields": {
+			req: &hardware.PatchRequest{
+				Id:         id,
+				MergePatch: `{"metadata":null}`,
+				Version:    3,
+			},
+			expectation: func(t *testing.T, hw *hardware.Hardware) {
+				assert.Empty(t, hw.Metadata)
+				assert.Equal(t, "server001", hw.Network.Interfaces[0].Dhcp.Hostname)
+			},
+		},
+		"update-mask": {
+			req: &hardware.PatchRequest{
+				Id: id,
+				Data: &hardware.Hardware{
+					Metadata: `{"state":"provisioning"}`,
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"metadata"}},
+			},
+			expectation: func(t *testing.T, hw *hardware.Hardware) {
+				assert.JSONEq(t, `{"state":"provisioning"}`, hw.Metadata)
+				assert.Equal(t, "server001", hw.Network.Interfaces[0].Dhcp.Hostname)
+			},
+		},
+		"update-mask-typed-metadata-keeps-other-fields": {
+			req: &hardware.PatchRequest{
+				Id: id,
+				Data: &hardware.Hardware{
+					TypedMetadata: &packet.Metadata{State: "provisioning"},
+					State:         hardware.State_STATE_BROKEN,
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"typed_metadata", "state"}},
+			},
+			expectation: func(t *testing.T, hw *hardware.Hardware) {
+				assert.JSONEq(t, `{"state":"provisioning"}`, hw.Metadata)
+				assert.Equal(t, hardware.State_STATE_BROKEN, hw.State)
+				assert.Equal(t, "server001", hw.Network.Interfaces[0].Dhcp.Hostname)
+			},
+		},
+		"update-mask-clears-unset-fields": {
+			req: &hardware.PatchRequest{
+				Id:         id,
+				Data:       &hardware.Hardware{},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"network"}},
+			},
+			expectation: func(t *testing.T, hw *hardware.Hardware) {
+				assert.Nil(t, hw.Network)
+				assert.NotEmpty(t, hw.Metadata)
+			},
+		},
+		"invalid-update-mask": {
+			req: &hardware.PatchRequest{
+				Id:         id,
+				Data:       &hardware.Hardware{},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"network.interfaces.dhcp"}},
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		"both-patch-and-mask": {
+			req: &hardware.PatchRequest{
+				Id:         id,
+				MergePatch: `{}`,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"metadata"}},
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		"patch-id": {
+			req: &hardware.PatchRequest{
+				Id:         id,
+				MergePatch: `{"id":"e0ef0eb6-b2d6-4b5b-8f3e-fde3a1d48f0c"}`,
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		"version-conflict": {
+			req: &hardware.PatchRequest{
+				Id:         id,
+				MergePatch: `{"metadata":null}`,
+				Version:    2,
+			},
+			expectedCode: codes.Aborted,
+		},
+		"not-found": {
+			req: &hardware.PatchRequest{
+				Id:         "e0ef0eb6-b2d6-4b5b-8f3e-fde3a1d48f0c",
+				MergePatch: `{"metadata":null}`,
+			},
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var inserted string
+			s := testServer(t, &mock.DB{
+				GetByIDFunc: func(ctx context.Context, hwID string) (string, error) {
+					if hwID != id {
+						return "", errors.Wrap(sql.ErrNoRows, "SELECT")
+					}
+					return stored, nil
+				},
+				InsertIntoDBFunc: func(ctx context.Context, data string) error {
+					inserted = data
+					return nil
+				},
+			})
+
+			hw, err := s.Patch(context.Background(), tc.req)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.Empty(t, inserted)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, inserted, `"version":3`)
+			tc.expectation(t, hw)
+		})
+	}
+}
+
+type listServer struct {
+	grpc.ServerStream
+	sent    []*hardware.Hardware
+	trailer metadata.MD
+}
+
+func (l *listServer) SetTrailer(md metadata.MD) {
+	l.trailer = metadata.Join(l.trailer, md)
+}
+
+func (l *listServer) Context() context.Context {
+	return context.Background()
+}
+
+func (l *listServer) Send(hw *hardware.Hardware) error {
+	l.sent = append(l.sent, hw)
+	return nil
+}
+
+func TestListHardware(t *testing.T) {
+	stored := []string{
+		`{"id":"a","labels":{"arch":"arm64","rack":"r1"},"metadata":"{\"facility\":{\"facility_code\":\"onprem\"}}","network":{"interfaces":[{"dhcp":{"arch":"aarch64"}}]}}`,
+		`{"id":"b","labels":{"arch":"arm64","rack":"r2","gpu":"true"},"metadata":"{\"facility\":{\"facility_code\":\"sjc1\"}}","network":{"interfaces":[{"dhcp":{"arch":"x86_64"}},{"dhcp":{"arch":"aarch64"}}]}}`,
+		`{"id":"c","labels":{"arch":"x86_64","rack":"r1"},"metadata":"not json","network":{"interfaces":[{"dhcp":{"arch":"x86_64"}}]}}`,
+	}
+
+	testCases := map[string]struct {
+		req            *hardware.ListRequest
+		expectedLabels map[string]string
+		expectedIDs    []string
+		expectedCode   codes.Code
+	}{
+		"everything": {
+			req:            &hardware.ListRequest{},
+			expectedLabels: map[string]string{},
+			expectedIDs:    []string{"a", "b", "c"},
+		},
+		"label-equality": {
+			req:            &hardware.ListRequest{LabelSelector: "arch=arm64"},
+			expectedLabels: map[string]string{"arch": "arm64"},
+			expectedIDs:    []string{"a", "b"},
+		},
+		"label-inequality-and-existence": {
+			req:            &hardware.ListRequest{LabelSelector: "rack!=r2,!gpu"},
+			expectedLabels: map[string]string{},
+			expectedIDs:    []string{"a", "c"},
+		},
+		"filters": {
+			req:            &hardware.ListRequest{Filters: []string{"network.interfaces.dhcp.arch=aarch64"}},
+			expectedLabels: map[string]string{},
+			expectedIDs:    []string{"a", "b"},
+		},
+		"metadata-filter": {
+			req:            &hardware.ListRequest{LabelSelector: "arch==arm64", Filters: []string{"metadata.facility.facility_code=sjc1"}},
+			expectedLabels: map[string]string{"arch": "arm64"},
+			expectedIDs:    []string{"b"},
+		},
+		"invalid-selector": {
+			req:          &hardware.ListRequest{LabelSelector: "=arm64"},
+			expectedCode: codes.InvalidArgument,
+		},
+		"invalid-filter": {
+			req:          &hardware.ListRequest{Filters: []string{"network..arch=x86_64"}},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var labels map[string]string
+			s := testServer(t, &mock.DB{
+				ListHardwareFunc: func(ctx context.Context, l map[string]string, opts db.ListOptions, fn func([]byte) error) error {
+					labels = l
+					for _, j := range stored {
+						if err := fn([]byte(j)); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			})
+			s.dbReady = true
+
+			stream := &listServer{}
+			err := s.List(tc.req, stream)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedLabels, labels)
+			ids := []string{}
+			for _, hw := range stream.sent {
+				ids = append(ids, hw.Id)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+type historyServer struct {
+	grpc.ServerStream
+	sent []*hardware.HardwareRevision
+}
+
+func (h *historyServer) Context() context.Context {
+	return context.Background()
+}
+
+func (h *historyServer) Send(rev *hardware.HardwareRevision) error {
+	h.sent = append(h.sent, rev)
+	return nil
+}
+
+func TestHardwareHistory(t *testing.T) {
+	const id = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+	createdAt := time.Date(2021, 4, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		id           string
+		revisions    []db.HardwareRevision
+		expected     []*hardware.HardwareRevision
+		expectedCode codes.Code
+	}{
+		"history": {
+			id: id,
+			revisions: []db.HardwareRevision{
+				{Revision: 1, HardwareID: id, Version: 1, EventType: db.HardwareCreated, Actor: "alice", CreatedAt: createdAt, Data: `{"id":"` + id + `","version":1}`, Diff: []pkg.Change{{Path: "id", New: []byte(`"` + id + `"`)}}},
+				{Revision: 2, HardwareID: id, Version: 2, EventType: db.HardwareUpdated, Actor: "bob", CreatedAt: createdAt, Data: `{"id":"` + id + `","version":2,"labels":{"rack":"r1"}}`, Diff: []pkg.Change{{Path: "labels", New: []byte(`{"rack":"r1"}`)}}},
+			},
+			expected: []*hardware.HardwareRevision{
+				{Revision: 1, HardwareId: id, Version: 1, EventType: db.HardwareCreated, Actor: "alice", Data: &hardware.Hardware{Id: id, Version: 1}, Changes: []*hardware.HardwareRevision_Change{{Path: "id", NewValue: `"` + id + `"`}}},
+				{Revision: 2, HardwareId: id, Version: 2, EventType: db.HardwareUpdated, Actor: "bob", Data: &hardware.Hardware{Id: id, Version: 2, Labels: map[string]string{"rack": "r1"}}, Changes: []*hardware.HardwareRevision_Change{{Path: "labels", NewValue: `{"rack":"r1"}`}}},
+			},
+		},
+		"not-found": {
+			id:           id,
+			expectedCode: codes.NotFound,
+		},
+		"missing-id": {
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := testServer(t, &mock.DB{
+				GetHardwareHistoryFunc: func(ctx context.Context, id string, fn func(db.HardwareRevision) error) error {
+					for _, rev := range tc.revisions {
+						if err := fn(rev); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			})
+			s.dbReady = true
+
+			stream := &historyServer{}
+			err := s.History(&hardware.GetRequest{Id: tc.id}, stream)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, stream.sent, len(tc.expected))
+			for i, rev := range stream.sent {
+				assert.Equal(t, createdAt.Unix(), rev.CreatedAt.GetSeconds())
+				rev.CreatedAt = nil
+				assert.True(t, proto.Equal(tc.expected[i], rev), "revision %d: %v", i, rev)
+			}
+		})
+	}
+}
+
+func TestPrepareMetadata(t *testing.T) {
+	testCases := map[string]struct {
+		hw               *hardware.Hardware
+		expectedMetadata string
+		expectedCode     codes.Code
+	}{
+		"empty": {
+			hw: &hardware.Hardware{},
+		},
+		"valid-metadata": {
+			hw:               &hardware.Hardware{Metadata: `{"state":"provisioning","custom":{"private_subnets":["10.0.0.0/8"]}}`},
+			expectedMetadata: `{"state":"provisioning","custom":{"private_subnets":["10.0.0.0/8"]}}`,
+		},
+		"unknown-fields": {
+			hw:               &hardware.Hardware{Metadata: `{"owner":"team-a"}`},
+			expectedMetadata: `{"owner":"team-a"}`,
+		},
+		"wrong-type": {
+			hw:           &hardware.Hardware{Metadata: `{"facility":"onprem"}`},
+			expectedCode: codes.InvalidArgument,
+		},
+		"not-an-object": {
+			hw:           &hardware.Hardware{Metadata: `["onprem"]`},
+			expectedCode: codes.InvalidArgument,
+		},
+		"typed-metadata": {
+			hw:               &hardware.Hardware{TypedMetadata: &packet.Metadata{State: "provisioning", Facility: &packet.Metadata_Facility{FacilityCode: "onprem"}}},
+			expectedMetadata: `{"state":"provisioning","facility":{"facility_code":"onprem"}}`,
+		},
+		"matching-metadata-and-typed-metadata": {
+			hw:               &hardware.Hardware{Metadata: `{"state":"provisioning"}`, TypedMetadata: &packet.Metadata{State: "provisioning"}},
+			expectedMetadata: `{"state":"provisioning"}`,
+		},
+		"conflicting-metadata-and-typed-metadata": {
+			hw:           &hardware.Hardware{Metadata: `{"state":"provisioning"}`, TypedMetadata: &packet.Metadata{State: "in_use"}},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := prepareMetadata(tc.hw)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Nil(t, tc.hw.TypedMetadata)
+			if tc.expectedMetadata == "" {
+				assert.Empty(t, tc.hw.Metadata)
+				return
+			}
+			assert.JSONEq(t, tc.expectedMetadata, tc.hw.Metadata)
+		})
+	}
+}
+
+func TestPushBatch(t *testing.T) {
+	const (
+		idA = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+		idB = "224ee6ab-ad62-4070-a900-ed816444cec0"
+	)
+	hw := func(id, mac string) *hardware.Hardware {
+		return &hardware.Hardware{
+			Id: id,
+			Network: &hardware.Hardware_Network{
+				Interfaces: []*hardware.Hardware_Network_Interface{{Dhcp: &hardware.Hardware_DHCP{Mac: mac}}},
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		req            *hardware.PushBatchRequest
+		insertErr      error
+		expectedErrors []string
+		expectedPushed bool
+		expectedInsert bool
+	}{
+		"pushed": {
+			req:            &hardware.PushBatchRequest{Data: []*hardware.Hardware{hw(idA, "08:00:27:00:00:01"), hw(idB, "08:00:27:00:00:02")}},
+			expectedErrors: []string{"", ""},
+			expectedPushed: true,
+			expectedInsert: true,
+		},
+		"dry-run": {
+			req:            &hardware.PushBatchRequest{Data: []*hardware.Hardware{hw(idA, "08:00:27:00:00:01")}, DryRun: true},
+			expectedErrors: []string{""},
+		},
+		"invalid-items": {
+			req: &hardware.PushBatchRequest{Data: []*hardware.Hardware{
+				hw(idA, "08:00:27:00:00:01"),
+				hw("server001", "08:00:27:00:00:02"),
+				hw(idA, "08:00:27:00:00:03"),
+				{Id: idB, Metadata: `{"facility":"onprem"}`},
+			}},
+			expectedErrors: []string{
+				"",
+				"id must be set to a UUID, got id: server001",
+				"id " + idA + " is already used by item 0",
+				// the messages of protojson are not stable
+				"invalid metadata: ",
+			},
+		},
+		"conflict-in-database": {
+			req:            &hardware.PushBatchRequest{Data: []*hardware.Hardware{hw(idA, "08:00:27:00:00:01"), hw(idB, "08:00:27:00:00:01")}},
+			insertErr:      &db.BatchError{Index: 1, Err: status.Error(codes.AlreadyExists, "MAC address 08:00:27:00:00:01 is already used by another hardware")},
+			expectedErrors: []string{"", "MAC address 08:00:27:00:00:01 is already used by another hardware"},
+			expectedInsert: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			inserted := false
+			s := testServer(t, &mock.DB{
+				InsertHardwareBatchFunc: func(ctx context.Context, data []string) error {
+					inserted = true
+					assert.Len(t, data, len(tc.req.Data))
+					return tc.insertErr
+				},
+			})
+			s.dbReady = true
+
+			res, err := s.PushBatch(context.Background(), tc.req)
+			assert.NoError(t, err)
+			assert.Len(t, res.Results, len(tc.expectedErrors))
+			for i, r := range res.Results {
+				if tc.expectedErrors[i] == "" {
+					assert.Empty(t, r.Error, "item %d", i)
+					continue
+				}
+				assert.True(t, strings.HasPrefix(r.Error, tc.expectedErrors[i]), "item %d: %s", i, r.Error)
+			}
+			assert.Equal(t, tc.expectedPushed, res.Pushed)
+			assert.Equal(t, tc.expectedInsert, inserted)
+		})
+	}
+}
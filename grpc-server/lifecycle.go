@@ -0,0 +1,328 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/metrics"
+	"github.com/tinkerbell/tink/protos/hardware"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	wkf "github.com/tinkerbell/tink/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stateTransitions lists the states the hardware can move to from each state
+var stateTransitions = map[hardware.State][]hardware.State{
+	hardware.State_STATE_UNSPECIFIED: {
+		hardware.State_STATE_DISCOVERED,
+		hardware.State_STATE_AVAILABLE,
+		hardware.State_STATE_PROVISIONING,
+		hardware.State_STATE_PROVISIONED,
+		hardware.State_STATE_DEPROVISIONING,
+		hardware.State_STATE_BROKEN,
+	},
+	hardware.State_STATE_DISCOVERED: {
+		hardware.State_STATE_AVAILABLE,
+		hardware.State_STATE_BROKEN,
+	},
+	hardware.State_STATE_AVAILABLE: {
+		hardware.State_STATE_PROVISIONING,
+		hardware.State_STATE_BROKEN,
+		hardware.State_STATE_DISCOVERED,
+	},
+	hardware.State_STATE_PROVISIONING: {
+		hardware.State_STATE_PROVISIONED,
+		hardware.State_STATE_BROKEN,
+		hardware.State_STATE_AVAILABLE,
+	},
+	hardware.State_STATE_PROVISIONED: {
+		hardware.State_STATE_DEPROVISIONING,
+		hardware.State_STATE_PROVISIONING,
+		hardware.State_STATE_BROKEN,
+	},
+	hardware.State_STATE_DEPROVISIONING: {
+		hardware.State_STATE_AVAILABLE,
+		hardware.State_STATE_BROKEN,
+	},
+	hardware.State_STATE_BROKEN: {
+		hardware.State_STATE_AVAILABLE,
+		hardware.State_STATE_DISCOVERED,
+	},
+}
+
+// statesLeadingTo returns the states from which the hardware can move to
+// the given state, sorted by value
+func statesLeadingTo(to hardware.State) []int32 {
+	from := []int32{}
+	for s := hardware.State_STATE_UNSPECIFIED; s <= hardware.State_STATE_BROKEN; s++ {
+		for _, next := range stateTransitions[s] {
+			if next == to {
+				from = append(from, int32(s))
+				break
+			}
+		}
+	}
+	return from
+}
+
+// kindStates returns the states hardware moves through when a workflow of
+// the given kind runs on it: running is the state while the workflow runs
+// and done the state once it succeeded
+func kindStates(kind string) (running, done hardware.State) {
+	if kind == wkf.KindDeprovision {
+		return hardware.State_STATE_DEPROVISIONING, hardware.State_STATE_AVAILABLE
+	}
+	return hardware.State_STATE_PROVISIONING, hardware.State_STATE_PROVISIONED
+}
+
+// canRunWorkflow tells if a workflow of the given kind can be created for
+// hardware in the given state. Provisioning needs available or provisioned
+// hardware, deprovisioning provisioned hardware.
+func canRunWorkflow(kind string, state hardware.State) bool {
+	switch state {
+	case hardware.State_STATE_UNSPECIFIED, hardware.State_STATE_PROVISIONED:
+		return true
+	case hardware.State_STATE_AVAILABLE:
+		return kind != wkf.KindDeprovision
+	}
+	return false
+}
+
+// workflowTransition returns the state the hardware moves to when a
+// workflow of the given kind running on it reports an action status. first
+// and last tell if the action is the first or the last one of the workflow.
+func workflowTransition(kind string, current hardware.State, action pb.State, first, last bool) (hardware.State, bool) {
+	running, done := kindStates(kind)
+	switch action {
+	case pb.State_STATE_RUNNING:
+		if first && current != running && canRunWorkflow(kind, current) {
+			return running, true
+		}
+	case pb.State_STATE_SUCCESS:
+		if last && current == running {
+			return done, true
+		}
+	case pb.State_STATE_FAILED, pb.State_STATE_TIMEOUT:
+		if current == running {
+			return hardware.State_STATE_BROKEN, true
+		}
+	}
+	return current, false
+}
+
+// checkPushedState makes sure a push sets only the state new hardware can
+// start from, the other states are reached with SetState and workflows
+func checkPushedState(hw *hardware.Hardware) error {
+	switch hw.GetState() {
+	case hardware.State_STATE_UNSPECIFIED, hardware.State_STATE_DISCOVERED, hardware.State_STATE_AVAILABLE:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "hardware can not be pushed as %s, use SetState to change its state", hw.GetState())
+}
+
+// SetState implements hardware.SetState
+func (s *server) SetState(ctx context.Context, in *hardware.SetStateRequest) (*hardware.Hardware, error) {
+	s.logger.Info("setstate")
+	labels := prometheus.Labels{"method": "SetState", "op": "update"}
+	metrics.CacheTotals.With(labels).Inc()
+	metrics.CacheInFlight.With(labels).Inc()
+	defer metrics.CacheInFlight.With(labels).Dec()
+
+	if in.GetId() == "" {
+		metrics.CacheErrors.With(labels).Inc()
+		return &hardware.Hardware{}, status.Error(codes.InvalidArgument, "id must be set to a UUID")
+	}
+	if in.GetState() == hardware.State_STATE_UNSPECIFIED {
+		metrics.CacheErrors.With(labels).Inc()
+		return &hardware.Hardware{}, status.Error(codes.InvalidArgument, "state must be set")
+	}
+	if _, ok := hardware.State_name[int32(in.GetState())]; !ok {
+		metrics.CacheErrors.With(labels).Inc()
+		return &hardware.Hardware{}, status.Errorf(codes.InvalidArgument, "unknown state %d", in.GetState())
+	}
+
+	s.dbLock.RLock()
+	ready := s.dbReady
+	s.dbLock.RUnlock()
+	if !ready {
+		metrics.CacheStalls.With(labels).Inc()
+		return &hardware.Hardware{}, errors.New("DB is not ready")
+	}
+
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	var from []int32
+	if !in.GetForce() {
+		from = statesLeadingTo(in.GetState())
+	}
+	l := s.logger.With("id", in.Id, "state", in.GetState().String(), "force", in.GetForce())
+	data, err := s.db.SetHardwareState(ctx, in.Id, from, int32(in.GetState()))
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		if pqErr := db.Error(err); pqErr != nil {
+			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
+		}
+		l.Error(err)
+		return &hardware.Hardware{}, err
+	}
+
+	hw := &hardware.Hardware{}
+	if err := json.Unmarshal([]byte(data), hw); err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l.Error(err)
+		return &hardware.Hardware{}, err
+	}
+	setTypedMetadata(hw)
+	l.Info("state changed")
+	s.notifyWatchers()
+
+	metrics.CacheHits.With(labels).Inc()
+	return hw, nil
+}
+
+// hardwareByDevice returns the hardware a device of a workflow refers to,
+// by MAC or IP address. It returns nil when the device is neither or when no
+// hardware has it.
+func (s *server) hardwareByDevice(ctx context.Context, device string) (*hardware.Hardware, error) {
+	var (
+		data string
+		err  error
+	)
+	if _, e := net.ParseMAC(device); e == nil {
+		data, err = s.db.GetByMAC(ctx, device)
+	} else if net.ParseIP(device) != nil {
+		data, err = s.db.GetByIP(ctx, device)
+	} else {
+		return nil, nil
+	}
+	if errors.Cause(err) == sql.ErrNoRows || (err == nil && data == "") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	hw := &hardware.Hardware{}
+	if err := json.Unmarshal([]byte(data), hw); err != nil {
+		return nil, err
+	}
+	return hw, nil
+}
+
+// workflowHardware returns the hardware referred to by the devices of a
+// workflow, every machine once
+func (s *server) workflowHardware(ctx context.Context, devices string) ([]*hardware.Hardware, error) {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(devices), &m); err != nil {
+		return nil, errors.Wrap(err, "invalid workflow hardware")
+	}
+	seen := map[string]bool{}
+	hws := []*hardware.Hardware{}
+	for _, v := range m {
+		device, ok := v.(string)
+		if !ok {
+			continue
+		}
+		hw, err := s.hardwareByDevice(ctx, device)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting the hardware of device %s", device)
+		}
+		if hw == nil || seen[hw.Id] {
+			continue
+		}
+		seen[hw.Id] = true
+		hws = append(hws, hw)
+	}
+	return hws, nil
+}
+
+// checkWorkflowHardware refuses a workflow of the given kind when one of its
+// devices is a machine that is not in a state accepting it. Devices that do
+// not match any hardware are allowed.
+func (s *server) checkWorkflowHardware(ctx context.Context, devices, kind string) error {
+	hws, err := s.workflowHardware(ctx, devices)
+	if err != nil {
+		return err
+	}
+	for _, hw := range hws {
+		if err := checkWorkflowState(hw, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkWorkflowState(hw *hardware.Hardware, kind string) error {
+	if canRunWorkflow(kind, hw.GetState()) {
+		return nil
+	}
+	if kind == wkf.KindDeprovision {
+		return status.Errorf(codes.FailedPrecondition, "hardware %s is %s, a deprovision workflow can only run on provisioned hardware", hw.Id, hw.GetState())
+	}
+	return status.Errorf(codes.FailedPrecondition, "hardware %s is %s, a workflow can only run on available or provisioned hardware", hw.Id, hw.GetState())
+}
+
+// templateKind returns the kind of the workflows created from a template
+func templateKind(data string) (string, error) {
+	wf, err := wkf.Parse([]byte(data))
+	if err != nil {
+		return "", err
+	}
+	if wf.Kind == "" {
+		return wkf.KindProvision, nil
+	}
+	return wf.Kind, nil
+}
+
+// updateHardwareLifecycle moves the hardware of a workflow to the state
+// following the reported action status. It is best effort: the errors are
+// logged, they do not fail the report.
+func (s *server) updateHardwareLifecycle(ctx context.Context, wfID string, action pb.State, first, last bool) {
+	l := s.logger.With("workflowID", wfID)
+	ctx = db.WithPrimary(ctx)
+	wf, err := s.db.GetWorkflow(ctx, wfID)
+	if err != nil {
+		l.Error(errors.Wrap(err, "updating the hardware state"))
+		return
+	}
+	if wf.Hardware == "" {
+		return
+	}
+	l = l.With("hardware", wf.Hardware)
+	// the template can be deleted while its workflows run
+	tmpl, err := s.db.GetTemplate(ctx, map[string]string{"id": wf.Template}, true)
+	if err != nil {
+		l.Error(errors.Wrapf(err, "updating the hardware state, getting the template %s", wf.Template))
+		return
+	}
+	kind, err := templateKind(tmpl.GetData())
+	if err != nil {
+		l.Error(errors.Wrapf(err, "updating the hardware state, parsing the template %s", wf.Template))
+		return
+	}
+	hws, err := s.workflowHardware(ctx, wf.Hardware)
+	if err != nil {
+		l.Error(errors.Wrap(err, "updating the hardware state"))
+		return
+	}
+	for _, hw := range hws {
+		to, ok := workflowTransition(kind, hw.GetState(), action, first, last)
+		if !ok {
+			continue
+		}
+		_, err := s.db.SetHardwareState(ctx, hw.Id, []int32{int32(hw.GetState())}, int32(to))
+		if err != nil {
+			l.With("id", hw.Id, "kind", kind).Error(errors.Wrap(err, fmt.Sprintf("moving the hardware %s from %s to %s", hw.Id, hw.GetState(), to)))
+			continue
+		}
+		l.With("id", hw.Id, "state", to.String()).Info("hardware state changed")
+		s.notifyWatchers()
+	}
+}
@@ -0,0 +1,202 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/db/mock"
+	"github.com/tinkerbell/tink/protos/hardware"
+	tb "github.com/tinkerbell/tink/protos/template"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	wkf "github.com/tinkerbell/tink/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatesLeadingTo(t *testing.T) {
+	assert.Equal(t, []int32{0, 3}, statesLeadingTo(hardware.State_STATE_PROVISIONED))
+	assert.Equal(t, []int32{0, 2, 4}, statesLeadingTo(hardware.State_STATE_PROVISIONING))
+	assert.Equal(t, []int32{}, statesLeadingTo(hardware.State_STATE_UNSPECIFIED))
+}
+
+func TestWorkflowTransition(t *testing.T) {
+	testCases := map[string]struct {
+		kind     string
+		current  hardware.State
+		action   pb.State
+		first    bool
+		last     bool
+		expected hardware.State
+		changed  bool
+	}{
+		"provision-start": {
+			current: hardware.State_STATE_AVAILABLE, action: pb.State_STATE_RUNNING, first: true,
+			expected: hardware.State_STATE_PROVISIONING, changed: true,
+		},
+		"legacy-start": {
+			current: hardware.State_STATE_UNSPECIFIED, action: pb.State_STATE_RUNNING, first: true,
+			expected: hardware.State_STATE_PROVISIONING, changed: true,
+		},
+		"reprovision-start": {
+			current: hardware.State_STATE_PROVISIONED, action: pb.State_STATE_RUNNING, first: true,
+			expected: hardware.State_STATE_PROVISIONING, changed: true,
+		},
+		"deprovision-start": {
+			kind: wkf.KindDeprovision, current: hardware.State_STATE_PROVISIONED, action: pb.State_STATE_RUNNING, first: true,
+			expected: hardware.State_STATE_DEPROVISIONING, changed: true,
+		},
+		"deprovision-of-available-hardware": {
+			kind: wkf.KindDeprovision, current: hardware.State_STATE_AVAILABLE, action: pb.State_STATE_RUNNING, first: true,
+			expected: hardware.State_STATE_AVAILABLE,
+		},
+		"second-action-running": {
+			current: hardware.State_STATE_PROVISIONING, action: pb.State_STATE_RUNNING,
+			expected: hardware.State_STATE_PROVISIONING,
+		},
+		"intermediate-success": {
+			current: hardware.State_STATE_PROVISIONING, action: pb.State_STATE_SUCCESS,
+			expected: hardware.State_STATE_PROVISIONING,
+		},
+		"provision-success": {
+			current: hardware.State_STATE_PROVISIONING, action: pb.State_STATE_SUCCESS, last: true,
+			expected: hardware.State_STATE_PROVISIONED, changed: true,
+		},
+		"provision-success-of-deprovisioning-hardware": {
+			current: hardware.State_STATE_DEPROVISIONING, action: pb.State_STATE_SUCCESS, last: true,
+			expected: hardware.State_STATE_DEPROVISIONING,
+		},
+		"deprovision-success": {
+			kind: wkf.KindDeprovision, current: hardware.State_STATE_DEPROVISIONING, action: pb.State_STATE_SUCCESS, last: true,
+			expected: hardware.State_STATE_AVAILABLE, changed: true,
+		},
+		"failure": {
+			current: hardware.State_STATE_PROVISIONING, action: pb.State_STATE_FAILED,
+			expected: hardware.State_STATE_BROKEN, changed: true,
+		},
+		"timeout": {
+			kind: wkf.KindDeprovision, current: hardware.State_STATE_DEPROVISIONING, action: pb.State_STATE_TIMEOUT, last: true,
+			expected: hardware.State_STATE_BROKEN, changed: true,
+		},
+		"failure-of-idle-hardware": {
+			current: hardware.State_STATE_AVAILABLE, action: pb.State_STATE_FAILED,
+			expected: hardware.State_STATE_AVAILABLE,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state, changed := workflowTransition(tc.kind, tc.current, tc.action, tc.first, tc.last)
+			assert.Equal(t, tc.expected, state)
+			assert.Equal(t, tc.changed, changed)
+		})
+	}
+}
+
+func TestSetState(t *testing.T) {
+	const id = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+	testCases := map[string]struct {
+		req          *hardware.SetStateRequest
+		expectedFrom []int32
+		expectedCode codes.Code
+	}{
+		"transition": {
+			req:          &hardware.SetStateRequest{Id: id, State: hardware.State_STATE_AVAILABLE},
+			expectedFrom: []int32{0, 1, 3, 5, 6},
+		},
+		"forced": {
+			req: &hardware.SetStateRequest{Id: id, State: hardware.State_STATE_AVAILABLE, Force: true},
+		},
+		"missing-id": {
+			req:          &hardware.SetStateRequest{State: hardware.State_STATE_AVAILABLE},
+			expectedCode: codes.InvalidArgument,
+		},
+		"missing-state": {
+			req:          &hardware.SetStateRequest{Id: id, Force: true},
+			expectedCode: codes.InvalidArgument,
+		},
+		"unknown-state": {
+			req:          &hardware.SetStateRequest{Id: id, State: 42},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			called := false
+			s := testServer(t, &mock.DB{
+				SetHardwareStateFunc: func(ctx context.Context, id string, from []int32, to int32) (string, error) {
+					called = true
+					assert.Equal(t, tc.expectedFrom, from)
+					assert.Equal(t, int32(tc.req.State), to)
+					return `{"id":"` + id + `","version":2,"state":2}`, nil
+				},
+			})
+			s.dbReady = true
+
+			hw, err := s.SetState(context.Background(), tc.req)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.False(t, called)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, hardware.State_STATE_AVAILABLE, hw.State)
+			assert.Equal(t, int64(2), hw.Version)
+		})
+	}
+}
+
+func TestUpdateHardwareLifecycle(t *testing.T) {
+	const id = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+	type change struct {
+		from []int32
+		to   int32
+	}
+	var (
+		changes  []change
+		state    = "2"
+		template = templateData
+		tmplErr  error
+	)
+	s := testServer(t, &mock.DB{
+		GetWorkflowFunc: func(ctx context.Context, wfID string) (db.Workflow, error) {
+			return db.Workflow{ID: wfID, Template: templateID, Hardware: `{"device_1": "08:00:27:00:00:01", "device_2": "192.168.1.5", "device_3": "unknown"}`}, nil
+		},
+		GetTemplateFunc: func(ctx context.Context, fields map[string]string, deleted bool) (*tb.WorkflowTemplate, error) {
+			assert.Equal(t, templateID, fields["id"])
+			assert.True(t, deleted)
+			return &tb.WorkflowTemplate{Data: template}, tmplErr
+		},
+		// both devices are the same machine
+		GetByMACFunc: func(ctx context.Context, mac string) (string, error) {
+			return `{"id":"` + id + `","state":` + state + `}`, nil
+		},
+		GetByIPFunc: func(ctx context.Context, ip string) (string, error) {
+			return `{"id":"` + id + `","state":` + state + `}`, nil
+		},
+		SetHardwareStateFunc: func(ctx context.Context, id string, from []int32, to int32) (string, error) {
+			changes = append(changes, change{from: from, to: to})
+			return "", nil
+		},
+	})
+
+	s.updateHardwareLifecycle(context.Background(), workflowID, pb.State_STATE_SUCCESS, false, false)
+	assert.Empty(t, changes)
+
+	s.updateHardwareLifecycle(context.Background(), workflowID, pb.State_STATE_RUNNING, true, false)
+	assert.Equal(t, []change{{from: []int32{2}, to: 3}}, changes)
+
+	// the transition follows the kind of the template
+	changes, state, template = nil, "4", deprovisionTemplateData
+	s.updateHardwareLifecycle(context.Background(), workflowID, pb.State_STATE_RUNNING, true, false)
+	assert.Equal(t, []change{{from: []int32{4}, to: 5}}, changes)
+
+	// without its template the kind of the workflow is unknown, the hardware
+	// is left as it is
+	changes, tmplErr = nil, errors.New("failed to get template")
+	s.updateHardwareLifecycle(context.Background(), workflowID, pb.State_STATE_RUNNING, true, false)
+	assert.Empty(t, changes)
+}
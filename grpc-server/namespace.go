@@ -0,0 +1,113 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serverWideServices are the services which are not split by namespace: the
+// events log records the changes of every namespace, the webhooks get them
+// all, and the administration purges, exports and imports everything. They
+// are served to the requests for all the namespaces and to the ones of the
+// default namespace, the one of an install without namespaces.
+var serverWideServices = []string{
+	"/github.com.tinkerbell.tink.protos.events.EventsService/",
+	"/github.com.tinkerbell.tink.protos.webhook.WebhookService/",
+	"/github.com.tinkerbell.tink.protos.admin.AdminService/",
+}
+
+// workerMethods are the methods of the workers. A worker addresses its
+// workflows by its id and by the ids of the workflows it got, whatever their
+// namespace, so its requests are only restricted to a namespace when it
+// sends one.
+var workerMethods = []string{
+	"/github.com.tinkerbell.tink.protos.workflow.WorkflowService/GetWorkflowContexts",
+	"/github.com.tinkerbell.tink.protos.workflow.WorkflowService/GetWorkflowContextList",
+	"/github.com.tinkerbell.tink.protos.workflow.WorkflowService/GetWorkflowActions",
+	"/github.com.tinkerbell.tink.protos.workflow.WorkflowService/ReportActionStatus",
+	"/github.com.tinkerbell.tink.protos.workflow.WorkflowService/GetWorkflowData",
+	"/github.com.tinkerbell.tink.protos.workflow.WorkflowService/GetWorkflowMetadata",
+	"/github.com.tinkerbell.tink.protos.workflow.WorkflowService/GetWorkflowDataVersion",
+	"/github.com.tinkerbell.tink.protos.workflow.WorkflowService/UpdateWorkflowData",
+}
+
+// namespaceUnaryInterceptor restricts the request to the namespace the
+// client sent in the metadata, the default one when there is none. With
+// allowAll the requests for pkg.AllNamespaces see all of them, otherwise
+// they are denied.
+func namespaceUnaryInterceptor(allowAll bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := namespaceContext(ctx, info.FullMethod, allowAll)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// namespaceStreamInterceptor is namespaceUnaryInterceptor for the streams
+func namespaceStreamInterceptor(allowAll bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := namespaceContext(ss.Context(), info.FullMethod, allowAll)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &namespacedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// namespaceContext returns ctx restricted to the namespace in its metadata,
+// for a call to method
+func namespaceContext(ctx context.Context, method string, allowAll bool) (context.Context, error) {
+	ns := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(pkg.NamespaceMetadataKey); len(v) > 0 {
+			ns = v[0]
+		}
+	}
+	if ns == "" {
+		for _, m := range workerMethods {
+			if method == m {
+				return ctx, nil
+			}
+		}
+		ns = db.DefaultNamespace
+	}
+	if ns == pkg.AllNamespaces {
+		if !allowAll {
+			return ctx, status.Error(codes.PermissionDenied, "the requests for all the namespaces are not allowed by the server")
+		}
+		return ctx, nil
+	}
+
+	for _, prefix := range serverWideServices {
+		if !strings.HasPrefix(method, prefix) {
+			continue
+		}
+		if ns != db.DefaultNamespace {
+			return ctx, status.Errorf(codes.PermissionDenied, "%s is not split by namespace, it is only served to the requests for all the namespaces or the default one", method)
+		}
+		return ctx, nil
+	}
+	if err := db.ValidateNamespace(ns); err != nil {
+		return ctx, err
+	}
+	return db.WithNamespace(ctx, ns), nil
+}
+
+// namespacedStream is a server stream with the context of its namespace
+type namespacedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *namespacedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,106 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNamespaceInterceptor(t *testing.T) {
+	const (
+		hardwareMethod = "/github.com.tinkerbell.tink.protos.hardware.HardwareService/All"
+		eventsMethod   = "/github.com.tinkerbell.tink.protos.events.EventsService/Watch"
+		workerMethod   = "/github.com.tinkerbell.tink.protos.workflow.WorkflowService/GetWorkflowContexts"
+	)
+	testCases := map[string]struct {
+		ctx      context.Context
+		method   string
+		allowAll bool
+		expected string
+		code     codes.Code
+	}{
+		"metadata": {
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "team-a")),
+			expected: "team-a",
+		},
+		"empty": {
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "")),
+			expected: db.DefaultNamespace,
+		},
+		"none": {
+			ctx:      context.Background(),
+			expected: db.DefaultNamespace,
+		},
+		"invalid": {
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "Team A")),
+			code: codes.InvalidArgument,
+		},
+		"all namespaces": {
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "*")),
+			allowAll: true,
+		},
+		"all namespaces not allowed": {
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "*")),
+			code: codes.PermissionDenied,
+		},
+		"server wide": {
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "*")),
+			method:   eventsMethod,
+			allowAll: true,
+		},
+		"server wide in a namespace": {
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "team-a")),
+			method:   eventsMethod,
+			allowAll: true,
+			code:     codes.PermissionDenied,
+		},
+		"server wide without a namespace": {
+			ctx:    context.Background(),
+			method: eventsMethod,
+		},
+		"server wide in the default namespace": {
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "default")),
+			method: eventsMethod,
+		},
+		"worker without a namespace": {
+			ctx:    context.Background(),
+			method: workerMethod,
+		},
+		"worker in a namespace": {
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("tink-namespace", "team-a")),
+			method:   workerMethod,
+			expected: "team-a",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = hardwareMethod
+			}
+			var ns string
+			_, err := namespaceUnaryInterceptor(tc.allowAll)(tc.ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				ns = db.NamespaceFromContext(ctx)
+				return nil, nil
+			})
+			assert.Equal(t, tc.code, status.Code(err))
+			assert.Equal(t, tc.expected, ns)
+
+			ns = ""
+			err = namespaceStreamInterceptor(tc.allowAll)(nil, &namespacedStream{ctx: tc.ctx}, &grpc.StreamServerInfo{FullMethod: method}, func(srv interface{}, stream grpc.ServerStream) error {
+				ns = db.NamespaceFromContext(stream.Context())
+				return nil
+			})
+			assert.Equal(t, tc.code, status.Code(err))
+			assert.Equal(t, tc.expected, ns)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package grpcserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const maxPageSize = 1000
+
+// errPageFull stops the iteration over the rows once the page is complete
+var errPageFull = errors.New("page is full")
+
+// pageToken is the content of a page token: the order of the pages and the
+// cursor of the last row of the previous page
+type pageToken struct {
+	OrderBy string `json:"order_by"`
+	db.Cursor
+}
+
+// pager splits the rows returned by a list method in pages. The page token
+// is the cursor of the last row of the previous page, so a page starts at
+// the right row even when rows were added or removed in between.
+type pager struct {
+	size    int
+	orderBy string
+	after   *db.Cursor
+	// current is the cursor of the row the database is returning, last the
+	// one of the last row of the page
+	current db.Cursor
+	last    db.Cursor
+	sent    int
+	more    bool
+}
+
+func newPager(pageSize int32, pageToken, orderBy string) (*pager, error) {
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size can not be negative")
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	p := &pager{size: int(pageSize), orderBy: normalizeOrderBy(orderBy)}
+	if pageToken == "" {
+		return p, nil
+	}
+	t, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if t.OrderBy != p.orderBy {
+		return nil, status.Errorf(codes.InvalidArgument, "the page_token is for the order %q, not %q", t.OrderBy, p.orderBy)
+	}
+	p.after = &t.Cursor
+	return p, nil
+}
+
+func decodePageToken(s string) (pageToken, error) {
+	var t pageToken
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return t, err
+	}
+	if t.ID == "" {
+		return t, errors.New("the cursor has no id")
+	}
+	return t, nil
+}
+
+// normalizeOrderBy returns orderBy in lower case with single spaces, the
+// tokens of two spellings of the same order are the same
+func normalizeOrderBy(orderBy string) string {
+	return strings.Join(strings.Fields(strings.ToLower(orderBy)), " ")
+}
+
+// options returns the database options for the page, which starts after the
+// cursor of the token. One row more than the page size is requested to know
+// if there is a following page, unless the rows get filtered after the
+// query.
+func (p *pager) options(opts db.ListOptions, filtered bool) db.ListOptions {
+	opts.After = p.after
+	opts.Cursor = func(c db.Cursor) {
+		p.current = c
+	}
+	if !filtered && p.size > 0 {
+		opts.Limit = p.size + 1
+	}
+	return opts
+}
+
+// add tells if the row is part of the page, it returns errPageFull once the
+// page is complete
+func (p *pager) add() (bool, error) {
+	if p.size > 0 && p.sent == p.size {
+		p.more = true
+		return false, errPageFull
+	}
+	p.sent++
+	p.last = p.current
+	return true, nil
+}
+
+// done handles the error returned while iterating over the rows and, when
+// there is a following page, it sets its token in the
+// pkg.NextPageTokenMetadataKey trailer with setTrailer
+func (p *pager) done(err error, setTrailer func(metadata.MD)) error {
+	if err != nil && errors.Cause(err) != errPageFull {
+		return err
+	}
+	if p.more {
+		b, err := json.Marshal(pageToken{OrderBy: p.orderBy, Cursor: p.last})
+		if err != nil {
+			return err
+		}
+		setTrailer(metadata.Pairs(pkg.NextPageTokenMetadataKey, base64.RawURLEncoding.EncodeToString(b)))
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/db/mock"
+	"github.com/tinkerbell/tink/pkg"
+	"github.com/tinkerbell/tink/protos/hardware"
+	"github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func encodeToken(t *testing.T, token string) string {
+	t.Helper()
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+func TestNewPager(t *testing.T) {
+	testCases := map[string]struct {
+		size          int32
+		token         string
+		orderBy       string
+		expectedSize  int
+		expectedAfter *db.Cursor
+		expectedCode  codes.Code
+	}{
+		"no-paging":     {},
+		"first-page":    {size: 10, expectedSize: 10},
+		"capped-size":   {size: 5000, expectedSize: maxPageSize},
+		"negative-size": {size: -1, expectedCode: codes.InvalidArgument},
+		"following-page": {
+			size:          10,
+			token:         `{"order_by":"created_at desc","value":"2020-01-01T00:00:00Z","id":"1"}`,
+			orderBy:       "Created_At  DESC",
+			expectedSize:  10,
+			expectedAfter: &db.Cursor{Value: "2020-01-01T00:00:00Z", ID: "1"},
+		},
+		"invalid-token": {size: 10, token: "not a token", expectedCode: codes.InvalidArgument},
+		"no-id":         {size: 10, token: `{"order_by":"","value":"1"}`, expectedCode: codes.InvalidArgument},
+		"other-order": {
+			size:         10,
+			token:        `{"order_by":"created_at","value":"2020-01-01T00:00:00Z","id":"1"}`,
+			orderBy:      "created_at desc",
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			token := tc.token
+			if token != "" && token != "not a token" {
+				token = encodeToken(t, token)
+			}
+			p, err := newPager(tc.size, token, tc.orderBy)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSize, p.size)
+			assert.Equal(t, tc.expectedAfter, p.after)
+		})
+	}
+}
+
+// pageHardware returns the stored hardware, sorted by id, honoring the
+// limit and the cursor like the database does
+func pageHardware(stored []string) func(context.Context, map[string]string, db.ListOptions, func([]byte) error) error {
+	return func(ctx context.Context, labels map[string]string, opts db.ListOptions, fn func([]byte) error) error {
+		returned := 0
+		for _, j := range stored {
+			var hw struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal([]byte(j), &hw); err != nil {
+				return err
+			}
+			if opts.After != nil && hw.ID <= opts.After.ID {
+				continue
+			}
+			if opts.Limit > 0 && returned == opts.Limit {
+				return nil
+			}
+			returned++
+			if opts.Cursor != nil {
+				opts.Cursor(db.Cursor{Value: hw.ID, ID: hw.ID})
+			}
+			if err := fn([]byte(j)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// nextPageToken returns the token of the following page set in trailer
+func nextPageToken(trailer metadata.MD) string {
+	if v := trailer.Get(pkg.NextPageTokenMetadataKey); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func TestListHardwarePages(t *testing.T) {
+	stored := []string{}
+	for i := 0; i < 5; i++ {
+		rack := "r1"
+		if i%2 == 1 {
+			rack = "r2"
+		}
+		stored = append(stored, fmt.Sprintf(`{"id":"%d","labels":{"rack":%q}}`, i, rack))
+	}
+
+	testCases := map[string]struct {
+		selector      string
+		expectedPages [][]string
+	}{
+		"paged-by-the-database": {
+			expectedPages: [][]string{{"0", "1"}, {"2", "3"}, {"4"}},
+		},
+		"paged-after-filtering": {
+			selector:      "rack!=r2",
+			expectedPages: [][]string{{"0", "2"}, {"4"}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := testServer(t, &mock.DB{ListHardwareFunc: pageHardware(stored)})
+			s.dbReady = true
+
+			pages := [][]string{}
+			req := &hardware.ListRequest{LabelSelector: tc.selector, PageSize: 2}
+			for {
+				stream := &listServer{}
+				err := s.All(req, stream)
+				assert.NoError(t, err)
+				page := []string{}
+				for _, hw := range stream.sent {
+					page = append(page, hw.Id)
+				}
+				pages = append(pages, page)
+				req.PageToken = nextPageToken(stream.trailer)
+				if req.PageToken == "" {
+					break
+				}
+				if len(pages) > len(tc.expectedPages) {
+					t.Fatal("too many pages")
+				}
+			}
+			assert.Equal(t, tc.expectedPages, pages)
+		})
+	}
+}
+
+func TestListHardwarePagesKeepTheirPlace(t *testing.T) {
+	stored := []string{`{"id":"1"}`, `{"id":"3"}`, `{"id":"5"}`, `{"id":"7"}`}
+	s := testServer(t, &mock.DB{ListHardwareFunc: func(ctx context.Context, labels map[string]string, opts db.ListOptions, fn func([]byte) error) error {
+		return pageHardware(stored)(ctx, labels, opts, fn)
+	}})
+	s.dbReady = true
+
+	stream := &listServer{}
+	req := &hardware.ListRequest{PageSize: 2}
+	assert.NoError(t, s.All(req, stream))
+	assert.Len(t, stream.sent, 2)
+
+	// a hardware added before the following page does not shift it
+	stored = append([]string{`{"id":"0"}`}, stored...)
+	req.PageToken = nextPageToken(stream.trailer)
+	stream = &listServer{}
+	assert.NoError(t, s.All(req, stream))
+	ids := []string{}
+	for _, hw := range stream.sent {
+		ids = append(ids, hw.Id)
+	}
+	assert.Equal(t, []string{"5", "7"}, ids)
+	assert.Empty(t, nextPageToken(stream.trailer))
+}
+
+type listWorkflowsServer struct {
+	grpc.ServerStream
+	sent    []*workflow.Workflow
+	trailer metadata.MD
+}
+
+func (l *listWorkflowsServer) Context() context.Context {
+	return context.Background()
+}
+
+func (l *listWorkflowsServer) Send(wf *workflow.Workflow) error {
+	l.sent = append(l.sent, wf)
+	return nil
+}
+
+func (l *listWorkflowsServer) SetTrailer(md metadata.MD) {
+	l.trailer = metadata.Join(l.trailer, md)
+}
+
+func TestListWorkflowsPages(t *testing.T) {
+	var (
+		filter db.WorkflowFilter
+		opts   db.ListOptions
+	)
+	s := testServer(t, &mock.DB{
+		ListWorkflowsFunc: func(ctx context.Context, f db.WorkflowFilter, o db.ListOptions, fn func(wf db.Workflow) error) error {
+			filter, opts = f, o
+			returned := 0
+			for i := 0; i < 3; i++ {
+				id := fmt.Sprint(i)
+				if o.After != nil && id <= o.After.ID {
+					continue
+				}
+				if o.Limit > 0 && returned == o.Limit {
+					return nil
+				}
+				returned++
+				o.Cursor(db.Cursor{Value: "2020-01-01T00:00:00Z", ID: id})
+				if err := fn(db.Workflow{ID: id}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		GetWorkflowContextsFunc: func(ctx context.Context, wfID string) (*workflow.WorkflowContext, error) {
+			return &workflow.WorkflowContext{}, nil
+		},
+	})
+	s.dbReady = true
+
+	stream := &listWorkflowsServer{}
+	err := s.ListWorkflows(&workflow.ListRequest{
+		PageSize: 2,
+		OrderBy:  "created_at desc",
+		States:   []workflow.State{workflow.State_STATE_FAILED, workflow.State_STATE_TIMEOUT},
+		Template: templateID,
+		Hardware: "08:00:27:00:00:01",
+	}, stream)
+	assert.NoError(t, err)
+	assert.Len(t, stream.sent, 2)
+	assert.Equal(t, db.WorkflowFilter{States: []int32{2, 3}, Template: templateID, Hardware: "08:00:27:00:00:01"}, filter)
+	assert.Equal(t, 3, opts.Limit)
+	assert.Equal(t, "created_at desc", opts.OrderBy)
+	assert.Nil(t, opts.After)
+
+	// the token of the following page comes in the trailer, the messages
+	// are only workflows
+	token := nextPageToken(stream.trailer)
+	assert.NotEmpty(t, token)
+	for _, wf := range stream.sent {
+		assert.NotEmpty(t, wf.Id)
+	}
+
+	// the token belongs to the order of the first page
+	err = s.ListWorkflows(&workflow.ListRequest{PageSize: 2, PageToken: token}, &listWorkflowsServer{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream = &listWorkflowsServer{}
+	err = s.ListWorkflows(&workflow.ListRequest{PageSize: 2, PageToken: token, OrderBy: "created_at desc"}, stream)
+	assert.NoError(t, err)
+	assert.Equal(t, &db.Cursor{Value: "2020-01-01T00:00:00Z", ID: "1"}, opts.After)
+	assert.Len(t, stream.sent, 1)
+	assert.Equal(t, "2", stream.sent[0].Id)
+	assert.Empty(t, nextPageToken(stream.trailer))
+}
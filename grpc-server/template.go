@@ -39,6 +39,7 @@ func (s *server) CreateTemplate(ctx context.Context, in *template.WorkflowTempla
 		l.Error(err)
 		return &template.CreateResponse{}, err
 	}
+	s.notifyWatchers()
 	s.logger.Info("done " + msg)
 	return &template.CreateResponse{Id: id.String()}, err
 }
@@ -99,6 +100,8 @@ func (s *server) DeleteTemplate(ctx context.Context, in *template.GetRequest) (*
 			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
 		}
 		l.Error(err)
+	} else {
+		s.notifyWatchers()
 	}
 	return &template.Empty{}, err
 }
@@ -116,6 +119,16 @@ func (s *server) ListTemplates(in *template.ListRequest, stream template.Templat
 		filter = strings.ReplaceAll(in.GetName(), "*", "%") // replace '*' with psql '%' wildcard
 	}
 
+	p, err := newPager(in.GetPageSize(), in.GetPageToken(), in.GetOrderBy())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return err
+	}
+	opts := db.ListOptions{OrderBy: in.GetOrderBy()}
+	if in.GetCreatedAfter() != nil {
+		opts.CreatedAfter = in.GetCreatedAfter().AsTime()
+	}
+
 	s.dbLock.RLock()
 	ready := s.dbReady
 	s.dbLock.RUnlock()
@@ -126,10 +139,13 @@ func (s *server) ListTemplates(in *template.ListRequest, stream template.Templat
 
 	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
 	defer timer.ObserveDuration()
-	err := s.db.ListTemplates(filter, func(id, n string, crTime, upTime *timestamp.Timestamp) error {
+	err = s.db.ListTemplates(stream.Context(), filter, p.options(opts, false), func(id, n string, crTime, upTime *timestamp.Timestamp) error {
+		if ok, err := p.add(); !ok {
+			return err
+		}
 		return stream.Send(&template.WorkflowTemplate{Id: id, Name: n, CreatedAt: crTime, UpdatedAt: upTime})
 	})
-
+	err = p.done(err, stream.SetTrailer)
 	if err != nil {
 		metrics.CacheErrors.With(labels).Inc()
 		return err
@@ -163,6 +179,8 @@ func (s *server) UpdateTemplate(ctx context.Context, in *template.WorkflowTempla
 			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
 		}
 		l.Error(err)
+	} else {
+		s.notifyWatchers()
 	}
 	return &template.Empty{}, err
 }
@@ -2,11 +2,15 @@ package grpcserver
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/packethost/pkg/log"
+	"github.com/pkg/errors"
 	"github.com/tinkerbell/tink/db"
 	pb "github.com/tinkerbell/tink/protos/workflow"
 	"google.golang.org/grpc/codes"
@@ -30,16 +34,17 @@ const (
 
 // GetWorkflowContexts implements tinkerbell.GetWorkflowContexts
 func (s *server) GetWorkflowContexts(req *pb.WorkflowContextRequest, stream pb.WorkflowService_GetWorkflowContextsServer) error {
-	wfs, err := getWorkflowsForWorker(s.db, req.WorkerId)
+	ctx := stream.Context()
+	wfs, err := getWorkflowsForWorker(ctx, s.db, req.WorkerId)
 	if err != nil {
 		return err
 	}
 	for _, wf := range wfs {
-		wfContext, err := s.db.GetWorkflowContexts(context.Background(), wf)
+		wfContext, err := s.db.GetWorkflowContexts(ctx, wf)
 		if err != nil {
 			return status.Errorf(codes.Aborted, err.Error())
 		}
-		if isApplicableToSend(context.Background(), s.logger, wfContext, req.WorkerId, s.db) {
+		if isApplicableToSend(ctx, s.logger, wfContext, req.WorkerId, s.db) {
 			if err := stream.Send(wfContext); err != nil {
 				return err
 			}
@@ -50,7 +55,7 @@ func (s *server) GetWorkflowContexts(req *pb.WorkflowContextRequest, stream pb.W
 
 // GetWorkflowContextList implements tinkerbell.GetWorkflowContextList
 func (s *server) GetWorkflowContextList(context context.Context, req *pb.WorkflowContextRequest) (*pb.WorkflowContextList, error) {
-	wfs, err := getWorkflowsForWorker(s.db, req.WorkerId)
+	wfs, err := getWorkflowsForWorker(context, s.db, req.WorkerId)
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +139,12 @@ func (s *server) ReportActionStatus(context context.Context, req *pb.WorkflowAct
 	if err != nil {
 		return &pb.Empty{}, status.Error(codes.Aborted, err.Error())
 	}
+	s.notifyWatchers()
+
+	first := actionIndex == 0 && req.GetActionStatus() == pb.State_STATE_RUNNING
+	last := isLastAction(wfContext, wfActions)
+	s.updateHardwareLifecycle(context, wfID, req.GetActionStatus(), first, last)
+	s.notifyWorkflowWebhooks(context, req, action.GetWorkerId(), first, last)
 
 	l = s.logger.With(
 		"workflowID", wfContext.GetWorkflowId(),
@@ -196,11 +207,63 @@ func (s *server) GetWorkflowDataVersion(context context.Context, req *pb.GetWork
 	return &pb.GetWorkflowDataResponse{Version: version}, nil
 }
 
-func getWorkflowsForWorker(db db.Database, id string) ([]string, error) {
+// workflowMetadata is the metadata tink-worker records with every version of
+// the workflow data
+type workflowMetadata struct {
+	WorkerID  string    `json:"workerID"`
+	Action    string    `json:"actionName"`
+	Task      string    `json:"taskName"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	SHA       string    `json:"sha256"`
+}
+
+// GetWorkflowDataRevision returns a version of the data of a workflow with
+// its metadata
+func (s *server) GetWorkflowDataRevision(context context.Context, req *pb.GetWorkflowDataRequest) (*pb.WorkflowDataRevision, error) {
+	wfID := req.GetWorkflowId()
+	if wfID == "" {
+		return &pb.WorkflowDataRevision{}, status.Errorf(codes.InvalidArgument, errInvalidWorkflowId)
+	}
+	if req.GetVersion() < 0 {
+		return &pb.WorkflowDataRevision{}, status.Errorf(codes.InvalidArgument, "invalid version %d", req.GetVersion())
+	}
+	rev, err := s.db.GetWorkflowDataRevision(context, wfID, req.GetVersion())
+	if errors.Cause(err) == sql.ErrNoRows {
+		return &pb.WorkflowDataRevision{}, status.Errorf(codes.NotFound, "workflow %s has no data version %d", wfID, req.GetVersion())
+	}
+	if err != nil {
+		return &pb.WorkflowDataRevision{}, status.Errorf(codes.Aborted, err.Error())
+	}
+
+	res := &pb.WorkflowDataRevision{
+		WorkflowId: rev.WorkflowID,
+		Version:    rev.Version,
+		Data:       rev.Data,
+		Pruned:     rev.Data == nil,
+	}
+	// the metadata is recorded as is, it may not come from tink-worker
+	var meta workflowMetadata
+	if err := json.Unmarshal(rev.Metadata, &meta); err != nil {
+		s.logger.With("workflowID", wfID, "version", rev.Version).Error(errors.Wrap(err, "invalid workflow metadata"))
+		return res, nil
+	}
+	res.Metadata = &pb.WorkflowMetadata{
+		WorkerId:   meta.WorkerID,
+		TaskName:   meta.Task,
+		ActionName: meta.Action,
+		Sha256:     meta.SHA,
+	}
+	if !meta.UpdatedAt.IsZero() {
+		res.Metadata.UpdatedAt, _ = ptypes.TimestampProto(meta.UpdatedAt)
+	}
+	return res, nil
+}
+
+func getWorkflowsForWorker(ctx context.Context, db db.Database, id string) ([]string, error) {
 	if id == "" {
 		return nil, status.Errorf(codes.InvalidArgument, errInvalidWorkerID)
 	}
-	wfs, err := db.GetWorkflowsForWorker(id)
+	wfs, err := db.GetWorkflowsForWorker(ctx, id)
 	if err != nil {
 		return nil, status.Errorf(codes.Aborted, err.Error())
 	}
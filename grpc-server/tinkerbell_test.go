@@ -2,11 +2,14 @@ package grpcserver
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/packethost/pkg/log"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -14,6 +17,8 @@ import (
 	"github.com/tinkerbell/tink/db/mock"
 	"github.com/tinkerbell/tink/metrics"
 	pb "github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -67,7 +72,7 @@ func TestGetWorkflowContextList(t *testing.T) {
 		"database failure": {
 			args: args{
 				db: &mock.DB{
-					GetWorkflowsForWorkerFunc: func(id string) ([]string, error) {
+					GetWorkflowsForWorkerFunc: func(ctx context.Context, id string) ([]string, error) {
 						return []string{workflowID}, nil
 					},
 					GetWorkflowContextsFunc: func(ctx context.Context, wfID string) (*pb.WorkflowContext, error) {
@@ -83,7 +88,7 @@ func TestGetWorkflowContextList(t *testing.T) {
 		"no workflows found": {
 			args: args{
 				db: &mock.DB{
-					GetWorkflowsForWorkerFunc: func(id string) ([]string, error) {
+					GetWorkflowsForWorkerFunc: func(ctx context.Context, id string) ([]string, error) {
 						return nil, nil
 					},
 					GetWorkflowContextsFunc: func(ctx context.Context, wfID string) (*pb.WorkflowContext, error) {
@@ -99,7 +104,7 @@ func TestGetWorkflowContextList(t *testing.T) {
 		"workflows found": {
 			args: args{
 				db: &mock.DB{
-					GetWorkflowsForWorkerFunc: func(id string) ([]string, error) {
+					GetWorkflowsForWorkerFunc: func(ctx context.Context, id string) ([]string, error) {
 						return []string{workflowID}, nil
 					},
 					GetWorkflowContextsFunc: func(ctx context.Context, wfID string) (*pb.WorkflowContext, error) {
@@ -758,7 +763,7 @@ func TestGetWorkflowsForWorker(t *testing.T) {
 		"database failure": {
 			args: args{
 				db: &mock.DB{
-					GetWorkflowsForWorkerFunc: func(id string) ([]string, error) {
+					GetWorkflowsForWorkerFunc: func(ctx context.Context, id string) ([]string, error) {
 						return nil, errors.New("database failed")
 					},
 				},
@@ -771,7 +776,7 @@ func TestGetWorkflowsForWorker(t *testing.T) {
 		"no workflows found": {
 			args: args{
 				db: &mock.DB{
-					GetWorkflowsForWorkerFunc: func(id string) ([]string, error) {
+					GetWorkflowsForWorkerFunc: func(ctx context.Context, id string) ([]string, error) {
 						return nil, nil
 					},
 				},
@@ -784,7 +789,7 @@ func TestGetWorkflowsForWorker(t *testing.T) {
 		"workflows found": {
 			args: args{
 				db: &mock.DB{
-					GetWorkflowsForWorkerFunc: func(id string) ([]string, error) {
+					GetWorkflowsForWorkerFunc: func(ctx context.Context, id string) ([]string, error) {
 						return []string{workflowID}, nil
 					},
 				},
@@ -799,7 +804,7 @@ func TestGetWorkflowsForWorker(t *testing.T) {
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			s := testServer(t, tc.args.db)
-			res, err := getWorkflowsForWorker(s.db, tc.args.workerID)
+			res, err := getWorkflowsForWorker(context.Background(), s.db, tc.args.workerID)
 			if err != nil {
 				assert.True(t, tc.want.expectedError)
 				assert.Error(t, err)
@@ -970,6 +975,88 @@ func TestGetWorkflowDataVersion(t *testing.T) {
 	}
 }
 
+func TestGetWorkflowDataRevision(t *testing.T) {
+	updatedAt := time.Date(2021, 4, 23, 12, 0, 0, 0, time.UTC)
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"workerID":   workerID,
+		"actionName": actionName,
+		"taskName":   taskName,
+		"updatedAt":  updatedAt,
+		"sha256":     "fcbf74596047b6d3e746702ccc2c697d87817371918a5042805c8c7c75b2cb5f",
+	})
+	testCases := map[string]struct {
+		req          *pb.GetWorkflowDataRequest
+		rev          db.WorkflowDataRevision
+		err          error
+		expected     *pb.WorkflowDataRevision
+		expectedCode codes.Code
+	}{
+		"retained": {
+			req: &pb.GetWorkflowDataRequest{WorkflowId: workflowID, Version: 2},
+			rev: db.WorkflowDataRevision{WorkflowID: workflowID, Version: 2, Metadata: metadata, Data: []byte(`{"disk":"/dev/sda"}`)},
+			expected: &pb.WorkflowDataRevision{
+				WorkflowId: workflowID,
+				Version:    2,
+				Data:       []byte(`{"disk":"/dev/sda"}`),
+				Metadata: &pb.WorkflowMetadata{
+					WorkerId:   workerID,
+					TaskName:   taskName,
+					ActionName: actionName,
+					UpdatedAt:  &timestamp.Timestamp{Seconds: updatedAt.Unix()},
+					Sha256:     "fcbf74596047b6d3e746702ccc2c697d87817371918a5042805c8c7c75b2cb5f",
+				},
+			},
+		},
+		"pruned": {
+			req:      &pb.GetWorkflowDataRequest{WorkflowId: workflowID, Version: 1},
+			rev:      db.WorkflowDataRevision{WorkflowID: workflowID, Version: 1, Metadata: []byte(`{}`)},
+			expected: &pb.WorkflowDataRevision{WorkflowId: workflowID, Version: 1, Pruned: true, Metadata: &pb.WorkflowMetadata{}},
+		},
+		"foreign-metadata": {
+			req:      &pb.GetWorkflowDataRequest{WorkflowId: workflowID},
+			rev:      db.WorkflowDataRevision{WorkflowID: workflowID, Version: 3, Metadata: []byte(`[]`), Data: []byte(`{}`)},
+			expected: &pb.WorkflowDataRevision{WorkflowId: workflowID, Version: 3, Data: []byte(`{}`)},
+		},
+		"unknown-version": {
+			req:          &pb.GetWorkflowDataRequest{WorkflowId: workflowID, Version: 4},
+			err:          errors.Wrap(sql.ErrNoRows, "SELECT from workflow_data"),
+			expectedCode: codes.NotFound,
+		},
+		"negative-version": {
+			req:          &pb.GetWorkflowDataRequest{WorkflowId: workflowID, Version: -1},
+			expectedCode: codes.InvalidArgument,
+		},
+		"missing-workflow-id": {
+			req:          &pb.GetWorkflowDataRequest{Version: 1},
+			expectedCode: codes.InvalidArgument,
+		},
+		"database-failure": {
+			req:          &pb.GetWorkflowDataRequest{WorkflowId: workflowID},
+			err:          errors.New("SELECT from workflow_data"),
+			expectedCode: codes.Aborted,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := testServer(t, &mock.DB{
+				GetWorkflowDataRevisionFunc: func(ctx context.Context, id string, version int32) (db.WorkflowDataRevision, error) {
+					assert.Equal(t, tc.req.WorkflowId, id)
+					assert.Equal(t, tc.req.Version, version)
+					return tc.rev, tc.err
+				},
+			})
+			res, err := s.GetWorkflowDataRevision(context.Background(), tc.req)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(tc.expected, res), "expected %v, got %v", tc.expected, res)
+		})
+	}
+}
+
 func TestIsApplicableToSend(t *testing.T) {
 	type (
 		args struct {
@@ -0,0 +1,232 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/metrics"
+	"github.com/tinkerbell/tink/protos/hardware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultWatchInterval = time.Second
+	// watchBatchSize is how many changes a watcher reads from the database
+	// at once
+	watchBatchSize = 100
+)
+
+var watchEventTypes = map[string]hardware.WatchEvent_Type{
+	db.HardwareCreated: hardware.WatchEvent_TYPE_CREATED,
+	db.HardwareUpdated: hardware.WatchEvent_TYPE_UPDATED,
+	db.HardwareDeleted: hardware.WatchEvent_TYPE_DELETED,
+}
+
+// Watch implements hardware.Watch
+func (s *server) Watch(in *hardware.WatchRequest, stream hardware.HardwareService_WatchServer) error {
+	labels := prometheus.Labels{"method": "Watch", "op": "push"}
+	metrics.CacheInFlight.With(labels).Inc()
+	defer metrics.CacheInFlight.With(labels).Dec()
+
+	if in.GetSinceRevision() < 0 {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Error(codes.InvalidArgument, "since_revision can not be negative")
+	}
+	if in.GetSendExisting() && in.GetSinceRevision() != 0 {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Error(codes.InvalidArgument, "send_existing can not be combined with since_revision")
+	}
+	m, err := newHardwareMatcher(in.GetLabelSelector(), in.GetFilters())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return err
+	}
+	ids := map[string]bool{}
+	for _, id := range in.GetIds() {
+		ids[id] = true
+	}
+	watched := func(hw *hardware.Hardware) (bool, error) {
+		if len(ids) > 0 && !ids[hw.Id] {
+			return false, nil
+		}
+		return m.matches(hw)
+	}
+
+	// the existing hardware has to be at least as recent as the revision
+	// the changes are followed from
+	ctx := db.WithPrimary(stream.Context())
+	l := s.logger.With("ids", in.GetIds(), "labelSelector", in.GetLabelSelector())
+	since := in.GetSinceRevision()
+	if since == 0 {
+		since, err = s.db.LatestHardwareRevision(ctx)
+		if err != nil {
+			metrics.CacheErrors.With(labels).Inc()
+			l.Error(err)
+			return err
+		}
+	}
+	if in.GetSendExisting() {
+		// a change made while the hardware is listed can be sent twice,
+		// as existing and as a change
+		err = s.selectHardware(ctx, in.GetLabelSelector(), in.GetFilters(), "", nil, func(hw *hardware.Hardware) error {
+			if len(ids) > 0 && !ids[hw.Id] {
+				return nil
+			}
+			return stream.Send(&hardware.WatchEvent{Type: hardware.WatchEvent_TYPE_EXISTING, Revision: since, Hardware: hw})
+		})
+		if err != nil {
+			metrics.CacheErrors.With(labels).Inc()
+			l.Error(err)
+			return err
+		}
+	}
+
+	err = s.followHardwareChanges(ctx, since, func(rev db.HardwareRevision) error {
+		hw, err := revisionHardware(rev.Data)
+		if err != nil {
+			return err
+		}
+		ok, err := watched(hw)
+		if err != nil {
+			return err
+		}
+		if rev.EventType == db.HardwareCompacted {
+			// the history of a purged hardware, the watcher missed its
+			// changes when it watched it
+			if ok {
+				return status.Errorf(codes.OutOfRange, "compacted: the changes of the hardware %s after this revision were purged", hw.Id)
+			}
+			return nil
+		}
+		typ := watchEventTypes[rev.EventType]
+		if !ok {
+			// an update can make the hardware leave the watch, the
+			// watcher is told it does not match anymore
+			if rev.PreviousData == "" {
+				return nil
+			}
+			previous, err := revisionHardware(rev.PreviousData)
+			if err != nil {
+				return err
+			}
+			if ok, err := watched(previous); !ok {
+				return err
+			}
+			typ = hardware.WatchEvent_TYPE_LEFT
+		}
+		err = stream.Send(&hardware.WatchEvent{Type: typ, Revision: rev.Revision, Hardware: hw})
+		return errors.Wrap(err, "stream send")
+	})
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l.Error(err)
+		return err
+	}
+	return nil
+}
+
+// revisionHardware decodes the data of a hardware revision
+func revisionHardware(data string) (*hardware.Hardware, error) {
+	hw := &hardware.Hardware{}
+	if err := json.Unmarshal([]byte(data), hw); err != nil {
+		return nil, errors.Wrap(err, "invalid hardware revision data")
+	}
+	setTypedMetadata(hw)
+	return hw, nil
+}
+
+// followHardwareChanges calls fn for every hardware revision made after
+// since, in order, until the client goes away or the server shuts down.
+func (s *server) followHardwareChanges(ctx context.Context, since int64, fn func(db.HardwareRevision) error) error {
+	return s.follow(ctx, func() (int, error) {
+		n := 0
+		err := s.db.GetHardwareChanges(ctx, since, watchBatchSize, func(rev db.HardwareRevision) error {
+			n++
+			since = rev.Revision
+			return fn(rev)
+		})
+		return n, err
+	})
+}
+
+// follow calls read until the client goes away or the server shuts down.
+// read reads the next batch of changes and returns how many it got, a full
+// batch is followed by another read right away. Otherwise follow waits for
+// the next watch interval, or for this server to change the data.
+func (s *server) follow(ctx context.Context, read func() (int, error)) error {
+	interval := s.watchInterval
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// taken before reading, so a change made during the read is not
+		// waited for until the next tick
+		changed := s.watchSignal()
+		n, err := read()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if n == watchBatchSize {
+			continue
+		}
+
+		select {
+		case <-s.quit:
+			s.logger.Info("server is shutting down")
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-changed:
+		}
+	}
+}
+
+// watchSignal returns a channel closed the next time the data changes
+func (s *server) watchSignal() <-chan struct{} {
+	s.watchLock.Lock()
+	defer s.watchLock.Unlock()
+	if s.watchChanged == nil {
+		s.watchChanged = make(chan struct{})
+	}
+	return s.watchChanged
+}
+
+// notifyWatchers wakes up the watchers after a change of the data, so they
+// do not wait for their next poll
+func (s *server) notifyWatchers() {
+	s.watchLock.Lock()
+	defer s.watchLock.Unlock()
+	if s.watchChanged != nil {
+		close(s.watchChanged)
+		s.watchChanged = nil
+	}
+}
+
+// DeprecatedWatch implements hardware.DeprecatedWatch on top of Watch
+func (s *server) DeprecatedWatch(in *hardware.GetRequest, stream hardware.HardwareService_DeprecatedWatchServer) error {
+	if in.GetId() == "" {
+		return status.Error(codes.InvalidArgument, "id must be set to a UUID")
+	}
+	return s.Watch(&hardware.WatchRequest{Ids: []string{in.Id}}, deprecatedWatchStream{stream})
+}
+
+// deprecatedWatchStream sends only the hardware of the watch events
+type deprecatedWatchStream struct {
+	hardware.HardwareService_DeprecatedWatchServer
+}
+
+func (w deprecatedWatchStream) Send(ev *hardware.WatchEvent) error {
+	return w.HardwareService_DeprecatedWatchServer.Send(ev.Hardware)
+}
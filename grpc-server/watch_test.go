@@ -0,0 +1,271 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/db/mock"
+	"github.com/tinkerbell/tink/protos/hardware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchServer collects the events until it has received want of them
+type watchServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	want   int
+	sent   []*hardware.WatchEvent
+}
+
+func newWatchServer(want int) *watchServer {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return &watchServer{ctx: ctx, cancel: cancel, want: want}
+}
+
+func (w *watchServer) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchServer) Send(ev *hardware.WatchEvent) error {
+	w.sent = append(w.sent, ev)
+	if len(w.sent) >= w.want {
+		w.cancel()
+	}
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	const (
+		id1 = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+		id2 = "e0ef0eb6-b2d6-4b5b-8f3e-fde3a1d48f0c"
+		id3 = "5b5b4e8f-0a5e-4a1e-9a3a-1c4c0e8e2d7b"
+	)
+	revisions := []db.HardwareRevision{
+		{Revision: 1, HardwareID: id1, EventType: db.HardwareCreated, Data: `{"id":"` + id1 + `","labels":{"rack":"r1"}}`},
+		{Revision: 2, HardwareID: id2, EventType: db.HardwareCreated, Data: `{"id":"` + id2 + `","labels":{"rack":"r2"}}`},
+		{Revision: 3, HardwareID: id1, EventType: db.HardwareUpdated, Data: `{"id":"` + id1 + `","version":2,"labels":{"rack":"r1"}}`},
+		{Revision: 4, HardwareID: id2, EventType: db.HardwareDeleted, Data: `{"id":"` + id2 + `","labels":{"rack":"r2"}}`},
+		{Revision: 5, HardwareID: id1, EventType: db.HardwareDeleted, Data: `{"id":"` + id1 + `","version":2,"labels":{"rack":"r1"}}`},
+		{Revision: 6, HardwareID: id3, EventType: db.HardwareCreated, Data: `{"id":"` + id3 + `","labels":{"rack":"r1"}}`},
+		{Revision: 7, HardwareID: id3, EventType: db.HardwareUpdated, Data: `{"id":"` + id3 + `","labels":{"rack":"r2"}}`, PreviousData: `{"id":"` + id3 + `","labels":{"rack":"r1"}}`},
+		{Revision: 8, HardwareID: id3, EventType: db.HardwareUpdated, Data: `{"id":"` + id3 + `","labels":{"rack":"r3"}}`, PreviousData: `{"id":"` + id3 + `","labels":{"rack":"r2"}}`},
+		{Revision: 9, HardwareID: id3, EventType: db.HardwareUpdated, Data: `{"id":"` + id3 + `","labels":{"rack":"r1"}}`, PreviousData: `{"id":"` + id3 + `","labels":{"rack":"r3"}}`},
+	}
+	type event struct {
+		Type     hardware.WatchEvent_Type
+		Revision int64
+		ID       string
+	}
+
+	testCases := map[string]struct {
+		req          *hardware.WatchRequest
+		latest       int64
+		want         int
+		expected     []event
+		expectedCode codes.Code
+	}{
+		"all": {
+			req:  &hardware.WatchRequest{SinceRevision: 2},
+			want: 3,
+			expected: []event{
+				{hardware.WatchEvent_TYPE_UPDATED, 3, id1},
+				{hardware.WatchEvent_TYPE_DELETED, 4, id2},
+				{hardware.WatchEvent_TYPE_DELETED, 5, id1},
+			},
+		},
+		"by-id": {
+			req:  &hardware.WatchRequest{Ids: []string{id2}},
+			want: 1,
+			// without since_revision the stream starts from the latest one
+			latest:   3,
+			expected: []event{{hardware.WatchEvent_TYPE_DELETED, 4, id2}},
+		},
+		"by-selector": {
+			req:  &hardware.WatchRequest{LabelSelector: "rack=r1"},
+			want: 6,
+			expected: []event{
+				{hardware.WatchEvent_TYPE_CREATED, 1, id1},
+				{hardware.WatchEvent_TYPE_UPDATED, 3, id1},
+				{hardware.WatchEvent_TYPE_DELETED, 5, id1},
+				{hardware.WatchEvent_TYPE_CREATED, 6, id3},
+				// the hardware leaving the selection is sent once
+				{hardware.WatchEvent_TYPE_LEFT, 7, id3},
+				{hardware.WatchEvent_TYPE_UPDATED, 9, id3},
+			},
+		},
+		"by-filter": {
+			req:      &hardware.WatchRequest{Filters: []string{"version=2"}, SinceRevision: 3},
+			want:     1,
+			expected: []event{{hardware.WatchEvent_TYPE_DELETED, 5, id1}},
+		},
+		"send-existing": {
+			req:    &hardware.WatchRequest{LabelSelector: "rack=r2", SendExisting: true},
+			latest: 2,
+			want:   2,
+			expected: []event{
+				{hardware.WatchEvent_TYPE_EXISTING, 2, id2},
+				{hardware.WatchEvent_TYPE_DELETED, 4, id2},
+			},
+		},
+		"existing-and-since": {
+			req:          &hardware.WatchRequest{SendExisting: true, SinceRevision: 2},
+			expectedCode: codes.InvalidArgument,
+		},
+		"negative-since": {
+			req:          &hardware.WatchRequest{SinceRevision: -1},
+			expectedCode: codes.InvalidArgument,
+		},
+		"invalid-selector": {
+			req:          &hardware.WatchRequest{LabelSelector: "=r1"},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := testServer(t, &mock.DB{
+				LatestHardwareRevisionFunc: func(ctx context.Context) (int64, error) {
+					return tc.latest, nil
+				},
+				ListHardwareFunc: func(ctx context.Context, labels map[string]string, opts db.ListOptions, fn func([]byte) error) error {
+					return fn([]byte(`{"id":"` + id2 + `","labels":{"rack":"r2"}}`))
+				},
+				GetHardwareChangesFunc: func(ctx context.Context, since int64, limit int, fn func(db.HardwareRevision) error) error {
+					// the revisions are read one at a time to go through
+					// the batches
+					assert.Equal(t, watchBatchSize, limit)
+					for _, rev := range revisions {
+						if rev.Revision > since {
+							return fn(rev)
+						}
+					}
+					return nil
+				},
+			})
+			s.watchInterval = time.Millisecond
+
+			stream := newWatchServer(tc.want)
+			defer stream.cancel()
+			err := s.Watch(tc.req, stream)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEqual(t, context.DeadlineExceeded, stream.ctx.Err())
+			events := []event{}
+			for _, ev := range stream.sent {
+				events = append(events, event{ev.Type, ev.Revision, ev.Hardware.Id})
+			}
+			assert.Equal(t, tc.expected, events)
+		})
+	}
+}
+
+func TestWatchWakesUpOnChange(t *testing.T) {
+	const id = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+	changes := make(chan db.HardwareRevision, 1)
+	s := testServer(t, &mock.DB{
+		GetHardwareChangesFunc: func(ctx context.Context, since int64, limit int, fn func(db.HardwareRevision) error) error {
+			select {
+			case rev := <-changes:
+				return fn(rev)
+			default:
+				return nil
+			}
+		},
+	})
+	// the poll alone would not find the change before the test times out
+	s.watchInterval = time.Hour
+
+	stream := newWatchServer(1)
+	defer stream.cancel()
+	done := make(chan error)
+	go func() {
+		done <- s.DeprecatedWatch(&hardware.GetRequest{Id: id}, deprecatedStream{stream})
+	}()
+
+	// wait for the watcher to be waiting for a change
+	for {
+		s.watchLock.Lock()
+		waiting := s.watchChanged != nil
+		s.watchLock.Unlock()
+		if waiting {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	changes <- db.HardwareRevision{Revision: 1, HardwareID: id, EventType: db.HardwareCreated, Data: `{"id":"` + id + `"}`}
+	s.notifyWatchers()
+
+	assert.NoError(t, <-done)
+	assert.NotEqual(t, context.DeadlineExceeded, stream.ctx.Err())
+	if assert.Len(t, stream.sent, 1) {
+		assert.Equal(t, id, stream.sent[0].Hardware.Id)
+	}
+}
+
+func TestWatchCompacted(t *testing.T) {
+	const (
+		watched = "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95"
+		other   = "e0ef0eb6-b2d6-4b5b-8f3e-fde3a1d48f0c"
+	)
+	revisions := []db.HardwareRevision{
+		{Revision: 42, HardwareID: other, EventType: db.HardwareCompacted, Data: `{"id":"` + other + `","labels":{"rack":"r2"}}`},
+		{Revision: 43, HardwareID: watched, EventType: db.HardwareCreated, Data: `{"id":"` + watched + `","labels":{"rack":"r1"}}`},
+		{Revision: 44, HardwareID: watched, EventType: db.HardwareCompacted, Data: `{"id":"` + watched + `","labels":{"rack":"r1"}}`},
+	}
+	s := testServer(t, &mock.DB{
+		GetHardwareChangesFunc: func(ctx context.Context, since int64, limit int, fn func(db.HardwareRevision) error) error {
+			for _, rev := range revisions {
+				if rev.Revision <= since {
+					continue
+				}
+				if err := fn(rev); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	s.watchInterval = time.Millisecond
+
+	// the purged history of a hardware which is not watched is skipped
+	stream := newWatchServer(2)
+	defer stream.cancel()
+	err := s.Watch(&hardware.WatchRequest{LabelSelector: "rack=r1", SinceRevision: 41}, stream)
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+	if assert.Len(t, stream.sent, 1) {
+		assert.Equal(t, int64(43), stream.sent[0].Revision)
+	}
+
+	stream = newWatchServer(1)
+	defer stream.cancel()
+	err = s.Watch(&hardware.WatchRequest{Ids: []string{other}, SinceRevision: 41}, stream)
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+	assert.Empty(t, stream.sent)
+
+	// nothing to send, the watch ends with its context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	stream = &watchServer{ctx: ctx, cancel: cancel, want: 1}
+	err = s.Watch(&hardware.WatchRequest{Ids: []string{other}, SinceRevision: 42}, stream)
+	assert.NoError(t, err)
+	assert.Empty(t, stream.sent)
+}
+
+// deprecatedStream adapts a watchServer to DeprecatedWatch, which sends
+// only the hardware
+type deprecatedStream struct {
+	*watchServer
+}
+
+func (d deprecatedStream) Send(hw *hardware.Hardware) error {
+	return d.watchServer.Send(&hardware.WatchEvent{Hardware: hw})
+}
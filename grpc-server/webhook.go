@@ -0,0 +1,409 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/metrics"
+	"github.com/tinkerbell/tink/protos/webhook"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultWebhookAttempts = 5
+	defaultWebhookTimeout  = 10 * time.Second
+	// defaultWebhookBackoff is the wait before the second attempt of a
+	// delivery, it doubles after every attempt
+	defaultWebhookBackoff = time.Second
+	// webhookShutdownGrace is how long the deliveries have, on top of
+	// their attempt in progress, to be recorded as failed when the server
+	// shuts down
+	webhookShutdownGrace = 5 * time.Second
+
+	// webhookSignatureHeader carries the HMAC-SHA256 of the timestamp
+	// header, a dot and the payload, keyed with the secret of the webhook,
+	// as sha256=<hex digest>
+	webhookSignatureHeader = "X-Tink-Signature"
+	// webhookTimestampHeader carries the Unix time in seconds of the
+	// attempt, every attempt of a delivery is signed again
+	webhookTimestampHeader = "X-Tink-Timestamp"
+	webhookEventHeader     = "X-Tink-Event"
+	webhookDeliveryHeader  = "X-Tink-Delivery"
+	// webhookSignatureTolerance is how far from their clock the receivers
+	// are expected to accept the timestamp of a request, a replayed request
+	// is refused once it is older
+	webhookSignatureTolerance = 5 * time.Minute
+)
+
+var webhookEventTypes = map[webhook.EventType]string{
+	webhook.EventType_EVENT_TYPE_WORKFLOW_STATE_CHANGED: "workflow_state_changed",
+	webhook.EventType_EVENT_TYPE_ACTION_FAILED:          "action_failed",
+}
+
+// webhookPayload is the JSON document POSTed to the webhooks
+type webhookPayload struct {
+	// ID identifies the delivery, it is the same for all its attempts
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	CreatedAt  time.Time `json:"created_at"`
+	WorkflowID string    `json:"workflow_id"`
+	// State is the state of the workflow for a workflow_state_changed
+	// event, the one of the action for an action_failed event
+	State      string `json:"state"`
+	TaskName   string `json:"task_name,omitempty"`
+	ActionName string `json:"action_name,omitempty"`
+	WorkerID   string `json:"worker_id,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// CreateWebhook implements webhook.CreateWebhook
+func (s *server) CreateWebhook(ctx context.Context, in *webhook.CreateRequest) (*webhook.CreateResponse, error) {
+	s.logger.Info("createwebhook")
+	labels := prometheus.Labels{"method": "CreateWebhook", "op": "insert"}
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	u, err := url.Parse(in.GetUrl())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		metrics.CacheErrors.With(labels).Inc()
+		return &webhook.CreateResponse{}, status.Error(codes.InvalidArgument, "url must be an absolute http or https URL")
+	}
+	if in.GetSecret() == "" {
+		metrics.CacheErrors.With(labels).Inc()
+		return &webhook.CreateResponse{}, status.Error(codes.InvalidArgument, "secret must be set, it signs the payloads")
+	}
+	wh := db.Webhook{
+		ID:        uuid.New().String(),
+		URL:       in.GetUrl(),
+		Secret:    in.GetSecret(),
+		CreatedAt: time.Now(),
+	}
+	for _, t := range in.GetEventTypes() {
+		name, ok := webhookEventTypes[t]
+		if !ok {
+			metrics.CacheErrors.With(labels).Inc()
+			return &webhook.CreateResponse{}, status.Errorf(codes.InvalidArgument, "invalid event type %s", t)
+		}
+		wh.EventTypes = append(wh.EventTypes, name)
+	}
+
+	if err := s.db.CreateWebhook(ctx, wh); err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l := s.logger
+		if pqErr := db.Error(err); pqErr != nil {
+			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
+		}
+		l.Error(err)
+		return &webhook.CreateResponse{}, err
+	}
+	s.logger.With("id", wh.ID, "url", wh.URL).Info("webhook created")
+	return &webhook.CreateResponse{Id: wh.ID}, nil
+}
+
+// DeleteWebhook implements webhook.DeleteWebhook
+func (s *server) DeleteWebhook(ctx context.Context, in *webhook.GetRequest) (*webhook.Empty, error) {
+	s.logger.Info("deletewebhook")
+	labels := prometheus.Labels{"method": "DeleteWebhook", "op": "delete"}
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	if in.GetId() == "" {
+		metrics.CacheErrors.With(labels).Inc()
+		return &webhook.Empty{}, status.Error(codes.InvalidArgument, "id must be set to a UUID")
+	}
+	err := s.db.DeleteWebhook(ctx, in.GetId())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l := s.logger
+		if pqErr := db.Error(err); pqErr != nil {
+			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
+		}
+		l.Error(err)
+	}
+	return &webhook.Empty{}, err
+}
+
+// ListWebhooks implements webhook.ListWebhooks
+func (s *server) ListWebhooks(_ *webhook.Empty, stream webhook.WebhookService_ListWebhooksServer) error {
+	s.logger.Info("listwebhooks")
+	labels := prometheus.Labels{"method": "ListWebhooks", "op": "list"}
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	err := s.db.ListWebhooks(stream.Context(), func(wh db.Webhook) error {
+		ts, err := ptypes.TimestampProto(wh.CreatedAt)
+		if err != nil {
+			return err
+		}
+		out := &webhook.Webhook{Id: wh.ID, Url: wh.URL, CreatedAt: ts}
+		for _, name := range wh.EventTypes {
+			out.EventTypes = append(out.EventTypes, webhookEventType(name))
+		}
+		return stream.Send(out)
+	})
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ListFailedDeliveries implements webhook.ListFailedDeliveries
+func (s *server) ListFailedDeliveries(in *webhook.ListFailedDeliveriesRequest, stream webhook.WebhookService_ListFailedDeliveriesServer) error {
+	s.logger.Info("listfaileddeliveries")
+	labels := prometheus.Labels{"method": "ListFailedDeliveries", "op": "list"}
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	err := s.db.ListWebhookFailures(stream.Context(), in.GetWebhookId(), func(f db.WebhookFailure) error {
+		ts, err := ptypes.TimestampProto(f.CreatedAt)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&webhook.FailedDelivery{
+			Id:        f.ID,
+			WebhookId: f.WebhookID,
+			EventType: webhookEventType(f.EventType),
+			Payload:   f.Payload,
+			Attempts:  f.Attempts,
+			Error:     f.Error,
+			CreatedAt: ts,
+		})
+	})
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+func webhookEventType(name string) webhook.EventType {
+	for t, n := range webhookEventTypes {
+		if n == name {
+			return t
+		}
+	}
+	return webhook.EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+// workflowStateChange returns the state the workflow moves to when one of
+// its actions reports a status. first and last tell if the action is the
+// first or the last one of the workflow.
+func workflowStateChange(action pb.State, first, last bool) (pb.State, bool) {
+	switch action {
+	case pb.State_STATE_RUNNING:
+		return action, first
+	case pb.State_STATE_SUCCESS:
+		return action, last
+	case pb.State_STATE_FAILED, pb.State_STATE_TIMEOUT:
+		return action, true
+	}
+	return action, false
+}
+
+// notifyWorkflowWebhooks sends the webhook events following an action
+// status report. The deliveries happen in the background, the report does
+// not wait for them.
+func (s *server) notifyWorkflowWebhooks(ctx context.Context, req *pb.WorkflowActionStatus, worker string, first, last bool) {
+	now := time.Now()
+	if state, ok := workflowStateChange(req.GetActionStatus(), first, last); ok {
+		s.sendWebhooks(ctx, webhookPayload{
+			Type:       webhookEventTypes[webhook.EventType_EVENT_TYPE_WORKFLOW_STATE_CHANGED],
+			CreatedAt:  now,
+			WorkflowID: req.GetWorkflowId(),
+			State:      state.String(),
+		})
+	}
+	switch req.GetActionStatus() {
+	case pb.State_STATE_FAILED, pb.State_STATE_TIMEOUT:
+		s.sendWebhooks(ctx, webhookPayload{
+			Type:       webhookEventTypes[webhook.EventType_EVENT_TYPE_ACTION_FAILED],
+			CreatedAt:  now,
+			WorkflowID: req.GetWorkflowId(),
+			State:      req.GetActionStatus().String(),
+			TaskName:   req.GetTaskName(),
+			ActionName: req.GetActionName(),
+			WorkerID:   worker,
+			Message:    req.GetMessage(),
+		})
+	}
+}
+
+// sendWebhooks delivers the payload to every webhook subscribed to its type
+func (s *server) sendWebhooks(ctx context.Context, payload webhookPayload) {
+	l := s.logger.With("type", payload.Type, "workflowID", payload.WorkflowID)
+	var subscribed []db.Webhook
+	err := s.db.ListWebhooks(ctx, func(wh db.Webhook) error {
+		if len(wh.EventTypes) == 0 {
+			subscribed = append(subscribed, wh)
+			return nil
+		}
+		for _, t := range wh.EventTypes {
+			if t == payload.Type {
+				subscribed = append(subscribed, wh)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		l.Error(errors.Wrap(err, "listing the webhooks"))
+		return
+	}
+	for _, wh := range subscribed {
+		payload.ID = uuid.New().String()
+		body, err := json.Marshal(payload)
+		if err != nil {
+			l.Error(err)
+			return
+		}
+		s.webhookDeliveries.Add(1)
+		go func(wh db.Webhook, id, eventType string, body []byte) {
+			defer s.webhookDeliveries.Done()
+			s.deliverWebhook(wh, id, eventType, body)
+		}(wh, payload.ID, payload.Type, body)
+	}
+}
+
+// deliverWebhook POSTs the body to the webhook until it is accepted or the
+// attempts are exhausted. A delivery that keeps failing is recorded in the
+// webhook failures.
+func (s *server) deliverWebhook(wh db.Webhook, id, eventType string, body []byte) {
+	attempts := s.webhookAttempts
+	if attempts <= 0 {
+		attempts = defaultWebhookAttempts
+	}
+	backoff := s.webhookBackoff
+	if backoff == 0 {
+		backoff = defaultWebhookBackoff
+	}
+	l := s.logger.With("webhookID", wh.ID, "delivery", id, "type", eventType)
+
+	var (
+		err     error
+		attempt int
+	)
+	for attempt = 1; ; attempt++ {
+		err = s.postWebhook(wh, id, eventType, body)
+		if err == nil {
+			l.With("attempt", attempt).Info("webhook delivered")
+			return
+		}
+		l.With("attempt", attempt).Error(err)
+		if attempt >= attempts || !s.waitWebhookRetry(backoff) {
+			break
+		}
+		backoff *= 2
+	}
+
+	err = s.db.InsertWebhookFailure(context.Background(), db.WebhookFailure{
+		ID:        id,
+		WebhookID: wh.ID,
+		EventType: eventType,
+		Payload:   string(body),
+		Attempts:  int32(attempt),
+		Error:     err.Error(),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		l.Error(errors.Wrap(err, "recording the failed delivery"))
+	}
+}
+
+// waitWebhookDeliveries waits for the deliveries in progress when the server
+// shuts down. They do not retry anymore and the attempt in progress is
+// bounded by the webhook timeout, past it and webhookShutdownGrace they are
+// given up, the server exits without them.
+func (s *server) waitWebhookDeliveries() bool {
+	timeout := s.webhookTimeout
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+	done := make(chan struct{})
+	go func() {
+		s.webhookDeliveries.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout + webhookShutdownGrace):
+		s.logger.Info("shutting down with webhook deliveries in progress")
+		return false
+	}
+}
+
+// waitWebhookRetry waits before the next attempt of a delivery. It returns
+// false when the server shuts down, the failure is then recorded right away.
+func (s *server) waitWebhookRetry(d time.Duration) bool {
+	select {
+	case <-s.quit:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func (s *server) postWebhook(wh db.Webhook, id, eventType string, body []byte) error {
+	timeout := s.webhookTimeout
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookSignatureHeader, signWebhookPayload(wh.Secret, timestamp, body))
+	req.Header.Set(webhookEventHeader, eventType)
+	req.Header.Set(webhookDeliveryHeader, id)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", res.Status)
+	}
+	return nil
+}
+
+// signWebhookPayload returns the value of the signature header of a payload
+// sent at timestamp. The timestamp is signed along with the payload so a
+// request can not be replayed later with another one.
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(timestamp + "."))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,281 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db"
+	"github.com/tinkerbell/tink/db/mock"
+	"github.com/tinkerbell/tink/protos/webhook"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	testCases := map[string]struct {
+		req                *webhook.CreateRequest
+		expectedEventTypes []string
+		expectedCode       codes.Code
+	}{
+		"all-events": {
+			req: &webhook.CreateRequest{Url: "https://tickets.example.com/hook", Secret: "s3cr3t"},
+		},
+		"some-events": {
+			req: &webhook.CreateRequest{
+				Url:        "http://tickets.example.com/hook",
+				Secret:     "s3cr3t",
+				EventTypes: []webhook.EventType{webhook.EventType_EVENT_TYPE_ACTION_FAILED},
+			},
+			expectedEventTypes: []string{"action_failed"},
+		},
+		"relative-url": {
+			req:          &webhook.CreateRequest{Url: "/hook", Secret: "s3cr3t"},
+			expectedCode: codes.InvalidArgument,
+		},
+		"unsupported-scheme": {
+			req:          &webhook.CreateRequest{Url: "ftp://tickets.example.com/hook", Secret: "s3cr3t"},
+			expectedCode: codes.InvalidArgument,
+		},
+		"missing-secret": {
+			req:          &webhook.CreateRequest{Url: "https://tickets.example.com/hook"},
+			expectedCode: codes.InvalidArgument,
+		},
+		"invalid-event-type": {
+			req: &webhook.CreateRequest{
+				Url:        "https://tickets.example.com/hook",
+				Secret:     "s3cr3t",
+				EventTypes: []webhook.EventType{webhook.EventType_EVENT_TYPE_UNSPECIFIED},
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var created *db.Webhook
+			s := testServer(t, &mock.DB{
+				CreateWebhookFunc: func(ctx context.Context, wh db.Webhook) error {
+					created = &wh
+					return nil
+				},
+			})
+
+			res, err := s.CreateWebhook(context.Background(), tc.req)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.Nil(t, created)
+				return
+			}
+			assert.NoError(t, err)
+			if assert.NotNil(t, created) {
+				assert.Equal(t, res.Id, created.ID)
+				assert.Equal(t, tc.req.Url, created.URL)
+				assert.Equal(t, tc.req.Secret, created.Secret)
+				assert.Equal(t, tc.expectedEventTypes, created.EventTypes)
+			}
+		})
+	}
+}
+
+func TestWorkflowStateChange(t *testing.T) {
+	testCases := map[string]struct {
+		action   pb.State
+		first    bool
+		last     bool
+		expected bool
+	}{
+		"first-action-running":  {action: pb.State_STATE_RUNNING, first: true, expected: true},
+		"second-action-running": {action: pb.State_STATE_RUNNING},
+		"last-action-success":   {action: pb.State_STATE_SUCCESS, last: true, expected: true},
+		"action-success":        {action: pb.State_STATE_SUCCESS},
+		"action-failed":         {action: pb.State_STATE_FAILED, expected: true},
+		"action-timeout":        {action: pb.State_STATE_TIMEOUT, expected: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state, changed := workflowStateChange(tc.action, tc.first, tc.last)
+			assert.Equal(t, tc.action, state)
+			assert.Equal(t, tc.expected, changed)
+		})
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event":"action_failed"}`)
+	signature := signWebhookPayload("s3cr3t", "1600000000", body)
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Len(t, signature, 7+64)
+	assert.Equal(t, signature, signWebhookPayload("s3cr3t", "1600000000", body))
+
+	// a request replayed with another timestamp does not match its
+	// signature
+	assert.NotEqual(t, signature, signWebhookPayload("s3cr3t", "1600000300", body))
+	assert.NotEqual(t, signature, signWebhookPayload("other", "1600000000", body))
+}
+
+// webhookEndpoint records the requests it receives, it answers with an
+// error to the first failures of them
+type webhookEndpoint struct {
+	sync.Mutex
+	failures int
+	payloads []webhookPayload
+	headers  []http.Header
+}
+
+func (e *webhookEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.Lock()
+	defer e.Unlock()
+	body, _ := ioutil.ReadAll(r.Body)
+	var p webhookPayload
+	_ = json.Unmarshal(body, &p)
+	e.payloads = append(e.payloads, p)
+	e.headers = append(e.headers, r.Header)
+	timestamp := r.Header.Get(webhookTimestampHeader)
+	if r.Header.Get(webhookSignatureHeader) != signWebhookPayload("s3cr3t", timestamp, body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(sent, 0)) > webhookSignatureTolerance {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if e.failures > 0 {
+		e.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestNotifyWorkflowWebhooks(t *testing.T) {
+	failedOnly := &webhookEndpoint{failures: 1}
+	all := &webhookEndpoint{}
+	failedOnlySrv := httptest.NewServer(failedOnly)
+	defer failedOnlySrv.Close()
+	allSrv := httptest.NewServer(all)
+	defer allSrv.Close()
+
+	var failures []db.WebhookFailure
+	s := testServer(t, &mock.DB{
+		ListWebhooksFunc: func(ctx context.Context, fn func(db.Webhook) error) error {
+			for _, wh := range []db.Webhook{
+				{ID: "failed-only", URL: failedOnlySrv.URL, Secret: "s3cr3t", EventTypes: []string{"action_failed"}},
+				{ID: "all", URL: allSrv.URL, Secret: "s3cr3t"},
+			} {
+				if err := fn(wh); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		InsertWebhookFailureFunc: func(ctx context.Context, f db.WebhookFailure) error {
+			failures = append(failures, f)
+			return nil
+		},
+	})
+	s.webhookBackoff = time.Millisecond
+
+	req := &pb.WorkflowActionStatus{
+		WorkflowId:   workflowID,
+		TaskName:     "provision",
+		ActionName:   "disk-wipe",
+		ActionStatus: pb.State_STATE_FAILED,
+		Message:      "exit status 1",
+	}
+	s.notifyWorkflowWebhooks(context.Background(), req, "worker-1", false, false)
+	s.webhookDeliveries.Wait()
+
+	assert.Empty(t, failures)
+	// the failed delivery was retried
+	if assert.Len(t, failedOnly.payloads, 2) {
+		p := failedOnly.payloads[1]
+		assert.Equal(t, "action_failed", p.Type)
+		assert.Equal(t, "disk-wipe", p.ActionName)
+		assert.Equal(t, "worker-1", p.WorkerID)
+		assert.Equal(t, "exit status 1", p.Message)
+		// the retries are the same delivery
+		assert.Equal(t, failedOnly.payloads[0].ID, p.ID)
+		assert.Equal(t, p.ID, failedOnly.headers[1].Get(webhookDeliveryHeader))
+	}
+	types := []string{}
+	for _, p := range all.payloads {
+		assert.Equal(t, workflowID, p.WorkflowID)
+		assert.Equal(t, "STATE_FAILED", p.State)
+		types = append(types, p.Type)
+	}
+	sort.Strings(types)
+	assert.Equal(t, []string{"action_failed", "workflow_state_changed"}, types)
+}
+
+func TestDeliverWebhookRecordsFailure(t *testing.T) {
+	endpoint := &webhookEndpoint{failures: 10}
+	srv := httptest.NewServer(endpoint)
+	defer srv.Close()
+
+	var failures []db.WebhookFailure
+	s := testServer(t, &mock.DB{
+		InsertWebhookFailureFunc: func(ctx context.Context, f db.WebhookFailure) error {
+			failures = append(failures, f)
+			return nil
+		},
+	})
+	s.webhookAttempts = 3
+	s.webhookBackoff = time.Millisecond
+
+	s.deliverWebhook(db.Webhook{ID: "hook", URL: srv.URL, Secret: "s3cr3t"}, "delivery", "action_failed", []byte(`{"id":"delivery"}`))
+
+	assert.Len(t, endpoint.payloads, 3)
+	if assert.Len(t, failures, 1) {
+		f := failures[0]
+		assert.Equal(t, "delivery", f.ID)
+		assert.Equal(t, "hook", f.WebhookID)
+		assert.Equal(t, "action_failed", f.EventType)
+		assert.Equal(t, `{"id":"delivery"}`, f.Payload)
+		assert.Equal(t, int32(3), f.Attempts)
+		assert.Contains(t, f.Error, "503")
+	}
+}
+
+func TestWebhookDeliveriesOnShutdown(t *testing.T) {
+	endpoint := &webhookEndpoint{failures: 10}
+	srv := httptest.NewServer(endpoint)
+	defer srv.Close()
+
+	var failures []db.WebhookFailure
+	s := testServer(t, &mock.DB{
+		InsertWebhookFailureFunc: func(ctx context.Context, f db.WebhookFailure) error {
+			failures = append(failures, f)
+			return nil
+		},
+	})
+	quit := make(chan struct{})
+	s.quit = quit
+	s.webhookBackoff = time.Hour
+
+	s.webhookDeliveries.Add(1)
+	go func() {
+		defer s.webhookDeliveries.Done()
+		s.deliverWebhook(db.Webhook{ID: "hook", URL: srv.URL, Secret: "s3cr3t"}, "delivery", "action_failed", []byte(`{"id":"delivery"}`))
+	}()
+
+	// the delivery waiting for its next attempt gives up when the server
+	// shuts down, its failure is recorded before the server exits
+	close(quit)
+	assert.True(t, s.waitWebhookDeliveries())
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "delivery", failures[0].ID)
+		assert.Equal(t, int32(1), failures[0].Attempts)
+	}
+}
@@ -1,19 +1,31 @@
 package grpcserver
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"strconv"
+	"strings"
+	"text/template"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tinkerbell/tink/db"
 	"github.com/tinkerbell/tink/metrics"
+	"github.com/tinkerbell/tink/pkg"
+	"github.com/tinkerbell/tink/protos/hardware"
 	"github.com/tinkerbell/tink/protos/workflow"
 	wkf "github.com/tinkerbell/tink/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-const errFailedToGetTemplate = "failed to get template with ID %s"
+const (
+	errFailedToGetTemplate = "failed to get template with ID %s"
+
+	defaultHardwareMapping = `{"device_1": "{{ (index .network.interfaces 0).dhcp.mac }}"}`
+)
 
 // CreateWorkflow implements workflow.CreateWorkflow
 func (s *server) CreateWorkflow(ctx context.Context, in *workflow.CreateRequest) (*workflow.CreateResponse, error) {
@@ -24,6 +36,8 @@ func (s *server) CreateWorkflow(ctx context.Context, in *workflow.CreateRequest)
 
 	const msg = "creating a new workflow"
 	labels["op"] = "createworkflow"
+	// the template and the hardware checked are the latest ones
+	ctx = db.WithPrimary(ctx)
 	id, err := uuid.NewUUID()
 	if err != nil {
 		return &workflow.CreateResponse{}, err
@@ -41,6 +55,8 @@ func (s *server) CreateWorkflow(ctx context.Context, in *workflow.CreateRequest)
 	if err != nil {
 		return &workflow.CreateResponse{}, errors.Wrapf(err, errFailedToGetTemplate, in.GetTemplate())
 	}
+	// the workflow and its hardware are in the namespace of the template
+	ctx = db.WithNamespace(ctx, wtmpl.GetNamespace())
 	data, err := wkf.RenderTemplate(in.GetTemplate(), wtmpl.GetData(), []byte(in.Hardware))
 
 	if err != nil {
@@ -48,12 +64,24 @@ func (s *server) CreateWorkflow(ctx context.Context, in *workflow.CreateRequest)
 		s.logger.Error(err)
 		return &workflow.CreateResponse{}, err
 	}
+	kind, err := templateKind(wtmpl.GetData())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return &workflow.CreateResponse{}, err
+	}
+	if err := s.checkWorkflowHardware(ctx, in.Hardware, kind); err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return &workflow.CreateResponse{}, err
+	}
 
 	wf := db.Workflow{
-		ID:       id.String(),
-		Template: in.Template,
-		Hardware: in.Hardware,
-		State:    workflow.State_value[workflow.State_STATE_PENDING.String()],
+		ID:        id.String(),
+		Template:  in.Template,
+		Hardware:  in.Hardware,
+		State:     workflow.State_value[workflow.State_STATE_PENDING.String()],
+		Namespace: wtmpl.GetNamespace(),
 	}
 	err = s.db.CreateWorkflow(ctx, wf, data, id)
 	if err != nil {
@@ -65,12 +93,172 @@ func (s *server) CreateWorkflow(ctx context.Context, in *workflow.CreateRequest)
 		l.Error(err)
 		return &workflow.CreateResponse{}, err
 	}
+	s.notifyWatchers()
 
 	l := s.logger.With("workflowID", id.String())
 	l.Info("done " + msg)
 	return &workflow.CreateResponse{Id: id.String()}, err
 }
 
+// CreateWorkflows implements workflow.CreateWorkflows
+func (s *server) CreateWorkflows(ctx context.Context, in *workflow.CreateWorkflowsRequest) (*workflow.CreateWorkflowsResponse, error) {
+	s.logger.Info("createworkflows")
+	labels := prometheus.Labels{"method": "CreateWorkflows", "op": ""}
+	metrics.CacheInFlight.With(labels).Inc()
+	defer metrics.CacheInFlight.With(labels).Dec()
+
+	// must be a copy so deferred cacheInFlight.Dec matches the Inc
+	labels = prometheus.Labels{"method": "CreateWorkflows", "op": ""}
+
+	if in.GetLabelSelector() == "" && len(in.GetFilters()) == 0 {
+		metrics.CacheTotals.With(labels).Inc()
+		metrics.CacheErrors.With(labels).Inc()
+		err := status.Error(codes.InvalidArgument, "at least one between label_selector and filters is required")
+		s.logger.Error(err)
+		return &workflow.CreateWorkflowsResponse{}, err
+	}
+	mapping := in.GetHardwareMapping()
+	if mapping == "" {
+		mapping = defaultHardwareMapping
+	}
+	mappingTmpl, err := template.New("hardware-mapping").Option("missingkey=error").Parse(mapping)
+	if err != nil {
+		metrics.CacheTotals.With(labels).Inc()
+		metrics.CacheErrors.With(labels).Inc()
+		return &workflow.CreateWorkflowsResponse{}, status.Errorf(codes.InvalidArgument, "invalid hardware mapping: %v", err)
+	}
+
+	const msg = "creating new workflows"
+	labels["op"] = "createworkflows"
+	// the template and the hardware selected are the latest ones
+	ctx = db.WithPrimary(ctx)
+	metrics.CacheTotals.With(labels).Inc()
+	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
+	defer timer.ObserveDuration()
+
+	s.logger.Info(msg)
+	fields := map[string]string{
+		"id": in.GetTemplate(),
+	}
+	wtmpl, err := s.db.GetTemplate(ctx, fields, false)
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return &workflow.CreateWorkflowsResponse{}, errors.Wrapf(err, errFailedToGetTemplate, in.GetTemplate())
+	}
+	// the workflows and their hardware are in the namespace of the template
+	ctx = db.WithNamespace(ctx, wtmpl.GetNamespace())
+	kind, err := templateKind(wtmpl.GetData())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return &workflow.CreateWorkflowsResponse{}, err
+	}
+
+	res := &workflow.CreateWorkflowsResponse{}
+	var (
+		wfs        []db.Workflow
+		data       []string
+		ineligible []string
+	)
+	// every machine is checked before any workflow gets created, a dry run
+	// reports the ineligible ones in their target
+	err = s.selectHardware(ctx, in.GetLabelSelector(), in.GetFilters(), "", nil, func(hw *hardware.Hardware) error {
+		if err := checkWorkflowState(hw, kind); err != nil {
+			ineligible = append(ineligible, status.Convert(err).Message())
+			if in.GetDryRun() {
+				res.Targets = append(res.Targets, &workflow.CreateWorkflowsResponse_Target{
+					HardwareId: hw.Id,
+					Error:      status.Convert(err).Message(),
+				})
+			}
+			return nil
+		}
+		devices, err := renderHardwareMapping(mappingTmpl, hw)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "hardware mapping for %s: %v", hw.Id, err)
+		}
+		d, err := wkf.RenderTemplate(in.GetTemplate(), wtmpl.GetData(), []byte(devices))
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "hardware %s: %v", hw.Id, err)
+		}
+		target := &workflow.CreateWorkflowsResponse_Target{
+			HardwareId: hw.Id,
+			Hardware:   devices,
+		}
+		if !in.GetDryRun() {
+			id, err := uuid.NewUUID()
+			if err != nil {
+				return err
+			}
+			target.WorkflowId = id.String()
+			wfs = append(wfs, db.Workflow{
+				ID:        target.WorkflowId,
+				Template:  in.Template,
+				Hardware:  devices,
+				State:     workflow.State_value[workflow.State_STATE_PENDING.String()],
+				Namespace: wtmpl.GetNamespace(),
+			})
+			data = append(data, d)
+		}
+		res.Targets = append(res.Targets, target)
+		return nil
+	})
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		s.logger.Error(err)
+		return &workflow.CreateWorkflowsResponse{}, err
+	}
+	if len(ineligible) > 0 && !in.GetDryRun() {
+		metrics.CacheErrors.With(labels).Inc()
+		err := status.Errorf(codes.FailedPrecondition, "no workflow created, %d of the %d target hardware can not run it: %s",
+			len(ineligible), len(ineligible)+len(res.Targets), strings.Join(ineligible, "; "))
+		s.logger.Error(err)
+		return &workflow.CreateWorkflowsResponse{}, err
+	}
+	if in.GetDryRun() || len(wfs) == 0 {
+		s.logger.With("targets", len(res.Targets), "dryRun", in.GetDryRun()).Info("done " + msg)
+		return res, nil
+	}
+
+	err = s.db.CreateWorkflows(ctx, wfs, data)
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l := s.logger
+		if pqErr := db.Error(err); pqErr != nil {
+			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
+		}
+		l.Error(err)
+		return &workflow.CreateWorkflowsResponse{}, err
+	}
+	s.notifyWatchers()
+
+	s.logger.With("targets", len(res.Targets)).Info("done " + msg)
+	return res, nil
+}
+
+// renderHardwareMapping executes the mapping template with the hardware as
+// data and it returns the devices JSON object it produces
+func renderHardwareMapping(t *template.Template, hw *hardware.Hardware) (string, error) {
+	doc, err := hardwareToMap(hw)
+	if err != nil {
+		return "", err
+	}
+	// every field of the metadata schema can be referenced, even when the
+	// hardware does not set it
+	if metadata, err := pkg.MetadataForTemplate(hw.Metadata); err == nil {
+		doc["metadata"] = metadata
+	}
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, doc); err != nil {
+		return "", err
+	}
+	devices := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &devices); err != nil {
+		return "", errors.Wrap(err, "the mapping has to render a JSON object")
+	}
+	return buf.String(), nil
+}
+
 // GetWorkflow implements workflow.GetWorkflow
 func (s *server) GetWorkflow(ctx context.Context, in *workflow.GetRequest) (*workflow.Workflow, error) {
 	s.logger.Info("getworkflow")
@@ -116,6 +304,7 @@ func (s *server) GetWorkflow(ctx context.Context, in *workflow.GetRequest) (*wor
 		CreatedAt: w.CreatedAt,
 		UpdatedAt: w.UpdatedAt,
 		Data:      data,
+		Namespace: w.Namespace,
 	}
 	l := s.logger.With("workflowID", w.ID)
 	l.Info("done " + msg)
@@ -146,19 +335,38 @@ func (s *server) DeleteWorkflow(ctx context.Context, in *workflow.GetRequest) (*
 			l = l.With("detail", pqErr.Detail, "where", pqErr.Where)
 		}
 		l.Error(err)
+	} else {
+		s.notifyWatchers()
 	}
 	l.Info("done " + msg)
 	return &workflow.Empty{}, err
 }
 
 // ListWorkflows implements workflow.ListWorkflows
-func (s *server) ListWorkflows(_ *workflow.Empty, stream workflow.WorkflowService_ListWorkflowsServer) error {
+func (s *server) ListWorkflows(in *workflow.ListRequest, stream workflow.WorkflowService_ListWorkflowsServer) error {
 	s.logger.Info("listworkflows")
 	labels := prometheus.Labels{"method": "ListWorkflows", "op": "list"}
 	metrics.CacheTotals.With(labels).Inc()
 	metrics.CacheInFlight.With(labels).Inc()
 	defer metrics.CacheInFlight.With(labels).Dec()
 
+	p, err := newPager(in.GetPageSize(), in.GetPageToken(), in.GetOrderBy())
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		return err
+	}
+	filter := db.WorkflowFilter{
+		Template: in.GetTemplate(),
+		Hardware: in.GetHardware(),
+	}
+	for _, state := range in.GetStates() {
+		filter.States = append(filter.States, int32(state))
+	}
+	opts := db.ListOptions{OrderBy: in.GetOrderBy()}
+	if in.GetCreatedAfter() != nil {
+		opts.CreatedAfter = in.GetCreatedAfter().AsTime()
+	}
+
 	s.dbLock.RLock()
 	ready := s.dbReady
 	s.dbLock.RUnlock()
@@ -169,7 +377,10 @@ func (s *server) ListWorkflows(_ *workflow.Empty, stream workflow.WorkflowServic
 
 	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
 	defer timer.ObserveDuration()
-	err := s.db.ListWorkflows(func(w db.Workflow) error {
+	err = s.db.ListWorkflows(stream.Context(), filter, p.options(opts, false), func(w db.Workflow) error {
+		if ok, err := p.add(); !ok {
+			return err
+		}
 		wf := &workflow.Workflow{
 			Id:        w.ID,
 			Template:  w.Template,
@@ -177,10 +388,11 @@ func (s *server) ListWorkflows(_ *workflow.Empty, stream workflow.WorkflowServic
 			CreatedAt: w.CreatedAt,
 			UpdatedAt: w.UpdatedAt,
 			State:     getWorkflowState(s.db, stream.Context(), w.ID),
+			Namespace: w.Namespace,
 		}
 		return stream.Send(wf)
 	})
-
+	err = p.done(err, stream.SetTrailer)
 	if err != nil {
 		metrics.CacheErrors.With(labels).Inc()
 		return err
@@ -254,7 +466,7 @@ func (s *server) ShowWorkflowEvents(req *workflow.GetRequest, stream workflow.Wo
 
 	timer := prometheus.NewTimer(metrics.CacheDuration.With(labels))
 	defer timer.ObserveDuration()
-	err := s.db.ShowWorkflowEvents(req.Id, func(w *workflow.WorkflowActionStatus) error {
+	err := s.db.ShowWorkflowEvents(stream.Context(), req.Id, func(w *workflow.WorkflowActionStatus) error {
 		wfs := &workflow.WorkflowActionStatus{
 			WorkerId:     w.WorkerId,
 			TaskName:     w.TaskName,
@@ -276,6 +488,96 @@ func (s *server) ShowWorkflowEvents(req *workflow.GetRequest, stream workflow.Wo
 	return nil
 }
 
+// WatchWorkflowEvents implements workflow.WatchWorkflowEvents
+func (s *server) WatchWorkflowEvents(req *workflow.WatchWorkflowEventsRequest, stream workflow.WorkflowService_WatchWorkflowEventsServer) error {
+	labels := prometheus.Labels{"method": "WatchWorkflowEvents", "op": "push"}
+	metrics.CacheInFlight.With(labels).Inc()
+	defer metrics.CacheInFlight.With(labels).Dec()
+
+	if req.GetId() == "" {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Error(codes.InvalidArgument, errInvalidWorkflowId)
+	}
+	if req.GetSkip() < 0 {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Error(codes.InvalidArgument, "skip can not be negative")
+	}
+	if req.GetAfterEventId() < 0 {
+		metrics.CacheErrors.With(labels).Inc()
+		return status.Error(codes.InvalidArgument, "after_event_id can not be negative")
+	}
+
+	ctx := stream.Context()
+	l := s.logger.With("workflowID", req.Id)
+	// each read resumes from the last event sent, which is read again for
+	// its state but not sent. The events skipped by count are the first
+	// ones, for the clients which do not know the event ids.
+	var (
+		from = req.GetAfterEventId()
+		skip = req.GetSkip()
+		last workflow.State
+	)
+	if from != 0 {
+		skip = 0
+	}
+	done := false
+	err := s.follow(ctx, func() (int, error) {
+		// the state is read before the events: the event of a final state
+		// is recorded after it
+		wfCtx, err := s.db.GetWorkflowContexts(ctx, req.Id)
+		if err != nil {
+			return 0, err
+		}
+		err = s.db.GetWorkflowEventsFrom(ctx, req.Id, from, func(id int64, ev *workflow.WorkflowActionStatus) error {
+			if id == from {
+				last = ev.ActionStatus
+				return nil
+			}
+			from, last = id, ev.ActionStatus
+			if skip > 0 {
+				skip--
+				return nil
+			}
+			ev.WorkflowId = req.Id
+			ev.EventId = id
+			return stream.Send(ev)
+		})
+		if err != nil {
+			return 0, err
+		}
+		state := workflowStateOf(wfCtx)
+		if isFinalWorkflowState(state) && last == state {
+			done = true
+			return 0, errWorkflowDone
+		}
+		return 0, nil
+	})
+	if done {
+		return nil
+	}
+	if err != nil {
+		metrics.CacheErrors.With(labels).Inc()
+		l.Error(err)
+		return err
+	}
+	if ctx.Err() == nil {
+		// the client resumes the stream with another server
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	return nil
+}
+
+// errWorkflowDone stops following a workflow that reached its final state
+var errWorkflowDone = errors.New("workflow is done")
+
+func isFinalWorkflowState(state workflow.State) bool {
+	switch state {
+	case workflow.State_STATE_SUCCESS, workflow.State_STATE_FAILED, workflow.State_STATE_TIMEOUT:
+		return true
+	}
+	return false
+}
+
 // This function will provide the workflow state on the basis of the state of the actions
 // For e.g. : If an action has Failed or Timeout then the workflow state will also be
 // considered as Failed/Timeout. And If an action is successful then the workflow state
@@ -283,6 +585,10 @@ func (s *server) ShowWorkflowEvents(req *workflow.GetRequest, stream workflow.Wo
 
 func getWorkflowState(db db.Database, ctx context.Context, id string) workflow.State {
 	wfCtx, _ := db.GetWorkflowContexts(ctx, id)
+	return workflowStateOf(wfCtx)
+}
+
+func workflowStateOf(wfCtx *workflow.WorkflowContext) workflow.State {
 	if wfCtx.CurrentActionState != workflow.State_STATE_SUCCESS {
 		return wfCtx.CurrentActionState
 	} else {
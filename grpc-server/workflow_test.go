@@ -11,6 +11,8 @@ import (
 	"github.com/tinkerbell/tink/db/mock"
 	tb "github.com/tinkerbell/tink/protos/template"
 	"github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -26,6 +28,17 @@ tasks:
     - name: "hello_world"
       image: hello-world
       timeout: 60`
+	deprovisionTemplateData = `version: "0.1"
+name: wipe_workflow
+kind: deprovision
+global_timeout: 600
+tasks:
+  - name: "wipe"
+    worker: "{{.device_1}}"
+    actions:
+    - name: "wipe"
+      image: wipe
+      timeout: 60`
 )
 
 func TestCreateWorkflow(t *testing.T) {
@@ -81,6 +94,30 @@ func TestCreateWorkflow(t *testing.T) {
 				expectedError: true,
 			},
 		},
+		"HardwareNotEligible": {
+			args: args{
+				db: &mock.DB{
+					GetTemplateFunc: func(ctx context.Context, fields map[string]string, deleted bool) (*tb.WorkflowTemplate, error) {
+						return &tb.WorkflowTemplate{
+							Id:   "",
+							Name: "",
+							Data: templateData,
+						}, nil
+					},
+					GetByMACFunc: func(ctx context.Context, mac string) (string, error) {
+						return `{"id":"0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95","state":3}`, nil
+					},
+					CreateWorkflowFunc: func(ctx context.Context, wf db.Workflow, data string, id uuid.UUID) error {
+						return nil
+					},
+				},
+				wfTemplate: templateID,
+				wfHardware: hw,
+			},
+			want: want{
+				expectedError: true,
+			},
+		},
 		"SuccessCreatingWorkflow": {
 			args: args{
 				db: &mock.DB{
@@ -126,6 +163,168 @@ func TestCreateWorkflow(t *testing.T) {
 	}
 }
 
+func TestCreateWorkflows(t *testing.T) {
+	stored := []string{
+		`{"id":"a","labels":{"rack":"r1"},"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:01","hostname":"server001"}}]}}`,
+		`{"id":"b","labels":{"rack":"r1"},"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:02","hostname":"server002"}}]}}`,
+		`{"id":"c","labels":{"rack":"r2"},"metadata":"{\"facility\":{\"facility_code\":\"onprem\"}}","network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:03","hostname":"server003"}}]}}`,
+		`{"id":"d","labels":{"zone":"z1"},"state":6,"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:04","hostname":"server004"}}]}}`,
+		`{"id":"e","labels":{"row":"w3"},"state":4,"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:05","hostname":"server005"}}]}}`,
+		`{"id":"f","labels":{"row":"w3"},"state":2,"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:06","hostname":"server006"}}]}}`,
+		`{"id":"g","labels":{"row":"w3"},"state":6,"network":{"interfaces":[{"dhcp":{"mac":"08:00:27:00:00:07","hostname":"server007"}}]}}`,
+	}
+	testCases := map[string]struct {
+		req              *workflow.CreateWorkflowsRequest
+		template         string
+		createErr        error
+		expectedCode     codes.Code
+		expectedTargets  map[string]string
+		expectedErrors   []string
+		expectedWorkflow bool
+	}{
+		"default-mapping": {
+			req: &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "rack=r1"},
+			expectedTargets: map[string]string{
+				"a": `{"device_1": "08:00:27:00:00:01"}`,
+				"b": `{"device_1": "08:00:27:00:00:02"}`,
+			},
+			expectedWorkflow: true,
+		},
+		"custom-mapping": {
+			req: &workflow.CreateWorkflowsRequest{
+				Template:        templateID,
+				Filters:         []string{"network.interfaces.dhcp.hostname=server003"},
+				HardwareMapping: `{"device_1": "{{ .id }}"}`,
+			},
+			expectedTargets:  map[string]string{"c": `{"device_1": "c"}`},
+			expectedWorkflow: true,
+		},
+		"metadata-mapping": {
+			req: &workflow.CreateWorkflowsRequest{
+				Template:        templateID,
+				LabelSelector:   "rack",
+				HardwareMapping: `{"device_1": "{{ .id }}/{{ .metadata.facility.facility_code }}/{{ .metadata.facility.plan_slug }}"}`,
+			},
+			expectedTargets: map[string]string{
+				"a": `{"device_1": "a//"}`,
+				"b": `{"device_1": "b//"}`,
+				"c": `{"device_1": "c/onprem/"}`,
+			},
+			expectedWorkflow: true,
+		},
+		"dry-run": {
+			req: &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "rack=r2", DryRun: true},
+			expectedTargets: map[string]string{
+				"c": `{"device_1": "08:00:27:00:00:03"}`,
+			},
+		},
+		"no-selector": {
+			req:          &workflow.CreateWorkflowsRequest{Template: templateID},
+			expectedCode: codes.InvalidArgument,
+		},
+		"invalid-mapping": {
+			req:          &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "rack=r1", HardwareMapping: `{"device_1": "{{ .missing }}"}`},
+			expectedCode: codes.InvalidArgument,
+		},
+		"mapping-not-json": {
+			req:          &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "rack=r1", HardwareMapping: `{{ .id }}`},
+			expectedCode: codes.InvalidArgument,
+		},
+		"hardware-not-eligible": {
+			req:          &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "zone=z1"},
+			expectedCode: codes.FailedPrecondition,
+		},
+		"some-hardware-not-eligible": {
+			req:          &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "row=w3"},
+			expectedCode: codes.FailedPrecondition,
+		},
+		"dry-run-some-hardware-not-eligible": {
+			req: &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "row=w3", DryRun: true},
+			expectedTargets: map[string]string{
+				"e": `{"device_1": "08:00:27:00:00:05"}`,
+				"f": `{"device_1": "08:00:27:00:00:06"}`,
+				"g": "",
+			},
+			expectedErrors: []string{"g"},
+		},
+		"deprovision": {
+			req:      &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "row=w3", DryRun: true},
+			template: deprovisionTemplateData,
+			expectedTargets: map[string]string{
+				"e": `{"device_1": "08:00:27:00:00:05"}`,
+				"f": "",
+				"g": "",
+			},
+			expectedErrors: []string{"f", "g"},
+		},
+		"failed-creating-workflows": {
+			req:          &workflow.CreateWorkflowsRequest{Template: templateID, LabelSelector: "rack=r1"},
+			createErr:    errors.New("failed to create workflows"),
+			expectedCode: codes.Unknown,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTestTimeout)
+	defer cancel()
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var created []db.Workflow
+			data := templateData
+			if tc.template != "" {
+				data = tc.template
+			}
+			s := testServer(t, &mock.DB{
+				GetTemplateFunc: func(ctx context.Context, fields map[string]string, deleted bool) (*tb.WorkflowTemplate, error) {
+					return &tb.WorkflowTemplate{Data: data}, nil
+				},
+				ListHardwareFunc: func(ctx context.Context, labels map[string]string, opts db.ListOptions, fn func([]byte) error) error {
+					for _, j := range stored {
+						if err := fn([]byte(j)); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+				CreateWorkflowsFunc: func(ctx context.Context, wfs []db.Workflow, data []string) error {
+					assert.Len(t, data, len(wfs))
+					created = wfs
+					return tc.createErr
+				},
+			})
+			res, err := s.CreateWorkflows(ctx, tc.req)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.Empty(t, res.GetTargets())
+				if tc.createErr == nil {
+					assert.Empty(t, created)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			targets := map[string]string{}
+			errs := []string{}
+			for _, target := range res.Targets {
+				targets[target.HardwareId] = target.Hardware
+				assert.Equal(t, tc.expectedWorkflow, target.WorkflowId != "")
+				if target.Error != "" {
+					errs = append(errs, target.HardwareId)
+				}
+			}
+			assert.Equal(t, tc.expectedTargets, targets)
+			assert.ElementsMatch(t, tc.expectedErrors, errs)
+			if tc.expectedWorkflow {
+				assert.Len(t, created, len(res.Targets))
+				for i, wf := range created {
+					assert.Equal(t, res.Targets[i].WorkflowId, wf.ID)
+					assert.Equal(t, res.Targets[i].Hardware, wf.Hardware)
+				}
+			} else {
+				assert.Empty(t, created)
+			}
+		})
+	}
+}
+
 func TestGetWorkflow(t *testing.T) {
 	type (
 		args struct {
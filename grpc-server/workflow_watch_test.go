@@ -0,0 +1,176 @@
+package grpcserver
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/db/mock"
+	pb "github.com/tinkerbell/tink/protos/workflow"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workflowEventsServer collects the workflow events
+type workflowEventsServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*pb.WorkflowActionStatus
+}
+
+func (w *workflowEventsServer) Context() context.Context {
+	return w.ctx
+}
+
+func (w *workflowEventsServer) Send(ev *pb.WorkflowActionStatus) error {
+	w.sent = append(w.sent, ev)
+	return nil
+}
+
+func TestWatchWorkflowEvents(t *testing.T) {
+	var (
+		lock   sync.Mutex
+		events []*pb.WorkflowActionStatus
+		wfCtx  = &pb.WorkflowContext{WorkflowId: workflowID, TotalNumberOfActions: 2}
+	)
+	// report records an action status the way ReportActionStatus does: the
+	// state first, the event after it
+	report := func(index int64, action string, state pb.State) {
+		lock.Lock()
+		defer lock.Unlock()
+		wfCtx = &pb.WorkflowContext{
+			WorkflowId:           workflowID,
+			CurrentAction:        action,
+			CurrentActionIndex:   index,
+			CurrentActionState:   state,
+			TotalNumberOfActions: 2,
+		}
+		events = append(events, &pb.WorkflowActionStatus{ActionName: action, ActionStatus: state})
+	}
+	s := testServer(t, &mock.DB{
+		GetWorkflowContextsFunc: func(ctx context.Context, wfID string) (*pb.WorkflowContext, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			return wfCtx, nil
+		},
+		GetWorkflowEventsFromFunc: func(ctx context.Context, wfID string, from int64, fn func(id int64, wfs *pb.WorkflowActionStatus) error) error {
+			lock.Lock()
+			evs := append([]*pb.WorkflowActionStatus{}, events...)
+			lock.Unlock()
+			// the ids of the events start at 1, the first one read is the
+			// one with the from id
+			if from > 0 {
+				if from > int64(len(evs)) {
+					return nil
+				}
+				evs = evs[from-1:]
+			} else {
+				from = 1
+			}
+			for i, ev := range evs {
+				// the server sets the workflow id of the events it sends
+				if err := fn(from+int64(i), &pb.WorkflowActionStatus{ActionName: ev.ActionName, ActionStatus: ev.ActionStatus}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	s.watchInterval = time.Millisecond
+
+	report(0, "disk-wipe", pb.State_STATE_RUNNING)
+	go func() {
+		report(0, "disk-wipe", pb.State_STATE_SUCCESS)
+		report(1, "install", pb.State_STATE_RUNNING)
+		report(1, "install", pb.State_STATE_SUCCESS)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := &workflowEventsServer{ctx: ctx}
+	err := s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{Id: workflowID}, stream)
+	assert.NoError(t, err)
+	assert.NoError(t, ctx.Err())
+	states := []pb.State{}
+	for i, ev := range stream.sent {
+		assert.Equal(t, workflowID, ev.WorkflowId)
+		assert.Equal(t, int64(i+1), ev.EventId)
+		states = append(states, ev.ActionStatus)
+	}
+	assert.Equal(t, []pb.State{pb.State_STATE_RUNNING, pb.State_STATE_SUCCESS, pb.State_STATE_RUNNING, pb.State_STATE_SUCCESS}, states)
+
+	// a resumed stream starts after the last event received and ends right
+	// away for a finished workflow
+	stream = &workflowEventsServer{ctx: ctx}
+	err = s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{Id: workflowID, AfterEventId: 3}, stream)
+	assert.NoError(t, err)
+	if assert.Len(t, stream.sent, 1) {
+		assert.Equal(t, "install", stream.sent[0].ActionName)
+		assert.Equal(t, int64(4), stream.sent[0].EventId)
+	}
+	stream = &workflowEventsServer{ctx: ctx}
+	err = s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{Id: workflowID, AfterEventId: 4}, stream)
+	assert.NoError(t, err)
+	assert.Empty(t, stream.sent)
+
+	// the clients which do not know the event ids skip the events already
+	// received
+	stream = &workflowEventsServer{ctx: ctx}
+	err = s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{Id: workflowID, Skip: 3}, stream)
+	assert.NoError(t, err)
+	if assert.Len(t, stream.sent, 1) {
+		assert.Equal(t, "install", stream.sent[0].ActionName)
+	}
+	stream = &workflowEventsServer{ctx: ctx}
+	err = s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{Id: workflowID, Skip: 4}, stream)
+	assert.NoError(t, err)
+	assert.Empty(t, stream.sent)
+}
+
+func TestWatchWorkflowEventsFinalEventNotRecordedYet(t *testing.T) {
+	calls := 0
+	s := testServer(t, &mock.DB{
+		GetWorkflowContextsFunc: func(ctx context.Context, wfID string) (*pb.WorkflowContext, error) {
+			return &pb.WorkflowContext{WorkflowId: workflowID, CurrentActionState: pb.State_STATE_FAILED, TotalNumberOfActions: 2}, nil
+		},
+		GetWorkflowEventsFromFunc: func(ctx context.Context, wfID string, from int64, fn func(id int64, wfs *pb.WorkflowActionStatus) error) error {
+			calls++
+			// the reads after the first one resume from the event it sent
+			if calls > 1 && from != 1 {
+				t.Errorf("expected the read to resume from the event 1, got %d", from)
+			}
+			if err := fn(1, &pb.WorkflowActionStatus{ActionStatus: pb.State_STATE_RUNNING}); err != nil {
+				return err
+			}
+			// the failure is recorded after the first read
+			if calls > 1 {
+				return fn(2, &pb.WorkflowActionStatus{ActionStatus: pb.State_STATE_FAILED})
+			}
+			return nil
+		},
+	})
+	s.watchInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := &workflowEventsServer{ctx: ctx}
+	err := s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{Id: workflowID}, stream)
+	assert.NoError(t, err)
+	if assert.Len(t, stream.sent, 2) {
+		assert.Equal(t, pb.State_STATE_FAILED, stream.sent[1].ActionStatus)
+	}
+}
+
+func TestWatchWorkflowEventsInvalidRequest(t *testing.T) {
+	s := testServer(t, &mock.DB{})
+	stream := &workflowEventsServer{ctx: context.Background()}
+	err := s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{Id: workflowID, Skip: -1}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = s.WatchWorkflowEvents(&pb.WatchWorkflowEventsRequest{Id: workflowID, AfterEventId: -1}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
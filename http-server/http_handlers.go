@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	tt "text/template"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
@@ -44,6 +45,8 @@ func RegisterHardwareServiceHandlerFromEndpoint(ctx context.Context, mux *runtim
 	client := hardware.NewHardwareServiceClient(conn)
 
 	// hardware push handler | POST /v1/hardware
+	// The stored hardware gets updated when the version in the body is the
+	// stored one, or whatever its version with ?force=true.
 	hardwarePushPattern := runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "hardware"}, "", runtime.AssumeColonVerbOpt(true)))
 	mux.Handle("POST", hardwarePushPattern, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		var hw pkg.HardwareWrapper
@@ -63,7 +66,13 @@ func RegisterHardwareServiceHandlerFromEndpoint(ctx context.Context, mux *runtim
 			return
 		}
 
-		if _, err := client.Push(ctx, &hardware.PushRequest{Data: hw.Hardware}); err != nil {
+		force, perr := strconv.ParseBool(req.URL.Query().Get("force"))
+		if perr != nil && req.URL.Query().Get("force") != "" {
+			writeResponse(w, http.StatusBadRequest, status.Errorf(codes.InvalidArgument, "invalid force: %v", perr).Error())
+			return
+		}
+
+		if _, err := client.Push(ctx, &hardware.PushRequest{Data: hw.Hardware, Force: force}); err != nil {
 			logger.Error(err)
 			writeResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -162,7 +171,7 @@ func RegisterHardwareServiceHandlerFromEndpoint(ctx context.Context, mux *runtim
 	// hardware all handler | GET /v1/hardware
 	hardwareAllPattern := runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "hardware"}, "", runtime.AssumeColonVerbOpt(true)))
 	mux.Handle("GET", hardwareAllPattern, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		alls, err := client.All(context.Background(), &hardware.Empty{})
+		alls, err := client.All(context.Background(), &hardware.ListRequest{})
 		if err != nil {
 			logger.Error(err)
 			writeResponse(w, http.StatusInternalServerError, err.Error())
@@ -480,7 +489,7 @@ func RegisterWorkflowSvcHandlerFromEndpoint(ctx context.Context, mux *runtime.Se
 	// workflow list handler | GET /v1/workflows
 	workflowListPattern := runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "workflows"}, "", runtime.AssumeColonVerbOpt(true)))
 	mux.Handle("GET", workflowListPattern, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		list, err := client.ListWorkflows(context.Background(), &workflow.Empty{})
+		list, err := client.ListWorkflows(context.Background(), &workflow.ListRequest{})
 		if err != nil {
 			logger.Error(err)
 			writeResponse(w, http.StatusInternalServerError, err.Error())
@@ -31,7 +31,11 @@ type server struct {
 	hardware.UnimplementedHardwareServiceServer
 }
 
+// lastForce is the force of the last push received
+var lastForce bool
+
 func (s *server) Push(ctx context.Context, in *hardware.PushRequest) (*hardware.Empty, error) {
+	lastForce = in.Force
 	hw := in.Data
 	if hw.Id == "" {
 		err := errors.New("id must be set to a UUID, got id: " + hw.Id)
@@ -94,6 +98,30 @@ func TestHardwarePushHandler(t *testing.T) {
 	}
 }
 
+func TestHardwarePushHandlerForce(t *testing.T) {
+	mux := grpcRuntime.NewServeMux()
+	dialOpts := []grpc.DialOption{grpc.WithContextDialer(bufDialer), grpc.WithInsecure()}
+	if err := RegisterHardwareServiceHandlerFromEndpoint(context.Background(), mux, "localhost:42113", dialOpts); err != nil {
+		t.Fatal(err)
+	}
+
+	for query, expected := range map[string]int{"": http.StatusOK, "?force=true": http.StatusOK, "?force=maybe": http.StatusBadRequest} {
+		lastForce = false
+		req, err := http.NewRequest("POST", "/v1/hardware"+query, strings.NewReader(hardwarePushData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+		if resp.Code != expected {
+			t.Errorf("%q: handler returned wrong status code: got %v want %v", query, resp.Code, expected)
+		}
+		if lastForce != (query == "?force=true") {
+			t.Errorf("%q: expected force to be %t", query, query == "?force=true")
+		}
+	}
+}
+
 var handlerTests = map[string]struct {
 	id     string
 	status int
@@ -18,8 +18,6 @@ var (
 	ingestCount    *prometheus.CounterVec
 	ingestErrors   *prometheus.CounterVec
 	ingestDuration *prometheus.GaugeVec
-
-	WatchMissTotal prometheus.Counter
 )
 
 // SetupMetrics sets the defaults for metrics
@@ -110,11 +108,6 @@ func SetupMetrics(facility string, logger log.Logger) {
 	initCounterLabels(ingestCount, labels)
 	initGaugeLabels(ingestDuration, labels)
 	initCounterLabels(ingestErrors, labels)
-
-	WatchMissTotal = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "watch_miss_count_total",
-		Help: "Number of missed updates due to a blocked channel.",
-	})
 }
 
 func initObserverLabels(m prometheus.ObserverVec, l []prometheus.Labels) {
@@ -0,0 +1,5 @@
+package pkg
+
+// ActorMetadataKey is the gRPC metadata clients use to tell the server who
+// is making a request. It ends up in the hardware history.
+const ActorMetadataKey = "tink-actor"
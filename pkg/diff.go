@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Change describes a field changed between two JSON documents. Old and New
+// are JSON encoded and they are empty when the field did not exist.
+type Change struct {
+	Path string          `json:"path"`
+	Old  json.RawMessage `json:"old,omitempty"`
+	New  json.RawMessage `json:"new,omitempty"`
+}
+
+// DiffJSON returns the fields changed between two documents decoded by
+// encoding/json, sorted by path. The path of a field looks like
+// network.interfaces[0].netboot.allow_pxe.
+func DiffJSON(before, after interface{}) []Change {
+	changes := []Change{}
+	diffValues("", before, after, &changes)
+	return changes
+}
+
+func diffValues(path string, before, after interface{}, changes *[]Change) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+	switch o := before.(type) {
+	case map[string]interface{}:
+		if n, ok := after.(map[string]interface{}); ok {
+			keys := map[string]struct{}{}
+			for k := range o {
+				keys[k] = struct{}{}
+			}
+			for k := range n {
+				keys[k] = struct{}{}
+			}
+			sorted := make([]string, 0, len(keys))
+			for k := range keys {
+				sorted = append(sorted, k)
+			}
+			sort.Strings(sorted)
+			for _, k := range sorted {
+				p := k
+				if path != "" {
+					p = path + "." + k
+				}
+				diffValues(p, o[k], n[k], changes)
+			}
+			return
+		}
+	case []interface{}:
+		if n, ok := after.([]interface{}); ok {
+			for i := 0; i < len(o) || i < len(n); i++ {
+				var ov, nv interface{}
+				if i < len(o) {
+					ov = o[i]
+				}
+				if i < len(n) {
+					nv = n[i]
+				}
+				diffValues(fmt.Sprintf("%s[%d]", path, i), ov, nv, changes)
+			}
+			return
+		}
+	}
+
+	c := Change{Path: path}
+	if before != nil {
+		c.Old, _ = json.Marshal(before)
+	}
+	if after != nil {
+		c.New, _ = json.Marshal(after)
+	}
+	*changes = append(*changes, c)
+}
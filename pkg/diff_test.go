@@ -0,0 +1,28 @@
+package pkg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffJSON(t *testing.T) {
+	decode := func(s string) interface{} {
+		var v interface{}
+		assert.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+
+	before := decode(`{"id":"a","labels":{"rack":"r1"},"network":{"interfaces":[{"netboot":{"allow_pxe":true}}]}}`)
+	after := decode(`{"id":"a","labels":{"rack":"r2","arch":"arm64"},"network":{"interfaces":[{"netboot":{"allow_pxe":false}},{"dhcp":{"mac":"08:00:27:00:00:02"}}]}}`)
+
+	expected := []Change{
+		{Path: "labels.arch", New: json.RawMessage(`"arm64"`)},
+		{Path: "labels.rack", Old: json.RawMessage(`"r1"`), New: json.RawMessage(`"r2"`)},
+		{Path: "network.interfaces[0].netboot.allow_pxe", Old: json.RawMessage(`true`), New: json.RawMessage(`false`)},
+		{Path: "network.interfaces[1]", New: json.RawMessage(`{"dhcp":{"mac":"08:00:27:00:00:02"}}`)},
+	}
+	assert.Equal(t, expected, DiffJSON(before, after))
+	assert.Empty(t, DiffJSON(before, before))
+}
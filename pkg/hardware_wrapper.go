@@ -50,6 +50,8 @@ func (h HardwareWrapper) MarshalJSON() ([]byte, error) {
 		}
 		tmp["metadata"] = metadata // set hw metadata field to the metadata map
 	}
+	// the typed metadata holds the same data as the metadata map
+	delete(tmp, "typed_metadata")
 	tmpByte, err := json.Marshal(tmp) // marshal hw map into []byte
 	if err != nil {
 		return nil, err
@@ -0,0 +1,95 @@
+package pkg
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tinkerbell/tink/protos/packet"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ParseMetadata decodes the hardware metadata with the schema from
+// packet.proto. Fields unknown to the schema are ignored, the known ones
+// have to hold a value of the right type.
+func ParseMetadata(metadata string) (*packet.Metadata, error) {
+	m := &packet.Metadata{}
+	if metadata == "" {
+		return m, nil
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(metadata), m); err != nil {
+		return nil, errors.Wrap(err, "invalid metadata")
+	}
+	return m, nil
+}
+
+// MarshalMetadata encodes typed metadata as the JSON document stored in the
+// hardware metadata field
+func MarshalMetadata(m *packet.Metadata) (string, error) {
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// MetadataForTemplate returns the metadata as a document to execute a
+// template with. Every field of the schema is present, set to its zero
+// value when the metadata does not have it, so a template can reference it
+// without failing. The fields unknown to the schema are kept as they are.
+func MetadataForTemplate(metadata string) (map[string]interface{}, error) {
+	m, err := ParseMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+	populate(m.ProtoReflect())
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if metadata == "" {
+		return doc, nil
+	}
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(metadata), &raw); err != nil {
+		return nil, errors.Wrap(err, "invalid metadata")
+	}
+	mergeDefaults(raw, doc)
+	return raw, nil
+}
+
+// populate sets every singular message field of m to an empty message, so
+// they get encoded as objects instead of null
+func populate(m protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			continue
+		}
+		populate(m.Mutable(fd).Message())
+	}
+}
+
+// mergeDefaults adds to dst the fields of defaults it does not have, walking
+// the nested objects
+func mergeDefaults(dst, defaults map[string]interface{}) {
+	for k, v := range defaults {
+		cur, ok := dst[k]
+		if !ok || cur == nil {
+			dst[k] = v
+			continue
+		}
+		curMap, ok := cur.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if defMap, ok := v.(map[string]interface{}); ok {
+			mergeDefaults(curMap, defMap)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinkerbell/tink/protos/hardware"
+)
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Metadata      string
+		ExpectedError bool
+	}{
+		{Name: "empty", Metadata: ""},
+		{Name: "known-fields", Metadata: `{"state":"provisioning","bonding_mode":5,"facility":{"facility_code":"onprem"},"instance":{"storage":{"disks":[{"device":"/dev/sda","wipe_table":true}]}}}`},
+		{Name: "unknown-fields", Metadata: `{"instance":{"operating_system_version":{"distro":"ubuntu"}},"custom_field":1}`},
+		{Name: "wrong-type", Metadata: `{"bonding_mode":"active-backup"}`, ExpectedError: true},
+		{Name: "wrong-nested-type", Metadata: `{"instance":{"storage":{"disks":{"device":"/dev/sda"}}}}`, ExpectedError: true},
+		{Name: "not-json", Metadata: `not json`, ExpectedError: true},
+	}
+
+	for _, s := range tests {
+		t.Run(s.Name, func(t *testing.T) {
+			_, err := ParseMetadata(s.Metadata)
+			if s.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseMetadataTestdata(t *testing.T) {
+	b, err := ioutil.ReadFile("../db/testdata/hardware.json")
+	assert.NoError(t, err)
+	hw := HardwareWrapper{Hardware: &hardware.Hardware{}}
+	assert.NoError(t, hw.UnmarshalJSON(b))
+
+	m, err := ParseMetadata(hw.Metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, "provisioning", m.GetState())
+	assert.Equal(t, "/dev/sda", m.GetInstance().GetStorage().GetDisks()[0].GetDevice())
+}
+
+func TestMetadataForTemplate(t *testing.T) {
+	doc, err := MetadataForTemplate(`{"facility":{"facility_code":"onprem"},"custom_field":"x"}`)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "x", doc["custom_field"])
+	facility := doc["facility"].(map[string]interface{})
+	assert.Equal(t, "onprem", facility["facility_code"])
+	assert.Equal(t, "", facility["plan_slug"])
+	instance := doc["instance"].(map[string]interface{})
+	assert.Equal(t, "", instance["hostname"])
+	assert.Equal(t, map[string]interface{}{"slug": "", "distro": "", "version": "", "image_tag": "", "os_slug": ""}, instance["operating_system"])
+
+	_, err = MetadataForTemplate(`{"state":1}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,12 @@
+package pkg
+
+// NamespaceMetadataKey is the gRPC metadata clients use to restrict a
+// request to the resources of a namespace. A request without it is
+// restricted to the default namespace.
+const NamespaceMetadataKey = "tink-namespace"
+
+// AllNamespaces is the namespace of the requests seeing the resources of all
+// the namespaces, when the server allows it. The events, the webhooks and
+// the administration are only served to them and to the requests of the
+// default namespace.
+const AllNamespaces = "*"
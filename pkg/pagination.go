@@ -0,0 +1,20 @@
+package pkg
+
+import (
+	"google.golang.org/grpc"
+)
+
+// NextPageTokenMetadataKey is the gRPC trailer of the paged list calls
+// holding the page_token of the following page. It is not set on the last
+// page.
+const NextPageTokenMetadataKey = "next-page-token"
+
+// NextPageToken returns the page_token of the page following the one stream
+// received, empty on the last page. The trailer is only known once stream
+// returned io.EOF.
+func NextPageToken(stream grpc.ClientStream) string {
+	if v := stream.Trailer().Get(NextPageTokenMetadataKey); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
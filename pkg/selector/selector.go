@@ -0,0 +1,203 @@
+// Package selector parses and evaluates the label selectors and the JSON path
+// filters used to select a subset of the hardware.
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Operator is the comparison a Requirement performs on a label
+type Operator string
+
+const (
+	Equals       Operator = "="
+	NotEquals    Operator = "!="
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single condition on a label
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Value    string
+}
+
+// Matches tells if the labels satisfy the requirement
+func (r Requirement) Matches(labels map[string]string) bool {
+	v, ok := labels[r.Key]
+	switch r.Operator {
+	case Equals:
+		return ok && v == r.Value
+	case NotEquals:
+		return !ok || v != r.Value
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+// Selector is a set of requirements, all of them have to match
+type Selector []Requirement
+
+// Parse parses a comma separated list of requirements like
+// "arch=arm64,rack!=r1,gpu,!decommissioned". An empty string returns a
+// selector matching everything.
+func Parse(s string) (Selector, error) {
+	sel := Selector{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var r Requirement
+		switch {
+		case strings.Contains(part, "!="):
+			kv := strings.SplitN(part, "!=", 2)
+			r = Requirement{Key: kv[0], Operator: NotEquals, Value: kv[1]}
+		case strings.Contains(part, "=="):
+			kv := strings.SplitN(part, "==", 2)
+			r = Requirement{Key: kv[0], Operator: Equals, Value: kv[1]}
+		case strings.Contains(part, "="):
+			kv := strings.SplitN(part, "=", 2)
+			r = Requirement{Key: kv[0], Operator: Equals, Value: kv[1]}
+		case strings.HasPrefix(part, "!"):
+			r = Requirement{Key: part[1:], Operator: DoesNotExist}
+		default:
+			r = Requirement{Key: part, Operator: Exists}
+		}
+		r.Key = strings.TrimSpace(r.Key)
+		r.Value = strings.TrimSpace(r.Value)
+		if r.Key == "" {
+			return nil, fmt.Errorf("invalid requirement %q, the label key is empty", part)
+		}
+		sel = append(sel, r)
+	}
+	return sel, nil
+}
+
+// Matches tells if the labels satisfy all the requirements of the selector
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equalities returns the labels the selector requires to be set to a
+// specific value. They can be used to pre-filter the hardware with a
+// containment query that hits the index.
+func (s Selector) Equalities() map[string]string {
+	labels := map[string]string{}
+	for _, r := range s {
+		if r.Operator == Equals {
+			labels[r.Key] = r.Value
+		}
+	}
+	return labels
+}
+
+// OnlyEqualities tells if the selector is made only of equality
+// requirements, so it is fully described by Equalities
+func (s Selector) OnlyEqualities() bool {
+	for _, r := range s {
+		if r.Operator != Equals {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter matches the value found at a path in a JSON document
+type Filter struct {
+	Path  []string
+	Value string
+}
+
+// ParseFilter parses a filter in the form path=value, where path is dot
+// separated
+func ParseFilter(s string) (Filter, error) {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+		return Filter{}, fmt.Errorf("invalid filter %q, expected path=value", s)
+	}
+	path := strings.Split(strings.TrimSpace(kv[0]), ".")
+	for _, p := range path {
+		if p == "" {
+			return Filter{}, fmt.Errorf("invalid filter %q, the path has an empty element", s)
+		}
+	}
+	return Filter{Path: path, Value: kv[1]}, nil
+}
+
+// ParseFilters parses every filter
+func ParseFilters(filters []string) ([]Filter, error) {
+	parsed := make([]Filter, 0, len(filters))
+	for _, s := range filters {
+		f, err := ParseFilter(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, f)
+	}
+	return parsed, nil
+}
+
+// Matches tells if one of the values found at the filter path in doc is
+// equal to the filter value. Arrays along the path are walked element by
+// element. doc is what encoding/json unmarshals into an interface{}.
+func (f Filter) Matches(doc interface{}) bool {
+	return match(doc, f.Path, f.Value)
+}
+
+// MatchesJSON is like Matches but it takes the encoded document
+func (f Filter) MatchesJSON(b []byte) (bool, error) {
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return false, errors.Wrap(err, "invalid JSON document")
+	}
+	return f.Matches(doc), nil
+}
+
+func match(doc interface{}, path []string, value string) bool {
+	if list, ok := doc.([]interface{}); ok {
+		for _, item := range list {
+			if match(item, path, value) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		switch v := doc.(type) {
+		case string:
+			return v == value
+		case bool:
+			return strconv.FormatBool(v) == value
+		case float64:
+			n, err := strconv.ParseFloat(value, 64)
+			return err == nil && n == v
+		case nil:
+			return value == "null"
+		}
+		return false
+	}
+	m, ok := doc.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	next, ok := m[path[0]]
+	if !ok {
+		return false
+	}
+	return match(next, path[1:], value)
+}
@@ -0,0 +1,126 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Input         string
+		Expected      Selector
+		ExpectedError bool
+	}{
+		{
+			Name:     "empty",
+			Input:    "",
+			Expected: Selector{},
+		},
+		{
+			Name:  "all-operators",
+			Input: "arch=arm64, rack==r1,zone!=west,gpu,!decommissioned",
+			Expected: Selector{
+				{Key: "arch", Operator: Equals, Value: "arm64"},
+				{Key: "rack", Operator: Equals, Value: "r1"},
+				{Key: "zone", Operator: NotEquals, Value: "west"},
+				{Key: "gpu", Operator: Exists},
+				{Key: "decommissioned", Operator: DoesNotExist},
+			},
+		},
+		{
+			Name:          "empty-key",
+			Input:         "arch=arm64,=r1",
+			ExpectedError: true,
+		},
+		{
+			Name:          "empty-negated-key",
+			Input:         "!",
+			ExpectedError: true,
+		},
+	}
+
+	for _, s := range tests {
+		t.Run(s.Name, func(t *testing.T) {
+			sel, err := Parse(s.Input)
+			if s.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, s.Expected, sel)
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"arch": "arm64", "rack": "r1"}
+	tests := []struct {
+		Selector string
+		Expected bool
+	}{
+		{Selector: "", Expected: true},
+		{Selector: "arch=arm64", Expected: true},
+		{Selector: "arch=arm64,rack=r2", Expected: false},
+		{Selector: "rack!=r2", Expected: true},
+		{Selector: "zone!=west", Expected: true},
+		{Selector: "rack", Expected: true},
+		{Selector: "zone", Expected: false},
+		{Selector: "!zone", Expected: true},
+		{Selector: "!rack", Expected: false},
+	}
+
+	for _, s := range tests {
+		t.Run(s.Selector, func(t *testing.T) {
+			sel, err := Parse(s.Selector)
+			assert.NoError(t, err)
+			assert.Equal(t, s.Expected, sel.Matches(labels))
+		})
+	}
+}
+
+func TestEqualities(t *testing.T) {
+	sel, err := Parse("arch=arm64,rack!=r1,gpu")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"arch": "arm64"}, sel.Equalities())
+}
+
+func TestFilterMatches(t *testing.T) {
+	doc := []byte(`{
+		"id": "0eba0bf8-3772-4b4a-ab9f-6ebe93b90a95",
+		"metadata": {"facility": {"facility_code": "onprem"}, "instance": {"ip_addresses": [{"public": false}, {"public": true}]}},
+		"network": {"interfaces": [{"dhcp": {"arch": "x86_64", "lease_time": 86400}}, {"dhcp": {"arch": "aarch64"}}]}
+	}`)
+	tests := []struct {
+		Filter        string
+		Expected      bool
+		ExpectedError bool
+	}{
+		{Filter: "metadata.facility.facility_code=onprem", Expected: true},
+		{Filter: "metadata.facility.facility_code=sjc1", Expected: false},
+		{Filter: "network.interfaces.dhcp.arch=aarch64", Expected: true},
+		{Filter: "network.interfaces.dhcp.arch=arm64", Expected: false},
+		{Filter: "network.interfaces.dhcp.lease_time=86400", Expected: true},
+		{Filter: "metadata.instance.ip_addresses.public=true", Expected: true},
+		{Filter: "metadata.facility=onprem", Expected: false},
+		{Filter: "metadata.missing.path=x", Expected: false},
+		{Filter: "metadata", ExpectedError: true},
+		{Filter: "=onprem", ExpectedError: true},
+		{Filter: "metadata..facility_code=onprem", ExpectedError: true},
+	}
+
+	for _, s := range tests {
+		t.Run(s.Filter, func(t *testing.T) {
+			f, err := ParseFilter(s.Filter)
+			if s.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			ok, err := f.MatchesJSON(doc)
+			assert.NoError(t, err)
+			assert.Equal(t, s.Expected, ok)
+		})
+	}
+}
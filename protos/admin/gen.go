@@ -0,0 +1,3 @@
+package admin
+
+//go:generate moq -out mock.go . AdminServiceClient AdminService_ExportClient AdminService_ImportClient
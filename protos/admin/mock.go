@@ -0,0 +1,812 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package admin
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Ensure, that AdminServiceClientMock does implement AdminServiceClient.
+// If this is not the case, regenerate this file with moq.
+var _ AdminServiceClient = &AdminServiceClientMock{}
+
+// AdminServiceClientMock is a mock implementation of AdminServiceClient.
+//
+//     func TestSomethingThatUsesAdminServiceClient(t *testing.T) {
+//
+//         // make and configure a mocked AdminServiceClient
+//         mockedAdminServiceClient := &AdminServiceClientMock{
+//             ExportFunc: func(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (AdminService_ExportClient, error) {
+// 	               panic("mock out the Export method")
+//             },
+//             GarbageCollectFunc: func(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error) {
+// 	               panic("mock out the GarbageCollect method")
+//             },
+//             ImportFunc: func(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportClient, error) {
+// 	               panic("mock out the Import method")
+//             },
+//         }
+//
+//         // use mockedAdminServiceClient in code that requires AdminServiceClient
+//         // and then make assertions.
+//
+//     }
+type AdminServiceClientMock struct {
+	// ExportFunc mocks the Export method.
+	ExportFunc func(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (AdminService_ExportClient, error)
+
+	// GarbageCollectFunc mocks the GarbageCollect method.
+	GarbageCollectFunc func(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error)
+
+	// ImportFunc mocks the Import method.
+	ImportFunc func(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportClient, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Export holds details about calls to the Export method.
+		Export []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *ExportRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// GarbageCollect holds details about calls to the GarbageCollect method.
+		GarbageCollect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *GarbageCollectRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Import holds details about calls to the Import method.
+		Import []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockExport         sync.RWMutex
+	lockGarbageCollect sync.RWMutex
+	lockImport         sync.RWMutex
+}
+
+// Export calls ExportFunc.
+func (mock *AdminServiceClientMock) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (AdminService_ExportClient, error) {
+	if mock.ExportFunc == nil {
+		panic("AdminServiceClientMock.ExportFunc: method is nil but AdminServiceClient.Export was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *ExportRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockExport.Lock()
+	mock.calls.Export = append(mock.calls.Export, callInfo)
+	mock.lockExport.Unlock()
+	return mock.ExportFunc(ctx, in, opts...)
+}
+
+// ExportCalls gets all the calls that were made to Export.
+// Check the length with:
+//     len(mockedAdminServiceClient.ExportCalls())
+func (mock *AdminServiceClientMock) ExportCalls() []struct {
+	Ctx  context.Context
+	In   *ExportRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *ExportRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockExport.RLock()
+	calls = mock.calls.Export
+	mock.lockExport.RUnlock()
+	return calls
+}
+
+// GarbageCollect calls GarbageCollectFunc.
+func (mock *AdminServiceClientMock) GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error) {
+	if mock.GarbageCollectFunc == nil {
+		panic("AdminServiceClientMock.GarbageCollectFunc: method is nil but AdminServiceClient.GarbageCollect was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *GarbageCollectRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockGarbageCollect.Lock()
+	mock.calls.GarbageCollect = append(mock.calls.GarbageCollect, callInfo)
+	mock.lockGarbageCollect.Unlock()
+	return mock.GarbageCollectFunc(ctx, in, opts...)
+}
+
+// GarbageCollectCalls gets all the calls that were made to GarbageCollect.
+// Check the length with:
+//     len(mockedAdminServiceClient.GarbageCollectCalls())
+func (mock *AdminServiceClientMock) GarbageCollectCalls() []struct {
+	Ctx  context.Context
+	In   *GarbageCollectRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *GarbageCollectRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockGarbageCollect.RLock()
+	calls = mock.calls.GarbageCollect
+	mock.lockGarbageCollect.RUnlock()
+	return calls
+}
+
+// Import calls ImportFunc.
+func (mock *AdminServiceClientMock) Import(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportClient, error) {
+	if mock.ImportFunc == nil {
+		panic("AdminServiceClientMock.ImportFunc: method is nil but AdminServiceClient.Import was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		Opts: opts,
+	}
+	mock.lockImport.Lock()
+	mock.calls.Import = append(mock.calls.Import, callInfo)
+	mock.lockImport.Unlock()
+	return mock.ImportFunc(ctx, opts...)
+}
+
+// ImportCalls gets all the calls that were made to Import.
+// Check the length with:
+//     len(mockedAdminServiceClient.ImportCalls())
+func (mock *AdminServiceClientMock) ImportCalls() []struct {
+	Ctx  context.Context
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Opts []grpc.CallOption
+	}
+	mock.lockImport.RLock()
+	calls = mock.calls.Import
+	mock.lockImport.RUnlock()
+	return calls
+}
+
+// Ensure, that AdminService_ExportClientMock does implement AdminService_ExportClient.
+// If this is not the case, regenerate this file with moq.
+var _ AdminService_ExportClient = &AdminService_ExportClientMock{}
+
+// AdminService_ExportClientMock is a mock implementation of AdminService_ExportClient.
+//
+//     func TestSomethingThatUsesAdminService_ExportClient(t *testing.T) {
+//
+//         // make and configure a mocked AdminService_ExportClient
+//         mockedAdminService_ExportClient := &AdminService_ExportClientMock{
+//             CloseSendFunc: func() error {
+// 	               panic("mock out the CloseSend method")
+//             },
+//             ContextFunc: func() context.Context {
+// 	               panic("mock out the Context method")
+//             },
+//             HeaderFunc: func() (metadata.MD, error) {
+// 	               panic("mock out the Header method")
+//             },
+//             RecvFunc: func() (*ArchiveChunk, error) {
+// 	               panic("mock out the Recv method")
+//             },
+//             RecvMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the RecvMsg method")
+//             },
+//             SendMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the SendMsg method")
+//             },
+//             TrailerFunc: func() metadata.MD {
+// 	               panic("mock out the Trailer method")
+//             },
+//         }
+//
+//         // use mockedAdminService_ExportClient in code that requires AdminService_ExportClient
+//         // and then make assertions.
+//
+//     }
+type AdminService_ExportClientMock struct {
+	// CloseSendFunc mocks the CloseSend method.
+	CloseSendFunc func() error
+
+	// ContextFunc mocks the Context method.
+	ContextFunc func() context.Context
+
+	// HeaderFunc mocks the Header method.
+	HeaderFunc func() (metadata.MD, error)
+
+	// RecvFunc mocks the Recv method.
+	RecvFunc func() (*ArchiveChunk, error)
+
+	// RecvMsgFunc mocks the RecvMsg method.
+	RecvMsgFunc func(m interface{}) error
+
+	// SendMsgFunc mocks the SendMsg method.
+	SendMsgFunc func(m interface{}) error
+
+	// TrailerFunc mocks the Trailer method.
+	TrailerFunc func() metadata.MD
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CloseSend holds details about calls to the CloseSend method.
+		CloseSend []struct {
+		}
+		// Context holds details about calls to the Context method.
+		Context []struct {
+		}
+		// Header holds details about calls to the Header method.
+		Header []struct {
+		}
+		// Recv holds details about calls to the Recv method.
+		Recv []struct {
+		}
+		// RecvMsg holds details about calls to the RecvMsg method.
+		RecvMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// SendMsg holds details about calls to the SendMsg method.
+		SendMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// Trailer holds details about calls to the Trailer method.
+		Trailer []struct {
+		}
+	}
+	lockCloseSend sync.RWMutex
+	lockContext   sync.RWMutex
+	lockHeader    sync.RWMutex
+	lockRecv      sync.RWMutex
+	lockRecvMsg   sync.RWMutex
+	lockSendMsg   sync.RWMutex
+	lockTrailer   sync.RWMutex
+}
+
+// CloseSend calls CloseSendFunc.
+func (mock *AdminService_ExportClientMock) CloseSend() error {
+	if mock.CloseSendFunc == nil {
+		panic("AdminService_ExportClientMock.CloseSendFunc: method is nil but AdminService_ExportClient.CloseSend was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCloseSend.Lock()
+	mock.calls.CloseSend = append(mock.calls.CloseSend, callInfo)
+	mock.lockCloseSend.Unlock()
+	return mock.CloseSendFunc()
+}
+
+// CloseSendCalls gets all the calls that were made to CloseSend.
+// Check the length with:
+//     len(mockedAdminService_ExportClient.CloseSendCalls())
+func (mock *AdminService_ExportClientMock) CloseSendCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCloseSend.RLock()
+	calls = mock.calls.CloseSend
+	mock.lockCloseSend.RUnlock()
+	return calls
+}
+
+// Context calls ContextFunc.
+func (mock *AdminService_ExportClientMock) Context() context.Context {
+	if mock.ContextFunc == nil {
+		panic("AdminService_ExportClientMock.ContextFunc: method is nil but AdminService_ExportClient.Context was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockContext.Lock()
+	mock.calls.Context = append(mock.calls.Context, callInfo)
+	mock.lockContext.Unlock()
+	return mock.ContextFunc()
+}
+
+// ContextCalls gets all the calls that were made to Context.
+// Check the length with:
+//     len(mockedAdminService_ExportClient.ContextCalls())
+func (mock *AdminService_ExportClientMock) ContextCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockContext.RLock()
+	calls = mock.calls.Context
+	mock.lockContext.RUnlock()
+	return calls
+}
+
+// Header calls HeaderFunc.
+func (mock *AdminService_ExportClientMock) Header() (metadata.MD, error) {
+	if mock.HeaderFunc == nil {
+		panic("AdminService_ExportClientMock.HeaderFunc: method is nil but AdminService_ExportClient.Header was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockHeader.Lock()
+	mock.calls.Header = append(mock.calls.Header, callInfo)
+	mock.lockHeader.Unlock()
+	return mock.HeaderFunc()
+}
+
+// HeaderCalls gets all the calls that were made to Header.
+// Check the length with:
+//     len(mockedAdminService_ExportClient.HeaderCalls())
+func (mock *AdminService_ExportClientMock) HeaderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHeader.RLock()
+	calls = mock.calls.Header
+	mock.lockHeader.RUnlock()
+	return calls
+}
+
+// Recv calls RecvFunc.
+func (mock *AdminService_ExportClientMock) Recv() (*ArchiveChunk, error) {
+	if mock.RecvFunc == nil {
+		panic("AdminService_ExportClientMock.RecvFunc: method is nil but AdminService_ExportClient.Recv was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRecv.Lock()
+	mock.calls.Recv = append(mock.calls.Recv, callInfo)
+	mock.lockRecv.Unlock()
+	return mock.RecvFunc()
+}
+
+// RecvCalls gets all the calls that were made to Recv.
+// Check the length with:
+//     len(mockedAdminService_ExportClient.RecvCalls())
+func (mock *AdminService_ExportClientMock) RecvCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRecv.RLock()
+	calls = mock.calls.Recv
+	mock.lockRecv.RUnlock()
+	return calls
+}
+
+// RecvMsg calls RecvMsgFunc.
+func (mock *AdminService_ExportClientMock) RecvMsg(m interface{}) error {
+	if mock.RecvMsgFunc == nil {
+		panic("AdminService_ExportClientMock.RecvMsgFunc: method is nil but AdminService_ExportClient.RecvMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockRecvMsg.Lock()
+	mock.calls.RecvMsg = append(mock.calls.RecvMsg, callInfo)
+	mock.lockRecvMsg.Unlock()
+	return mock.RecvMsgFunc(m)
+}
+
+// RecvMsgCalls gets all the calls that were made to RecvMsg.
+// Check the length with:
+//     len(mockedAdminService_ExportClient.RecvMsgCalls())
+func (mock *AdminService_ExportClientMock) RecvMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockRecvMsg.RLock()
+	calls = mock.calls.RecvMsg
+	mock.lockRecvMsg.RUnlock()
+	return calls
+}
+
+// SendMsg calls SendMsgFunc.
+func (mock *AdminService_ExportClientMock) SendMsg(m interface{}) error {
+	if mock.SendMsgFunc == nil {
+		panic("AdminService_ExportClientMock.SendMsgFunc: method is nil but AdminService_ExportClient.SendMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockSendMsg.Lock()
+	mock.calls.SendMsg = append(mock.calls.SendMsg, callInfo)
+	mock.lockSendMsg.Unlock()
+	return mock.SendMsgFunc(m)
+}
+
+// SendMsgCalls gets all the calls that were made to SendMsg.
+// Check the length with:
+//     len(mockedAdminService_ExportClient.SendMsgCalls())
+func (mock *AdminService_ExportClientMock) SendMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockSendMsg.RLock()
+	calls = mock.calls.SendMsg
+	mock.lockSendMsg.RUnlock()
+	return calls
+}
+
+// Trailer calls TrailerFunc.
+func (mock *AdminService_ExportClientMock) Trailer() metadata.MD {
+	if mock.TrailerFunc == nil {
+		panic("AdminService_ExportClientMock.TrailerFunc: method is nil but AdminService_ExportClient.Trailer was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTrailer.Lock()
+	mock.calls.Trailer = append(mock.calls.Trailer, callInfo)
+	mock.lockTrailer.Unlock()
+	return mock.TrailerFunc()
+}
+
+// TrailerCalls gets all the calls that were made to Trailer.
+// Check the length with:
+//     len(mockedAdminService_ExportClient.TrailerCalls())
+func (mock *AdminService_ExportClientMock) TrailerCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTrailer.RLock()
+	calls = mock.calls.Trailer
+	mock.lockTrailer.RUnlock()
+	return calls
+}
+
+// Ensure, that AdminService_ImportClientMock does implement AdminService_ImportClient.
+// If this is not the case, regenerate this file with moq.
+var _ AdminService_ImportClient = &AdminService_ImportClientMock{}
+
+// AdminService_ImportClientMock is a mock implementation of AdminService_ImportClient.
+//
+//     func TestSomethingThatUsesAdminService_ImportClient(t *testing.T) {
+//
+//         // make and configure a mocked AdminService_ImportClient
+//         mockedAdminService_ImportClient := &AdminService_ImportClientMock{
+//             CloseAndRecvFunc: func() (*ImportResponse, error) {
+// 	               panic("mock out the CloseAndRecv method")
+//             },
+//             CloseSendFunc: func() error {
+// 	               panic("mock out the CloseSend method")
+//             },
+//             ContextFunc: func() context.Context {
+// 	               panic("mock out the Context method")
+//             },
+//             HeaderFunc: func() (metadata.MD, error) {
+// 	               panic("mock out the Header method")
+//             },
+//             RecvMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the RecvMsg method")
+//             },
+//             SendFunc: func(in1 *ArchiveChunk) error {
+// 	               panic("mock out the Send method")
+//             },
+//             SendMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the SendMsg method")
+//             },
+//             TrailerFunc: func() metadata.MD {
+// 	               panic("mock out the Trailer method")
+//             },
+//         }
+//
+//         // use mockedAdminService_ImportClient in code that requires AdminService_ImportClient
+//         // and then make assertions.
+//
+//     }
+type AdminService_ImportClientMock struct {
+	// CloseAndRecvFunc mocks the CloseAndRecv method.
+	CloseAndRecvFunc func() (*ImportResponse, error)
+
+	// CloseSendFunc mocks the CloseSend method.
+	CloseSendFunc func() error
+
+	// ContextFunc mocks the Context method.
+	ContextFunc func() context.Context
+
+	// HeaderFunc mocks the Header method.
+	HeaderFunc func() (metadata.MD, error)
+
+	// RecvMsgFunc mocks the RecvMsg method.
+	RecvMsgFunc func(m interface{}) error
+
+	// SendFunc mocks the Send method.
+	SendFunc func(in1 *ArchiveChunk) error
+
+	// SendMsgFunc mocks the SendMsg method.
+	SendMsgFunc func(m interface{}) error
+
+	// TrailerFunc mocks the Trailer method.
+	TrailerFunc func() metadata.MD
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CloseAndRecv holds details about calls to the CloseAndRecv method.
+		CloseAndRecv []struct {
+		}
+		// CloseSend holds details about calls to the CloseSend method.
+		CloseSend []struct {
+		}
+		// Context holds details about calls to the Context method.
+		Context []struct {
+		}
+		// Header holds details about calls to the Header method.
+		Header []struct {
+		}
+		// RecvMsg holds details about calls to the RecvMsg method.
+		RecvMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// In1 is the in1 argument value.
+			In1 *ArchiveChunk
+		}
+		// SendMsg holds details about calls to the SendMsg method.
+		SendMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// Trailer holds details about calls to the Trailer method.
+		Trailer []struct {
+		}
+	}
+	lockCloseAndRecv sync.RWMutex
+	lockCloseSend    sync.RWMutex
+	lockContext      sync.RWMutex
+	lockHeader       sync.RWMutex
+	lockRecvMsg      sync.RWMutex
+	lockSend         sync.RWMutex
+	lockSendMsg      sync.RWMutex
+	lockTrailer      sync.RWMutex
+}
+
+// CloseAndRecv calls CloseAndRecvFunc.
+func (mock *AdminService_ImportClientMock) CloseAndRecv() (*ImportResponse, error) {
+	if mock.CloseAndRecvFunc == nil {
+		panic("AdminService_ImportClientMock.CloseAndRecvFunc: method is nil but AdminService_ImportClient.CloseAndRecv was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCloseAndRecv.Lock()
+	mock.calls.CloseAndRecv = append(mock.calls.CloseAndRecv, callInfo)
+	mock.lockCloseAndRecv.Unlock()
+	return mock.CloseAndRecvFunc()
+}
+
+// CloseAndRecvCalls gets all the calls that were made to CloseAndRecv.
+// Check the length with:
+//     len(mockedAdminService_ImportClient.CloseAndRecvCalls())
+func (mock *AdminService_ImportClientMock) CloseAndRecvCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCloseAndRecv.RLock()
+	calls = mock.calls.CloseAndRecv
+	mock.lockCloseAndRecv.RUnlock()
+	return calls
+}
+
+// CloseSend calls CloseSendFunc.
+func (mock *AdminService_ImportClientMock) CloseSend() error {
+	if mock.CloseSendFunc == nil {
+		panic("AdminService_ImportClientMock.CloseSendFunc: method is nil but AdminService_ImportClient.CloseSend was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCloseSend.Lock()
+	mock.calls.CloseSend = append(mock.calls.CloseSend, callInfo)
+	mock.lockCloseSend.Unlock()
+	return mock.CloseSendFunc()
+}
+
+// CloseSendCalls gets all the calls that were made to CloseSend.
+// Check the length with:
+//     len(mockedAdminService_ImportClient.CloseSendCalls())
+func (mock *AdminService_ImportClientMock) CloseSendCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCloseSend.RLock()
+	calls = mock.calls.CloseSend
+	mock.lockCloseSend.RUnlock()
+	return calls
+}
+
+// Context calls ContextFunc.
+func (mock *AdminService_ImportClientMock) Context() context.Context {
+	if mock.ContextFunc == nil {
+		panic("AdminService_ImportClientMock.ContextFunc: method is nil but AdminService_ImportClient.Context was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockContext.Lock()
+	mock.calls.Context = append(mock.calls.Context, callInfo)
+	mock.lockContext.Unlock()
+	return mock.ContextFunc()
+}
+
+// ContextCalls gets all the calls that were made to Context.
+// Check the length with:
+//     len(mockedAdminService_ImportClient.ContextCalls())
+func (mock *AdminService_ImportClientMock) ContextCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockContext.RLock()
+	calls = mock.calls.Context
+	mock.lockContext.RUnlock()
+	return calls
+}
+
+// Header calls HeaderFunc.
+func (mock *AdminService_ImportClientMock) Header() (metadata.MD, error) {
+	if mock.HeaderFunc == nil {
+		panic("AdminService_ImportClientMock.HeaderFunc: method is nil but AdminService_ImportClient.Header was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockHeader.Lock()
+	mock.calls.Header = append(mock.calls.Header, callInfo)
+	mock.lockHeader.Unlock()
+	return mock.HeaderFunc()
+}
+
+// HeaderCalls gets all the calls that were made to Header.
+// Check the length with:
+//     len(mockedAdminService_ImportClient.HeaderCalls())
+func (mock *AdminService_ImportClientMock) HeaderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHeader.RLock()
+	calls = mock.calls.Header
+	mock.lockHeader.RUnlock()
+	return calls
+}
+
+// RecvMsg calls RecvMsgFunc.
+func (mock *AdminService_ImportClientMock) RecvMsg(m interface{}) error {
+	if mock.RecvMsgFunc == nil {
+		panic("AdminService_ImportClientMock.RecvMsgFunc: method is nil but AdminService_ImportClient.RecvMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockRecvMsg.Lock()
+	mock.calls.RecvMsg = append(mock.calls.RecvMsg, callInfo)
+	mock.lockRecvMsg.Unlock()
+	return mock.RecvMsgFunc(m)
+}
+
+// RecvMsgCalls gets all the calls that were made to RecvMsg.
+// Check the length with:
+//     len(mockedAdminService_ImportClient.RecvMsgCalls())
+func (mock *AdminService_ImportClientMock) RecvMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockRecvMsg.RLock()
+	calls = mock.calls.RecvMsg
+	mock.lockRecvMsg.RUnlock()
+	return calls
+}
+
+// Send calls SendFunc.
+func (mock *AdminService_ImportClientMock) Send(in1 *ArchiveChunk) error {
+	if mock.SendFunc == nil {
+		panic("AdminService_ImportClientMock.SendFunc: method is nil but AdminService_ImportClient.Send was just called")
+	}
+	callInfo := struct {
+		In1 *ArchiveChunk
+	}{
+		In1: in1,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	return mock.SendFunc(in1)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//     len(mockedAdminService_ImportClient.SendCalls())
+func (mock *AdminService_ImportClientMock) SendCalls() []struct {
+	In1 *ArchiveChunk
+} {
+	var calls []struct {
+		In1 *ArchiveChunk
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
+
+// SendMsg calls SendMsgFunc.
+func (mock *AdminService_ImportClientMock) SendMsg(m interface{}) error {
+	if mock.SendMsgFunc == nil {
+		panic("AdminService_ImportClientMock.SendMsgFunc: method is nil but AdminService_ImportClient.SendMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockSendMsg.Lock()
+	mock.calls.SendMsg = append(mock.calls.SendMsg, callInfo)
+	mock.lockSendMsg.Unlock()
+	return mock.SendMsgFunc(m)
+}
+
+// SendMsgCalls gets all the calls that were made to SendMsg.
+// Check the length with:
+//     len(mockedAdminService_ImportClient.SendMsgCalls())
+func (mock *AdminService_ImportClientMock) SendMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockSendMsg.RLock()
+	calls = mock.calls.SendMsg
+	mock.lockSendMsg.RUnlock()
+	return calls
+}
+
+// Trailer calls TrailerFunc.
+func (mock *AdminService_ImportClientMock) Trailer() metadata.MD {
+	if mock.TrailerFunc == nil {
+		panic("AdminService_ImportClientMock.TrailerFunc: method is nil but AdminService_ImportClient.Trailer was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTrailer.Lock()
+	mock.calls.Trailer = append(mock.calls.Trailer, callInfo)
+	mock.lockTrailer.Unlock()
+	return mock.TrailerFunc()
+}
+
+// TrailerCalls gets all the calls that were made to Trailer.
+// Check the length with:
+//     len(mockedAdminService_ImportClient.TrailerCalls())
+func (mock *AdminService_ImportClientMock) TrailerCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTrailer.RLock()
+	calls = mock.calls.Trailer
+	mock.lockTrailer.RUnlock()
+	return calls
+}
@@ -0,0 +1,3 @@
+package events
+
+//go:generate moq -out mock.go . EventsServiceClient EventsService_EventsClient
@@ -0,0 +1,377 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package events
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Ensure, that EventsServiceClientMock does implement EventsServiceClient.
+// If this is not the case, regenerate this file with moq.
+var _ EventsServiceClient = &EventsServiceClientMock{}
+
+// EventsServiceClientMock is a mock implementation of EventsServiceClient.
+//
+//     func TestSomethingThatUsesEventsServiceClient(t *testing.T) {
+//
+//         // make and configure a mocked EventsServiceClient
+//         mockedEventsServiceClient := &EventsServiceClientMock{
+//             EventsFunc: func(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (EventsService_EventsClient, error) {
+// 	               panic("mock out the Events method")
+//             },
+//         }
+//
+//         // use mockedEventsServiceClient in code that requires EventsServiceClient
+//         // and then make assertions.
+//
+//     }
+type EventsServiceClientMock struct {
+	// EventsFunc mocks the Events method.
+	EventsFunc func(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (EventsService_EventsClient, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Events holds details about calls to the Events method.
+		Events []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *EventsRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockEvents sync.RWMutex
+}
+
+// Events calls EventsFunc.
+func (mock *EventsServiceClientMock) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (EventsService_EventsClient, error) {
+	if mock.EventsFunc == nil {
+		panic("EventsServiceClientMock.EventsFunc: method is nil but EventsServiceClient.Events was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *EventsRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockEvents.Lock()
+	mock.calls.Events = append(mock.calls.Events, callInfo)
+	mock.lockEvents.Unlock()
+	return mock.EventsFunc(ctx, in, opts...)
+}
+
+// EventsCalls gets all the calls that were made to Events.
+// Check the length with:
+//     len(mockedEventsServiceClient.EventsCalls())
+func (mock *EventsServiceClientMock) EventsCalls() []struct {
+	Ctx  context.Context
+	In   *EventsRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *EventsRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockEvents.RLock()
+	calls = mock.calls.Events
+	mock.lockEvents.RUnlock()
+	return calls
+}
+
+// Ensure, that EventsService_EventsClientMock does implement EventsService_EventsClient.
+// If this is not the case, regenerate this file with moq.
+var _ EventsService_EventsClient = &EventsService_EventsClientMock{}
+
+// EventsService_EventsClientMock is a mock implementation of EventsService_EventsClient.
+//
+//     func TestSomethingThatUsesEventsService_EventsClient(t *testing.T) {
+//
+//         // make and configure a mocked EventsService_EventsClient
+//         mockedEventsService_EventsClient := &EventsService_EventsClientMock{
+//             CloseSendFunc: func() error {
+// 	               panic("mock out the CloseSend method")
+//             },
+//             ContextFunc: func() context.Context {
+// 	               panic("mock out the Context method")
+//             },
+//             HeaderFunc: func() (metadata.MD, error) {
+// 	               panic("mock out the Header method")
+//             },
+//             RecvFunc: func() (*Event, error) {
+// 	               panic("mock out the Recv method")
+//             },
+//             RecvMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the RecvMsg method")
+//             },
+//             SendMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the SendMsg method")
+//             },
+//             TrailerFunc: func() metadata.MD {
+// 	               panic("mock out the Trailer method")
+//             },
+//         }
+//
+//         // use mockedEventsService_EventsClient in code that requires EventsService_EventsClient
+//         // and then make assertions.
+//
+//     }
+type EventsService_EventsClientMock struct {
+	// CloseSendFunc mocks the CloseSend method.
+	CloseSendFunc func() error
+
+	// ContextFunc mocks the Context method.
+	ContextFunc func() context.Context
+
+	// HeaderFunc mocks the Header method.
+	HeaderFunc func() (metadata.MD, error)
+
+	// RecvFunc mocks the Recv method.
+	RecvFunc func() (*Event, error)
+
+	// RecvMsgFunc mocks the RecvMsg method.
+	RecvMsgFunc func(m interface{}) error
+
+	// SendMsgFunc mocks the SendMsg method.
+	SendMsgFunc func(m interface{}) error
+
+	// TrailerFunc mocks the Trailer method.
+	TrailerFunc func() metadata.MD
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CloseSend holds details about calls to the CloseSend method.
+		CloseSend []struct {
+		}
+		// Context holds details about calls to the Context method.
+		Context []struct {
+		}
+		// Header holds details about calls to the Header method.
+		Header []struct {
+		}
+		// Recv holds details about calls to the Recv method.
+		Recv []struct {
+		}
+		// RecvMsg holds details about calls to the RecvMsg method.
+		RecvMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// SendMsg holds details about calls to the SendMsg method.
+		SendMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// Trailer holds details about calls to the Trailer method.
+		Trailer []struct {
+		}
+	}
+	lockCloseSend sync.RWMutex
+	lockContext   sync.RWMutex
+	lockHeader    sync.RWMutex
+	lockRecv      sync.RWMutex
+	lockRecvMsg   sync.RWMutex
+	lockSendMsg   sync.RWMutex
+	lockTrailer   sync.RWMutex
+}
+
+// CloseSend calls CloseSendFunc.
+func (mock *EventsService_EventsClientMock) CloseSend() error {
+	if mock.CloseSendFunc == nil {
+		panic("EventsService_EventsClientMock.CloseSendFunc: method is nil but EventsService_EventsClient.CloseSend was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCloseSend.Lock()
+	mock.calls.CloseSend = append(mock.calls.CloseSend, callInfo)
+	mock.lockCloseSend.Unlock()
+	return mock.CloseSendFunc()
+}
+
+// CloseSendCalls gets all the calls that were made to CloseSend.
+// Check the length with:
+//     len(mockedEventsService_EventsClient.CloseSendCalls())
+func (mock *EventsService_EventsClientMock) CloseSendCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCloseSend.RLock()
+	calls = mock.calls.CloseSend
+	mock.lockCloseSend.RUnlock()
+	return calls
+}
+
+// Context calls ContextFunc.
+func (mock *EventsService_EventsClientMock) Context() context.Context {
+	if mock.ContextFunc == nil {
+		panic("EventsService_EventsClientMock.ContextFunc: method is nil but EventsService_EventsClient.Context was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockContext.Lock()
+	mock.calls.Context = append(mock.calls.Context, callInfo)
+	mock.lockContext.Unlock()
+	return mock.ContextFunc()
+}
+
+// ContextCalls gets all the calls that were made to Context.
+// Check the length with:
+//     len(mockedEventsService_EventsClient.ContextCalls())
+func (mock *EventsService_EventsClientMock) ContextCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockContext.RLock()
+	calls = mock.calls.Context
+	mock.lockContext.RUnlock()
+	return calls
+}
+
+// Header calls HeaderFunc.
+func (mock *EventsService_EventsClientMock) Header() (metadata.MD, error) {
+	if mock.HeaderFunc == nil {
+		panic("EventsService_EventsClientMock.HeaderFunc: method is nil but EventsService_EventsClient.Header was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockHeader.Lock()
+	mock.calls.Header = append(mock.calls.Header, callInfo)
+	mock.lockHeader.Unlock()
+	return mock.HeaderFunc()
+}
+
+// HeaderCalls gets all the calls that were made to Header.
+// Check the length with:
+//     len(mockedEventsService_EventsClient.HeaderCalls())
+func (mock *EventsService_EventsClientMock) HeaderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHeader.RLock()
+	calls = mock.calls.Header
+	mock.lockHeader.RUnlock()
+	return calls
+}
+
+// Recv calls RecvFunc.
+func (mock *EventsService_EventsClientMock) Recv() (*Event, error) {
+	if mock.RecvFunc == nil {
+		panic("EventsService_EventsClientMock.RecvFunc: method is nil but EventsService_EventsClient.Recv was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRecv.Lock()
+	mock.calls.Recv = append(mock.calls.Recv, callInfo)
+	mock.lockRecv.Unlock()
+	return mock.RecvFunc()
+}
+
+// RecvCalls gets all the calls that were made to Recv.
+// Check the length with:
+//     len(mockedEventsService_EventsClient.RecvCalls())
+func (mock *EventsService_EventsClientMock) RecvCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRecv.RLock()
+	calls = mock.calls.Recv
+	mock.lockRecv.RUnlock()
+	return calls
+}
+
+// RecvMsg calls RecvMsgFunc.
+func (mock *EventsService_EventsClientMock) RecvMsg(m interface{}) error {
+	if mock.RecvMsgFunc == nil {
+		panic("EventsService_EventsClientMock.RecvMsgFunc: method is nil but EventsService_EventsClient.RecvMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockRecvMsg.Lock()
+	mock.calls.RecvMsg = append(mock.calls.RecvMsg, callInfo)
+	mock.lockRecvMsg.Unlock()
+	return mock.RecvMsgFunc(m)
+}
+
+// RecvMsgCalls gets all the calls that were made to RecvMsg.
+// Check the length with:
+//     len(mockedEventsService_EventsClient.RecvMsgCalls())
+func (mock *EventsService_EventsClientMock) RecvMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockRecvMsg.RLock()
+	calls = mock.calls.RecvMsg
+	mock.lockRecvMsg.RUnlock()
+	return calls
+}
+
+// SendMsg calls SendMsgFunc.
+func (mock *EventsService_EventsClientMock) SendMsg(m interface{}) error {
+	if mock.SendMsgFunc == nil {
+		panic("EventsService_EventsClientMock.SendMsgFunc: method is nil but EventsService_EventsClient.SendMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockSendMsg.Lock()
+	mock.calls.SendMsg = append(mock.calls.SendMsg, callInfo)
+	mock.lockSendMsg.Unlock()
+	return mock.SendMsgFunc(m)
+}
+
+// SendMsgCalls gets all the calls that were made to SendMsg.
+// Check the length with:
+//     len(mockedEventsService_EventsClient.SendMsgCalls())
+func (mock *EventsService_EventsClientMock) SendMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockSendMsg.RLock()
+	calls = mock.calls.SendMsg
+	mock.lockSendMsg.RUnlock()
+	return calls
+}
+
+// Trailer calls TrailerFunc.
+func (mock *EventsService_EventsClientMock) Trailer() metadata.MD {
+	if mock.TrailerFunc == nil {
+		panic("EventsService_EventsClientMock.TrailerFunc: method is nil but EventsService_EventsClient.Trailer was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTrailer.Lock()
+	mock.calls.Trailer = append(mock.calls.Trailer, callInfo)
+	mock.lockTrailer.Unlock()
+	return mock.TrailerFunc()
+}
+
+// TrailerCalls gets all the calls that were made to Trailer.
+// Check the length with:
+//     len(mockedEventsService_EventsClient.TrailerCalls())
+func (mock *EventsService_EventsClientMock) TrailerCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTrailer.RLock()
+	calls = mock.calls.Trailer
+	mock.lockTrailer.RUnlock()
+	return calls
+}
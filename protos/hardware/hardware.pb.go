@@ -16,12 +16,15 @@ import (
 	sync "sync"
 
 	proto "github.com/golang/protobuf/proto"
+	packet "github.com/tinkerbell/tink/protos/packet"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -35,6 +38,148 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+//
+// State is the lifecycle state of a hardware.
+//
+// The kind of the template tells what a workflow does to its hardware. A
+// provision workflow, the default, can be created for available and
+// provisioned hardware: the hardware becomes provisioning when it starts and
+// provisioned when it succeeds. A deprovision workflow (kind: deprovision)
+// can be created for provisioned hardware only: the hardware becomes
+// deprovisioning when it starts and available when it succeeds. When either
+// fails or times out the hardware becomes broken.
+type State int32
+
+const (
+	//
+	// The state of the hardware pushed before the lifecycle existed. It can
+	// move to any state and it accepts workflows.
+	State_STATE_UNSPECIFIED State = 0
+	//
+	// The hardware has been found but it is not ready to be used yet.
+	State_STATE_DISCOVERED     State = 1
+	State_STATE_AVAILABLE      State = 2
+	State_STATE_PROVISIONING   State = 3
+	State_STATE_PROVISIONED    State = 4
+	State_STATE_DEPROVISIONING State = 5
+	//
+	// The hardware needs a human to look at it.
+	State_STATE_BROKEN State = 6
+)
+
+// Enum value maps for State.
+var (
+	State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_DISCOVERED",
+		2: "STATE_AVAILABLE",
+		3: "STATE_PROVISIONING",
+		4: "STATE_PROVISIONED",
+		5: "STATE_DEPROVISIONING",
+		6: "STATE_BROKEN",
+	}
+	State_value = map[string]int32{
+		"STATE_UNSPECIFIED":    0,
+		"STATE_DISCOVERED":     1,
+		"STATE_AVAILABLE":      2,
+		"STATE_PROVISIONING":   3,
+		"STATE_PROVISIONED":    4,
+		"STATE_DEPROVISIONING": 5,
+		"STATE_BROKEN":         6,
+	}
+)
+
+func (x State) Enum() *State {
+	p := new(State)
+	*p = x
+	return p
+}
+
+func (x State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (State) Descriptor() protoreflect.EnumDescriptor {
+	return file_hardware_hardware_proto_enumTypes[0].Descriptor()
+}
+
+func (State) Type() protoreflect.EnumType {
+	return &file_hardware_hardware_proto_enumTypes[0]
+}
+
+func (x State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use State.Descriptor instead.
+func (State) EnumDescriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{0}
+}
+
+type WatchEvent_Type int32
+
+const (
+	WatchEvent_TYPE_UNSPECIFIED WatchEvent_Type = 0
+	//
+	// The hardware existed when the stream started, see send_existing.
+	WatchEvent_TYPE_EXISTING WatchEvent_Type = 1
+	WatchEvent_TYPE_CREATED  WatchEvent_Type = 2
+	WatchEvent_TYPE_UPDATED  WatchEvent_Type = 3
+	WatchEvent_TYPE_DELETED  WatchEvent_Type = 4
+	//
+	// The hardware got updated and it does not match the label selector or
+	// the filters of the watch anymore. No change of it is sent until it
+	// matches again.
+	WatchEvent_TYPE_LEFT WatchEvent_Type = 5
+)
+
+// Enum value maps for WatchEvent_Type.
+var (
+	WatchEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_EXISTING",
+		2: "TYPE_CREATED",
+		3: "TYPE_UPDATED",
+		4: "TYPE_DELETED",
+		5: "TYPE_LEFT",
+	}
+	WatchEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_EXISTING":    1,
+		"TYPE_CREATED":     2,
+		"TYPE_UPDATED":     3,
+		"TYPE_DELETED":     4,
+		"TYPE_LEFT":        5,
+	}
+)
+
+func (x WatchEvent_Type) Enum() *WatchEvent_Type {
+	p := new(WatchEvent_Type)
+	*p = x
+	return p
+}
+
+func (x WatchEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_hardware_hardware_proto_enumTypes[1].Descriptor()
+}
+
+func (WatchEvent_Type) Type() protoreflect.EnumType {
+	return &file_hardware_hardware_proto_enumTypes[1]
+}
+
+func (x WatchEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchEvent_Type.Descriptor instead.
+func (WatchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{10, 0}
+}
+
 //
 // PushRequest is the body for the Push method. It contains information about
 // a piece of hardware.
@@ -48,6 +193,9 @@ type PushRequest struct {
 	// what it looks like.
 	// Hostname, MAC address, DHCP, network interfaces, metadata and so on.
 	Data *Hardware `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	//
+	// Overwrite the stored hardware whatever its version.
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
 }
 
 func (x *PushRequest) Reset() {
@@ -89,6 +237,146 @@ func (x *PushRequest) GetData() *Hardware {
 	return nil
 }
 
+func (x *PushRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+//
+// PushBatchRequest is the body for the PushBatch method.
+type PushBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// The hardware to push, every id can be present only once.
+	Data []*Hardware `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	//
+	// Only validate the hardware, without writing it.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	//
+	// Overwrite the stored hardware whatever its version, like
+	// PushRequest.force.
+	Force bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *PushBatchRequest) Reset() {
+	*x = PushBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushBatchRequest) ProtoMessage() {}
+
+func (x *PushBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushBatchRequest.ProtoReflect.Descriptor instead.
+func (*PushBatchRequest) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PushBatchRequest) GetData() []*Hardware {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PushBatchRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *PushBatchRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+//
+// PushBatchResponse reports the result of a PushBatch for every item of the
+// request, in the same order.
+type PushBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*PushBatchResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	//
+	// Tells if the hardware got written. It is false for a dry run and when at
+	// least one of the items has an error.
+	Pushed bool `protobuf:"varint,2,opt,name=pushed,proto3" json:"pushed,omitempty"`
+}
+
+func (x *PushBatchResponse) Reset() {
+	*x = PushBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushBatchResponse) ProtoMessage() {}
+
+func (x *PushBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushBatchResponse.ProtoReflect.Descriptor instead.
+func (*PushBatchResponse) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PushBatchResponse) GetResults() []*PushBatchResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *PushBatchResponse) GetPushed() bool {
+	if x != nil {
+		return x.Pushed
+	}
+	return false
+}
+
 //
 // Empty represents an empty response
 type Empty struct {
@@ -100,7 +388,7 @@ type Empty struct {
 func (x *Empty) Reset() {
 	*x = Empty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[1]
+		mi := &file_hardware_hardware_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -113,7 +401,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[1]
+	mi := &file_hardware_hardware_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -126,7 +414,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{1}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{3}
 }
 
 //
@@ -145,7 +433,7 @@ type GetRequest struct {
 func (x *GetRequest) Reset() {
 	*x = GetRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[2]
+		mi := &file_hardware_hardware_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -158,7 +446,7 @@ func (x *GetRequest) String() string {
 func (*GetRequest) ProtoMessage() {}
 
 func (x *GetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[2]
+	mi := &file_hardware_hardware_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -171,7 +459,7 @@ func (x *GetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{2}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetRequest) GetMac() string {
@@ -195,6 +483,106 @@ func (x *GetRequest) GetId() string {
 	return ""
 }
 
+//
+// ListRequest selects the hardware returned by the List method. A hardware
+// is returned only when it matches the label selector and all the filters.
+type ListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// A comma separated list of label requirements. Supported requirements
+	// are key=value, key==value, key!=value, key (the label is set) and !key
+	// (the label is not set). For example: "arch=arm64,rack!=r1".
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	//
+	// Filters in the form path=value, where path is a dot separated path in
+	// the JSON representation of the hardware, metadata included. A filter
+	// matches when one of the values found at path is equal to value, so
+	// "network.interfaces.dhcp.arch=aarch64" matches hardware with at least
+	// one aarch64 interface.
+	Filters []string `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+	//
+	// The maximum number of hardware returned, zero returns all of them.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	//
+	// The next-page-token trailer of the previous call, it returns the
+	// following page. The pages follow the order of the first one.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	//
+	// The field the hardware is sorted by: id (default) or inserted_at, the
+	// time of the last write. Append " desc" for a descending order.
+	OrderBy string `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *ListRequest) GetFilters() []string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *ListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
 //
 // Hardware describes a specific device managed by Tinkerbell
 type Hardware struct {
@@ -207,8 +595,13 @@ type Hardware struct {
 	Network *Hardware_Network `protobuf:"bytes,6,opt,name=network,proto3" json:"network,omitempty"`
 	//
 	// A UUID representing a unique identifier for your hardware
-	Id      string `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
-	Version int64  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	Id string `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	//
+	// The version of the hardware data. It is managed by the server and it gets
+	// incremented on every write. Push it back unchanged to update the
+	// hardware, the push fails if somebody modified it in the meantime. Leave
+	// it empty only to create a hardware.
+	Version int64 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
 	//
 	// Metadata served by Hegel and that are accessible from the server itself To
 	// know more about what Hegel is have a look at the documentation
@@ -216,27 +609,570 @@ type Hardware struct {
 	//
 	// And at this URL you can find an explanation of how a metadata looks like:
 	// https://docs.tinkerbell.org/about/hardware-data/
-	// Right now it is a string but we want to structure it a bit more.
+	// It is a JSON document validated against the Metadata message from
+	// packet.proto: fields unknown to the schema are accepted, but the known
+	// ones have to hold a value of the right type.
 	Metadata string `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	//
+	// Labels are key value pairs attached to the hardware. They are not
+	// interpreted by Tinkerbell but they can be used to select a subset of
+	// the hardware with the List method.
+	Labels map[string]string `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	//
+	// The metadata decoded with the schema from packet.proto. The server fills
+	// it on every read when the metadata matches the schema. It can be used in
+	// place of metadata on push, setting both is an error.
+	TypedMetadata *packet.Metadata `protobuf:"bytes,11,opt,name=typed_metadata,json=typedMetadata,proto3" json:"typed_metadata,omitempty"`
+	//
+	// The lifecycle state of the hardware. It is managed by the server: new
+	// hardware starts available, or discovered when pushed as such, and it
+	// moves on with SetState and with the workflows running on it. A push
+	// never changes the state of existing hardware.
+	State State `protobuf:"varint,12,opt,name=state,proto3,enum=github.com.tinkerbell.tink.protos.hardware.State" json:"state,omitempty"`
+	//
+	// The namespace the hardware belongs to. It is the namespace of the
+	// request, set with the tink-namespace metadata, or the default one when
+	// the request has none. A push can not move existing hardware to another
+	// namespace.
+	Namespace string `protobuf:"bytes,13,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
 func (x *Hardware) Reset() {
 	*x = Hardware{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[3]
+		mi := &file_hardware_hardware_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hardware) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hardware) ProtoMessage() {}
+
+func (x *Hardware) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hardware.ProtoReflect.Descriptor instead.
+func (*Hardware) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Hardware) GetNetwork() *Hardware_Network {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+func (x *Hardware) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Hardware) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Hardware) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *Hardware) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Hardware) GetTypedMetadata() *packet.Metadata {
+	if x != nil {
+		return x.TypedMetadata
+	}
+	return nil
+}
+
+func (x *Hardware) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *Hardware) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+//
+// DeleteRequest gets used when you want to delete an hardware by its identifier.
+// Usually it is a UUID.
+type DeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+//
+// SetStateRequest is the body for the SetState method.
+type SetStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State State  `protobuf:"varint,2,opt,name=state,proto3,enum=github.com.tinkerbell.tink.protos.hardware.State" json:"state,omitempty"`
+	//
+	// Skip the check of the transition, to recover from a wrong state.
+	Force bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *SetStateRequest) Reset() {
+	*x = SetStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStateRequest) ProtoMessage() {}
+
+func (x *SetStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStateRequest.ProtoReflect.Descriptor instead.
+func (*SetStateRequest) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetStateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetStateRequest) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *SetStateRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+//
+// WatchRequest selects the hardware whose changes are streamed by Watch. The
+// criteria are combined, an empty request watches all the hardware.
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// Watch only the hardware with one of these IDs.
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	//
+	// Watch only the hardware matching the label selector, see ListRequest.
+	LabelSelector string `protobuf:"bytes,2,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	//
+	// Watch only the hardware matching all the filters, see ListRequest.
+	Filters []string `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty"`
+	//
+	// Stream the changes made after this revision, usually the last one
+	// received before the stream broke. When it is 0 the stream starts with
+	// the changes made after the call. The stream fails with OUT_OF_RANGE when
+	// a purge removed changes of a watched hardware made after the revision,
+	// the client has to start over with send_existing.
+	SinceRevision int64 `protobuf:"varint,4,opt,name=since_revision,json=sinceRevision,proto3" json:"since_revision,omitempty"`
+	//
+	// Start the stream with the matching hardware as it is, in events of type
+	// EXISTING, then stream the changes made after them. It can not be combined
+	// with since_revision.
+	SendExisting bool `protobuf:"varint,5,opt,name=send_existing,json=sendExisting,proto3" json:"send_existing,omitempty"`
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetFilters() []string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetSinceRevision() int64 {
+	if x != nil {
+		return x.SinceRevision
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetSendExisting() bool {
+	if x != nil {
+		return x.SendExisting
+	}
+	return false
+}
+
+//
+// WatchEvent is a change of a hardware streamed by Watch.
+type WatchEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type WatchEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=github.com.tinkerbell.tink.protos.hardware.WatchEvent_Type" json:"type,omitempty"`
+	//
+	// The revision of the change, to pass as since_revision when resuming the
+	// stream. The existing hardware all carry the revision the changes start
+	// from.
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	//
+	// The hardware after the change, or as it was before a deletion.
+	Hardware *Hardware `protobuf:"bytes,3,opt,name=hardware,proto3" json:"hardware,omitempty"`
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchEvent) GetType() WatchEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return WatchEvent_TYPE_UNSPECIFIED
+}
+
+func (x *WatchEvent) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *WatchEvent) GetHardware() *Hardware {
+	if x != nil {
+		return x.Hardware
+	}
+	return nil
+}
+
+//
+// HardwareRevision is an entry in the history of a hardware.
+type HardwareRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// The sequence number of the revision, it grows across all the hardware.
+	Revision   int64  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	HardwareId string `protobuf:"bytes,2,opt,name=hardware_id,json=hardwareId,proto3" json:"hardware_id,omitempty"`
+	//
+	// The version of the hardware after the revision.
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	//
+	// What happened to the hardware: created, updated or deleted.
+	EventType string `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	//
+	// Who made the change, as reported by the client or its address.
+	Actor     string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	//
+	// The hardware as it is after the revision.
+	Data *Hardware `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+	//
+	// The fields changed by the revision, empty for a deletion.
+	Changes []*HardwareRevision_Change `protobuf:"bytes,8,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *HardwareRevision) Reset() {
+	*x = HardwareRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HardwareRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HardwareRevision) ProtoMessage() {}
+
+func (x *HardwareRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HardwareRevision.ProtoReflect.Descriptor instead.
+func (*HardwareRevision) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HardwareRevision) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *HardwareRevision) GetHardwareId() string {
+	if x != nil {
+		return x.HardwareId
+	}
+	return ""
+}
+
+func (x *HardwareRevision) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HardwareRevision) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *HardwareRevision) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *HardwareRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *HardwareRevision) GetData() *Hardware {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *HardwareRevision) GetChanges() []*HardwareRevision_Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+//
+// PatchRequest describes a partial update for an existing hardware. Only one
+// between merge_patch and update_mask can be set.
+type PatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// The identifier of the hardware to patch.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	//
+	// A JSON merge patch (RFC 7386) applied to the JSON representation of the
+	// hardware, the same one accepted by Push.
+	MergePatch string `protobuf:"bytes,2,opt,name=merge_patch,json=mergePatch,proto3" json:"merge_patch,omitempty"`
+	//
+	// The hardware holding the new values for the fields listed in update_mask.
+	Data *Hardware `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	//
+	// The fields to copy from data to the stored hardware. A field listed in
+	// the mask but not set in data gets cleared.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	//
+	// When set the patch gets applied only if it matches the version of the
+	// stored hardware.
+	Version int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *PatchRequest) Reset() {
+	*x = PatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Hardware) String() string {
+func (x *PatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Hardware) ProtoMessage() {}
+func (*PatchRequest) ProtoMessage() {}
 
-func (x *Hardware) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[3]
+func (x *PatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,67 +1183,74 @@ func (x *Hardware) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Hardware.ProtoReflect.Descriptor instead.
-func (*Hardware) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use PatchRequest.ProtoReflect.Descriptor instead.
+func (*PatchRequest) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Hardware) GetNetwork() *Hardware_Network {
+func (x *PatchRequest) GetId() string {
 	if x != nil {
-		return x.Network
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *Hardware) GetId() string {
+func (x *PatchRequest) GetMergePatch() string {
 	if x != nil {
-		return x.Id
+		return x.MergePatch
 	}
 	return ""
 }
 
-func (x *Hardware) GetVersion() int64 {
+func (x *PatchRequest) GetData() *Hardware {
 	if x != nil {
-		return x.Version
+		return x.Data
 	}
-	return 0
+	return nil
 }
 
-func (x *Hardware) GetMetadata() string {
+func (x *PatchRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	if x != nil {
-		return x.Metadata
+		return x.UpdateMask
 	}
-	return ""
+	return nil
 }
 
-//
-// DeleteRequest gets used when you want to delete an hardware by its identifier.
-// Usually it is a UUID.
-type DeleteRequest struct {
+func (x *PatchRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type PushBatchResponse_Result struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	//
+	// What is wrong with the item, empty when it is valid.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
+func (x *PushBatchResponse_Result) Reset() {
+	*x = PushBatchResponse_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[4]
+		mi := &file_hardware_hardware_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteRequest) String() string {
+func (x *PushBatchResponse_Result) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*PushBatchResponse_Result) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[4]
+func (x *PushBatchResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,18 +1261,25 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use PushBatchResponse_Result.ProtoReflect.Descriptor instead.
+func (*PushBatchResponse_Result) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{2, 0}
 }
 
-func (x *DeleteRequest) GetId() string {
+func (x *PushBatchResponse_Result) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
+func (x *PushBatchResponse_Result) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 //
 // DHCP represents
 type Hardware_DHCP struct {
@@ -365,7 +1315,7 @@ type Hardware_DHCP struct {
 func (x *Hardware_DHCP) Reset() {
 	*x = Hardware_DHCP{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[5]
+		mi := &file_hardware_hardware_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -378,7 +1328,7 @@ func (x *Hardware_DHCP) String() string {
 func (*Hardware_DHCP) ProtoMessage() {}
 
 func (x *Hardware_DHCP) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[5]
+	mi := &file_hardware_hardware_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +1341,7 @@ func (x *Hardware_DHCP) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hardware_DHCP.ProtoReflect.Descriptor instead.
 func (*Hardware_DHCP) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{3, 0}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{6, 0}
 }
 
 func (x *Hardware_DHCP) GetMac() string {
@@ -490,7 +1440,7 @@ type Hardware_Netboot struct {
 func (x *Hardware_Netboot) Reset() {
 	*x = Hardware_Netboot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[6]
+		mi := &file_hardware_hardware_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -503,7 +1453,7 @@ func (x *Hardware_Netboot) String() string {
 func (*Hardware_Netboot) ProtoMessage() {}
 
 func (x *Hardware_Netboot) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[6]
+	mi := &file_hardware_hardware_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +1466,7 @@ func (x *Hardware_Netboot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hardware_Netboot.ProtoReflect.Descriptor instead.
 func (*Hardware_Netboot) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{3, 1}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{6, 1}
 }
 
 func (x *Hardware_Netboot) GetAllowPxe() bool {
@@ -564,7 +1514,7 @@ type Hardware_Network struct {
 func (x *Hardware_Network) Reset() {
 	*x = Hardware_Network{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[7]
+		mi := &file_hardware_hardware_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -577,7 +1527,7 @@ func (x *Hardware_Network) String() string {
 func (*Hardware_Network) ProtoMessage() {}
 
 func (x *Hardware_Network) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[7]
+	mi := &file_hardware_hardware_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +1540,7 @@ func (x *Hardware_Network) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hardware_Network.ProtoReflect.Descriptor instead.
 func (*Hardware_Network) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{3, 2}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{6, 2}
 }
 
 func (x *Hardware_Network) GetInterfaces() []*Hardware_Network_Interface {
@@ -614,7 +1564,7 @@ type Hardware_DHCP_IP struct {
 func (x *Hardware_DHCP_IP) Reset() {
 	*x = Hardware_DHCP_IP{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[8]
+		mi := &file_hardware_hardware_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -627,7 +1577,7 @@ func (x *Hardware_DHCP_IP) String() string {
 func (*Hardware_DHCP_IP) ProtoMessage() {}
 
 func (x *Hardware_DHCP_IP) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[8]
+	mi := &file_hardware_hardware_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -640,7 +1590,7 @@ func (x *Hardware_DHCP_IP) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hardware_DHCP_IP.ProtoReflect.Descriptor instead.
 func (*Hardware_DHCP_IP) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{3, 0, 0}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{6, 0, 0}
 }
 
 func (x *Hardware_DHCP_IP) GetAddress() string {
@@ -689,7 +1639,7 @@ type Hardware_Netboot_IPXE struct {
 func (x *Hardware_Netboot_IPXE) Reset() {
 	*x = Hardware_Netboot_IPXE{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[9]
+		mi := &file_hardware_hardware_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -702,7 +1652,7 @@ func (x *Hardware_Netboot_IPXE) String() string {
 func (*Hardware_Netboot_IPXE) ProtoMessage() {}
 
 func (x *Hardware_Netboot_IPXE) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[9]
+	mi := &file_hardware_hardware_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -715,7 +1665,7 @@ func (x *Hardware_Netboot_IPXE) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hardware_Netboot_IPXE.ProtoReflect.Descriptor instead.
 func (*Hardware_Netboot_IPXE) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{3, 1, 0}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{6, 1, 0}
 }
 
 func (x *Hardware_Netboot_IPXE) GetUrl() string {
@@ -747,7 +1697,7 @@ type Hardware_Netboot_Osie struct {
 func (x *Hardware_Netboot_Osie) Reset() {
 	*x = Hardware_Netboot_Osie{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[10]
+		mi := &file_hardware_hardware_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -760,7 +1710,7 @@ func (x *Hardware_Netboot_Osie) String() string {
 func (*Hardware_Netboot_Osie) ProtoMessage() {}
 
 func (x *Hardware_Netboot_Osie) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[10]
+	mi := &file_hardware_hardware_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -773,7 +1723,7 @@ func (x *Hardware_Netboot_Osie) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hardware_Netboot_Osie.ProtoReflect.Descriptor instead.
 func (*Hardware_Netboot_Osie) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{3, 1, 1}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{6, 1, 1}
 }
 
 func (x *Hardware_Netboot_Osie) GetBaseUrl() string {
@@ -817,7 +1767,7 @@ type Hardware_Network_Interface struct {
 func (x *Hardware_Network_Interface) Reset() {
 	*x = Hardware_Network_Interface{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hardware_hardware_proto_msgTypes[11]
+		mi := &file_hardware_hardware_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -830,7 +1780,7 @@ func (x *Hardware_Network_Interface) String() string {
 func (*Hardware_Network_Interface) ProtoMessage() {}
 
 func (x *Hardware_Network_Interface) ProtoReflect() protoreflect.Message {
-	mi := &file_hardware_hardware_proto_msgTypes[11]
+	mi := &file_hardware_hardware_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +1793,7 @@ func (x *Hardware_Network_Interface) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Hardware_Network_Interface.ProtoReflect.Descriptor instead.
 func (*Hardware_Network_Interface) Descriptor() ([]byte, []int) {
-	return file_hardware_hardware_proto_rawDescGZIP(), []int{3, 2, 0}
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{6, 2, 0}
 }
 
 func (x *Hardware_Network_Interface) GetDhcp() *Hardware_DHCP {
@@ -860,6 +1810,74 @@ func (x *Hardware_Network_Interface) GetNetboot() *Hardware_Netboot {
 	return nil
 }
 
+//
+// Change describes a field changed by the revision. The values are JSON
+// encoded, they are empty when the field did not exist.
+type HardwareRevision_Change struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// The path of the field, like network.interfaces[0].netboot.allow_pxe
+	Path     string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	OldValue string `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue string `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+}
+
+func (x *HardwareRevision_Change) Reset() {
+	*x = HardwareRevision_Change{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hardware_hardware_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HardwareRevision_Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HardwareRevision_Change) ProtoMessage() {}
+
+func (x *HardwareRevision_Change) ProtoReflect() protoreflect.Message {
+	mi := &file_hardware_hardware_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HardwareRevision_Change.ProtoReflect.Descriptor instead.
+func (*HardwareRevision_Change) Descriptor() ([]byte, []int) {
+	return file_hardware_hardware_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *HardwareRevision_Change) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HardwareRevision_Change) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *HardwareRevision_Change) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
 var File_hardware_hardware_proto protoreflect.FileDescriptor
 
 var file_hardware_hardware_proto_rawDesc = []byte{
@@ -869,170 +1887,384 @@ var file_hardware_hardware_proto_rawDesc = []byte{
 	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72,
 	0x64, 0x77, 0x61, 0x72, 0x65, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70,
 	0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x57, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x48, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61,
-	0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x07, 0x0a, 0x05,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x3e, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6d, 0x61, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xb7, 0x0a, 0x0a, 0x08, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61,
-	0x72, 0x65, 0x12, 0x56, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
-	0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x1a, 0xa6, 0x03, 0x0a, 0x04, 0x44, 0x48, 0x43, 0x50, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x68,
-	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68,
-	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x73, 0x65,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61,
-	0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x61,
-	0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d,
-	0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x61, 0x72, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68,
-	0x12, 0x12, 0x0a, 0x04, 0x75, 0x65, 0x66, 0x69, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
-	0x75, 0x65, 0x66, 0x69, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x66, 0x61, 0x63, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x4c, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x3c, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72,
-	0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x44, 0x48, 0x43, 0x50, 0x2e, 0x49, 0x50, 0x52, 0x02, 0x69,
-	0x70, 0x1a, 0x6a, 0x0a, 0x02, 0x49, 0x50, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x6d, 0x61, 0x73, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x67,
-	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x61,
-	0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x4a, 0x04, 0x08,
-	0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x1a, 0x8a, 0x03, 0x0a, 0x07, 0x4e, 0x65,
-	0x74, 0x62, 0x6f, 0x6f, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x70,
-	0x78, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50,
-	0x78, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x77, 0x6f, 0x72, 0x6b,
-	0x66, 0x6c, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f,
-	0x77, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x55, 0x0a, 0x04, 0x69, 0x70, 0x78,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e,
-	0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64,
-	0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e, 0x65,
-	0x74, 0x62, 0x6f, 0x6f, 0x74, 0x2e, 0x49, 0x50, 0x58, 0x45, 0x52, 0x04, 0x69, 0x70, 0x78, 0x65,
-	0x12, 0x55, 0x0a, 0x04, 0x6f, 0x73, 0x69, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41,
-	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b,
-	0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64,
-	0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74, 0x62, 0x6f, 0x6f, 0x74, 0x2e, 0x4f, 0x73, 0x69,
-	0x65, 0x52, 0x04, 0x6f, 0x73, 0x69, 0x65, 0x1a, 0x34, 0x0a, 0x04, 0x49, 0x50, 0x58, 0x45, 0x12,
-	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
-	0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x51, 0x0a,
-	0x04, 0x4f, 0x73, 0x69, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x75, 0x72,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x55, 0x72, 0x6c,
-	0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x69, 0x74,
-	0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x69, 0x74, 0x72, 0x64,
-	0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x1a, 0xb8, 0x02, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x12, 0x66, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x46, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74,
-	0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77,
-	0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0a,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x1a, 0xb2, 0x01, 0x0a, 0x09, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x04, 0x64, 0x68, 0x63, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74,
-	0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77,
-	0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x44, 0x48, 0x43,
-	0x50, 0x52, 0x04, 0x64, 0x68, 0x63, 0x70, 0x12, 0x56, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x62, 0x6f,
-	0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2f, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6d, 0x0a, 0x0b, 0x50,
+	0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
 	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c,
 	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72,
-	0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e,
-	0x65, 0x74, 0x62, 0x6f, 0x6f, 0x74, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x62, 0x6f, 0x6f, 0x74, 0x4a,
-	0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x04, 0x10,
-	0x05, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08,
-	0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22,
-	0x1f, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x32, 0xfa, 0x07, 0x0a, 0x0f, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x8b, 0x01, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x37, 0x2e,
+	0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x10, 0x50,
+	0x75, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x48, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e,
 	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65,
 	0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77,
+	0x61, 0x72, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79,
+	0x5f, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0xbb, 0x01, 0x0a, 0x11, 0x50, 0x75, 0x73,
+	0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x44, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x73,
+	0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x75, 0x73, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x70, 0x75, 0x73, 0x68, 0x65, 0x64, 0x1a, 0x2e, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x3e, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x61, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0xa5, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x19, 0x0a, 0x08,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x22, 0x94, 0x0d, 0x0a, 0x08, 0x48, 0x61, 0x72, 0x64,
+	0x77, 0x61, 0x72, 0x65, 0x12, 0x56, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69,
+	0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61,
+	0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x58, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x40, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
+	0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e,
+	0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x59, 0x0a, 0x0e,
+	0x74, 0x79, 0x70, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x74, 0x79, 0x70, 0x65, 0x64, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
 	0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74,
 	0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77,
-	0x61, 0x72, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x11, 0x22, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x3a,
-	0x01, 0x2a, 0x12, 0x92, 0x01, 0x0a, 0x05, 0x42, 0x79, 0x4d, 0x41, 0x43, 0x12, 0x36, 0x2e, 0x67,
+	0x61, 0x72, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0xa6,
+	0x03, 0x0a, 0x04, 0x44, 0x48, 0x43, 0x50, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x74,
+	0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72,
+	0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x65, 0x66, 0x69, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x75, 0x65,
+	0x66, 0x69, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x4c, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77,
+	0x61, 0x72, 0x65, 0x2e, 0x44, 0x48, 0x43, 0x50, 0x2e, 0x49, 0x50, 0x52, 0x02, 0x69, 0x70, 0x1a,
+	0x6a, 0x0a, 0x02, 0x49, 0x50, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6e, 0x65, 0x74, 0x6d, 0x61, 0x73, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x4a, 0x04, 0x08, 0x02, 0x10,
+	0x03, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x1a, 0x8a, 0x03, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x62,
+	0x6f, 0x6f, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x78, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x78, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x66, 0x6c,
+	0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x57,
+	0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x55, 0x0a, 0x04, 0x69, 0x70, 0x78, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69,
+	0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61,
+	0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74, 0x62,
+	0x6f, 0x6f, 0x74, 0x2e, 0x49, 0x50, 0x58, 0x45, 0x52, 0x04, 0x69, 0x70, 0x78, 0x65, 0x12, 0x55,
+	0x0a, 0x04, 0x6f, 0x73, 0x69, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x67,
 	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72,
 	0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61,
+	0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74, 0x62, 0x6f, 0x6f, 0x74, 0x2e, 0x4f, 0x73, 0x69, 0x65, 0x52,
+	0x04, 0x6f, 0x73, 0x69, 0x65, 0x1a, 0x34, 0x0a, 0x04, 0x49, 0x50, 0x58, 0x45, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x51, 0x0a, 0x04, 0x4f,
+	0x73, 0x69, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x16,
+	0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x69, 0x74, 0x72, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x69, 0x74, 0x72, 0x64, 0x4a, 0x04,
+	0x08, 0x04, 0x10, 0x05, 0x1a, 0xb8, 0x02, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x12, 0x66, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x46, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
 	0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e,
 	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
-	0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x15, 0x22, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
-	0x2f, 0x6d, 0x61, 0x63, 0x3a, 0x01, 0x2a, 0x12, 0x90, 0x01, 0x0a, 0x04, 0x42, 0x79, 0x49, 0x50,
-	0x12, 0x36, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69,
+	0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x1a, 0xb2, 0x01, 0x0a, 0x09, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x04, 0x64, 0x68, 0x63, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x44, 0x48, 0x43, 0x50, 0x52,
+	0x04, 0x64, 0x68, 0x63, 0x70, 0x12, 0x56, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x62, 0x6f, 0x6f, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74,
+	0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77,
+	0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4e, 0x65, 0x74,
+	0x62, 0x6f, 0x6f, 0x74, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x62, 0x6f, 0x6f, 0x74, 0x4a, 0x04, 0x08,
+	0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x1a,
+	0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02,
+	0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x04,
+	0x10, 0x05, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04, 0x08, 0x0e, 0x10, 0x0f, 0x22, 0x1f,
+	0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x80, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x47, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x31, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
+	0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72,
+	0x63, 0x65, 0x22, 0xad, 0x01, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x65, 0x6e, 0x64, 0x45, 0x78, 0x69, 0x73, 0x74, 0x69,
+	0x6e, 0x67, 0x22, 0xc1, 0x02, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x4f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x3b, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x50,
+	0x0a, 0x08, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69,
 	0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c,
-	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72,
-	0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x1a,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64,
-	0x77, 0x61, 0x72, 0x65, 0x2f, 0x69, 0x70, 0x3a, 0x01, 0x2a, 0x12, 0x8f, 0x01, 0x0a, 0x04, 0x42,
-	0x79, 0x49, 0x44, 0x12, 0x36, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61,
+	0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x52, 0x08, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x22, 0x74, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11,
+	0x0a, 0x0d, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45,
+	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x4c, 0x45, 0x46, 0x54, 0x10, 0x05, 0x22, 0xda, 0x03, 0x0a, 0x10, 0x48, 0x61, 0x72, 0x64, 0x77,
+	0x61, 0x72, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x61, 0x72, 0x64, 0x77,
+	0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x61,
+	0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x48, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61,
+	0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x5d, 0x0a, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x43, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77,
+	0x61, 0x72, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x1a, 0x56, 0x0a, 0x06, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x6c, 0x64,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x6c,
+	0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0xe0, 0x01, 0x0a, 0x0c, 0x50, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x5f, 0x70, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x67, 0x65,
+	0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x48, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b,
+	0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x2a, 0xa4, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x15, 0x0a, 0x11, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x13, 0x0a,
+	0x0f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45,
+	0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x56,
+	0x49, 0x53, 0x49, 0x4f, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x54,
+	0x41, 0x54, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x45, 0x44, 0x10,
+	0x04, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x44, 0x45, 0x50, 0x52, 0x4f,
+	0x56, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x5f, 0x42, 0x52, 0x4f, 0x4b, 0x45, 0x4e, 0x10, 0x06, 0x32, 0xc2, 0x0f,
+	0x0a, 0x0f, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x8b, 0x01, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x37, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65,
+	0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68,
+	0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x22, 0x0c,
+	0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x3a, 0x01, 0x2a, 0x12,
+	0xa7, 0x01, 0x0a, 0x09, 0x50, 0x75, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3c, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3d, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62,
+	0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x17, 0x22, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x2f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x3a, 0x01, 0x2a, 0x12, 0x92, 0x01, 0x0a, 0x05, 0x42, 0x79,
+	0x4d, 0x41, 0x43, 0x12, 0x36, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
 	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
 	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69,
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62,
 	0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
 	0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
-	0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x68,
-	0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x86, 0x01, 0x0a,
-	0x03, 0x41, 0x6c, 0x6c, 0x12, 0x31, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x68,
+	0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x6d, 0x61, 0x63, 0x3a, 0x01, 0x2a, 0x12, 0x90,
+	0x01, 0x0a, 0x04, 0x42, 0x79, 0x49, 0x50, 0x12, 0x36, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e,
+	0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64,
+	0x77, 0x61, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72,
+	0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f,
+	0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x69, 0x70, 0x3a, 0x01,
+	0x2a, 0x12, 0x8f, 0x01, 0x0a, 0x04, 0x42, 0x79, 0x49, 0x44, 0x12, 0x36, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65,
+	0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68,
+	0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
+	0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e,
+	0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13,
+	0x12, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x12, 0x8c, 0x01, 0x0a, 0x03, 0x41, 0x6c, 0x6c, 0x12, 0x37, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62,
+	0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x30, 0x01, 0x12, 0x95, 0x01, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x37, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62,
+	0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x16, 0x22, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x2f, 0x6c, 0x69, 0x73, 0x74, 0x3a, 0x01, 0x2a, 0x30, 0x01, 0x12, 0x9a, 0x01, 0x0a, 0x05, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x38, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
 	0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e,
 	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
-	0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x36,
+	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12,
+	0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x77, 0x61, 0x74,
+	0x63, 0x68, 0x3a, 0x01, 0x2a, 0x30, 0x01, 0x12, 0x81, 0x01, 0x0a, 0x0f, 0x44, 0x65, 0x70, 0x72,
+	0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x36, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62,
+	0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x30, 0x01, 0x12, 0x95, 0x01, 0x0a, 0x05,
+	0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x38, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69,
+	0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61,
+	0x72, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72,
+	0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x32, 0x11, 0x2f,
+	0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x3a, 0x01, 0x2a, 0x12, 0xa4, 0x01, 0x0a, 0x07, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x36, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3c, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e,
+	0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64,
+	0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f,
+	0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x30, 0x01, 0x12, 0xa1, 0x01, 0x0a, 0x08, 0x53,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3b, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
 	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e,
 	0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64,
-	0x77, 0x61, 0x72, 0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x14, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77,
-	0x61, 0x72, 0x65, 0x30, 0x01, 0x12, 0x81, 0x01, 0x0a, 0x0f, 0x44, 0x65, 0x70, 0x72, 0x65, 0x63,
-	0x61, 0x74, 0x65, 0x64, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x36, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x77, 0x61, 0x72, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
+	0x65, 0x2e, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1c, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x91,
+	0x01, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x39, 0x2e, 0x67, 0x69, 0x74, 0x68,
 	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c,
 	0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61,
-	0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x48,
-	0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x30, 0x01, 0x12, 0x91, 0x01, 0x0a, 0x06, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x12, 0x39, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
 	0x6d, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e,
 	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
-	0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x31, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x73, 0x2e, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x2a, 0x11, 0x2f, 0x76, 0x31, 0x2f,
-	0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x42, 0x2c, 0x5a,
-	0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x69, 0x6e, 0x6b,
-	0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2f, 0x74, 0x69, 0x6e, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x2a,
+	0x11, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x7b, 0x69,
+	0x64, 0x7d, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x74, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x62, 0x65, 0x6c, 0x6c, 0x2f, 0x74, 0x69, 0x6e, 0x6b,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1047,49 +2279,91 @@ func file_hardware_hardware_proto_rawDescGZIP() []byte {
 	return file_hardware_hardware_proto_rawDescData
 }
 
-var file_hardware_hardware_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_hardware_hardware_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_hardware_hardware_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_hardware_hardware_proto_goTypes = []interface{}{
-	(*PushRequest)(nil),                // 0: github.com.tinkerbell.tink.protos.hardware.PushRequest
-	(*Empty)(nil),                      // 1: github.com.tinkerbell.tink.protos.hardware.Empty
-	(*GetRequest)(nil),                 // 2: github.com.tinkerbell.tink.protos.hardware.GetRequest
-	(*Hardware)(nil),                   // 3: github.com.tinkerbell.tink.protos.hardware.Hardware
-	(*DeleteRequest)(nil),              // 4: github.com.tinkerbell.tink.protos.hardware.DeleteRequest
-	(*Hardware_DHCP)(nil),              // 5: github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP
-	(*Hardware_Netboot)(nil),           // 6: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot
-	(*Hardware_Network)(nil),           // 7: github.com.tinkerbell.tink.protos.hardware.Hardware.Network
-	(*Hardware_DHCP_IP)(nil),           // 8: github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP.IP
-	(*Hardware_Netboot_IPXE)(nil),      // 9: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.IPXE
-	(*Hardware_Netboot_Osie)(nil),      // 10: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.Osie
-	(*Hardware_Network_Interface)(nil), // 11: github.com.tinkerbell.tink.protos.hardware.Hardware.Network.Interface
+	(State)(0),                         // 0: github.com.tinkerbell.tink.protos.hardware.State
+	(WatchEvent_Type)(0),               // 1: github.com.tinkerbell.tink.protos.hardware.WatchEvent.Type
+	(*PushRequest)(nil),                // 2: github.com.tinkerbell.tink.protos.hardware.PushRequest
+	(*PushBatchRequest)(nil),           // 3: github.com.tinkerbell.tink.protos.hardware.PushBatchRequest
+	(*PushBatchResponse)(nil),          // 4: github.com.tinkerbell.tink.protos.hardware.PushBatchResponse
+	(*Empty)(nil),                      // 5: github.com.tinkerbell.tink.protos.hardware.Empty
+	(*GetRequest)(nil),                 // 6: github.com.tinkerbell.tink.protos.hardware.GetRequest
+	(*ListRequest)(nil),                // 7: github.com.tinkerbell.tink.protos.hardware.ListRequest
+	(*Hardware)(nil),                   // 8: github.com.tinkerbell.tink.protos.hardware.Hardware
+	(*DeleteRequest)(nil),              // 9: github.com.tinkerbell.tink.protos.hardware.DeleteRequest
+	(*SetStateRequest)(nil),            // 10: github.com.tinkerbell.tink.protos.hardware.SetStateRequest
+	(*WatchRequest)(nil),               // 11: github.com.tinkerbell.tink.protos.hardware.WatchRequest
+	(*WatchEvent)(nil),                 // 12: github.com.tinkerbell.tink.protos.hardware.WatchEvent
+	(*HardwareRevision)(nil),           // 13: github.com.tinkerbell.tink.protos.hardware.HardwareRevision
+	(*PatchRequest)(nil),               // 14: github.com.tinkerbell.tink.protos.hardware.PatchRequest
+	(*PushBatchResponse_Result)(nil),   // 15: github.com.tinkerbell.tink.protos.hardware.PushBatchResponse.Result
+	(*Hardware_DHCP)(nil),              // 16: github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP
+	(*Hardware_Netboot)(nil),           // 17: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot
+	(*Hardware_Network)(nil),           // 18: github.com.tinkerbell.tink.protos.hardware.Hardware.Network
+	nil,                                // 19: github.com.tinkerbell.tink.protos.hardware.Hardware.LabelsEntry
+	(*Hardware_DHCP_IP)(nil),           // 20: github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP.IP
+	(*Hardware_Netboot_IPXE)(nil),      // 21: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.IPXE
+	(*Hardware_Netboot_Osie)(nil),      // 22: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.Osie
+	(*Hardware_Network_Interface)(nil), // 23: github.com.tinkerbell.tink.protos.hardware.Hardware.Network.Interface
+	(*HardwareRevision_Change)(nil),    // 24: github.com.tinkerbell.tink.protos.hardware.HardwareRevision.Change
+	(*packet.Metadata)(nil),            // 25: github.com.tinkerbell.tink.protos.packet.Metadata
+	(*timestamppb.Timestamp)(nil),      // 26: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 27: google.protobuf.FieldMask
 }
 var file_hardware_hardware_proto_depIdxs = []int32{
-	3,  // 0: github.com.tinkerbell.tink.protos.hardware.PushRequest.data:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware
-	7,  // 1: github.com.tinkerbell.tink.protos.hardware.Hardware.network:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Network
-	8,  // 2: github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP.ip:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP.IP
-	9,  // 3: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.ipxe:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.IPXE
-	10, // 4: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.osie:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.Osie
-	11, // 5: github.com.tinkerbell.tink.protos.hardware.Hardware.Network.interfaces:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Network.Interface
-	5,  // 6: github.com.tinkerbell.tink.protos.hardware.Hardware.Network.Interface.dhcp:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP
-	6,  // 7: github.com.tinkerbell.tink.protos.hardware.Hardware.Network.Interface.netboot:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot
-	0,  // 8: github.com.tinkerbell.tink.protos.hardware.HardwareService.Push:input_type -> github.com.tinkerbell.tink.protos.hardware.PushRequest
-	2,  // 9: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByMAC:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
-	2,  // 10: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByIP:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
-	2,  // 11: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByID:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
-	1,  // 12: github.com.tinkerbell.tink.protos.hardware.HardwareService.All:input_type -> github.com.tinkerbell.tink.protos.hardware.Empty
-	2,  // 13: github.com.tinkerbell.tink.protos.hardware.HardwareService.DeprecatedWatch:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
-	4,  // 14: github.com.tinkerbell.tink.protos.hardware.HardwareService.Delete:input_type -> github.com.tinkerbell.tink.protos.hardware.DeleteRequest
-	1,  // 15: github.com.tinkerbell.tink.protos.hardware.HardwareService.Push:output_type -> github.com.tinkerbell.tink.protos.hardware.Empty
-	3,  // 16: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByMAC:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
-	3,  // 17: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByIP:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
-	3,  // 18: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByID:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
-	3,  // 19: github.com.tinkerbell.tink.protos.hardware.HardwareService.All:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
-	3,  // 20: github.com.tinkerbell.tink.protos.hardware.HardwareService.DeprecatedWatch:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
-	1,  // 21: github.com.tinkerbell.tink.protos.hardware.HardwareService.Delete:output_type -> github.com.tinkerbell.tink.protos.hardware.Empty
-	15, // [15:22] is the sub-list for method output_type
-	8,  // [8:15] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	8,  // 0: github.com.tinkerbell.tink.protos.hardware.PushRequest.data:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	8,  // 1: github.com.tinkerbell.tink.protos.hardware.PushBatchRequest.data:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	15, // 2: github.com.tinkerbell.tink.protos.hardware.PushBatchResponse.results:type_name -> github.com.tinkerbell.tink.protos.hardware.PushBatchResponse.Result
+	18, // 3: github.com.tinkerbell.tink.protos.hardware.Hardware.network:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Network
+	19, // 4: github.com.tinkerbell.tink.protos.hardware.Hardware.labels:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.LabelsEntry
+	25, // 5: github.com.tinkerbell.tink.protos.hardware.Hardware.typed_metadata:type_name -> github.com.tinkerbell.tink.protos.packet.Metadata
+	0,  // 6: github.com.tinkerbell.tink.protos.hardware.Hardware.state:type_name -> github.com.tinkerbell.tink.protos.hardware.State
+	0,  // 7: github.com.tinkerbell.tink.protos.hardware.SetStateRequest.state:type_name -> github.com.tinkerbell.tink.protos.hardware.State
+	1,  // 8: github.com.tinkerbell.tink.protos.hardware.WatchEvent.type:type_name -> github.com.tinkerbell.tink.protos.hardware.WatchEvent.Type
+	8,  // 9: github.com.tinkerbell.tink.protos.hardware.WatchEvent.hardware:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	26, // 10: github.com.tinkerbell.tink.protos.hardware.HardwareRevision.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 11: github.com.tinkerbell.tink.protos.hardware.HardwareRevision.data:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	24, // 12: github.com.tinkerbell.tink.protos.hardware.HardwareRevision.changes:type_name -> github.com.tinkerbell.tink.protos.hardware.HardwareRevision.Change
+	8,  // 13: github.com.tinkerbell.tink.protos.hardware.PatchRequest.data:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	27, // 14: github.com.tinkerbell.tink.protos.hardware.PatchRequest.update_mask:type_name -> google.protobuf.FieldMask
+	20, // 15: github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP.ip:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP.IP
+	21, // 16: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.ipxe:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.IPXE
+	22, // 17: github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.osie:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot.Osie
+	23, // 18: github.com.tinkerbell.tink.protos.hardware.Hardware.Network.interfaces:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Network.Interface
+	16, // 19: github.com.tinkerbell.tink.protos.hardware.Hardware.Network.Interface.dhcp:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.DHCP
+	17, // 20: github.com.tinkerbell.tink.protos.hardware.Hardware.Network.Interface.netboot:type_name -> github.com.tinkerbell.tink.protos.hardware.Hardware.Netboot
+	2,  // 21: github.com.tinkerbell.tink.protos.hardware.HardwareService.Push:input_type -> github.com.tinkerbell.tink.protos.hardware.PushRequest
+	3,  // 22: github.com.tinkerbell.tink.protos.hardware.HardwareService.PushBatch:input_type -> github.com.tinkerbell.tink.protos.hardware.PushBatchRequest
+	6,  // 23: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByMAC:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
+	6,  // 24: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByIP:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
+	6,  // 25: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByID:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
+	7,  // 26: github.com.tinkerbell.tink.protos.hardware.HardwareService.All:input_type -> github.com.tinkerbell.tink.protos.hardware.ListRequest
+	7,  // 27: github.com.tinkerbell.tink.protos.hardware.HardwareService.List:input_type -> github.com.tinkerbell.tink.protos.hardware.ListRequest
+	11, // 28: github.com.tinkerbell.tink.protos.hardware.HardwareService.Watch:input_type -> github.com.tinkerbell.tink.protos.hardware.WatchRequest
+	6,  // 29: github.com.tinkerbell.tink.protos.hardware.HardwareService.DeprecatedWatch:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
+	14, // 30: github.com.tinkerbell.tink.protos.hardware.HardwareService.Patch:input_type -> github.com.tinkerbell.tink.protos.hardware.PatchRequest
+	6,  // 31: github.com.tinkerbell.tink.protos.hardware.HardwareService.History:input_type -> github.com.tinkerbell.tink.protos.hardware.GetRequest
+	10, // 32: github.com.tinkerbell.tink.protos.hardware.HardwareService.SetState:input_type -> github.com.tinkerbell.tink.protos.hardware.SetStateRequest
+	9,  // 33: github.com.tinkerbell.tink.protos.hardware.HardwareService.Delete:input_type -> github.com.tinkerbell.tink.protos.hardware.DeleteRequest
+	5,  // 34: github.com.tinkerbell.tink.protos.hardware.HardwareService.Push:output_type -> github.com.tinkerbell.tink.protos.hardware.Empty
+	4,  // 35: github.com.tinkerbell.tink.protos.hardware.HardwareService.PushBatch:output_type -> github.com.tinkerbell.tink.protos.hardware.PushBatchResponse
+	8,  // 36: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByMAC:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	8,  // 37: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByIP:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	8,  // 38: github.com.tinkerbell.tink.protos.hardware.HardwareService.ByID:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	8,  // 39: github.com.tinkerbell.tink.protos.hardware.HardwareService.All:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	8,  // 40: github.com.tinkerbell.tink.protos.hardware.HardwareService.List:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	12, // 41: github.com.tinkerbell.tink.protos.hardware.HardwareService.Watch:output_type -> github.com.tinkerbell.tink.protos.hardware.WatchEvent
+	8,  // 42: github.com.tinkerbell.tink.protos.hardware.HardwareService.DeprecatedWatch:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	8,  // 43: github.com.tinkerbell.tink.protos.hardware.HardwareService.Patch:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	13, // 44: github.com.tinkerbell.tink.protos.hardware.HardwareService.History:output_type -> github.com.tinkerbell.tink.protos.hardware.HardwareRevision
+	8,  // 45: github.com.tinkerbell.tink.protos.hardware.HardwareService.SetState:output_type -> github.com.tinkerbell.tink.protos.hardware.Hardware
+	5,  // 46: github.com.tinkerbell.tink.protos.hardware.HardwareService.Delete:output_type -> github.com.tinkerbell.tink.protos.hardware.Empty
+	34, // [34:47] is the sub-list for method output_type
+	21, // [21:34] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_hardware_hardware_proto_init() }
@@ -1110,8 +2384,92 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+		file_hardware_hardware_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hardware); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetStateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1122,8 +2480,8 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRequest); i {
+		file_hardware_hardware_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1134,8 +2492,8 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Hardware); i {
+		file_hardware_hardware_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1146,8 +2504,8 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteRequest); i {
+		file_hardware_hardware_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HardwareRevision); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1158,7 +2516,31 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_hardware_hardware_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushBatchResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hardware_hardware_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Hardware_DHCP); i {
 			case 0:
 				return &v.state
@@ -1170,7 +2552,7 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_hardware_hardware_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Hardware_Netboot); i {
 			case 0:
 				return &v.state
@@ -1182,7 +2564,7 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_hardware_hardware_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Hardware_Network); i {
 			case 0:
 				return &v.state
@@ -1194,7 +2576,7 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_hardware_hardware_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Hardware_DHCP_IP); i {
 			case 0:
 				return &v.state
@@ -1206,7 +2588,7 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_hardware_hardware_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Hardware_Netboot_IPXE); i {
 			case 0:
 				return &v.state
@@ -1218,7 +2600,7 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_hardware_hardware_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Hardware_Netboot_Osie); i {
 			case 0:
 				return &v.state
@@ -1230,7 +2612,7 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
-		file_hardware_hardware_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_hardware_hardware_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Hardware_Network_Interface); i {
 			case 0:
 				return &v.state
@@ -1242,19 +2624,32 @@ func file_hardware_hardware_proto_init() {
 				return nil
 			}
 		}
+		file_hardware_hardware_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HardwareRevision_Change); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_hardware_hardware_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_hardware_hardware_proto_goTypes,
 		DependencyIndexes: file_hardware_hardware_proto_depIdxs,
+		EnumInfos:         file_hardware_hardware_proto_enumTypes,
 		MessageInfos:      file_hardware_hardware_proto_msgTypes,
 	}.Build()
 	File_hardware_hardware_proto = out.File
@@ -1275,20 +2670,47 @@ const _ = grpc.SupportPackageIsVersion6
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type HardwareServiceClient interface {
-	// Push adds a new Hardware profile to the data store.
+	// Push adds a new Hardware profile to the data store. A Hardware already
+	// stored has to be pushed with the version it has, otherwise Push fails
+	// with an Aborted error, or a FailedPrecondition one when the version is
+	// missing. The force field skips the check.
 	Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*Empty, error)
+	// PushBatch validates and adds many Hardware profiles at once. They are
+	// written in a single transaction: when one of them is not valid nothing
+	// gets written, and the response tells what is wrong with every item.
+	PushBatch(ctx context.Context, in *PushBatchRequest, opts ...grpc.CallOption) (*PushBatchResponse, error)
 	// ByMac returns the Hardware with the given hardware MAC Address.
 	ByMAC(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Hardware, error)
 	// ByIP returns the Hardware with the given IP Address.
 	ByIP(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Hardware, error)
 	// ByID returns the Hardware with the given ID.
 	ByID(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Hardware, error)
-	// All returns all of the Hardware profiles.
-	All(ctx context.Context, in *Empty, opts ...grpc.CallOption) (HardwareService_AllClient, error)
-	// DeprecatedWatch watches for events on the given hardware and streams the matching Hardware.
-	// We would like to keep Watch as the function used to stream events,
-	// and ideally Watch should be more powerful than the DeprecatedWatch
+	// All returns all of the Hardware profiles. The request can be used to
+	// page through them, the token of the following page is sent in the
+	// next-page-token trailer.
+	All(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_AllClient, error)
+	// List returns the Hardware profiles matching the given label selector and
+	// filters.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_ListClient, error)
+	// Watch streams the creations, updates and deletions of the Hardware
+	// matching the request. Every event carries the revision of the change: a
+	// client resumes a broken stream from the last revision it received
+	// without missing any change.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HardwareService_WatchClient, error)
+	// DeprecatedWatch streams the Hardware with the given ID every time it
+	// changes. It is kept for the existing clients, use Watch instead.
 	DeprecatedWatch(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_DeprecatedWatchClient, error)
+	// Patch applies a partial update to the Hardware with the given ID and it
+	// returns the result. The update is described either as a JSON merge patch
+	// or as a field mask selecting the fields to copy from the given Hardware.
+	Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*Hardware, error)
+	// History returns the revisions of the Hardware with the given ID, oldest
+	// first. Every write and deletion of the Hardware adds a revision.
+	History(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_HistoryClient, error)
+	// SetState moves the Hardware with the given ID to a new lifecycle state.
+	// It fails with a FailedPrecondition error when the transition is not
+	// allowed from the current state, unless it is forced.
+	SetState(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*Hardware, error)
 	// Delete deletes the given hardware from the data store.
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
 }
@@ -1310,6 +2732,15 @@ func (c *hardwareServiceClient) Push(ctx context.Context, in *PushRequest, opts
 	return out, nil
 }
 
+func (c *hardwareServiceClient) PushBatch(ctx context.Context, in *PushBatchRequest, opts ...grpc.CallOption) (*PushBatchResponse, error) {
+	out := new(PushBatchResponse)
+	err := c.cc.Invoke(ctx, "/github.com.tinkerbell.tink.protos.hardware.HardwareService/PushBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hardwareServiceClient) ByMAC(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Hardware, error) {
 	out := new(Hardware)
 	err := c.cc.Invoke(ctx, "/github.com.tinkerbell.tink.protos.hardware.HardwareService/ByMAC", in, out, opts...)
@@ -1337,7 +2768,7 @@ func (c *hardwareServiceClient) ByID(ctx context.Context, in *GetRequest, opts .
 	return out, nil
 }
 
-func (c *hardwareServiceClient) All(ctx context.Context, in *Empty, opts ...grpc.CallOption) (HardwareService_AllClient, error) {
+func (c *hardwareServiceClient) All(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_AllClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_HardwareService_serviceDesc.Streams[0], "/github.com.tinkerbell.tink.protos.hardware.HardwareService/All", opts...)
 	if err != nil {
 		return nil, err
@@ -1369,8 +2800,72 @@ func (x *hardwareServiceAllClient) Recv() (*Hardware, error) {
 	return m, nil
 }
 
+func (c *hardwareServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_HardwareService_serviceDesc.Streams[1], "/github.com.tinkerbell.tink.protos.hardware.HardwareService/List", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hardwareServiceListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HardwareService_ListClient interface {
+	Recv() (*Hardware, error)
+	grpc.ClientStream
+}
+
+type hardwareServiceListClient struct {
+	grpc.ClientStream
+}
+
+func (x *hardwareServiceListClient) Recv() (*Hardware, error) {
+	m := new(Hardware)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hardwareServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HardwareService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_HardwareService_serviceDesc.Streams[2], "/github.com.tinkerbell.tink.protos.hardware.HardwareService/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hardwareServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HardwareService_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type hardwareServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *hardwareServiceWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *hardwareServiceClient) DeprecatedWatch(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_DeprecatedWatchClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_HardwareService_serviceDesc.Streams[1], "/github.com.tinkerbell.tink.protos.hardware.HardwareService/DeprecatedWatch", opts...)
+	stream, err := c.cc.NewStream(ctx, &_HardwareService_serviceDesc.Streams[3], "/github.com.tinkerbell.tink.protos.hardware.HardwareService/DeprecatedWatch", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1401,6 +2896,56 @@ func (x *hardwareServiceDeprecatedWatchClient) Recv() (*Hardware, error) {
 	return m, nil
 }
 
+func (c *hardwareServiceClient) Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*Hardware, error) {
+	out := new(Hardware)
+	err := c.cc.Invoke(ctx, "/github.com.tinkerbell.tink.protos.hardware.HardwareService/Patch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hardwareServiceClient) History(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_HistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_HardwareService_serviceDesc.Streams[4], "/github.com.tinkerbell.tink.protos.hardware.HardwareService/History", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hardwareServiceHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HardwareService_HistoryClient interface {
+	Recv() (*HardwareRevision, error)
+	grpc.ClientStream
+}
+
+type hardwareServiceHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *hardwareServiceHistoryClient) Recv() (*HardwareRevision, error) {
+	m := new(HardwareRevision)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hardwareServiceClient) SetState(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*Hardware, error) {
+	out := new(Hardware)
+	err := c.cc.Invoke(ctx, "/github.com.tinkerbell.tink.protos.hardware.HardwareService/SetState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *hardwareServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/github.com.tinkerbell.tink.protos.hardware.HardwareService/Delete", in, out, opts...)
@@ -1412,20 +2957,47 @@ func (c *hardwareServiceClient) Delete(ctx context.Context, in *DeleteRequest, o
 
 // HardwareServiceServer is the server API for HardwareService service.
 type HardwareServiceServer interface {
-	// Push adds a new Hardware profile to the data store.
+	// Push adds a new Hardware profile to the data store. A Hardware already
+	// stored has to be pushed with the version it has, otherwise Push fails
+	// with an Aborted error, or a FailedPrecondition one when the version is
+	// missing. The force field skips the check.
 	Push(context.Context, *PushRequest) (*Empty, error)
+	// PushBatch validates and adds many Hardware profiles at once. They are
+	// written in a single transaction: when one of them is not valid nothing
+	// gets written, and the response tells what is wrong with every item.
+	PushBatch(context.Context, *PushBatchRequest) (*PushBatchResponse, error)
 	// ByMac returns the Hardware with the given hardware MAC Address.
 	ByMAC(context.Context, *GetRequest) (*Hardware, error)
 	// ByIP returns the Hardware with the given IP Address.
 	ByIP(context.Context, *GetRequest) (*Hardware, error)
 	// ByID returns the Hardware with the given ID.
 	ByID(context.Context, *GetRequest) (*Hardware, error)
-	// All returns all of the Hardware profiles.
-	All(*Empty, HardwareService_AllServer) error
-	// DeprecatedWatch watches for events on the given hardware and streams the matching Hardware.
-	// We would like to keep Watch as the function used to stream events,
-	// and ideally Watch should be more powerful than the DeprecatedWatch
+	// All returns all of the Hardware profiles. The request can be used to
+	// page through them, the token of the following page is sent in the
+	// next-page-token trailer.
+	All(*ListRequest, HardwareService_AllServer) error
+	// List returns the Hardware profiles matching the given label selector and
+	// filters.
+	List(*ListRequest, HardwareService_ListServer) error
+	// Watch streams the creations, updates and deletions of the Hardware
+	// matching the request. Every event carries the revision of the change: a
+	// client resumes a broken stream from the last revision it received
+	// without missing any change.
+	Watch(*WatchRequest, HardwareService_WatchServer) error
+	// DeprecatedWatch streams the Hardware with the given ID every time it
+	// changes. It is kept for the existing clients, use Watch instead.
 	DeprecatedWatch(*GetRequest, HardwareService_DeprecatedWatchServer) error
+	// Patch applies a partial update to the Hardware with the given ID and it
+	// returns the result. The update is described either as a JSON merge patch
+	// or as a field mask selecting the fields to copy from the given Hardware.
+	Patch(context.Context, *PatchRequest) (*Hardware, error)
+	// History returns the revisions of the Hardware with the given ID, oldest
+	// first. Every write and deletion of the Hardware adds a revision.
+	History(*GetRequest, HardwareService_HistoryServer) error
+	// SetState moves the Hardware with the given ID to a new lifecycle state.
+	// It fails with a FailedPrecondition error when the transition is not
+	// allowed from the current state, unless it is forced.
+	SetState(context.Context, *SetStateRequest) (*Hardware, error)
 	// Delete deletes the given hardware from the data store.
 	Delete(context.Context, *DeleteRequest) (*Empty, error)
 }
@@ -1437,6 +3009,9 @@ type UnimplementedHardwareServiceServer struct {
 func (*UnimplementedHardwareServiceServer) Push(context.Context, *PushRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
 }
+func (*UnimplementedHardwareServiceServer) PushBatch(context.Context, *PushBatchRequest) (*PushBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushBatch not implemented")
+}
 func (*UnimplementedHardwareServiceServer) ByMAC(context.Context, *GetRequest) (*Hardware, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ByMAC not implemented")
 }
@@ -1446,12 +3021,27 @@ func (*UnimplementedHardwareServiceServer) ByIP(context.Context, *GetRequest) (*
 func (*UnimplementedHardwareServiceServer) ByID(context.Context, *GetRequest) (*Hardware, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ByID not implemented")
 }
-func (*UnimplementedHardwareServiceServer) All(*Empty, HardwareService_AllServer) error {
+func (*UnimplementedHardwareServiceServer) All(*ListRequest, HardwareService_AllServer) error {
 	return status.Errorf(codes.Unimplemented, "method All not implemented")
 }
+func (*UnimplementedHardwareServiceServer) List(*ListRequest, HardwareService_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedHardwareServiceServer) Watch(*WatchRequest, HardwareService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (*UnimplementedHardwareServiceServer) DeprecatedWatch(*GetRequest, HardwareService_DeprecatedWatchServer) error {
 	return status.Errorf(codes.Unimplemented, "method DeprecatedWatch not implemented")
 }
+func (*UnimplementedHardwareServiceServer) Patch(context.Context, *PatchRequest) (*Hardware, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Patch not implemented")
+}
+func (*UnimplementedHardwareServiceServer) History(*GetRequest, HardwareService_HistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (*UnimplementedHardwareServiceServer) SetState(context.Context, *SetStateRequest) (*Hardware, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetState not implemented")
+}
 func (*UnimplementedHardwareServiceServer) Delete(context.Context, *DeleteRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
@@ -1478,6 +3068,24 @@ func _HardwareService_Push_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HardwareService_PushBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HardwareServiceServer).PushBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/github.com.tinkerbell.tink.protos.hardware.HardwareService/PushBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HardwareServiceServer).PushBatch(ctx, req.(*PushBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _HardwareService_ByMAC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRequest)
 	if err := dec(in); err != nil {
@@ -1533,7 +3141,7 @@ func _HardwareService_ByID_Handler(srv interface{}, ctx context.Context, dec fun
 }
 
 func _HardwareService_All_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+	m := new(ListRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -1553,6 +3161,48 @@ func (x *hardwareServiceAllServer) Send(m *Hardware) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _HardwareService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HardwareServiceServer).List(m, &hardwareServiceListServer{stream})
+}
+
+type HardwareService_ListServer interface {
+	Send(*Hardware) error
+	grpc.ServerStream
+}
+
+type hardwareServiceListServer struct {
+	grpc.ServerStream
+}
+
+func (x *hardwareServiceListServer) Send(m *Hardware) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _HardwareService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HardwareServiceServer).Watch(m, &hardwareServiceWatchServer{stream})
+}
+
+type HardwareService_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type hardwareServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *hardwareServiceWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _HardwareService_DeprecatedWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1574,6 +3224,63 @@ func (x *hardwareServiceDeprecatedWatchServer) Send(m *Hardware) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _HardwareService_Patch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HardwareServiceServer).Patch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/github.com.tinkerbell.tink.protos.hardware.HardwareService/Patch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HardwareServiceServer).Patch(ctx, req.(*PatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HardwareService_History_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HardwareServiceServer).History(m, &hardwareServiceHistoryServer{stream})
+}
+
+type HardwareService_HistoryServer interface {
+	Send(*HardwareRevision) error
+	grpc.ServerStream
+}
+
+type hardwareServiceHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *hardwareServiceHistoryServer) Send(m *HardwareRevision) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _HardwareService_SetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HardwareServiceServer).SetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/github.com.tinkerbell.tink.protos.hardware.HardwareService/SetState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HardwareServiceServer).SetState(ctx, req.(*SetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _HardwareService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -1600,6 +3307,10 @@ var _HardwareService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Push",
 			Handler:    _HardwareService_Push_Handler,
 		},
+		{
+			MethodName: "PushBatch",
+			Handler:    _HardwareService_PushBatch_Handler,
+		},
 		{
 			MethodName: "ByMAC",
 			Handler:    _HardwareService_ByMAC_Handler,
@@ -1612,6 +3323,14 @@ var _HardwareService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ByID",
 			Handler:    _HardwareService_ByID_Handler,
 		},
+		{
+			MethodName: "Patch",
+			Handler:    _HardwareService_Patch_Handler,
+		},
+		{
+			MethodName: "SetState",
+			Handler:    _HardwareService_SetState_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _HardwareService_Delete_Handler,
@@ -1623,11 +3342,26 @@ var _HardwareService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _HardwareService_All_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "List",
+			Handler:       _HardwareService_List_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _HardwareService_Watch_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "DeprecatedWatch",
 			Handler:       _HardwareService_DeprecatedWatch_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "History",
+			Handler:       _HardwareService_History_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hardware/hardware.proto",
 }
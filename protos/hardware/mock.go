@@ -21,7 +21,7 @@ var _ HardwareServiceClient = &HardwareServiceClientMock{}
 //
 //         // make and configure a mocked HardwareServiceClient
 //         mockedHardwareServiceClient := &HardwareServiceClientMock{
-//             AllFunc: func(ctx context.Context, in *Empty, opts ...grpc.CallOption) (HardwareService_AllClient, error) {
+//             AllFunc: func(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_AllClient, error) {
 // 	               panic("mock out the All method")
 //             },
 //             ByIDFunc: func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Hardware, error) {
@@ -39,9 +39,27 @@ var _ HardwareServiceClient = &HardwareServiceClientMock{}
 //             DeprecatedWatchFunc: func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_DeprecatedWatchClient, error) {
 // 	               panic("mock out the DeprecatedWatch method")
 //             },
+//             HistoryFunc: func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_HistoryClient, error) {
+// 	               panic("mock out the History method")
+//             },
+//             ListFunc: func(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_ListClient, error) {
+// 	               panic("mock out the List method")
+//             },
+//             PatchFunc: func(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*Hardware, error) {
+// 	               panic("mock out the Patch method")
+//             },
 //             PushFunc: func(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*Empty, error) {
 // 	               panic("mock out the Push method")
 //             },
+//             PushBatchFunc: func(ctx context.Context, in *PushBatchRequest, opts ...grpc.CallOption) (*PushBatchResponse, error) {
+// 	               panic("mock out the PushBatch method")
+//             },
+//             SetStateFunc: func(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*Hardware, error) {
+// 	               panic("mock out the SetState method")
+//             },
+//             WatchFunc: func(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HardwareService_WatchClient, error) {
+// 	               panic("mock out the Watch method")
+//             },
 //         }
 //
 //         // use mockedHardwareServiceClient in code that requires HardwareServiceClient
@@ -50,7 +68,7 @@ var _ HardwareServiceClient = &HardwareServiceClientMock{}
 //     }
 type HardwareServiceClientMock struct {
 	// AllFunc mocks the All method.
-	AllFunc func(ctx context.Context, in *Empty, opts ...grpc.CallOption) (HardwareService_AllClient, error)
+	AllFunc func(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_AllClient, error)
 
 	// ByIDFunc mocks the ByID method.
 	ByIDFunc func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Hardware, error)
@@ -67,9 +85,27 @@ type HardwareServiceClientMock struct {
 	// DeprecatedWatchFunc mocks the DeprecatedWatch method.
 	DeprecatedWatchFunc func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_DeprecatedWatchClient, error)
 
+	// HistoryFunc mocks the History method.
+	HistoryFunc func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_HistoryClient, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_ListClient, error)
+
+	// PatchFunc mocks the Patch method.
+	PatchFunc func(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*Hardware, error)
+
 	// PushFunc mocks the Push method.
 	PushFunc func(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*Empty, error)
 
+	// PushBatchFunc mocks the PushBatch method.
+	PushBatchFunc func(ctx context.Context, in *PushBatchRequest, opts ...grpc.CallOption) (*PushBatchResponse, error)
+
+	// SetStateFunc mocks the SetState method.
+	SetStateFunc func(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*Hardware, error)
+
+	// WatchFunc mocks the Watch method.
+	WatchFunc func(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HardwareService_WatchClient, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// All holds details about calls to the All method.
@@ -77,7 +113,7 @@ type HardwareServiceClientMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
-			In *Empty
+			In *ListRequest
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
@@ -126,6 +162,33 @@ type HardwareServiceClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// History holds details about calls to the History method.
+		History []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *GetRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *ListRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Patch holds details about calls to the Patch method.
+		Patch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *PatchRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 		// Push holds details about calls to the Push method.
 		Push []struct {
 			// Ctx is the ctx argument value.
@@ -135,6 +198,33 @@ type HardwareServiceClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// PushBatch holds details about calls to the PushBatch method.
+		PushBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *PushBatchRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// SetState holds details about calls to the SetState method.
+		SetState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *SetStateRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Watch holds details about calls to the Watch method.
+		Watch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *WatchRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 	}
 	lockAll             sync.RWMutex
 	lockByID            sync.RWMutex
@@ -142,17 +232,23 @@ type HardwareServiceClientMock struct {
 	lockByMAC           sync.RWMutex
 	lockDelete          sync.RWMutex
 	lockDeprecatedWatch sync.RWMutex
+	lockHistory         sync.RWMutex
+	lockList            sync.RWMutex
+	lockPatch           sync.RWMutex
 	lockPush            sync.RWMutex
+	lockPushBatch       sync.RWMutex
+	lockSetState        sync.RWMutex
+	lockWatch           sync.RWMutex
 }
 
 // All calls AllFunc.
-func (mock *HardwareServiceClientMock) All(ctx context.Context, in *Empty, opts ...grpc.CallOption) (HardwareService_AllClient, error) {
+func (mock *HardwareServiceClientMock) All(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_AllClient, error) {
 	if mock.AllFunc == nil {
 		panic("HardwareServiceClientMock.AllFunc: method is nil but HardwareServiceClient.All was just called")
 	}
 	callInfo := struct {
 		Ctx  context.Context
-		In   *Empty
+		In   *ListRequest
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
@@ -170,12 +266,12 @@ func (mock *HardwareServiceClientMock) All(ctx context.Context, in *Empty, opts
 //     len(mockedHardwareServiceClient.AllCalls())
 func (mock *HardwareServiceClientMock) AllCalls() []struct {
 	Ctx  context.Context
-	In   *Empty
+	In   *ListRequest
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *Empty
+		In   *ListRequest
 		Opts []grpc.CallOption
 	}
 	mock.lockAll.RLock()
@@ -379,6 +475,123 @@ func (mock *HardwareServiceClientMock) DeprecatedWatchCalls() []struct {
 	return calls
 }
 
+// History calls HistoryFunc.
+func (mock *HardwareServiceClientMock) History(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (HardwareService_HistoryClient, error) {
+	if mock.HistoryFunc == nil {
+		panic("HardwareServiceClientMock.HistoryFunc: method is nil but HardwareServiceClient.History was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *GetRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockHistory.Lock()
+	mock.calls.History = append(mock.calls.History, callInfo)
+	mock.lockHistory.Unlock()
+	return mock.HistoryFunc(ctx, in, opts...)
+}
+
+// HistoryCalls gets all the calls that were made to History.
+// Check the length with:
+//     len(mockedHardwareServiceClient.HistoryCalls())
+func (mock *HardwareServiceClientMock) HistoryCalls() []struct {
+	Ctx  context.Context
+	In   *GetRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *GetRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockHistory.RLock()
+	calls = mock.calls.History
+	mock.lockHistory.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *HardwareServiceClientMock) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (HardwareService_ListClient, error) {
+	if mock.ListFunc == nil {
+		panic("HardwareServiceClientMock.ListFunc: method is nil but HardwareServiceClient.List was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *ListRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, in, opts...)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//     len(mockedHardwareServiceClient.ListCalls())
+func (mock *HardwareServiceClientMock) ListCalls() []struct {
+	Ctx  context.Context
+	In   *ListRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *ListRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Patch calls PatchFunc.
+func (mock *HardwareServiceClientMock) Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*Hardware, error) {
+	if mock.PatchFunc == nil {
+		panic("HardwareServiceClientMock.PatchFunc: method is nil but HardwareServiceClient.Patch was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *PatchRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockPatch.Lock()
+	mock.calls.Patch = append(mock.calls.Patch, callInfo)
+	mock.lockPatch.Unlock()
+	return mock.PatchFunc(ctx, in, opts...)
+}
+
+// PatchCalls gets all the calls that were made to Patch.
+// Check the length with:
+//     len(mockedHardwareServiceClient.PatchCalls())
+func (mock *HardwareServiceClientMock) PatchCalls() []struct {
+	Ctx  context.Context
+	In   *PatchRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *PatchRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockPatch.RLock()
+	calls = mock.calls.Patch
+	mock.lockPatch.RUnlock()
+	return calls
+}
+
 // Push calls PushFunc.
 func (mock *HardwareServiceClientMock) Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*Empty, error) {
 	if mock.PushFunc == nil {
@@ -418,6 +631,123 @@ func (mock *HardwareServiceClientMock) PushCalls() []struct {
 	return calls
 }
 
+// PushBatch calls PushBatchFunc.
+func (mock *HardwareServiceClientMock) PushBatch(ctx context.Context, in *PushBatchRequest, opts ...grpc.CallOption) (*PushBatchResponse, error) {
+	if mock.PushBatchFunc == nil {
+		panic("HardwareServiceClientMock.PushBatchFunc: method is nil but HardwareServiceClient.PushBatch was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *PushBatchRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockPushBatch.Lock()
+	mock.calls.PushBatch = append(mock.calls.PushBatch, callInfo)
+	mock.lockPushBatch.Unlock()
+	return mock.PushBatchFunc(ctx, in, opts...)
+}
+
+// PushBatchCalls gets all the calls that were made to PushBatch.
+// Check the length with:
+//     len(mockedHardwareServiceClient.PushBatchCalls())
+func (mock *HardwareServiceClientMock) PushBatchCalls() []struct {
+	Ctx  context.Context
+	In   *PushBatchRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *PushBatchRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockPushBatch.RLock()
+	calls = mock.calls.PushBatch
+	mock.lockPushBatch.RUnlock()
+	return calls
+}
+
+// SetState calls SetStateFunc.
+func (mock *HardwareServiceClientMock) SetState(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*Hardware, error) {
+	if mock.SetStateFunc == nil {
+		panic("HardwareServiceClientMock.SetStateFunc: method is nil but HardwareServiceClient.SetState was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *SetStateRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockSetState.Lock()
+	mock.calls.SetState = append(mock.calls.SetState, callInfo)
+	mock.lockSetState.Unlock()
+	return mock.SetStateFunc(ctx, in, opts...)
+}
+
+// SetStateCalls gets all the calls that were made to SetState.
+// Check the length with:
+//     len(mockedHardwareServiceClient.SetStateCalls())
+func (mock *HardwareServiceClientMock) SetStateCalls() []struct {
+	Ctx  context.Context
+	In   *SetStateRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *SetStateRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockSetState.RLock()
+	calls = mock.calls.SetState
+	mock.lockSetState.RUnlock()
+	return calls
+}
+
+// Watch calls WatchFunc.
+func (mock *HardwareServiceClientMock) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HardwareService_WatchClient, error) {
+	if mock.WatchFunc == nil {
+		panic("HardwareServiceClientMock.WatchFunc: method is nil but HardwareServiceClient.Watch was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *WatchRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockWatch.Lock()
+	mock.calls.Watch = append(mock.calls.Watch, callInfo)
+	mock.lockWatch.Unlock()
+	return mock.WatchFunc(ctx, in, opts...)
+}
+
+// WatchCalls gets all the calls that were made to Watch.
+// Check the length with:
+//     len(mockedHardwareServiceClient.WatchCalls())
+func (mock *HardwareServiceClientMock) WatchCalls() []struct {
+	Ctx  context.Context
+	In   *WatchRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *WatchRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockWatch.RLock()
+	calls = mock.calls.Watch
+	mock.lockWatch.RUnlock()
+	return calls
+}
+
 // Ensure, that HardwareService_AllClientMock does implement HardwareService_AllClient.
 // If this is not the case, regenerate this file with moq.
 var _ HardwareService_AllClient = &HardwareService_AllClientMock{}
@@ -0,0 +1,3 @@
+package webhook
+
+//go:generate moq -out mock.go . WebhookServiceClient WebhookService_ListWebhooksClient WebhookService_ListFailedDeliveriesClient
@@ -0,0 +1,830 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Ensure, that WebhookServiceClientMock does implement WebhookServiceClient.
+// If this is not the case, regenerate this file with moq.
+var _ WebhookServiceClient = &WebhookServiceClientMock{}
+
+// WebhookServiceClientMock is a mock implementation of WebhookServiceClient.
+//
+//     func TestSomethingThatUsesWebhookServiceClient(t *testing.T) {
+//
+//         // make and configure a mocked WebhookServiceClient
+//         mockedWebhookServiceClient := &WebhookServiceClientMock{
+//             CreateWebhookFunc: func(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+// 	               panic("mock out the CreateWebhook method")
+//             },
+//             DeleteWebhookFunc: func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Empty, error) {
+// 	               panic("mock out the DeleteWebhook method")
+//             },
+//             ListFailedDeliveriesFunc: func(ctx context.Context, in *ListFailedDeliveriesRequest, opts ...grpc.CallOption) (WebhookService_ListFailedDeliveriesClient, error) {
+// 	               panic("mock out the ListFailedDeliveries method")
+//             },
+//             ListWebhooksFunc: func(ctx context.Context, in *Empty, opts ...grpc.CallOption) (WebhookService_ListWebhooksClient, error) {
+// 	               panic("mock out the ListWebhooks method")
+//             },
+//         }
+//
+//         // use mockedWebhookServiceClient in code that requires WebhookServiceClient
+//         // and then make assertions.
+//
+//     }
+type WebhookServiceClientMock struct {
+	// CreateWebhookFunc mocks the CreateWebhook method.
+	CreateWebhookFunc func(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+
+	// DeleteWebhookFunc mocks the DeleteWebhook method.
+	DeleteWebhookFunc func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Empty, error)
+
+	// ListFailedDeliveriesFunc mocks the ListFailedDeliveries method.
+	ListFailedDeliveriesFunc func(ctx context.Context, in *ListFailedDeliveriesRequest, opts ...grpc.CallOption) (WebhookService_ListFailedDeliveriesClient, error)
+
+	// ListWebhooksFunc mocks the ListWebhooks method.
+	ListWebhooksFunc func(ctx context.Context, in *Empty, opts ...grpc.CallOption) (WebhookService_ListWebhooksClient, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateWebhook holds details about calls to the CreateWebhook method.
+		CreateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *CreateRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// DeleteWebhook holds details about calls to the DeleteWebhook method.
+		DeleteWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *GetRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// ListFailedDeliveries holds details about calls to the ListFailedDeliveries method.
+		ListFailedDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *ListFailedDeliveriesRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// ListWebhooks holds details about calls to the ListWebhooks method.
+		ListWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockCreateWebhook        sync.RWMutex
+	lockDeleteWebhook        sync.RWMutex
+	lockListFailedDeliveries sync.RWMutex
+	lockListWebhooks         sync.RWMutex
+}
+
+// CreateWebhook calls CreateWebhookFunc.
+func (mock *WebhookServiceClientMock) CreateWebhook(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	if mock.CreateWebhookFunc == nil {
+		panic("WebhookServiceClientMock.CreateWebhookFunc: method is nil but WebhookServiceClient.CreateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *CreateRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockCreateWebhook.Lock()
+	mock.calls.CreateWebhook = append(mock.calls.CreateWebhook, callInfo)
+	mock.lockCreateWebhook.Unlock()
+	return mock.CreateWebhookFunc(ctx, in, opts...)
+}
+
+// CreateWebhookCalls gets all the calls that were made to CreateWebhook.
+// Check the length with:
+//     len(mockedWebhookServiceClient.CreateWebhookCalls())
+func (mock *WebhookServiceClientMock) CreateWebhookCalls() []struct {
+	Ctx  context.Context
+	In   *CreateRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *CreateRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockCreateWebhook.RLock()
+	calls = mock.calls.CreateWebhook
+	mock.lockCreateWebhook.RUnlock()
+	return calls
+}
+
+// DeleteWebhook calls DeleteWebhookFunc.
+func (mock *WebhookServiceClientMock) DeleteWebhook(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Empty, error) {
+	if mock.DeleteWebhookFunc == nil {
+		panic("WebhookServiceClientMock.DeleteWebhookFunc: method is nil but WebhookServiceClient.DeleteWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *GetRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockDeleteWebhook.Lock()
+	mock.calls.DeleteWebhook = append(mock.calls.DeleteWebhook, callInfo)
+	mock.lockDeleteWebhook.Unlock()
+	return mock.DeleteWebhookFunc(ctx, in, opts...)
+}
+
+// DeleteWebhookCalls gets all the calls that were made to DeleteWebhook.
+// Check the length with:
+//     len(mockedWebhookServiceClient.DeleteWebhookCalls())
+func (mock *WebhookServiceClientMock) DeleteWebhookCalls() []struct {
+	Ctx  context.Context
+	In   *GetRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *GetRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockDeleteWebhook.RLock()
+	calls = mock.calls.DeleteWebhook
+	mock.lockDeleteWebhook.RUnlock()
+	return calls
+}
+
+// ListFailedDeliveries calls ListFailedDeliveriesFunc.
+func (mock *WebhookServiceClientMock) ListFailedDeliveries(ctx context.Context, in *ListFailedDeliveriesRequest, opts ...grpc.CallOption) (WebhookService_ListFailedDeliveriesClient, error) {
+	if mock.ListFailedDeliveriesFunc == nil {
+		panic("WebhookServiceClientMock.ListFailedDeliveriesFunc: method is nil but WebhookServiceClient.ListFailedDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *ListFailedDeliveriesRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockListFailedDeliveries.Lock()
+	mock.calls.ListFailedDeliveries = append(mock.calls.ListFailedDeliveries, callInfo)
+	mock.lockListFailedDeliveries.Unlock()
+	return mock.ListFailedDeliveriesFunc(ctx, in, opts...)
+}
+
+// ListFailedDeliveriesCalls gets all the calls that were made to ListFailedDeliveries.
+// Check the length with:
+//     len(mockedWebhookServiceClient.ListFailedDeliveriesCalls())
+func (mock *WebhookServiceClientMock) ListFailedDeliveriesCalls() []struct {
+	Ctx  context.Context
+	In   *ListFailedDeliveriesRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *ListFailedDeliveriesRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockListFailedDeliveries.RLock()
+	calls = mock.calls.ListFailedDeliveries
+	mock.lockListFailedDeliveries.RUnlock()
+	return calls
+}
+
+// ListWebhooks calls ListWebhooksFunc.
+func (mock *WebhookServiceClientMock) ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (WebhookService_ListWebhooksClient, error) {
+	if mock.ListWebhooksFunc == nil {
+		panic("WebhookServiceClientMock.ListWebhooksFunc: method is nil but WebhookServiceClient.ListWebhooks was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *Empty
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockListWebhooks.Lock()
+	mock.calls.ListWebhooks = append(mock.calls.ListWebhooks, callInfo)
+	mock.lockListWebhooks.Unlock()
+	return mock.ListWebhooksFunc(ctx, in, opts...)
+}
+
+// ListWebhooksCalls gets all the calls that were made to ListWebhooks.
+// Check the length with:
+//     len(mockedWebhookServiceClient.ListWebhooksCalls())
+func (mock *WebhookServiceClientMock) ListWebhooksCalls() []struct {
+	Ctx  context.Context
+	In   *Empty
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *Empty
+		Opts []grpc.CallOption
+	}
+	mock.lockListWebhooks.RLock()
+	calls = mock.calls.ListWebhooks
+	mock.lockListWebhooks.RUnlock()
+	return calls
+}
+
+// Ensure, that WebhookService_ListWebhooksClientMock does implement WebhookService_ListWebhooksClient.
+// If this is not the case, regenerate this file with moq.
+var _ WebhookService_ListWebhooksClient = &WebhookService_ListWebhooksClientMock{}
+
+// WebhookService_ListWebhooksClientMock is a mock implementation of WebhookService_ListWebhooksClient.
+//
+//     func TestSomethingThatUsesWebhookService_ListWebhooksClient(t *testing.T) {
+//
+//         // make and configure a mocked WebhookService_ListWebhooksClient
+//         mockedWebhookService_ListWebhooksClient := &WebhookService_ListWebhooksClientMock{
+//             CloseSendFunc: func() error {
+// 	               panic("mock out the CloseSend method")
+//             },
+//             ContextFunc: func() context.Context {
+// 	               panic("mock out the Context method")
+//             },
+//             HeaderFunc: func() (metadata.MD, error) {
+// 	               panic("mock out the Header method")
+//             },
+//             RecvFunc: func() (*Webhook, error) {
+// 	               panic("mock out the Recv method")
+//             },
+//             RecvMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the RecvMsg method")
+//             },
+//             SendMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the SendMsg method")
+//             },
+//             TrailerFunc: func() metadata.MD {
+// 	               panic("mock out the Trailer method")
+//             },
+//         }
+//
+//         // use mockedWebhookService_ListWebhooksClient in code that requires WebhookService_ListWebhooksClient
+//         // and then make assertions.
+//
+//     }
+type WebhookService_ListWebhooksClientMock struct {
+	// CloseSendFunc mocks the CloseSend method.
+	CloseSendFunc func() error
+
+	// ContextFunc mocks the Context method.
+	ContextFunc func() context.Context
+
+	// HeaderFunc mocks the Header method.
+	HeaderFunc func() (metadata.MD, error)
+
+	// RecvFunc mocks the Recv method.
+	RecvFunc func() (*Webhook, error)
+
+	// RecvMsgFunc mocks the RecvMsg method.
+	RecvMsgFunc func(m interface{}) error
+
+	// SendMsgFunc mocks the SendMsg method.
+	SendMsgFunc func(m interface{}) error
+
+	// TrailerFunc mocks the Trailer method.
+	TrailerFunc func() metadata.MD
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CloseSend holds details about calls to the CloseSend method.
+		CloseSend []struct {
+		}
+		// Context holds details about calls to the Context method.
+		Context []struct {
+		}
+		// Header holds details about calls to the Header method.
+		Header []struct {
+		}
+		// Recv holds details about calls to the Recv method.
+		Recv []struct {
+		}
+		// RecvMsg holds details about calls to the RecvMsg method.
+		RecvMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// SendMsg holds details about calls to the SendMsg method.
+		SendMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// Trailer holds details about calls to the Trailer method.
+		Trailer []struct {
+		}
+	}
+	lockCloseSend sync.RWMutex
+	lockContext   sync.RWMutex
+	lockHeader    sync.RWMutex
+	lockRecv      sync.RWMutex
+	lockRecvMsg   sync.RWMutex
+	lockSendMsg   sync.RWMutex
+	lockTrailer   sync.RWMutex
+}
+
+// CloseSend calls CloseSendFunc.
+func (mock *WebhookService_ListWebhooksClientMock) CloseSend() error {
+	if mock.CloseSendFunc == nil {
+		panic("WebhookService_ListWebhooksClientMock.CloseSendFunc: method is nil but WebhookService_ListWebhooksClient.CloseSend was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCloseSend.Lock()
+	mock.calls.CloseSend = append(mock.calls.CloseSend, callInfo)
+	mock.lockCloseSend.Unlock()
+	return mock.CloseSendFunc()
+}
+
+// CloseSendCalls gets all the calls that were made to CloseSend.
+// Check the length with:
+//     len(mockedWebhookService_ListWebhooksClient.CloseSendCalls())
+func (mock *WebhookService_ListWebhooksClientMock) CloseSendCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCloseSend.RLock()
+	calls = mock.calls.CloseSend
+	mock.lockCloseSend.RUnlock()
+	return calls
+}
+
+// Context calls ContextFunc.
+func (mock *WebhookService_ListWebhooksClientMock) Context() context.Context {
+	if mock.ContextFunc == nil {
+		panic("WebhookService_ListWebhooksClientMock.ContextFunc: method is nil but WebhookService_ListWebhooksClient.Context was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockContext.Lock()
+	mock.calls.Context = append(mock.calls.Context, callInfo)
+	mock.lockContext.Unlock()
+	return mock.ContextFunc()
+}
+
+// ContextCalls gets all the calls that were made to Context.
+// Check the length with:
+//     len(mockedWebhookService_ListWebhooksClient.ContextCalls())
+func (mock *WebhookService_ListWebhooksClientMock) ContextCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockContext.RLock()
+	calls = mock.calls.Context
+	mock.lockContext.RUnlock()
+	return calls
+}
+
+// Header calls HeaderFunc.
+func (mock *WebhookService_ListWebhooksClientMock) Header() (metadata.MD, error) {
+	if mock.HeaderFunc == nil {
+		panic("WebhookService_ListWebhooksClientMock.HeaderFunc: method is nil but WebhookService_ListWebhooksClient.Header was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockHeader.Lock()
+	mock.calls.Header = append(mock.calls.Header, callInfo)
+	mock.lockHeader.Unlock()
+	return mock.HeaderFunc()
+}
+
+// HeaderCalls gets all the calls that were made to Header.
+// Check the length with:
+//     len(mockedWebhookService_ListWebhooksClient.HeaderCalls())
+func (mock *WebhookService_ListWebhooksClientMock) HeaderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHeader.RLock()
+	calls = mock.calls.Header
+	mock.lockHeader.RUnlock()
+	return calls
+}
+
+// Recv calls RecvFunc.
+func (mock *WebhookService_ListWebhooksClientMock) Recv() (*Webhook, error) {
+	if mock.RecvFunc == nil {
+		panic("WebhookService_ListWebhooksClientMock.RecvFunc: method is nil but WebhookService_ListWebhooksClient.Recv was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRecv.Lock()
+	mock.calls.Recv = append(mock.calls.Recv, callInfo)
+	mock.lockRecv.Unlock()
+	return mock.RecvFunc()
+}
+
+// RecvCalls gets all the calls that were made to Recv.
+// Check the length with:
+//     len(mockedWebhookService_ListWebhooksClient.RecvCalls())
+func (mock *WebhookService_ListWebhooksClientMock) RecvCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRecv.RLock()
+	calls = mock.calls.Recv
+	mock.lockRecv.RUnlock()
+	return calls
+}
+
+// RecvMsg calls RecvMsgFunc.
+func (mock *WebhookService_ListWebhooksClientMock) RecvMsg(m interface{}) error {
+	if mock.RecvMsgFunc == nil {
+		panic("WebhookService_ListWebhooksClientMock.RecvMsgFunc: method is nil but WebhookService_ListWebhooksClient.RecvMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockRecvMsg.Lock()
+	mock.calls.RecvMsg = append(mock.calls.RecvMsg, callInfo)
+	mock.lockRecvMsg.Unlock()
+	return mock.RecvMsgFunc(m)
+}
+
+// RecvMsgCalls gets all the calls that were made to RecvMsg.
+// Check the length with:
+//     len(mockedWebhookService_ListWebhooksClient.RecvMsgCalls())
+func (mock *WebhookService_ListWebhooksClientMock) RecvMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockRecvMsg.RLock()
+	calls = mock.calls.RecvMsg
+	mock.lockRecvMsg.RUnlock()
+	return calls
+}
+
+// SendMsg calls SendMsgFunc.
+func (mock *WebhookService_ListWebhooksClientMock) SendMsg(m interface{}) error {
+	if mock.SendMsgFunc == nil {
+		panic("WebhookService_ListWebhooksClientMock.SendMsgFunc: method is nil but WebhookService_ListWebhooksClient.SendMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockSendMsg.Lock()
+	mock.calls.SendMsg = append(mock.calls.SendMsg, callInfo)
+	mock.lockSendMsg.Unlock()
+	return mock.SendMsgFunc(m)
+}
+
+// SendMsgCalls gets all the calls that were made to SendMsg.
+// Check the length with:
+//     len(mockedWebhookService_ListWebhooksClient.SendMsgCalls())
+func (mock *WebhookService_ListWebhooksClientMock) SendMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockSendMsg.RLock()
+	calls = mock.calls.SendMsg
+	mock.lockSendMsg.RUnlock()
+	return calls
+}
+
+// Trailer calls TrailerFunc.
+func (mock *WebhookService_ListWebhooksClientMock) Trailer() metadata.MD {
+	if mock.TrailerFunc == nil {
+		panic("WebhookService_ListWebhooksClientMock.TrailerFunc: method is nil but WebhookService_ListWebhooksClient.Trailer was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTrailer.Lock()
+	mock.calls.Trailer = append(mock.calls.Trailer, callInfo)
+	mock.lockTrailer.Unlock()
+	return mock.TrailerFunc()
+}
+
+// TrailerCalls gets all the calls that were made to Trailer.
+// Check the length with:
+//     len(mockedWebhookService_ListWebhooksClient.TrailerCalls())
+func (mock *WebhookService_ListWebhooksClientMock) TrailerCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTrailer.RLock()
+	calls = mock.calls.Trailer
+	mock.lockTrailer.RUnlock()
+	return calls
+}
+
+// Ensure, that WebhookService_ListFailedDeliveriesClientMock does implement WebhookService_ListFailedDeliveriesClient.
+// If this is not the case, regenerate this file with moq.
+var _ WebhookService_ListFailedDeliveriesClient = &WebhookService_ListFailedDeliveriesClientMock{}
+
+// WebhookService_ListFailedDeliveriesClientMock is a mock implementation of WebhookService_ListFailedDeliveriesClient.
+//
+//     func TestSomethingThatUsesWebhookService_ListFailedDeliveriesClient(t *testing.T) {
+//
+//         // make and configure a mocked WebhookService_ListFailedDeliveriesClient
+//         mockedWebhookService_ListFailedDeliveriesClient := &WebhookService_ListFailedDeliveriesClientMock{
+//             CloseSendFunc: func() error {
+// 	               panic("mock out the CloseSend method")
+//             },
+//             ContextFunc: func() context.Context {
+// 	               panic("mock out the Context method")
+//             },
+//             HeaderFunc: func() (metadata.MD, error) {
+// 	               panic("mock out the Header method")
+//             },
+//             RecvFunc: func() (*FailedDelivery, error) {
+// 	               panic("mock out the Recv method")
+//             },
+//             RecvMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the RecvMsg method")
+//             },
+//             SendMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the SendMsg method")
+//             },
+//             TrailerFunc: func() metadata.MD {
+// 	               panic("mock out the Trailer method")
+//             },
+//         }
+//
+//         // use mockedWebhookService_ListFailedDeliveriesClient in code that requires WebhookService_ListFailedDeliveriesClient
+//         // and then make assertions.
+//
+//     }
+type WebhookService_ListFailedDeliveriesClientMock struct {
+	// CloseSendFunc mocks the CloseSend method.
+	CloseSendFunc func() error
+
+	// ContextFunc mocks the Context method.
+	ContextFunc func() context.Context
+
+	// HeaderFunc mocks the Header method.
+	HeaderFunc func() (metadata.MD, error)
+
+	// RecvFunc mocks the Recv method.
+	RecvFunc func() (*FailedDelivery, error)
+
+	// RecvMsgFunc mocks the RecvMsg method.
+	RecvMsgFunc func(m interface{}) error
+
+	// SendMsgFunc mocks the SendMsg method.
+	SendMsgFunc func(m interface{}) error
+
+	// TrailerFunc mocks the Trailer method.
+	TrailerFunc func() metadata.MD
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CloseSend holds details about calls to the CloseSend method.
+		CloseSend []struct {
+		}
+		// Context holds details about calls to the Context method.
+		Context []struct {
+		}
+		// Header holds details about calls to the Header method.
+		Header []struct {
+		}
+		// Recv holds details about calls to the Recv method.
+		Recv []struct {
+		}
+		// RecvMsg holds details about calls to the RecvMsg method.
+		RecvMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// SendMsg holds details about calls to the SendMsg method.
+		SendMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// Trailer holds details about calls to the Trailer method.
+		Trailer []struct {
+		}
+	}
+	lockCloseSend sync.RWMutex
+	lockContext   sync.RWMutex
+	lockHeader    sync.RWMutex
+	lockRecv      sync.RWMutex
+	lockRecvMsg   sync.RWMutex
+	lockSendMsg   sync.RWMutex
+	lockTrailer   sync.RWMutex
+}
+
+// CloseSend calls CloseSendFunc.
+func (mock *WebhookService_ListFailedDeliveriesClientMock) CloseSend() error {
+	if mock.CloseSendFunc == nil {
+		panic("WebhookService_ListFailedDeliveriesClientMock.CloseSendFunc: method is nil but WebhookService_ListFailedDeliveriesClient.CloseSend was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCloseSend.Lock()
+	mock.calls.CloseSend = append(mock.calls.CloseSend, callInfo)
+	mock.lockCloseSend.Unlock()
+	return mock.CloseSendFunc()
+}
+
+// CloseSendCalls gets all the calls that were made to CloseSend.
+// Check the length with:
+//     len(mockedWebhookService_ListFailedDeliveriesClient.CloseSendCalls())
+func (mock *WebhookService_ListFailedDeliveriesClientMock) CloseSendCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCloseSend.RLock()
+	calls = mock.calls.CloseSend
+	mock.lockCloseSend.RUnlock()
+	return calls
+}
+
+// Context calls ContextFunc.
+func (mock *WebhookService_ListFailedDeliveriesClientMock) Context() context.Context {
+	if mock.ContextFunc == nil {
+		panic("WebhookService_ListFailedDeliveriesClientMock.ContextFunc: method is nil but WebhookService_ListFailedDeliveriesClient.Context was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockContext.Lock()
+	mock.calls.Context = append(mock.calls.Context, callInfo)
+	mock.lockContext.Unlock()
+	return mock.ContextFunc()
+}
+
+// ContextCalls gets all the calls that were made to Context.
+// Check the length with:
+//     len(mockedWebhookService_ListFailedDeliveriesClient.ContextCalls())
+func (mock *WebhookService_ListFailedDeliveriesClientMock) ContextCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockContext.RLock()
+	calls = mock.calls.Context
+	mock.lockContext.RUnlock()
+	return calls
+}
+
+// Header calls HeaderFunc.
+func (mock *WebhookService_ListFailedDeliveriesClientMock) Header() (metadata.MD, error) {
+	if mock.HeaderFunc == nil {
+		panic("WebhookService_ListFailedDeliveriesClientMock.HeaderFunc: method is nil but WebhookService_ListFailedDeliveriesClient.Header was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockHeader.Lock()
+	mock.calls.Header = append(mock.calls.Header, callInfo)
+	mock.lockHeader.Unlock()
+	return mock.HeaderFunc()
+}
+
+// HeaderCalls gets all the calls that were made to Header.
+// Check the length with:
+//     len(mockedWebhookService_ListFailedDeliveriesClient.HeaderCalls())
+func (mock *WebhookService_ListFailedDeliveriesClientMock) HeaderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHeader.RLock()
+	calls = mock.calls.Header
+	mock.lockHeader.RUnlock()
+	return calls
+}
+
+// Recv calls RecvFunc.
+func (mock *WebhookService_ListFailedDeliveriesClientMock) Recv() (*FailedDelivery, error) {
+	if mock.RecvFunc == nil {
+		panic("WebhookService_ListFailedDeliveriesClientMock.RecvFunc: method is nil but WebhookService_ListFailedDeliveriesClient.Recv was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRecv.Lock()
+	mock.calls.Recv = append(mock.calls.Recv, callInfo)
+	mock.lockRecv.Unlock()
+	return mock.RecvFunc()
+}
+
+// RecvCalls gets all the calls that were made to Recv.
+// Check the length with:
+//     len(mockedWebhookService_ListFailedDeliveriesClient.RecvCalls())
+func (mock *WebhookService_ListFailedDeliveriesClientMock) RecvCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRecv.RLock()
+	calls = mock.calls.Recv
+	mock.lockRecv.RUnlock()
+	return calls
+}
+
+// RecvMsg calls RecvMsgFunc.
+func (mock *WebhookService_ListFailedDeliveriesClientMock) RecvMsg(m interface{}) error {
+	if mock.RecvMsgFunc == nil {
+		panic("WebhookService_ListFailedDeliveriesClientMock.RecvMsgFunc: method is nil but WebhookService_ListFailedDeliveriesClient.RecvMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockRecvMsg.Lock()
+	mock.calls.RecvMsg = append(mock.calls.RecvMsg, callInfo)
+	mock.lockRecvMsg.Unlock()
+	return mock.RecvMsgFunc(m)
+}
+
+// RecvMsgCalls gets all the calls that were made to RecvMsg.
+// Check the length with:
+//     len(mockedWebhookService_ListFailedDeliveriesClient.RecvMsgCalls())
+func (mock *WebhookService_ListFailedDeliveriesClientMock) RecvMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockRecvMsg.RLock()
+	calls = mock.calls.RecvMsg
+	mock.lockRecvMsg.RUnlock()
+	return calls
+}
+
+// SendMsg calls SendMsgFunc.
+func (mock *WebhookService_ListFailedDeliveriesClientMock) SendMsg(m interface{}) error {
+	if mock.SendMsgFunc == nil {
+		panic("WebhookService_ListFailedDeliveriesClientMock.SendMsgFunc: method is nil but WebhookService_ListFailedDeliveriesClient.SendMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockSendMsg.Lock()
+	mock.calls.SendMsg = append(mock.calls.SendMsg, callInfo)
+	mock.lockSendMsg.Unlock()
+	return mock.SendMsgFunc(m)
+}
+
+// SendMsgCalls gets all the calls that were made to SendMsg.
+// Check the length with:
+//     len(mockedWebhookService_ListFailedDeliveriesClient.SendMsgCalls())
+func (mock *WebhookService_ListFailedDeliveriesClientMock) SendMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockSendMsg.RLock()
+	calls = mock.calls.SendMsg
+	mock.lockSendMsg.RUnlock()
+	return calls
+}
+
+// Trailer calls TrailerFunc.
+func (mock *WebhookService_ListFailedDeliveriesClientMock) Trailer() metadata.MD {
+	if mock.TrailerFunc == nil {
+		panic("WebhookService_ListFailedDeliveriesClientMock.TrailerFunc: method is nil but WebhookService_ListFailedDeliveriesClient.Trailer was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTrailer.Lock()
+	mock.calls.Trailer = append(mock.calls.Trailer, callInfo)
+	mock.lockTrailer.Unlock()
+	return mock.TrailerFunc()
+}
+
+// TrailerCalls gets all the calls that were made to Trailer.
+// Check the length with:
+//     len(mockedWebhookService_ListFailedDeliveriesClient.TrailerCalls())
+func (mock *WebhookService_ListFailedDeliveriesClientMock) TrailerCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTrailer.RLock()
+	calls = mock.calls.Trailer
+	mock.lockTrailer.RUnlock()
+	return calls
+}
@@ -1,3 +1,3 @@
 package workflow
 
-//go:generate moq -out mock.go . WorkflowServiceClient WorkflowService_ListWorkflowsClient
+//go:generate moq -out mock.go . WorkflowServiceClient WorkflowService_ListWorkflowsClient WorkflowService_WatchWorkflowEventsClient
@@ -24,6 +24,9 @@ var _ WorkflowServiceClient = &WorkflowServiceClientMock{}
 //             CreateWorkflowFunc: func(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
 // 	               panic("mock out the CreateWorkflow method")
 //             },
+//             CreateWorkflowsFunc: func(ctx context.Context, in *CreateWorkflowsRequest, opts ...grpc.CallOption) (*CreateWorkflowsResponse, error) {
+// 	               panic("mock out the CreateWorkflows method")
+//             },
 //             DeleteWorkflowFunc: func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Empty, error) {
 // 	               panic("mock out the DeleteWorkflow method")
 //             },
@@ -45,13 +48,16 @@ var _ WorkflowServiceClient = &WorkflowServiceClientMock{}
 //             GetWorkflowDataFunc: func(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*GetWorkflowDataResponse, error) {
 // 	               panic("mock out the GetWorkflowData method")
 //             },
+//             GetWorkflowDataRevisionFunc: func(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*WorkflowDataRevision, error) {
+// 	               panic("mock out the GetWorkflowDataRevision method")
+//             },
 //             GetWorkflowDataVersionFunc: func(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*GetWorkflowDataResponse, error) {
 // 	               panic("mock out the GetWorkflowDataVersion method")
 //             },
 //             GetWorkflowMetadataFunc: func(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*GetWorkflowDataResponse, error) {
 // 	               panic("mock out the GetWorkflowMetadata method")
 //             },
-//             ListWorkflowsFunc: func(ctx context.Context, in *Empty, opts ...grpc.CallOption) (WorkflowService_ListWorkflowsClient, error) {
+//             ListWorkflowsFunc: func(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (WorkflowService_ListWorkflowsClient, error) {
 // 	               panic("mock out the ListWorkflows method")
 //             },
 //             ReportActionStatusFunc: func(ctx context.Context, in *WorkflowActionStatus, opts ...grpc.CallOption) (*Empty, error) {
@@ -63,6 +69,9 @@ var _ WorkflowServiceClient = &WorkflowServiceClientMock{}
 //             UpdateWorkflowDataFunc: func(ctx context.Context, in *UpdateWorkflowDataRequest, opts ...grpc.CallOption) (*Empty, error) {
 // 	               panic("mock out the UpdateWorkflowData method")
 //             },
+//             WatchWorkflowEventsFunc: func(ctx context.Context, in *WatchWorkflowEventsRequest, opts ...grpc.CallOption) (WorkflowService_WatchWorkflowEventsClient, error) {
+// 	               panic("mock out the WatchWorkflowEvents method")
+//             },
 //         }
 //
 //         // use mockedWorkflowServiceClient in code that requires WorkflowServiceClient
@@ -73,6 +82,9 @@ type WorkflowServiceClientMock struct {
 	// CreateWorkflowFunc mocks the CreateWorkflow method.
 	CreateWorkflowFunc func(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 
+	// CreateWorkflowsFunc mocks the CreateWorkflows method.
+	CreateWorkflowsFunc func(ctx context.Context, in *CreateWorkflowsRequest, opts ...grpc.CallOption) (*CreateWorkflowsResponse, error)
+
 	// DeleteWorkflowFunc mocks the DeleteWorkflow method.
 	DeleteWorkflowFunc func(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Empty, error)
 
@@ -94,6 +106,9 @@ type WorkflowServiceClientMock struct {
 	// GetWorkflowDataFunc mocks the GetWorkflowData method.
 	GetWorkflowDataFunc func(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*GetWorkflowDataResponse, error)
 
+	// GetWorkflowDataRevisionFunc mocks the GetWorkflowDataRevision method.
+	GetWorkflowDataRevisionFunc func(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*WorkflowDataRevision, error)
+
 	// GetWorkflowDataVersionFunc mocks the GetWorkflowDataVersion method.
 	GetWorkflowDataVersionFunc func(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*GetWorkflowDataResponse, error)
 
@@ -101,7 +116,7 @@ type WorkflowServiceClientMock struct {
 	GetWorkflowMetadataFunc func(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*GetWorkflowDataResponse, error)
 
 	// ListWorkflowsFunc mocks the ListWorkflows method.
-	ListWorkflowsFunc func(ctx context.Context, in *Empty, opts ...grpc.CallOption) (WorkflowService_ListWorkflowsClient, error)
+	ListWorkflowsFunc func(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (WorkflowService_ListWorkflowsClient, error)
 
 	// ReportActionStatusFunc mocks the ReportActionStatus method.
 	ReportActionStatusFunc func(ctx context.Context, in *WorkflowActionStatus, opts ...grpc.CallOption) (*Empty, error)
@@ -112,6 +127,9 @@ type WorkflowServiceClientMock struct {
 	// UpdateWorkflowDataFunc mocks the UpdateWorkflowData method.
 	UpdateWorkflowDataFunc func(ctx context.Context, in *UpdateWorkflowDataRequest, opts ...grpc.CallOption) (*Empty, error)
 
+	// WatchWorkflowEventsFunc mocks the WatchWorkflowEvents method.
+	WatchWorkflowEventsFunc func(ctx context.Context, in *WatchWorkflowEventsRequest, opts ...grpc.CallOption) (WorkflowService_WatchWorkflowEventsClient, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateWorkflow holds details about calls to the CreateWorkflow method.
@@ -123,6 +141,15 @@ type WorkflowServiceClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// CreateWorkflows holds details about calls to the CreateWorkflows method.
+		CreateWorkflows []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *CreateWorkflowsRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 		// DeleteWorkflow holds details about calls to the DeleteWorkflow method.
 		DeleteWorkflow []struct {
 			// Ctx is the ctx argument value.
@@ -186,6 +213,15 @@ type WorkflowServiceClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// GetWorkflowDataRevision holds details about calls to the GetWorkflowDataRevision method.
+		GetWorkflowDataRevision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *GetWorkflowDataRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 		// GetWorkflowDataVersion holds details about calls to the GetWorkflowDataVersion method.
 		GetWorkflowDataVersion []struct {
 			// Ctx is the ctx argument value.
@@ -209,7 +245,7 @@ type WorkflowServiceClientMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
-			In *Empty
+			In *ListRequest
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
@@ -240,21 +276,33 @@ type WorkflowServiceClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// WatchWorkflowEvents holds details about calls to the WatchWorkflowEvents method.
+		WatchWorkflowEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *WatchWorkflowEventsRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 	}
-	lockCreateWorkflow         sync.RWMutex
-	lockDeleteWorkflow         sync.RWMutex
-	lockGetWorkflow            sync.RWMutex
-	lockGetWorkflowActions     sync.RWMutex
-	lockGetWorkflowContext     sync.RWMutex
-	lockGetWorkflowContextList sync.RWMutex
-	lockGetWorkflowContexts    sync.RWMutex
-	lockGetWorkflowData        sync.RWMutex
-	lockGetWorkflowDataVersion sync.RWMutex
-	lockGetWorkflowMetadata    sync.RWMutex
-	lockListWorkflows          sync.RWMutex
-	lockReportActionStatus     sync.RWMutex
-	lockShowWorkflowEvents     sync.RWMutex
-	lockUpdateWorkflowData     sync.RWMutex
+	lockCreateWorkflow          sync.RWMutex
+	lockCreateWorkflows         sync.RWMutex
+	lockDeleteWorkflow          sync.RWMutex
+	lockGetWorkflow             sync.RWMutex
+	lockGetWorkflowActions      sync.RWMutex
+	lockGetWorkflowContext      sync.RWMutex
+	lockGetWorkflowContextList  sync.RWMutex
+	lockGetWorkflowContexts     sync.RWMutex
+	lockGetWorkflowData         sync.RWMutex
+	lockGetWorkflowDataRevision sync.RWMutex
+	lockGetWorkflowDataVersion  sync.RWMutex
+	lockGetWorkflowMetadata     sync.RWMutex
+	lockListWorkflows           sync.RWMutex
+	lockReportActionStatus      sync.RWMutex
+	lockShowWorkflowEvents      sync.RWMutex
+	lockUpdateWorkflowData      sync.RWMutex
+	lockWatchWorkflowEvents     sync.RWMutex
 }
 
 // CreateWorkflow calls CreateWorkflowFunc.
@@ -296,6 +344,45 @@ func (mock *WorkflowServiceClientMock) CreateWorkflowCalls() []struct {
 	return calls
 }
 
+// CreateWorkflows calls CreateWorkflowsFunc.
+func (mock *WorkflowServiceClientMock) CreateWorkflows(ctx context.Context, in *CreateWorkflowsRequest, opts ...grpc.CallOption) (*CreateWorkflowsResponse, error) {
+	if mock.CreateWorkflowsFunc == nil {
+		panic("WorkflowServiceClientMock.CreateWorkflowsFunc: method is nil but WorkflowServiceClient.CreateWorkflows was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *CreateWorkflowsRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockCreateWorkflows.Lock()
+	mock.calls.CreateWorkflows = append(mock.calls.CreateWorkflows, callInfo)
+	mock.lockCreateWorkflows.Unlock()
+	return mock.CreateWorkflowsFunc(ctx, in, opts...)
+}
+
+// CreateWorkflowsCalls gets all the calls that were made to CreateWorkflows.
+// Check the length with:
+//     len(mockedWorkflowServiceClient.CreateWorkflowsCalls())
+func (mock *WorkflowServiceClientMock) CreateWorkflowsCalls() []struct {
+	Ctx  context.Context
+	In   *CreateWorkflowsRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *CreateWorkflowsRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockCreateWorkflows.RLock()
+	calls = mock.calls.CreateWorkflows
+	mock.lockCreateWorkflows.RUnlock()
+	return calls
+}
+
 // DeleteWorkflow calls DeleteWorkflowFunc.
 func (mock *WorkflowServiceClientMock) DeleteWorkflow(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Empty, error) {
 	if mock.DeleteWorkflowFunc == nil {
@@ -569,6 +656,45 @@ func (mock *WorkflowServiceClientMock) GetWorkflowDataCalls() []struct {
 	return calls
 }
 
+// GetWorkflowDataRevision calls GetWorkflowDataRevisionFunc.
+func (mock *WorkflowServiceClientMock) GetWorkflowDataRevision(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*WorkflowDataRevision, error) {
+	if mock.GetWorkflowDataRevisionFunc == nil {
+		panic("WorkflowServiceClientMock.GetWorkflowDataRevisionFunc: method is nil but WorkflowServiceClient.GetWorkflowDataRevision was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *GetWorkflowDataRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockGetWorkflowDataRevision.Lock()
+	mock.calls.GetWorkflowDataRevision = append(mock.calls.GetWorkflowDataRevision, callInfo)
+	mock.lockGetWorkflowDataRevision.Unlock()
+	return mock.GetWorkflowDataRevisionFunc(ctx, in, opts...)
+}
+
+// GetWorkflowDataRevisionCalls gets all the calls that were made to GetWorkflowDataRevision.
+// Check the length with:
+//     len(mockedWorkflowServiceClient.GetWorkflowDataRevisionCalls())
+func (mock *WorkflowServiceClientMock) GetWorkflowDataRevisionCalls() []struct {
+	Ctx  context.Context
+	In   *GetWorkflowDataRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *GetWorkflowDataRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockGetWorkflowDataRevision.RLock()
+	calls = mock.calls.GetWorkflowDataRevision
+	mock.lockGetWorkflowDataRevision.RUnlock()
+	return calls
+}
+
 // GetWorkflowDataVersion calls GetWorkflowDataVersionFunc.
 func (mock *WorkflowServiceClientMock) GetWorkflowDataVersion(ctx context.Context, in *GetWorkflowDataRequest, opts ...grpc.CallOption) (*GetWorkflowDataResponse, error) {
 	if mock.GetWorkflowDataVersionFunc == nil {
@@ -648,13 +774,13 @@ func (mock *WorkflowServiceClientMock) GetWorkflowMetadataCalls() []struct {
 }
 
 // ListWorkflows calls ListWorkflowsFunc.
-func (mock *WorkflowServiceClientMock) ListWorkflows(ctx context.Context, in *Empty, opts ...grpc.CallOption) (WorkflowService_ListWorkflowsClient, error) {
+func (mock *WorkflowServiceClientMock) ListWorkflows(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (WorkflowService_ListWorkflowsClient, error) {
 	if mock.ListWorkflowsFunc == nil {
 		panic("WorkflowServiceClientMock.ListWorkflowsFunc: method is nil but WorkflowServiceClient.ListWorkflows was just called")
 	}
 	callInfo := struct {
 		Ctx  context.Context
-		In   *Empty
+		In   *ListRequest
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
@@ -672,12 +798,12 @@ func (mock *WorkflowServiceClientMock) ListWorkflows(ctx context.Context, in *Em
 //     len(mockedWorkflowServiceClient.ListWorkflowsCalls())
 func (mock *WorkflowServiceClientMock) ListWorkflowsCalls() []struct {
 	Ctx  context.Context
-	In   *Empty
+	In   *ListRequest
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *Empty
+		In   *ListRequest
 		Opts []grpc.CallOption
 	}
 	mock.lockListWorkflows.RLock()
@@ -803,6 +929,45 @@ func (mock *WorkflowServiceClientMock) UpdateWorkflowDataCalls() []struct {
 	return calls
 }
 
+// WatchWorkflowEvents calls WatchWorkflowEventsFunc.
+func (mock *WorkflowServiceClientMock) WatchWorkflowEvents(ctx context.Context, in *WatchWorkflowEventsRequest, opts ...grpc.CallOption) (WorkflowService_WatchWorkflowEventsClient, error) {
+	if mock.WatchWorkflowEventsFunc == nil {
+		panic("WorkflowServiceClientMock.WatchWorkflowEventsFunc: method is nil but WorkflowServiceClient.WatchWorkflowEvents was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *WatchWorkflowEventsRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockWatchWorkflowEvents.Lock()
+	mock.calls.WatchWorkflowEvents = append(mock.calls.WatchWorkflowEvents, callInfo)
+	mock.lockWatchWorkflowEvents.Unlock()
+	return mock.WatchWorkflowEventsFunc(ctx, in, opts...)
+}
+
+// WatchWorkflowEventsCalls gets all the calls that were made to WatchWorkflowEvents.
+// Check the length with:
+//     len(mockedWorkflowServiceClient.WatchWorkflowEventsCalls())
+func (mock *WorkflowServiceClientMock) WatchWorkflowEventsCalls() []struct {
+	Ctx  context.Context
+	In   *WatchWorkflowEventsRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *WatchWorkflowEventsRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockWatchWorkflowEvents.RLock()
+	calls = mock.calls.WatchWorkflowEvents
+	mock.lockWatchWorkflowEvents.RUnlock()
+	return calls
+}
+
 // Ensure, that WorkflowService_ListWorkflowsClientMock does implement WorkflowService_ListWorkflowsClient.
 // If this is not the case, regenerate this file with moq.
 var _ WorkflowService_ListWorkflowsClient = &WorkflowService_ListWorkflowsClientMock{}
@@ -1090,3 +1255,291 @@ func (mock *WorkflowService_ListWorkflowsClientMock) TrailerCalls() []struct {
 	mock.lockTrailer.RUnlock()
 	return calls
 }
+
+// Ensure, that WorkflowService_WatchWorkflowEventsClientMock does implement WorkflowService_WatchWorkflowEventsClient.
+// If this is not the case, regenerate this file with moq.
+var _ WorkflowService_WatchWorkflowEventsClient = &WorkflowService_WatchWorkflowEventsClientMock{}
+
+// WorkflowService_WatchWorkflowEventsClientMock is a mock implementation of WorkflowService_WatchWorkflowEventsClient.
+//
+//     func TestSomethingThatUsesWorkflowService_WatchWorkflowEventsClient(t *testing.T) {
+//
+//         // make and configure a mocked WorkflowService_WatchWorkflowEventsClient
+//         mockedWorkflowService_WatchWorkflowEventsClient := &WorkflowService_WatchWorkflowEventsClientMock{
+//             CloseSendFunc: func() error {
+// 	               panic("mock out the CloseSend method")
+//             },
+//             ContextFunc: func() context.Context {
+// 	               panic("mock out the Context method")
+//             },
+//             HeaderFunc: func() (metadata.MD, error) {
+// 	               panic("mock out the Header method")
+//             },
+//             RecvFunc: func() (*WorkflowActionStatus, error) {
+// 	               panic("mock out the Recv method")
+//             },
+//             RecvMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the RecvMsg method")
+//             },
+//             SendMsgFunc: func(m interface{}) error {
+// 	               panic("mock out the SendMsg method")
+//             },
+//             TrailerFunc: func() metadata.MD {
+// 	               panic("mock out the Trailer method")
+//             },
+//         }
+//
+//         // use mockedWorkflowService_WatchWorkflowEventsClient in code that requires WorkflowService_WatchWorkflowEventsClient
+//         // and then make assertions.
+//
+//     }
+type WorkflowService_WatchWorkflowEventsClientMock struct {
+	// CloseSendFunc mocks the CloseSend method.
+	CloseSendFunc func() error
+
+	// ContextFunc mocks the Context method.
+	ContextFunc func() context.Context
+
+	// HeaderFunc mocks the Header method.
+	HeaderFunc func() (metadata.MD, error)
+
+	// RecvFunc mocks the Recv method.
+	RecvFunc func() (*WorkflowActionStatus, error)
+
+	// RecvMsgFunc mocks the RecvMsg method.
+	RecvMsgFunc func(m interface{}) error
+
+	// SendMsgFunc mocks the SendMsg method.
+	SendMsgFunc func(m interface{}) error
+
+	// TrailerFunc mocks the Trailer method.
+	TrailerFunc func() metadata.MD
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CloseSend holds details about calls to the CloseSend method.
+		CloseSend []struct {
+		}
+		// Context holds details about calls to the Context method.
+		Context []struct {
+		}
+		// Header holds details about calls to the Header method.
+		Header []struct {
+		}
+		// Recv holds details about calls to the Recv method.
+		Recv []struct {
+		}
+		// RecvMsg holds details about calls to the RecvMsg method.
+		RecvMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// SendMsg holds details about calls to the SendMsg method.
+		SendMsg []struct {
+			// M is the m argument value.
+			M interface{}
+		}
+		// Trailer holds details about calls to the Trailer method.
+		Trailer []struct {
+		}
+	}
+	lockCloseSend sync.RWMutex
+	lockContext   sync.RWMutex
+	lockHeader    sync.RWMutex
+	lockRecv      sync.RWMutex
+	lockRecvMsg   sync.RWMutex
+	lockSendMsg   sync.RWMutex
+	lockTrailer   sync.RWMutex
+}
+
+// CloseSend calls CloseSendFunc.
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) CloseSend() error {
+	if mock.CloseSendFunc == nil {
+		panic("WorkflowService_WatchWorkflowEventsClientMock.CloseSendFunc: method is nil but WorkflowService_WatchWorkflowEventsClient.CloseSend was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCloseSend.Lock()
+	mock.calls.CloseSend = append(mock.calls.CloseSend, callInfo)
+	mock.lockCloseSend.Unlock()
+	return mock.CloseSendFunc()
+}
+
+// CloseSendCalls gets all the calls that were made to CloseSend.
+// Check the length with:
+//     len(mockedWorkflowService_WatchWorkflowEventsClient.CloseSendCalls())
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) CloseSendCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCloseSend.RLock()
+	calls = mock.calls.CloseSend
+	mock.lockCloseSend.RUnlock()
+	return calls
+}
+
+// Context calls ContextFunc.
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) Context() context.Context {
+	if mock.ContextFunc == nil {
+		panic("WorkflowService_WatchWorkflowEventsClientMock.ContextFunc: method is nil but WorkflowService_WatchWorkflowEventsClient.Context was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockContext.Lock()
+	mock.calls.Context = append(mock.calls.Context, callInfo)
+	mock.lockContext.Unlock()
+	return mock.ContextFunc()
+}
+
+// ContextCalls gets all the calls that were made to Context.
+// Check the length with:
+//     len(mockedWorkflowService_WatchWorkflowEventsClient.ContextCalls())
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) ContextCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockContext.RLock()
+	calls = mock.calls.Context
+	mock.lockContext.RUnlock()
+	return calls
+}
+
+// Header calls HeaderFunc.
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) Header() (metadata.MD, error) {
+	if mock.HeaderFunc == nil {
+		panic("WorkflowService_WatchWorkflowEventsClientMock.HeaderFunc: method is nil but WorkflowService_WatchWorkflowEventsClient.Header was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockHeader.Lock()
+	mock.calls.Header = append(mock.calls.Header, callInfo)
+	mock.lockHeader.Unlock()
+	return mock.HeaderFunc()
+}
+
+// HeaderCalls gets all the calls that were made to Header.
+// Check the length with:
+//     len(mockedWorkflowService_WatchWorkflowEventsClient.HeaderCalls())
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) HeaderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHeader.RLock()
+	calls = mock.calls.Header
+	mock.lockHeader.RUnlock()
+	return calls
+}
+
+// Recv calls RecvFunc.
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) Recv() (*WorkflowActionStatus, error) {
+	if mock.RecvFunc == nil {
+		panic("WorkflowService_WatchWorkflowEventsClientMock.RecvFunc: method is nil but WorkflowService_WatchWorkflowEventsClient.Recv was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRecv.Lock()
+	mock.calls.Recv = append(mock.calls.Recv, callInfo)
+	mock.lockRecv.Unlock()
+	return mock.RecvFunc()
+}
+
+// RecvCalls gets all the calls that were made to Recv.
+// Check the length with:
+//     len(mockedWorkflowService_WatchWorkflowEventsClient.RecvCalls())
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) RecvCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRecv.RLock()
+	calls = mock.calls.Recv
+	mock.lockRecv.RUnlock()
+	return calls
+}
+
+// RecvMsg calls RecvMsgFunc.
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) RecvMsg(m interface{}) error {
+	if mock.RecvMsgFunc == nil {
+		panic("WorkflowService_WatchWorkflowEventsClientMock.RecvMsgFunc: method is nil but WorkflowService_WatchWorkflowEventsClient.RecvMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockRecvMsg.Lock()
+	mock.calls.RecvMsg = append(mock.calls.RecvMsg, callInfo)
+	mock.lockRecvMsg.Unlock()
+	return mock.RecvMsgFunc(m)
+}
+
+// RecvMsgCalls gets all the calls that were made to RecvMsg.
+// Check the length with:
+//     len(mockedWorkflowService_WatchWorkflowEventsClient.RecvMsgCalls())
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) RecvMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockRecvMsg.RLock()
+	calls = mock.calls.RecvMsg
+	mock.lockRecvMsg.RUnlock()
+	return calls
+}
+
+// SendMsg calls SendMsgFunc.
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) SendMsg(m interface{}) error {
+	if mock.SendMsgFunc == nil {
+		panic("WorkflowService_WatchWorkflowEventsClientMock.SendMsgFunc: method is nil but WorkflowService_WatchWorkflowEventsClient.SendMsg was just called")
+	}
+	callInfo := struct {
+		M interface{}
+	}{
+		M: m,
+	}
+	mock.lockSendMsg.Lock()
+	mock.calls.SendMsg = append(mock.calls.SendMsg, callInfo)
+	mock.lockSendMsg.Unlock()
+	return mock.SendMsgFunc(m)
+}
+
+// SendMsgCalls gets all the calls that were made to SendMsg.
+// Check the length with:
+//     len(mockedWorkflowService_WatchWorkflowEventsClient.SendMsgCalls())
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) SendMsgCalls() []struct {
+	M interface{}
+} {
+	var calls []struct {
+		M interface{}
+	}
+	mock.lockSendMsg.RLock()
+	calls = mock.calls.SendMsg
+	mock.lockSendMsg.RUnlock()
+	return calls
+}
+
+// Trailer calls TrailerFunc.
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) Trailer() metadata.MD {
+	if mock.TrailerFunc == nil {
+		panic("WorkflowService_WatchWorkflowEventsClientMock.TrailerFunc: method is nil but WorkflowService_WatchWorkflowEventsClient.Trailer was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTrailer.Lock()
+	mock.calls.Trailer = append(mock.calls.Trailer, callInfo)
+	mock.lockTrailer.Unlock()
+	return mock.TrailerFunc()
+}
+
+// TrailerCalls gets all the calls that were made to Trailer.
+// Check the length with:
+//     len(mockedWorkflowService_WatchWorkflowEventsClient.TrailerCalls())
+func (mock *WorkflowService_WatchWorkflowEventsClientMock) TrailerCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTrailer.RLock()
+	calls = mock.calls.Trailer
+	mock.lockTrailer.RUnlock()
+	return calls
+}
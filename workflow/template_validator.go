@@ -22,6 +22,8 @@ const (
 	errActionInvalidImage     = "invalid action image: %s"
 	errTemplateParsing        = "failed to parse template with ID %s"
 	errInvalidHardwareAddress = "failed to render template, invalid hardware address: %s"
+	errInvalidMaxDataVersions = "max_data_versions cannot be negative: %d"
+	errInvalidKind            = "invalid workflow kind %q, expected provision or deprovision"
 )
 
 // Parse parses the template yaml content into a Workflow
@@ -105,6 +107,16 @@ func validate(wf *Workflow) error {
 		return errors.Errorf(errTemplateInvalidVersion, wf.Version)
 	}
 
+	if wf.MaxDataVersions < 0 {
+		return errors.Errorf(errInvalidMaxDataVersions, wf.MaxDataVersions)
+	}
+
+	switch wf.Kind {
+	case "", KindProvision, KindDeprovision:
+	default:
+		return errors.Errorf(errInvalidKind, wf.Kind)
+	}
+
 	if len(wf.Tasks) == 0 {
 		return errors.New("template must have at least one task defined")
 	}
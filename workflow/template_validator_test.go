@@ -169,6 +169,20 @@ func TestValidateTemplate(t *testing.T) {
 			wf:            workflow(withTemplateInvalidVersion()),
 			expectedError: true,
 		},
+		{
+			name:          "template max data versions is negative",
+			wf:            workflow(withTemplateNegativeMaxDataVersions()),
+			expectedError: true,
+		},
+		{
+			name:          "template kind is invalid",
+			wf:            workflow(withTemplateKind("reprovision")),
+			expectedError: true,
+		},
+		{
+			name: "template kind is deprovision",
+			wf:   workflow(withTemplateKind(KindDeprovision)),
+		},
 		{
 			name:          "template tasks is nil",
 			wf:            workflow(withTemplateNilTasks()),
@@ -427,6 +441,18 @@ func withTemplateInvalidVersion() workflowModifier {
 	}
 }
 
+func withTemplateNegativeMaxDataVersions() workflowModifier {
+	return func(wf *Workflow) {
+		wf.MaxDataVersions = -1
+	}
+}
+
+func withTemplateKind(kind string) workflowModifier {
+	return func(wf *Workflow) {
+		wf.Kind = kind
+	}
+}
+
 func withTemplateNilTasks() workflowModifier {
 	return func(wf *Workflow) {
 		wf.Tasks = nil
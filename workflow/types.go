@@ -1,5 +1,16 @@
 package workflow
 
+// The kinds of workflow, they tell the server how the workflow changes the
+// lifecycle state of its hardware
+const (
+	// KindProvision installs the hardware, it is the kind of the
+	// templates which do not set one
+	KindProvision = "provision"
+	// KindDeprovision wipes provisioned hardware to make it available
+	// again
+	KindDeprovision = "deprovision"
+)
+
 // Workflow represents a workflow to be executed
 type Workflow struct {
 	Version       string `yaml:"version"`
@@ -7,6 +18,12 @@ type Workflow struct {
 	ID            string `yaml:"id"`
 	GlobalTimeout int    `yaml:"global_timeout"`
 	Tasks         []Task `yaml:"tasks"`
+	// MaxDataVersions is how many versions of the workflow data keep their
+	// data, the server setting applies when it is zero
+	MaxDataVersions int `yaml:"max_data_versions,omitempty"`
+	// Kind is KindProvision or KindDeprovision, KindProvision when it is
+	// empty
+	Kind string `yaml:"kind,omitempty"`
 }
 
 // Task represents a task to be executed as part of a workflow